@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
@@ -20,10 +21,13 @@ func parseStatusFilters(raw []string) []entities.AppointmentStatus {
 	normalize := func(token string) {
 		token = strings.TrimSpace(strings.ToLower(token))
 		switch token {
-		case string(entities.AppointmentStatusPending),
+		case string(entities.AppointmentStatusDraft),
+			string(entities.AppointmentStatusPending),
 			string(entities.AppointmentStatusOngoing),
+			string(entities.AppointmentStatusPaused),
 			string(entities.AppointmentStatusCompleted),
-			string(entities.AppointmentStatusCanceled):
+			string(entities.AppointmentStatusCanceled),
+			string(entities.AppointmentStatusArchived):
 			status := entities.AppointmentStatus(token)
 			if _, ok := seen[status]; !ok {
 				seen[status] = struct{}{}
@@ -87,7 +91,7 @@ func (h *Handler) CreateAppointment(c *gin.Context) {
 }
 
 // @Summary Update an appointment
-// @Description Update an appointment. Updates are blocked if any slot has been booked.
+// @Description Update an appointment. Updates are blocked if any slot has been booked. Allowed for the appointment owner and organization admins and owners.
 // @Tags Appointments
 // @Accept  application/json
 // @Produce  application/json
@@ -168,12 +172,130 @@ func (h *Handler) DeleteAppointment(c *gin.Context) {
 	c.JSON(http.StatusOK, appointment)
 }
 
+// @Summary Publish a draft appointment
+// @Description Makes a draft appointment bookable. Send a future publish_at to schedule publishing instead; the status scheduler publishes it at that time.
+// @Tags Appointments
+// @Accept  application/json
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Param   publish  body  requests.PublishAppointmentRequest  false  "Optional publish time"
+// @Security BearerAuth
+// @Success 200 {object} entities.Appointment
+// @Failure 400 {object} responses.APIErrorResponse "Invalid appointment id or payload"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Failure 409 {object} responses.APIErrorResponse "Appointment is not a draft"
+// @Router /appointments/{id}/publish [post]
+// @ID publishAppointment
+func (h *Handler) PublishAppointment(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid appointment id")
+		return
+	}
+
+	var req requests.PublishAppointmentRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+			return
+		}
+	}
+
+	appointment, err := h.appointmentService.PublishAppointment(c.Request.Context(), appointmentID, userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, appointment)
+}
+
+// @Summary Pause an appointment
+// @Description Stops new bookings on a published appointment. Existing bookings are kept.
+// @Tags Appointments
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.Appointment
+// @Failure 400 {object} responses.APIErrorResponse "Invalid appointment id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Failure 409 {object} responses.APIErrorResponse "Appointment cannot be paused"
+// @Router /appointments/{id}/pause [post]
+// @ID pauseAppointment
+func (h *Handler) PauseAppointment(c *gin.Context) {
+	h.changeAppointmentStatus(c, h.appointmentService.PauseAppointment)
+}
+
+// @Summary Resume a paused appointment
+// @Description Reopens a paused appointment for new bookings.
+// @Tags Appointments
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.Appointment
+// @Failure 400 {object} responses.APIErrorResponse "Invalid appointment id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Failure 409 {object} responses.APIErrorResponse "Appointment is not paused"
+// @Router /appointments/{id}/resume [post]
+// @ID resumeAppointment
+func (h *Handler) ResumeAppointment(c *gin.Context) {
+	h.changeAppointmentStatus(c, h.appointmentService.ResumeAppointment)
+}
+
+// @Summary Archive an appointment
+// @Description Retires a draft, completed or canceled appointment. Archived appointments cannot be booked or reopened.
+// @Tags Appointments
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.Appointment
+// @Failure 400 {object} responses.APIErrorResponse "Invalid appointment id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Failure 409 {object} responses.APIErrorResponse "Appointment cannot be archived"
+// @Router /appointments/{id}/archive [post]
+// @ID archiveAppointment
+func (h *Handler) ArchiveAppointment(c *gin.Context) {
+	h.changeAppointmentStatus(c, h.appointmentService.ArchiveAppointment)
+}
+
+func (h *Handler) changeAppointmentStatus(c *gin.Context, change func(ctx context.Context, appointmentID uuid.UUID, ownerID uuid.UUID) (*entities.Appointment, error)) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid appointment id")
+		return
+	}
+
+	appointment, err := change(c.Request.Context(), appointmentID, userID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, appointment)
+}
+
 // @Summary Get appointments created by the user
-// @Description Retrieves a paginated list of appointments created by the currently authenticated user.
+// @Description Retrieves a paginated list of appointments created by the currently authenticated user or shared with an organization they belong to.
 // @Tags Appointments
 // @Produce  application/json
 // @Security BearerAuth
-// @Param status query []string false "Filter by appointment status (pending, ongoing, completed, canceled)"
+// @Param status query []string false "Filter by appointment status (draft, pending, ongoing, paused, completed, canceled, archived)"
 // @Param page query int false "Page number (default: 1)"
 // @Param size query int false "Page size (default: 10)"
 // @Success 200 {object} responses.PaginatedResponse{items=[]responses.AppointmentResponse}
@@ -194,7 +316,7 @@ func (h *Handler) GetAppointmentsCreatedByUser(c *gin.Context) {
 }
 
 // @Summary Get appointment by app code
-// @Description Retrieves appointment details by its unique app_code, public endpoint for booking flow
+// @Description Retrieves appointment details by its unique app_code, public endpoint for booking flow. ticket_sales lists the seats booked in each ticket type.
 // @Tags Appointments
 // @Produce  application/json
 // @Param   app_code  path   string  true  "Appointment identifier (app_code)"
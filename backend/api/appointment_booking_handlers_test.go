@@ -68,7 +68,7 @@ func TestCreateAppointmentAPI(t *testing.T) {
 			mockAppointmentService := new(mocks.AppointmentService)
 			tc.setupMock(mockAppointmentService)
 
-			handler := NewHandler(nil, mockAppointmentService, nil, nil, nil, nil)
+			handler := NewHandler(nil, mockAppointmentService, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 			router := gin.New()
 			router.Use(middleware.RequestID())
 			router.Use(gin.Recovery())
@@ -115,7 +115,7 @@ func TestCancelBookingAPI(t *testing.T) {
 			name:        "Success",
 			bookingCode: "BK123XYZ",
 			setupMock: func(mockService *mocks.BookingService) {
-				mockService.On("CancelBookingByCode", "BK123XYZ").Return(&entities.Booking{Status: entities.BookingStatusCancelled}, nil).Once()
+				mockService.On("CancelBookingByCode", "BK123XYZ", "").Return(&entities.Booking{Status: entities.BookingStatusCancelled}, nil).Once()
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedContains:   fmt.Sprintf(`"status":"%s"`, entities.BookingStatusCancelled),
@@ -125,7 +125,7 @@ func TestCancelBookingAPI(t *testing.T) {
 			bookingCode: "NOTFOUND",
 			setupMock: func(mockService *mocks.BookingService) {
 				// Mock service returning an error that will trigger the API error handling
-				mockService.On("CancelBookingByCode", "NOTFOUND").Return((*entities.Booking)(nil), apperrors.NewAppError(apperrors.CodeBookingNotFound, "resource_not_found", http.StatusNotFound, "booking not found", nil)).Once()
+				mockService.On("CancelBookingByCode", "NOTFOUND", "").Return((*entities.Booking)(nil), apperrors.NewAppError(apperrors.CodeBookingNotFound, "resource_not_found", http.StatusNotFound, "booking not found", nil)).Once()
 			},
 			expectedStatusCode: http.StatusNotFound,
 			expectedError: &apiErrorPayload{
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/m13ha/asiko/errors/apierrors"
+	"github.com/m13ha/asiko/middleware"
+	"github.com/m13ha/asiko/models/requests"
+)
+
+// @Summary List appointment hosts
+// @Description Lists the hosts bookings on the appointment are assigned to, in priority order, with the assignment strategy.
+// @Tags Appointments
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Security BearerAuth
+// @Success 200 {object} responses.AppointmentHostsResponse
+// @Failure 400 {object} responses.APIErrorResponse "Invalid appointment id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Not allowed to view this appointment"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Router /appointments/{id}/hosts [get]
+// @ID listAppointmentHosts
+func (h *Handler) ListAppointmentHosts(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid appointment id")
+		return
+	}
+
+	hosts, err := h.appointmentService.ListHosts(c.Request.Context(), appointmentID, userID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hosts)
+}
+
+// @Summary Set appointment hosts
+// @Description Replaces the appointment's hosts. New bookings go to a free host picked by round_robin, least_busy (fewest bookings this week) or priority (lowest priority value first). Hosts must be the owner or members of the appointment's organization; an empty list hands bookings back to the owner.
+// @Tags Appointments
+// @Accept  application/json
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Param   hosts  body   requests.AppointmentHostsRequest  true  "Hosts and assignment strategy"
+// @Security BearerAuth
+// @Success 200 {object} responses.AppointmentHostsResponse
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Not allowed to manage this appointment"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Router /appointments/{id}/hosts [put]
+// @ID setAppointmentHosts
+func (h *Handler) SetAppointmentHosts(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid appointment id")
+		return
+	}
+
+	var req requests.AppointmentHostsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	hosts, err := h.appointmentService.SetHosts(c.Request.Context(), appointmentID, userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hosts)
+}
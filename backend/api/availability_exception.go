@@ -0,0 +1,177 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/m13ha/asiko/errors/apierrors"
+	"github.com/m13ha/asiko/middleware"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/models/responses"
+)
+
+// @Summary List availability exceptions
+// @Description Lists the closed, shortened and extra days configured for an appointment.
+// @Tags Appointments
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Security BearerAuth
+// @Success 200 {array} entities.AvailabilityException
+// @Failure 400 {object} responses.APIErrorResponse "Invalid appointment id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Router /appointments/{id}/exceptions [get]
+// @ID listAvailabilityExceptions
+func (h *Handler) ListAvailabilityExceptions(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid appointment id")
+		return
+	}
+
+	exceptions, err := h.exceptionService.ListExceptions(c.Request.Context(), appointmentID, userID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, exceptions)
+}
+
+// @Summary Add an availability exception
+// @Description Closes, shortens or adds an extra window to a single date. Slots on that date are regenerated and bookings in removed slots are cancelled.
+// @Tags Appointments
+// @Accept  application/json
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Param   exception  body   requests.AvailabilityExceptionRequest  true  "Exception Details"
+// @Security BearerAuth
+// @Success 201 {object} entities.AvailabilityException
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Failure 409 {object} responses.APIErrorResponse "Date already has a closed or shortened exception"
+// @Router /appointments/{id}/exceptions [post]
+// @ID createAvailabilityException
+func (h *Handler) CreateAvailabilityException(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid appointment id")
+		return
+	}
+
+	var req requests.AvailabilityExceptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	exception, err := h.exceptionService.CreateException(c.Request.Context(), appointmentID, userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, exception)
+}
+
+// @Summary Update an availability exception
+// @Description Updates an exception. Slots on both the previous and the new date are regenerated.
+// @Tags Appointments
+// @Accept  application/json
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Param   exception_id  path  string  true  "Exception ID"
+// @Param   exception  body   requests.AvailabilityExceptionRequest  true  "Exception Details"
+// @Security BearerAuth
+// @Success 200 {object} entities.AvailabilityException
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment or exception not found"
+// @Failure 409 {object} responses.APIErrorResponse "Date already has a closed or shortened exception"
+// @Router /appointments/{id}/exceptions/{exception_id} [put]
+// @ID updateAvailabilityException
+func (h *Handler) UpdateAvailabilityException(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid appointment id")
+		return
+	}
+	exceptionID, err := uuid.Parse(c.Param("exception_id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid exception id")
+		return
+	}
+
+	var req requests.AvailabilityExceptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	exception, err := h.exceptionService.UpdateException(c.Request.Context(), appointmentID, exceptionID, userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, exception)
+}
+
+// @Summary Delete an availability exception
+// @Description Removes an exception and restores the regular slots for its date.
+// @Tags Appointments
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Param   exception_id  path  string  true  "Exception ID"
+// @Security BearerAuth
+// @Success 200 {object} responses.SimpleMessage
+// @Failure 400 {object} responses.APIErrorResponse "Invalid id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment or exception not found"
+// @Router /appointments/{id}/exceptions/{exception_id} [delete]
+// @ID deleteAvailabilityException
+func (h *Handler) DeleteAvailabilityException(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid appointment id")
+		return
+	}
+	exceptionID, err := uuid.Parse(c.Param("exception_id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid exception id")
+		return
+	}
+
+	if err := h.exceptionService.DeleteException(c.Request.Context(), appointmentID, exceptionID, userID); err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SimpleMessage{Message: "Availability exception deleted"})
+}
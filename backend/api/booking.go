@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -31,6 +32,49 @@ func (h *Handler) GetUserRegisteredBookings(c *gin.Context) {
 	}
 
 	ctx := c.Request.Context()
+	statuses := parseBookingStatusFilters(c)
+
+	bookings, err := h.bookingService.GetUserBookings(ctx, c.Request, userID.String(), statuses)
+	if err != nil {
+		apierrors.InternalServerError(c, "Internal server error")
+		return
+	}
+
+	c.JSON(http.StatusOK, bookings)
+}
+
+// @Summary Get bookings assigned to the user as host
+// @Description Retrieves a paginated list of the bookings assigned to the currently authenticated user as one of an appointment's hosts.
+// @Tags Bookings
+// @Produce  application/json
+// @Security BearerAuth
+// @Param status query []string false "Filter by booking status (active, pending, cancelled, etc.)"
+// @Param page query int false "Page number (default: 1)"
+// @Param size query int false "Page size (default: 10)"
+// @Success 200 {object} responses.PaginatedResponse{items=[]entities.Booking}
+// @Failure 401 {object} responses.APIErrorResponse "Unauthorized"
+// @Failure 500 {object} responses.APIErrorResponse "Internal server error"
+// @Router /appointments/assigned [get]
+// @ID getHostAssignedBookings
+func (h *Handler) GetHostAssignedBookings(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "Unauthorized")
+		return
+	}
+
+	bookings, err := h.bookingService.GetHostBookings(c.Request.Context(), c.Request, userID, parseBookingStatusFilters(c))
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bookings)
+}
+
+// parseBookingStatusFilters reads the status query as repeated or comma-separated values,
+// lowercased and de-duplicated.
+func parseBookingStatusFilters(c *gin.Context) []string {
 	rawStatuses := c.QueryArray("status")
 	if len(rawStatuses) == 0 {
 		if s := c.Query("status"); s != "" {
@@ -56,17 +100,11 @@ func (h *Handler) GetUserRegisteredBookings(c *gin.Context) {
 		}
 	}
 
-	bookings, err := h.bookingService.GetUserBookings(ctx, c.Request, userID.String(), statuses)
-	if err != nil {
-		apierrors.InternalServerError(c, "Internal server error")
-		return
-	}
-
-	c.JSON(http.StatusOK, bookings)
+	return statuses
 }
 
 // @Summary Book an appointment (Guest)
-// @Description Creates a booking for an appointment as a guest user. Name and email/phone are required.
+// @Description Creates a booking for an appointment as a guest user. Name and email/phone are required. Appointments with ticket types need a ticket_type that is on sale and has seats left. A promo_code takes its discount off the price; invalid, expired or used-up codes fail the booking.
 // @Tags Bookings
 // @Accept  application/json
 // @Produce  application/json
@@ -93,7 +131,7 @@ func (h *Handler) BookGuestAppointment(c *gin.Context) {
 }
 
 // @Summary Book an appointment (Registered User)
-// @Description Creates a booking for an appointment as a registered user.
+// @Description Creates a booking for an appointment as a registered user. Appointments with ticket types need a ticket_type that is on sale and has seats left. A promo_code takes its discount off the price; invalid, expired or used-up codes fail the booking.
 // @Tags Bookings
 // @Accept  application/json
 // @Produce  application/json
@@ -127,6 +165,62 @@ func (h *Handler) BookRegisteredUserAppointment(c *gin.Context) {
 	c.JSON(http.StatusCreated, booking)
 }
 
+// @Summary Join the waitlist for a full slot or party
+// @Description Queues the caller for a slot or party with no room left. When seats are freed by a cancellation or rejection, the oldest entry that fits is booked automatically and emailed. Guests must supply name and email/phone; a bearer token books as the registered user.
+// @Tags Bookings
+// @Accept  application/json
+// @Produce  application/json
+// @Param   booking  body   requests.BookingRequest  true  "Slot and booker details"
+// @Success 201 {object} entities.WaitlistEntry
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Failure 409 {object} responses.APIErrorResponse "Slot still has room or already on the waitlist"
+// @Router /appointments/waitlist [post]
+// @ID joinWaitlist
+func (h *Handler) JoinWaitlist(c *gin.Context) {
+	var req requests.BookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "Invalid request payload")
+		return
+	}
+
+	entry, err := h.bookingService.JoinWaitlist(req, middleware.GetUserIDFromContext(c))
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// @Summary Hold seats on a slot
+// @Description Reserves seats on a slot for a few minutes while the guest completes their details. Pass the returned token as hold_token when booking to redeem the seats; unredeemed holds are released automatically once they expire.
+// @Tags Bookings
+// @Accept  application/json
+// @Produce  application/json
+// @Param   hold  body   requests.SlotHoldRequest  true  "Slot and number of seats to hold"
+// @Success 201 {object} entities.SlotHold
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Failure 409 {object} responses.APIErrorResponse "Slot unavailable or not enough capacity"
+// @Router /appointments/holds [post]
+// @ID holdSlot
+func (h *Handler) HoldSlot(c *gin.Context) {
+	var req requests.SlotHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "Invalid request payload")
+		return
+	}
+
+	hold, err := h.bookingService.HoldSlot(req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, hold)
+}
+
 // @Summary Get available slots for an appointment
 // @Description Retrieves a paginated list of all available booking slots for a given appointment.
 // @Tags Bookings
@@ -134,8 +228,9 @@ func (h *Handler) BookRegisteredUserAppointment(c *gin.Context) {
 // @Param   app_code  path   string  true  "Appointment identifier (app_code)"
 // @Param page query int false "Page number (default: 1)"
 // @Param size query int false "Page size (default: 500)"
-// @Success 200 {object} responses.PaginatedResponse{items=[]entities.Booking}
+// @Success 200 {object} responses.PaginatedResponse{items=[]responses.AvailableSlotResponse}
 // @Failure 400 {object} responses.APIErrorResponse "Missing appointment code parameter"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
 // @Failure 500 {object} responses.APIErrorResponse "Internal server error"
 // @Router /appointments/slots/{app_code} [get]
 // @ID getAvailableSlots
@@ -148,7 +243,7 @@ func (h *Handler) GetAvailableSlots(c *gin.Context) {
 
 	slots, err := h.bookingService.GetAvailableSlots(c.Request, appcode)
 	if err != nil {
-		apierrors.InternalServerError(c, "Internal server error")
+		apierrors.HandleAppError(c, err)
 		return
 	}
 
@@ -156,15 +251,16 @@ func (h *Handler) GetAvailableSlots(c *gin.Context) {
 }
 
 // @Summary Get available slots for a specific day
-// @Description Retrieves a paginated list of available slots for an appointment on a specific day.
+// @Description Retrieves a paginated list of available slots for an appointment on a specific day of the appointment's time zone.
 // @Tags Bookings
 // @Produce  application/json
 // @Param   app_code    path   string  true  "Appointment identifier (app_code)"
-// @Param   date  query  string  true  "Date in YYYY-MM-DD format"
+// @Param   date  query  string  true  "Local date in YYYY-MM-DD format"
 // @Param page query int false "Page number (default: 1)"
 // @Param size query int false "Page size (default: 200)"
-// @Success 200 {object} responses.PaginatedResponse{items=[]entities.Booking}
+// @Success 200 {object} responses.PaginatedResponse{items=[]responses.AvailableSlotResponse}
 // @Failure 400 {object} responses.APIErrorResponse "Missing or invalid parameters"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
 // @Failure 500 {object} responses.APIErrorResponse "Internal server error"
 // @Router /appointments/slots/{app_code}/by-day [get]
 // @ID getAvailableSlotsByDay
@@ -183,7 +279,7 @@ func (h *Handler) GetAvailableSlotsByDay(c *gin.Context) {
 
 	slots, err := h.bookingService.GetAvailableSlotsByDay(c.Request, appcode, dateStr)
 	if err != nil {
-		apierrors.InternalServerError(c, "Internal server error")
+		apierrors.HandleAppError(c, err)
 		return
 	}
 
@@ -191,12 +287,13 @@ func (h *Handler) GetAvailableSlotsByDay(c *gin.Context) {
 }
 
 // @Summary Get available dates for an appointment
-// @Description Retrieves a list of dates that have at least one available slot.
+// @Description Retrieves the local dates that have at least one available slot, with the UTC bounds of each day.
 // @Tags Bookings
 // @Produce  application/json
 // @Param   app_code    path   string  true  "Appointment identifier (app_code)"
-// @Success 200 {array} string
+// @Success 200 {array} responses.AvailableDateResponse
 // @Failure 400 {object} responses.APIErrorResponse "Missing appointment code parameter"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
 // @Failure 500 {object} responses.APIErrorResponse "Internal server error"
 // @Router /appointments/dates/{app_code} [get]
 // @ID getAvailableDates
@@ -209,7 +306,7 @@ func (h *Handler) GetAvailableDates(c *gin.Context) {
 
 	dates, err := h.bookingService.GetAvailableDates(c.Request.Context(), appcode)
 	if err != nil {
-		apierrors.InternalServerError(c, "Internal server error")
+		apierrors.HandleAppError(c, err)
 		return
 	}
 
@@ -217,7 +314,7 @@ func (h *Handler) GetAvailableDates(c *gin.Context) {
 }
 
 // @Summary Get all bookings for an appointment
-// @Description Retrieves a paginated list of all users/bookings for a specific appointment.
+// @Description Retrieves a paginated list of all users/bookings for a specific appointment. Allowed for the appointment owner and any member of its organization.
 // @Tags Appointments
 // @Produce  application/json
 // @Param   app_code  path   string  true  "Appointment identifier (app_code)"
@@ -227,6 +324,7 @@ func (h *Handler) GetAvailableDates(c *gin.Context) {
 // @Success 200 {object} responses.PaginatedResponse{items=[]entities.Booking}
 // @Failure 400 {object} responses.APIErrorResponse "Missing appointment code parameter"
 // @Failure 401 {object} responses.APIErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.APIErrorResponse "No access to the appointment's bookings"
 // @Failure 500 {object} responses.APIErrorResponse "Internal server error"
 // @Router /appointments/users/{app_code} [get]
 // @ID getUsersRegisteredForAppointment
@@ -237,22 +335,57 @@ func (h *Handler) GetUsersRegisteredForAppointment(c *gin.Context) {
 		return
 	}
 
-	// userID, ok := middleware.GetUUIDFromContext(c)
-	// if !ok {
-	// 	apierrors.UnauthorizedError(c, "Unauthorized")
-	// 	return
-	// }
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "Unauthorized")
+		return
+	}
 
 	ctx := c.Request.Context()
-	bookings, err := h.bookingService.GetAllBookingsForAppointment(ctx, c.Request, appCode)
+	bookings, err := h.bookingService.GetAllBookingsForAppointment(ctx, c.Request, appCode, userID)
 	if err != nil {
-		apierrors.InternalServerError(c, "Internal server error")
+		apierrors.HandleAppError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, bookings)
 }
 
+// @Summary Export attendees for an appointment
+// @Description Downloads the appointment's active bookings as CSV, including a column for each custom form field. Only the appointment owner and members of its organization may export.
+// @Tags Appointments
+// @Produce  text/csv
+// @Param   app_code  path   string  true  "Appointment identifier (app_code)"
+// @Security BearerAuth
+// @Success 200 {file} file "CSV attendee list"
+// @Failure 401 {object} responses.APIErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.APIErrorResponse "No access to the appointment's bookings"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Router /appointments/users/{app_code}/export [get]
+// @ID exportAppointmentAttendees
+func (h *Handler) ExportAppointmentAttendees(c *gin.Context) {
+	appCode := c.Param("app_code")
+	if appCode == "" {
+		apierrors.BadRequestError(c, "Missing appointment code parameter")
+		return
+	}
+
+	ownerID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "Unauthorized")
+		return
+	}
+
+	data, err := h.bookingService.ExportAttendees(appCode, ownerID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", appCode+"-attendees.csv"))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
 // @Summary Get booking by code
 // @Description Retrieves booking details by its unique booking_code.
 // @Tags Bookings
@@ -288,6 +421,7 @@ func (h *Handler) GetBookingByCodeHandler(c *gin.Context) {
 // @Param   booking      body   requests.BookingRequest  true  "New Booking Details"
 // @Success 200 {object} entities.Booking
 // @Failure 400 {object} responses.APIErrorResponse "Invalid request, validation error, or slot not available"
+// @Failure 403 {object} responses.APIErrorResponse "Reschedule cutoff passed or reschedule limit reached"
 // @Failure 404 {object} responses.APIErrorResponse "Booking not found"
 // @Failure 409 {object} responses.APIErrorResponse "Requested slot not available or capacity exceeded"
 // @Router /bookings/{booking_code} [put]
@@ -311,7 +445,7 @@ func (h *Handler) UpdateBookingByCodeHandler(c *gin.Context) {
 		return
 	}
 
-	booking, err := h.bookingService.UpdateBookingByCode(code, req)
+	booking, err := h.bookingService.UpdateBookingByCode(code, req, middleware.GetUserIDFromContext(c))
 	if err != nil {
 		apierrors.HandleAppError(c, err)
 		return
@@ -321,7 +455,7 @@ func (h *Handler) UpdateBookingByCodeHandler(c *gin.Context) {
 }
 
 // @Summary Confirm a booking
-// @Description Confirms a pending booking by its unique booking_code.
+// @Description Confirms a pending booking by its unique booking_code. Allowed for the appointment owner and organization staff, admins and owners.
 // @Tags Bookings
 // @Produce  application/json
 // @Param   booking_code  path   string  true  "Unique Booking Code"
@@ -362,6 +496,7 @@ func (h *Handler) ConfirmBookingHandler(c *gin.Context) {
 // @Param   booking_code  path   string  true  "Unique Booking Code"
 // @Success 200 {object} entities.Booking
 // @Failure 400 {object} responses.APIErrorResponse "Error while cancelling booking"
+// @Failure 403 {object} responses.APIErrorResponse "Cancellation cutoff passed"
 // @Failure 404 {object} responses.APIErrorResponse "Booking not found"
 // @Router /bookings/{booking_code} [delete]
 // @ID cancelBookingByCode
@@ -372,7 +507,7 @@ func (h *Handler) CancelBookingByCodeHandler(c *gin.Context) {
 		return
 	}
 
-	booking, err := h.bookingService.CancelBookingByCode(code)
+	booking, err := h.bookingService.CancelBookingByCode(code, middleware.GetUserIDFromContext(c))
 	if err != nil {
 		apierrors.HandleAppError(c, err)
 		return
@@ -382,7 +517,7 @@ func (h *Handler) CancelBookingByCodeHandler(c *gin.Context) {
 }
 
 // @Summary Reject a booking
-// @Description Rejects a booking by its unique booking_code. This is a soft delete.
+// @Description Rejects a booking by its unique booking_code. This is a soft delete. Allowed for the appointment owner and organization staff, admins and owners.
 // @Tags Bookings
 // @Produce  application/json
 // @Param   booking_code  path   string  true  "Unique Booking Code"
@@ -414,3 +549,140 @@ func (h *Handler) RejectBookingHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, booking)
 }
+
+// @Summary Check in a booking
+// @Description Records that the guest has arrived. Bookings that have already ended are marked attended.
+// @Tags Bookings
+// @Produce  application/json
+// @Param   booking_code  path   string  true  "Unique Booking Code"
+// @Security BearerAuth
+// @Success 200 {object} entities.Booking
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request"
+// @Failure 401 {object} responses.APIErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.APIErrorResponse "Forbidden"
+// @Failure 404 {object} responses.APIErrorResponse "Booking not found"
+// @Failure 409 {object} responses.APIErrorResponse "Booking cannot be checked in"
+// @Router /bookings/{booking_code}/check-in [post]
+// @ID checkInBookingByCode
+func (h *Handler) CheckInBookingHandler(c *gin.Context) {
+	code := c.Param("booking_code")
+	if code == "" {
+		apierrors.BadRequestError(c, "Missing booking_code parameter")
+		return
+	}
+
+	ownerID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "Unauthorized")
+		return
+	}
+
+	booking, err := h.bookingService.CheckInBooking(code, ownerID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, booking)
+}
+
+// @Summary Mark a booking as no-show
+// @Description Records that the guest never turned up. Only allowed once the booking has started.
+// @Tags Bookings
+// @Produce  application/json
+// @Param   booking_code  path   string  true  "Unique Booking Code"
+// @Security BearerAuth
+// @Success 200 {object} entities.Booking
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request"
+// @Failure 401 {object} responses.APIErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.APIErrorResponse "Forbidden"
+// @Failure 404 {object} responses.APIErrorResponse "Booking not found"
+// @Failure 409 {object} responses.APIErrorResponse "Booking cannot be marked no-show"
+// @Router /bookings/{booking_code}/no-show [post]
+// @ID markBookingNoShow
+func (h *Handler) MarkNoShowHandler(c *gin.Context) {
+	code := c.Param("booking_code")
+	if code == "" {
+		apierrors.BadRequestError(c, "Missing booking_code parameter")
+		return
+	}
+
+	ownerID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "Unauthorized")
+		return
+	}
+
+	booking, err := h.bookingService.MarkNoShow(code, ownerID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, booking)
+}
+
+// @Summary Get a booking ticket
+// @Description Returns the booking's signed ticket as a QR code PNG to present at check-in.
+// @Tags Bookings
+// @Produce  image/png
+// @Param   booking_code  path   string  true  "Unique Booking Code"
+// @Success 200 {file} file "Ticket QR code PNG"
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request"
+// @Failure 404 {object} responses.APIErrorResponse "Booking not found"
+// @Failure 409 {object} responses.APIErrorResponse "Booking no longer admits guests"
+// @Router /bookings/{booking_code}/ticket [get]
+// @ID getBookingTicket
+func (h *Handler) GetBookingTicketHandler(c *gin.Context) {
+	code := c.Param("booking_code")
+	if code == "" {
+		apierrors.BadRequestError(c, "Missing booking_code parameter")
+		return
+	}
+
+	png, err := h.bookingService.GetBookingTicket(code)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", code+"-ticket.png"))
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+// @Summary Scan a ticket
+// @Description Validates a scanned ticket and checks the guest in. Each ticket admits once.
+// @Tags Bookings
+// @Accept  json
+// @Produce  application/json
+// @Param   request body requests.TicketScanRequest true "Scanned ticket"
+// @Security BearerAuth
+// @Success 200 {object} entities.Booking
+// @Failure 400 {object} responses.APIErrorResponse "Invalid ticket"
+// @Failure 401 {object} responses.APIErrorResponse "Unauthorized"
+// @Failure 403 {object} responses.APIErrorResponse "Forbidden"
+// @Failure 404 {object} responses.APIErrorResponse "Booking not found"
+// @Failure 409 {object} responses.APIErrorResponse "Ticket already scanned"
+// @Router /tickets/scan [post]
+// @ID scanTicket
+func (h *Handler) ScanTicketHandler(c *gin.Context) {
+	var req requests.TicketScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "Invalid request payload")
+		return
+	}
+
+	ownerID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "Unauthorized")
+		return
+	}
+
+	booking, err := h.bookingService.ScanTicket(req, ownerID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, booking)
+}
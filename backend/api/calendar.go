@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/m13ha/asiko/errors/apierrors"
+	"github.com/m13ha/asiko/middleware"
+	"github.com/m13ha/asiko/models/responses"
+)
+
+// @Summary Create a calendar feed URL
+// @Description Issues a new tokenized iCalendar feed URL listing the owner's bookings. Any previous feed URL stops working.
+// @Tags Calendar
+// @Produce  application/json
+// @Security BearerAuth
+// @Success 201 {object} responses.CalendarFeedResponse
+// @Failure 401 {object} responses.APIErrorResponse "Unauthorized"
+// @Failure 404 {object} responses.APIErrorResponse "User not found"
+// @Router /calendar/feed-token [post]
+// @ID rotateCalendarFeedToken
+func (h *Handler) RotateCalendarFeedToken(c *gin.Context) {
+	ownerID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "Unauthorized")
+		return
+	}
+
+	token, err := h.bookingService.RotateCalendarFeedToken(ownerID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.CalendarFeedResponse{
+		Token: token,
+		URL:   requestBaseURL(c) + "/calendar/feeds/" + token + ".ics",
+	})
+}
+
+// @Summary Get a calendar feed
+// @Description Returns the owner's bookings as a read-only iCalendar feed for calendar subscriptions.
+// @Tags Calendar
+// @Produce  text/calendar
+// @Param   token  path   string  true  "Calendar feed token, optionally suffixed with .ics"
+// @Success 200 {file} file "iCalendar feed"
+// @Failure 404 {object} responses.APIErrorResponse "Calendar feed not found"
+// @Router /calendar/feeds/{token} [get]
+// @ID getCalendarFeed
+func (h *Handler) GetCalendarFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	feed, err := h.bookingService.GetCalendarFeed(token)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
+
+// requestBaseURL is the scheme and host the client reached the API on, honouring proxies.
+func requestBaseURL(c *gin.Context) string {
+	scheme := "http"
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+	} else if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
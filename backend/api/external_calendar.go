@@ -0,0 +1,156 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/m13ha/asiko/errors/apierrors"
+	"github.com/m13ha/asiko/middleware"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/models/responses"
+)
+
+// @Summary List external calendars
+// @Description Lists the owner's imported and subscribed calendars whose busy times block slots.
+// @Tags Calendar
+// @Produce  application/json
+// @Security BearerAuth
+// @Success 200 {array} entities.ExternalCalendar
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Router /calendar/external [get]
+// @ID listExternalCalendars
+func (h *Handler) ListExternalCalendars(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	calendars, err := h.externalCalendarService.ListCalendars(userID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendars)
+}
+
+// @Summary Subscribe to an external calendar
+// @Description Registers an ICS feed URL. Its busy times block overlapping slots and are re-synced periodically.
+// @Tags Calendar
+// @Accept  application/json
+// @Produce  application/json
+// @Param   calendar  body   requests.ExternalCalendarRequest  true  "Calendar feed"
+// @Security BearerAuth
+// @Success 201 {object} entities.ExternalCalendar
+// @Failure 400 {object} responses.APIErrorResponse "Invalid URL or the feed could not be loaded"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Router /calendar/external [post]
+// @ID subscribeExternalCalendar
+func (h *Handler) SubscribeExternalCalendar(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	var req requests.ExternalCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	calendar, err := h.externalCalendarService.SubscribeCalendar(userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, calendar)
+}
+
+// @Summary Import an ICS file
+// @Description Uploads an ICS file whose busy times block overlapping slots until the import is deleted.
+// @Tags Calendar
+// @Accept  multipart/form-data
+// @Produce  application/json
+// @Param   file  formData  file    true   "ICS file"
+// @Param   name  formData  string  false  "Calendar name"
+// @Security BearerAuth
+// @Success 201 {object} entities.ExternalCalendar
+// @Failure 400 {object} responses.APIErrorResponse "Missing or invalid ICS file"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Router /calendar/external/import [post]
+// @ID importExternalCalendar
+func (h *Handler) ImportExternalCalendar(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		apierrors.BadRequestError(c, "an ICS file is required")
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		apierrors.BadRequestError(c, "could not read the uploaded file")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		apierrors.BadRequestError(c, "could not read the uploaded file")
+		return
+	}
+
+	name := c.PostForm("name")
+	if name == "" {
+		name = header.Filename
+	}
+
+	calendar, err := h.externalCalendarService.ImportCalendar(userID, name, data)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, calendar)
+}
+
+// @Summary Delete an external calendar
+// @Description Removes an imported or subscribed calendar and releases the slots it was blocking.
+// @Tags Calendar
+// @Produce  application/json
+// @Param   id  path  string  true  "External calendar ID"
+// @Security BearerAuth
+// @Success 200 {object} responses.SimpleMessage
+// @Failure 400 {object} responses.APIErrorResponse "Invalid id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 404 {object} responses.APIErrorResponse "External calendar not found"
+// @Router /calendar/external/{id} [delete]
+// @ID deleteExternalCalendar
+func (h *Handler) DeleteExternalCalendar(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	calendarID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid calendar id")
+		return
+	}
+
+	if err := h.externalCalendarService.DeleteCalendar(userID, calendarID); err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SimpleMessage{Message: "External calendar deleted"})
+}
@@ -13,9 +13,15 @@ type Handler struct {
 	analyticsService         services.AnalyticsService
 	banService               services.BanListService
 	eventNotificationService services.EventNotificationService
+	exceptionService         services.AvailabilityExceptionService
+	externalCalendarService  services.ExternalCalendarService
+	organizationService      services.OrganizationService
+	resourceService          services.ResourceService
+	paymentService           services.PaymentService
+	promoCodeService         services.PromoCodeService
 }
 
-func NewHandler(userService services.UserService, appointmentService services.AppointmentService, bookingService services.BookingService, analyticsService services.AnalyticsService, banServices services.BanListService, eventNotificationService services.EventNotificationService) *Handler {
+func NewHandler(userService services.UserService, appointmentService services.AppointmentService, bookingService services.BookingService, analyticsService services.AnalyticsService, banServices services.BanListService, eventNotificationService services.EventNotificationService, exceptionService services.AvailabilityExceptionService, externalCalendarService services.ExternalCalendarService, organizationService services.OrganizationService, resourceService services.ResourceService, paymentService services.PaymentService, promoCodeService services.PromoCodeService) *Handler {
 	return &Handler{
 		userService:              userService,
 		appointmentService:       appointmentService,
@@ -23,11 +29,17 @@ func NewHandler(userService services.UserService, appointmentService services.Ap
 		analyticsService:         analyticsService,
 		banService:               banServices,
 		eventNotificationService: eventNotificationService,
+		exceptionService:         exceptionService,
+		externalCalendarService:  externalCalendarService,
+		organizationService:      organizationService,
+		resourceService:          resourceService,
+		paymentService:           paymentService,
+		promoCodeService:         promoCodeService,
 	}
 }
 
-func RegisterRoutes(r *gin.Engine, userService services.UserService, appointmentService services.AppointmentService, bookingService services.BookingService, analyticsService services.AnalyticsService, banServices services.BanListService, eventNotificationService services.EventNotificationService) {
-	h := NewHandler(userService, appointmentService, bookingService, analyticsService, banServices, eventNotificationService)
+func RegisterRoutes(r *gin.Engine, userService services.UserService, appointmentService services.AppointmentService, bookingService services.BookingService, analyticsService services.AnalyticsService, banServices services.BanListService, eventNotificationService services.EventNotificationService, exceptionService services.AvailabilityExceptionService, externalCalendarService services.ExternalCalendarService, organizationService services.OrganizationService, resourceService services.ResourceService, paymentService services.PaymentService, promoCodeService services.PromoCodeService) {
+	h := NewHandler(userService, appointmentService, bookingService, analyticsService, banServices, eventNotificationService, exceptionService, externalCalendarService, organizationService, resourceService, paymentService, promoCodeService)
 
 	r.POST("/login", h.Login)
 	r.POST("/logout", h.Logout)
@@ -40,23 +52,51 @@ func RegisterRoutes(r *gin.Engine, userService services.UserService, appointment
 	r.POST("/auth/reset-password", h.ResetPasswordHandler)
 	r.POST("/auth/change-password", middleware.AuthMiddleware(), h.ChangePasswordHandler)
 	r.POST("/appointments/book", h.BookGuestAppointment)
+	r.POST("/appointments/waitlist", middleware.OptionalAuthMiddleware(), h.JoinWaitlist)
+	r.POST("/appointments/holds", h.HoldSlot)
 	r.GET("/appointments/code/:app_code", h.GetAppointmentByAppCode)
 	r.GET("/appointments/slots/:app_code", h.GetAvailableSlots)
 	r.GET("/appointments/dates/:app_code", h.GetAvailableDates)
 	r.GET("/appointments/slots/:app_code/by-day", h.GetAvailableSlotsByDay)
 	r.GET("/bookings/:booking_code", h.GetBookingByCodeHandler)
-	r.PUT("/bookings/:booking_code", h.UpdateBookingByCodeHandler)
-	r.DELETE("/bookings/:booking_code", h.CancelBookingByCodeHandler)
+	r.PUT("/bookings/:booking_code", middleware.OptionalAuthMiddleware(), h.UpdateBookingByCodeHandler)
+	r.DELETE("/bookings/:booking_code", middleware.OptionalAuthMiddleware(), h.CancelBookingByCodeHandler)
 	r.POST("/bookings/:booking_code/confirm", middleware.AuthMiddleware(), h.ConfirmBookingHandler)
 	r.POST("/bookings/:booking_code/reject", middleware.AuthMiddleware(), h.RejectBookingHandler)
+	r.POST("/bookings/:booking_code/check-in", middleware.AuthMiddleware(), h.CheckInBookingHandler)
+	r.POST("/bookings/:booking_code/no-show", middleware.AuthMiddleware(), h.MarkNoShowHandler)
+	r.GET("/bookings/:booking_code/ticket", h.GetBookingTicketHandler)
+	r.POST("/bookings/:booking_code/payment", h.CreateBookingPaymentHandler)
+	r.POST("/payments/webhook", h.PaymentWebhookHandler)
+	r.POST("/tickets/scan", middleware.AuthMiddleware(), h.ScanTicketHandler)
+	r.GET("/calendar/feeds/:token", h.GetCalendarFeed)
+	r.POST("/calendar/feed-token", middleware.AuthMiddleware(), h.RotateCalendarFeedToken)
+	r.GET("/calendar/external", middleware.AuthMiddleware(), h.ListExternalCalendars)
+	r.POST("/calendar/external", middleware.AuthMiddleware(), h.SubscribeExternalCalendar)
+	r.POST("/calendar/external/import", middleware.AuthMiddleware(), h.ImportExternalCalendar)
+	r.DELETE("/calendar/external/:id", middleware.AuthMiddleware(), h.DeleteExternalCalendar)
 
 	// Protected routes with authentication middleware
 	r.POST("/appointments", middleware.AuthMiddleware(), h.CreateAppointment)
 	r.PATCH("/appointments/:id", middleware.AuthMiddleware(), h.UpdateAppointment)
 	r.DELETE("/appointments/:id", middleware.AuthMiddleware(), h.DeleteAppointment)
+	r.POST("/appointments/:id/publish", middleware.AuthMiddleware(), h.PublishAppointment)
+	r.POST("/appointments/:id/pause", middleware.AuthMiddleware(), h.PauseAppointment)
+	r.POST("/appointments/:id/resume", middleware.AuthMiddleware(), h.ResumeAppointment)
+	r.POST("/appointments/:id/archive", middleware.AuthMiddleware(), h.ArchiveAppointment)
+	r.GET("/appointments/:id/exceptions", middleware.AuthMiddleware(), h.ListAvailabilityExceptions)
+	r.POST("/appointments/:id/exceptions", middleware.AuthMiddleware(), h.CreateAvailabilityException)
+	r.PUT("/appointments/:id/exceptions/:exception_id", middleware.AuthMiddleware(), h.UpdateAvailabilityException)
+	r.DELETE("/appointments/:id/exceptions/:exception_id", middleware.AuthMiddleware(), h.DeleteAvailabilityException)
+	r.GET("/appointments/:id/hosts", middleware.AuthMiddleware(), h.ListAppointmentHosts)
+	r.PUT("/appointments/:id/hosts", middleware.AuthMiddleware(), h.SetAppointmentHosts)
+	r.GET("/appointments/:id/resources", middleware.AuthMiddleware(), h.ListAppointmentResources)
+	r.PUT("/appointments/:id/resources", middleware.AuthMiddleware(), h.SetAppointmentResources)
 	r.GET("/appointments/my", middleware.AuthMiddleware(), h.GetAppointmentsCreatedByUser)
 	r.GET("/appointments/registered", middleware.AuthMiddleware(), h.GetUserRegisteredBookings)
+	r.GET("/appointments/assigned", middleware.AuthMiddleware(), h.GetHostAssignedBookings)
 	r.GET("/appointments/users/:app_code", middleware.AuthMiddleware(), h.GetUsersRegisteredForAppointment)
+	r.GET("/appointments/users/:app_code/export", middleware.AuthMiddleware(), h.ExportAppointmentAttendees)
 	r.POST("/appointments/book/registered", middleware.AuthMiddleware(), h.BookRegisteredUserAppointment)
 	r.GET("/analytics", middleware.AuthMiddleware(), h.GetUserAnalytics)
 
@@ -67,6 +107,34 @@ func RegisterRoutes(r *gin.Engine, userService services.UserService, appointment
 		banList.GET("", h.GetBanList)
 	}
 
+	organizations := r.Group("/organizations", middleware.AuthMiddleware())
+	{
+		organizations.GET("", h.ListOrganizations)
+		organizations.POST("", h.CreateOrganization)
+		organizations.PATCH("/:id", h.UpdateOrganization)
+		organizations.DELETE("/:id", h.DeleteOrganization)
+		organizations.GET("/:id/members", h.ListOrganizationMembers)
+		organizations.POST("/:id/members", h.AddOrganizationMember)
+		organizations.PATCH("/:id/members/:user_id", h.UpdateOrganizationMember)
+		organizations.DELETE("/:id/members/:user_id", h.RemoveOrganizationMember)
+	}
+
+	resources := r.Group("/resources", middleware.AuthMiddleware())
+	{
+		resources.GET("", h.ListResources)
+		resources.POST("", h.CreateResource)
+		resources.PATCH("/:id", h.UpdateResource)
+		resources.DELETE("/:id", h.DeleteResource)
+	}
+
+	promoCodes := r.Group("/promo-codes", middleware.AuthMiddleware())
+	{
+		promoCodes.GET("", h.ListPromoCodes)
+		promoCodes.POST("", h.CreatePromoCode)
+		promoCodes.PATCH("/:id", h.UpdatePromoCode)
+		promoCodes.DELETE("/:id", h.DeletePromoCode)
+	}
+
 	notifications := r.Group("/notifications", middleware.AuthMiddleware())
 	{
 		notifications.GET("", h.GetNotificationsHandler)
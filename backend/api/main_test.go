@@ -16,8 +16,14 @@ func setupTestRouter() (*gin.Engine, *mocks.UserService, *mocks.AppointmentServi
 	mockAnalyticsService := new(mocks.AnalyticsService)
 	mockBanListService := new(mocks.BanListService)
 	mockEventNotificationService := new(mocks.EventNotificationService)
+	mockExceptionService := new(mocks.AvailabilityExceptionService)
+	mockExternalCalendarService := new(mocks.ExternalCalendarService)
+	mockOrganizationService := new(mocks.OrganizationService)
+	mockResourceService := new(mocks.ResourceService)
+	mockPaymentService := new(mocks.PaymentService)
+	mockPromoCodeService := new(mocks.PromoCodeService)
 
-	h := NewHandler(mockUserService, mockAppointmentService, mockBookingService, mockAnalyticsService, mockBanListService, mockEventNotificationService)
+	h := NewHandler(mockUserService, mockAppointmentService, mockBookingService, mockAnalyticsService, mockBanListService, mockEventNotificationService, mockExceptionService, mockExternalCalendarService, mockOrganizationService, mockResourceService, mockPaymentService, mockPromoCodeService)
 
 	router := gin.New()
 	router.Use(middleware.RequestID())
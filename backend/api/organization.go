@@ -0,0 +1,315 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/m13ha/asiko/errors/apierrors"
+	"github.com/m13ha/asiko/middleware"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/models/responses"
+)
+
+// @Summary List organizations
+// @Description Lists the organizations the user is a member of.
+// @Tags Organizations
+// @Produce  application/json
+// @Security BearerAuth
+// @Success 200 {array} entities.Organization
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Router /organizations [get]
+// @ID listOrganizations
+func (h *Handler) ListOrganizations(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	organizations, err := h.organizationService.ListOrganizations(userID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organizations)
+}
+
+// @Summary Create an organization
+// @Description Creates an organization with the caller as its owner. Appointments shared with it can be managed by its members according to their role.
+// @Tags Organizations
+// @Accept  application/json
+// @Produce  application/json
+// @Param   organization  body   requests.OrganizationRequest  true  "Organization details"
+// @Security BearerAuth
+// @Success 201 {object} entities.Organization
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Router /organizations [post]
+// @ID createOrganization
+func (h *Handler) CreateOrganization(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	var req requests.OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	organization, err := h.organizationService.CreateOrganization(userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, organization)
+}
+
+// @Summary Rename an organization
+// @Description Renames the organization. Owners only.
+// @Tags Organizations
+// @Accept  application/json
+// @Produce  application/json
+// @Param   id  path  string  true  "Organization ID"
+// @Param   organization  body   requests.OrganizationRequest  true  "Organization details"
+// @Security BearerAuth
+// @Success 200 {object} entities.Organization
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Role does not allow this action"
+// @Router /organizations/{id} [patch]
+// @ID updateOrganization
+func (h *Handler) UpdateOrganization(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid organization id")
+		return
+	}
+
+	var req requests.OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	organization, err := h.organizationService.UpdateOrganization(organizationID, userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// @Summary Delete an organization
+// @Description Deletes the organization and its memberships. Its appointments stay with the members who created them. Owners only.
+// @Tags Organizations
+// @Produce  application/json
+// @Param   id  path  string  true  "Organization ID"
+// @Security BearerAuth
+// @Success 200 {object} responses.SimpleMessage
+// @Failure 400 {object} responses.APIErrorResponse "Invalid organization id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Role does not allow this action"
+// @Router /organizations/{id} [delete]
+// @ID deleteOrganization
+func (h *Handler) DeleteOrganization(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid organization id")
+		return
+	}
+
+	if err := h.organizationService.DeleteOrganization(organizationID, userID); err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SimpleMessage{Message: "organization deleted"})
+}
+
+// @Summary List organization members
+// @Description Lists the organization's members and their roles. Any member may list them.
+// @Tags Organizations
+// @Produce  application/json
+// @Param   id  path  string  true  "Organization ID"
+// @Security BearerAuth
+// @Success 200 {array} responses.OrganizationMemberResponse
+// @Failure 400 {object} responses.APIErrorResponse "Invalid organization id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Not a member of the organization"
+// @Router /organizations/{id}/members [get]
+// @ID listOrganizationMembers
+func (h *Handler) ListOrganizationMembers(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid organization id")
+		return
+	}
+
+	members, err := h.organizationService.ListMembers(organizationID, userID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// @Summary Add an organization member
+// @Description Gives a registered user a role (owner, admin, staff or viewer). Owners and admins may add members; only owners may add owners.
+// @Tags Organizations
+// @Accept  application/json
+// @Produce  application/json
+// @Param   id  path  string  true  "Organization ID"
+// @Param   member  body   requests.OrganizationMemberRequest  true  "Member details"
+// @Security BearerAuth
+// @Success 201 {object} responses.OrganizationMemberResponse
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Role does not allow this action"
+// @Failure 404 {object} responses.APIErrorResponse "No user with that email"
+// @Failure 409 {object} responses.APIErrorResponse "Already a member"
+// @Router /organizations/{id}/members [post]
+// @ID addOrganizationMember
+func (h *Handler) AddOrganizationMember(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid organization id")
+		return
+	}
+
+	var req requests.OrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	member, err := h.organizationService.AddMember(organizationID, userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, member)
+}
+
+// @Summary Change a member's role
+// @Description Changes a member's role. Owners and admins may change roles; only owners may promote to or demote from owner, and the last owner cannot be demoted.
+// @Tags Organizations
+// @Accept  application/json
+// @Produce  application/json
+// @Param   id  path  string  true  "Organization ID"
+// @Param   user_id  path  string  true  "Member's user ID"
+// @Param   role  body   requests.OrganizationRoleRequest  true  "New role"
+// @Security BearerAuth
+// @Success 200 {object} responses.OrganizationMemberResponse
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Role does not allow this action"
+// @Failure 404 {object} responses.APIErrorResponse "Member not found"
+// @Failure 409 {object} responses.APIErrorResponse "Last owner cannot be demoted"
+// @Router /organizations/{id}/members/{user_id} [patch]
+// @ID updateOrganizationMember
+func (h *Handler) UpdateOrganizationMember(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid organization id")
+		return
+	}
+	memberUserID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid user id")
+		return
+	}
+
+	var req requests.OrganizationRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	member, err := h.organizationService.UpdateMemberRole(organizationID, userID, memberUserID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// @Summary Remove an organization member
+// @Description Removes a member. Members may remove themselves; owners and admins may remove others, and only owners may remove owners. The last owner cannot leave.
+// @Tags Organizations
+// @Produce  application/json
+// @Param   id  path  string  true  "Organization ID"
+// @Param   user_id  path  string  true  "Member's user ID"
+// @Security BearerAuth
+// @Success 200 {object} responses.SimpleMessage
+// @Failure 400 {object} responses.APIErrorResponse "Invalid organization or user id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Role does not allow this action"
+// @Failure 404 {object} responses.APIErrorResponse "Member not found"
+// @Failure 409 {object} responses.APIErrorResponse "Last owner cannot leave"
+// @Router /organizations/{id}/members/{user_id} [delete]
+// @ID removeOrganizationMember
+func (h *Handler) RemoveOrganizationMember(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	organizationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid organization id")
+		return
+	}
+	memberUserID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid user id")
+		return
+	}
+
+	if err := h.organizationService.RemoveMember(organizationID, userID, memberUserID); err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SimpleMessage{Message: "member removed"})
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/m13ha/asiko/errors/apierrors"
+	"github.com/m13ha/asiko/models/responses"
+)
+
+// maxWebhookBytes caps the payment webhook body read into memory.
+const maxWebhookBytes = 1 << 20
+
+// @Summary Start a booking payment
+// @Description Creates a payment intent for a booking on a paid appointment. Complete the payment with the provider using client_secret; the booking stays pending until the provider's webhook reports it paid, and is then confirmed. Call again to retry after a failed payment.
+// @Tags Payments
+// @Produce  application/json
+// @Param   booking_code  path   string  true  "Unique Booking Code"
+// @Success 201 {object} responses.PaymentIntentResponse
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request"
+// @Failure 404 {object} responses.APIErrorResponse "Booking not found"
+// @Failure 409 {object} responses.APIErrorResponse "Booking has nothing to pay or is already paid"
+// @Failure 500 {object} responses.APIErrorResponse "Payment provider error"
+// @Router /bookings/{booking_code}/payment [post]
+// @ID createBookingPayment
+func (h *Handler) CreateBookingPaymentHandler(c *gin.Context) {
+	code := c.Param("booking_code")
+	if code == "" {
+		apierrors.BadRequestError(c, "Missing booking_code parameter")
+		return
+	}
+
+	intent, err := h.paymentService.CreatePaymentIntent(c.Request.Context(), code)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, intent)
+}
+
+// @Summary Payment provider webhook
+// @Description Receives signed payment events from the payment provider. Successful payments mark the booking paid and confirm it; payments for bookings that were cancelled in the meantime are refunded.
+// @Tags Payments
+// @Accept  application/json
+// @Produce  application/json
+// @Success 200 {object} responses.SimpleMessage
+// @Failure 400 {object} responses.APIErrorResponse "Unreadable body"
+// @Failure 401 {object} responses.APIErrorResponse "Invalid signature"
+// @Router /payments/webhook [post]
+// @ID paymentWebhook
+func (h *Handler) PaymentWebhookHandler(c *gin.Context) {
+	payload, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBytes))
+	if err != nil {
+		apierrors.BadRequestError(c, "Could not read webhook body")
+		return
+	}
+
+	if err := h.paymentService.HandleWebhook(c.Request.Context(), payload, c.Request.Header); err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SimpleMessage{Message: "Webhook processed"})
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/m13ha/asiko/errors/apierrors"
+	"github.com/m13ha/asiko/middleware"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/models/responses"
+)
+
+// @Summary List promo codes
+// @Description Lists the user's promo codes with how often each has been redeemed.
+// @Tags Promo Codes
+// @Produce  application/json
+// @Security BearerAuth
+// @Success 200 {array} entities.PromoCode
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Router /promo-codes [get]
+// @ID listPromoCodes
+func (h *Handler) ListPromoCodes(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	promos, err := h.promoCodeService.ListPromoCodes(userID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promos)
+}
+
+// @Summary Create a promo code
+// @Description Creates a code guests can enter when booking for a percent or fixed discount off the price. Codes are case-insensitive and unique per owner. Limits cap redemptions in total and per guest email; cancelled bookings keep counting against them. Restricting the code to appointments applies it only to those, otherwise it applies to every appointment the user created.
+// @Tags Promo Codes
+// @Accept  application/json
+// @Produce  application/json
+// @Param   promo_code  body   requests.PromoCodeRequest  true  "Promo code details"
+// @Security BearerAuth
+// @Success 201 {object} entities.PromoCode
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Not allowed to manage a restricted appointment"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Failure 409 {object} responses.APIErrorResponse "Code already in use"
+// @Router /promo-codes [post]
+// @ID createPromoCode
+func (h *Handler) CreatePromoCode(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	var req requests.PromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	promo, err := h.promoCodeService.CreatePromoCode(c.Request.Context(), userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, promo)
+}
+
+// @Summary Update a promo code
+// @Description Replaces the promo code's details. Bookings that already used it keep their discount and keep counting against the new limits.
+// @Tags Promo Codes
+// @Accept  application/json
+// @Produce  application/json
+// @Param   id  path  string  true  "Promo code ID"
+// @Param   promo_code  body   requests.PromoCodeRequest  true  "Promo code details"
+// @Security BearerAuth
+// @Success 200 {object} entities.PromoCode
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Not the owner of this promo code"
+// @Failure 404 {object} responses.APIErrorResponse "Promo code or appointment not found"
+// @Failure 409 {object} responses.APIErrorResponse "Code already in use"
+// @Router /promo-codes/{id} [patch]
+// @ID updatePromoCode
+func (h *Handler) UpdatePromoCode(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	promoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid promo code id")
+		return
+	}
+
+	var req requests.PromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	promo, err := h.promoCodeService.UpdatePromoCode(c.Request.Context(), promoID, userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promo)
+}
+
+// @Summary Delete a promo code
+// @Description Deletes the promo code so it can no longer be redeemed. Bookings that used it keep their discount.
+// @Tags Promo Codes
+// @Produce  application/json
+// @Param   id  path  string  true  "Promo code ID"
+// @Security BearerAuth
+// @Success 200 {object} responses.SimpleMessage
+// @Failure 400 {object} responses.APIErrorResponse "Invalid promo code id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Not the owner of this promo code"
+// @Failure 404 {object} responses.APIErrorResponse "Promo code not found"
+// @Router /promo-codes/{id} [delete]
+// @ID deletePromoCode
+func (h *Handler) DeletePromoCode(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	promoID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid promo code id")
+		return
+	}
+
+	if err := h.promoCodeService.DeletePromoCode(promoID, userID); err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SimpleMessage{Message: "promo code deleted"})
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/m13ha/asiko/errors/apierrors"
+	"github.com/m13ha/asiko/middleware"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/models/responses"
+)
+
+// @Summary List resources
+// @Description Lists the rooms and equipment the user owns or that are shared with their organizations.
+// @Tags Resources
+// @Produce  application/json
+// @Security BearerAuth
+// @Success 200 {array} entities.Resource
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Router /resources [get]
+// @ID listResources
+func (h *Handler) ListResources(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	resources, err := h.resourceService.ListResources(userID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resources)
+}
+
+// @Summary Create a resource
+// @Description Creates a room or piece of equipment. Capacity is how many bookings, across every appointment requiring the resource, may use it at the same time; bookings in one group slot or party share a unit. Sharing it with an organization lets members who manage appointments there use it.
+// @Tags Resources
+// @Accept  application/json
+// @Produce  application/json
+// @Param   resource  body   requests.ResourceRequest  true  "Resource details"
+// @Security BearerAuth
+// @Success 201 {object} entities.Resource
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Role does not allow sharing with the organization"
+// @Router /resources [post]
+// @ID createResource
+func (h *Handler) CreateResource(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	var req requests.ResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	resource, err := h.resourceService.CreateResource(userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resource)
+}
+
+// @Summary Update a resource
+// @Description Replaces the resource's details. Lowering the capacity only limits new bookings.
+// @Tags Resources
+// @Accept  application/json
+// @Produce  application/json
+// @Param   id  path  string  true  "Resource ID"
+// @Param   resource  body   requests.ResourceRequest  true  "Resource details"
+// @Security BearerAuth
+// @Success 200 {object} entities.Resource
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Not allowed to manage this resource"
+// @Failure 404 {object} responses.APIErrorResponse "Resource not found"
+// @Router /resources/{id} [patch]
+// @ID updateResource
+func (h *Handler) UpdateResource(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	resourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid resource id")
+		return
+	}
+
+	var req requests.ResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	resource, err := h.resourceService.UpdateResource(resourceID, userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resource)
+}
+
+// @Summary Delete a resource
+// @Description Deletes the resource. Appointments that required it no longer do; existing bookings are kept.
+// @Tags Resources
+// @Produce  application/json
+// @Param   id  path  string  true  "Resource ID"
+// @Security BearerAuth
+// @Success 200 {object} responses.SimpleMessage
+// @Failure 400 {object} responses.APIErrorResponse "Invalid resource id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Not allowed to manage this resource"
+// @Failure 404 {object} responses.APIErrorResponse "Resource not found"
+// @Router /resources/{id} [delete]
+// @ID deleteResource
+func (h *Handler) DeleteResource(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	resourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid resource id")
+		return
+	}
+
+	if err := h.resourceService.DeleteResource(resourceID, userID); err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SimpleMessage{Message: "resource deleted"})
+}
+
+// @Summary List appointment resources
+// @Description Lists the resources every booking on the appointment uses.
+// @Tags Appointments
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Security BearerAuth
+// @Success 200 {array} entities.Resource
+// @Failure 400 {object} responses.APIErrorResponse "Invalid appointment id"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Not allowed to view this appointment"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment not found"
+// @Router /appointments/{id}/resources [get]
+// @ID listAppointmentResources
+func (h *Handler) ListAppointmentResources(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid appointment id")
+		return
+	}
+
+	resources, err := h.resourceService.ListAppointmentResources(c.Request.Context(), appointmentID, userID)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resources)
+}
+
+// @Summary Set appointment resources
+// @Description Replaces the resources every new booking on the appointment uses. A slot can only be booked while each resource has a free unit over its time range, so appointments sharing a room cannot book it at the same time. An empty list removes them.
+// @Tags Appointments
+// @Accept  application/json
+// @Produce  application/json
+// @Param   id  path  string  true  "Appointment ID"
+// @Param   resources  body   requests.AppointmentResourcesRequest  true  "Required resources"
+// @Security BearerAuth
+// @Success 200 {array} entities.Resource
+// @Failure 400 {object} responses.APIErrorResponse "Invalid request payload or validation error"
+// @Failure 401 {object} responses.APIErrorResponse "Authentication required"
+// @Failure 403 {object} responses.APIErrorResponse "Not allowed to manage this appointment or resource"
+// @Failure 404 {object} responses.APIErrorResponse "Appointment or resource not found"
+// @Router /appointments/{id}/resources [put]
+// @ID setAppointmentResources
+func (h *Handler) SetAppointmentResources(c *gin.Context) {
+	userID, ok := middleware.GetUUIDFromContext(c)
+	if !ok {
+		apierrors.UnauthorizedError(c, "authentication required")
+		return
+	}
+
+	appointmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.BadRequestError(c, "invalid appointment id")
+		return
+	}
+
+	var req requests.AppointmentResourcesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequestError(c, "invalid request payload: "+err.Error())
+		return
+	}
+
+	resources, err := h.resourceService.SetAppointmentResources(c.Request.Context(), appointmentID, userID, req)
+	if err != nil {
+		apierrors.HandleAppError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resources)
+}
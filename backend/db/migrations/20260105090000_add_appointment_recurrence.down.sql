@@ -0,0 +1,3 @@
+-- 20260105090000_add_appointment_recurrence.down.sql
+
+ALTER TABLE appointments DROP COLUMN IF EXISTS recurrence_rule;
@@ -0,0 +1,3 @@
+-- 20260105090000_add_appointment_recurrence.up.sql
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS recurrence_rule TEXT;
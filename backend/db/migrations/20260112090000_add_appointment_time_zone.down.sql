@@ -0,0 +1,3 @@
+-- 20260112090000_add_appointment_time_zone.down.sql
+
+ALTER TABLE appointments DROP COLUMN IF EXISTS time_zone;
@@ -0,0 +1,3 @@
+-- 20260112090000_add_appointment_time_zone.up.sql
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC';
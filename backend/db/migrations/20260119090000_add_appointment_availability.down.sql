@@ -0,0 +1,3 @@
+-- 20260119090000_add_appointment_availability.down.sql
+
+ALTER TABLE appointments DROP COLUMN IF EXISTS availability;
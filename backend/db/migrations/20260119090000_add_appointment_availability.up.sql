@@ -0,0 +1,3 @@
+-- 20260119090000_add_appointment_availability.up.sql
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS availability JSONB;
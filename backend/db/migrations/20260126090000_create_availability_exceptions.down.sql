@@ -0,0 +1,3 @@
+-- 20260126090000_create_availability_exceptions.down.sql
+
+DROP TABLE IF EXISTS availability_exceptions;
@@ -0,0 +1,15 @@
+-- 20260126090000_create_availability_exceptions.up.sql
+
+CREATE TABLE IF NOT EXISTS availability_exceptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    type TEXT NOT NULL,
+    start_time TEXT,
+    end_time TEXT,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_availability_exceptions_appointment_id ON availability_exceptions(appointment_id);
@@ -0,0 +1,4 @@
+-- 20260202090000_add_appointment_buffers.down.sql
+
+ALTER TABLE appointments DROP COLUMN IF EXISTS post_buffer_minutes;
+ALTER TABLE appointments DROP COLUMN IF EXISTS pre_buffer_minutes;
@@ -0,0 +1,4 @@
+-- 20260202090000_add_appointment_buffers.up.sql
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS pre_buffer_minutes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS post_buffer_minutes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- 20260209090000_add_appointment_booking_window.down.sql
+
+ALTER TABLE appointments DROP COLUMN IF EXISTS max_advance_days;
+ALTER TABLE appointments DROP COLUMN IF EXISTS min_notice_minutes;
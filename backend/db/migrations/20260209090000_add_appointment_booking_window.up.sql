@@ -0,0 +1,4 @@
+-- 20260209090000_add_appointment_booking_window.up.sql
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS min_notice_minutes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS max_advance_days INTEGER NOT NULL DEFAULT 0;
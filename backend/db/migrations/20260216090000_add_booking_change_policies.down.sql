@@ -0,0 +1,6 @@
+-- 20260216090000_add_booking_change_policies.down.sql
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS reschedule_count;
+ALTER TABLE appointments DROP COLUMN IF EXISTS max_reschedules;
+ALTER TABLE appointments DROP COLUMN IF EXISTS reschedule_cutoff_hours;
+ALTER TABLE appointments DROP COLUMN IF EXISTS cancellation_cutoff_hours;
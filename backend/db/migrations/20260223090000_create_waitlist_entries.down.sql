@@ -0,0 +1,3 @@
+-- 20260223090000_create_waitlist_entries.down.sql
+
+DROP TABLE IF EXISTS waitlist_entries;
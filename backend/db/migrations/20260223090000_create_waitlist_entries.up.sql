@@ -0,0 +1,22 @@
+-- 20260223090000_create_waitlist_entries.up.sql
+
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    app_code TEXT NOT NULL,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    name TEXT,
+    email TEXT,
+    phone TEXT,
+    attendee_count INTEGER NOT NULL DEFAULT 1,
+    description TEXT,
+    status TEXT NOT NULL DEFAULT 'waiting',
+    booking_id UUID REFERENCES bookings(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_queue ON waitlist_entries(appointment_id, start_time, status, created_at);
@@ -0,0 +1,3 @@
+-- 20260302090000_create_slot_holds.down.sql
+
+DROP TABLE IF EXISTS slot_holds;
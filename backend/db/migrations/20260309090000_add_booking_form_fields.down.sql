@@ -0,0 +1,5 @@
+-- 20260309090000_add_booking_form_fields.down.sql
+
+ALTER TABLE waitlist_entries DROP COLUMN IF EXISTS form_answers;
+ALTER TABLE bookings DROP COLUMN IF EXISTS form_answers;
+ALTER TABLE appointments DROP COLUMN IF EXISTS form_fields;
@@ -0,0 +1,5 @@
+-- 20260309090000_add_booking_form_fields.up.sql
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS form_fields JSONB;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS form_answers JSONB;
+ALTER TABLE waitlist_entries ADD COLUMN IF NOT EXISTS form_answers JSONB;
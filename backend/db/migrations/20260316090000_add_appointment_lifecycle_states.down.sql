@@ -0,0 +1,8 @@
+-- 20260316090000_add_appointment_lifecycle_states.down.sql
+
+-- Postgres cannot drop enum values, so rows are moved back onto the original statuses instead.
+UPDATE appointments SET status = 'pending' WHERE status IN ('draft', 'paused');
+UPDATE appointments SET status = 'completed' WHERE status = 'archived';
+
+DROP INDEX IF EXISTS idx_appointments_publish_at;
+ALTER TABLE appointments DROP COLUMN IF EXISTS publish_at;
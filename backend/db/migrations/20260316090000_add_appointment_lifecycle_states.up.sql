@@ -0,0 +1,9 @@
+-- 20260316090000_add_appointment_lifecycle_states.up.sql
+
+ALTER TYPE appointment_status ADD VALUE IF NOT EXISTS 'draft';
+ALTER TYPE appointment_status ADD VALUE IF NOT EXISTS 'paused';
+ALTER TYPE appointment_status ADD VALUE IF NOT EXISTS 'archived';
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_appointments_publish_at ON appointments(publish_at) WHERE publish_at IS NOT NULL;
@@ -0,0 +1,28 @@
+-- 20260323090000_add_booking_attendance.down.sql
+
+UPDATE bookings SET status = 'expired' WHERE status IN ('checked_in', 'attended', 'no_show');
+
+DROP INDEX IF EXISTS uniq_bookings_active_email;
+DROP INDEX IF EXISTS uniq_bookings_active_device;
+DROP INDEX IF EXISTS uniq_bookings_active_phone;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bookings_active_email
+    ON bookings (appointment_id, lower(email))
+    WHERE email IS NOT NULL
+      AND email <> ''
+      AND status IN ('active', 'ongoing', 'pending', 'confirmed');
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bookings_active_device
+    ON bookings (appointment_id, device_id)
+    WHERE device_id IS NOT NULL
+      AND device_id <> ''
+      AND status IN ('active', 'ongoing', 'pending', 'confirmed');
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bookings_active_phone
+    ON bookings (appointment_id, phone)
+    WHERE phone IS NOT NULL
+      AND phone <> ''
+      AND status IN ('active', 'ongoing', 'pending', 'confirmed');
+
+ALTER TABLE appointments DROP COLUMN IF EXISTS no_show_grace_minutes;
+ALTER TABLE bookings DROP COLUMN IF EXISTS checked_in_at;
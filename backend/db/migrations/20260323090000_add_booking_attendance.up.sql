@@ -0,0 +1,27 @@
+-- 20260323090000_add_booking_attendance.up.sql
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS no_show_grace_minutes INTEGER NOT NULL DEFAULT 0;
+
+-- Checked-in guests still hold their booking, so they keep counting towards the anti-scalping limits.
+DROP INDEX IF EXISTS uniq_bookings_active_email;
+DROP INDEX IF EXISTS uniq_bookings_active_device;
+DROP INDEX IF EXISTS uniq_bookings_active_phone;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bookings_active_email
+    ON bookings (appointment_id, lower(email))
+    WHERE email IS NOT NULL
+      AND email <> ''
+      AND status IN ('active', 'ongoing', 'pending', 'confirmed', 'checked_in');
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bookings_active_device
+    ON bookings (appointment_id, device_id)
+    WHERE device_id IS NOT NULL
+      AND device_id <> ''
+      AND status IN ('active', 'ongoing', 'pending', 'confirmed', 'checked_in');
+
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bookings_active_phone
+    ON bookings (appointment_id, phone)
+    WHERE phone IS NOT NULL
+      AND phone <> ''
+      AND status IN ('active', 'ongoing', 'pending', 'confirmed', 'checked_in');
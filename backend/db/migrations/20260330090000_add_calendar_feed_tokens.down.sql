@@ -0,0 +1,4 @@
+-- 20260330090000_add_calendar_feed_tokens.down.sql
+
+DROP INDEX IF EXISTS idx_users_calendar_feed_token;
+ALTER TABLE users DROP COLUMN IF EXISTS calendar_feed_token;
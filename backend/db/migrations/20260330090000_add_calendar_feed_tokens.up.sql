@@ -0,0 +1,5 @@
+-- 20260330090000_add_calendar_feed_tokens.up.sql
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS calendar_feed_token TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_calendar_feed_token ON users(calendar_feed_token);
@@ -0,0 +1,4 @@
+-- 20260406090000_add_external_calendars.down.sql
+
+DROP TABLE IF EXISTS external_calendars;
+ALTER TABLE bookings DROP COLUMN IF EXISTS blocked;
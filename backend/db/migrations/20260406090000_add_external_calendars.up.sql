@@ -0,0 +1,17 @@
+-- 20260406090000_add_external_calendars.up.sql
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS blocked BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS external_calendars (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    url TEXT NOT NULL DEFAULT '',
+    busy JSONB,
+    last_synced_at TIMESTAMP WITH TIME ZONE,
+    sync_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_external_calendars_owner_id ON external_calendars(owner_id);
@@ -0,0 +1,4 @@
+-- 20260413090000_add_host_conflicts.down.sql
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS host_conflict;
+ALTER TABLE appointments DROP COLUMN IF EXISTS allow_parallel_bookings;
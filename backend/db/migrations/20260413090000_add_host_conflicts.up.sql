@@ -0,0 +1,4 @@
+-- 20260413090000_add_host_conflicts.up.sql
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS allow_parallel_bookings BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS host_conflict BOOLEAN NOT NULL DEFAULT FALSE;
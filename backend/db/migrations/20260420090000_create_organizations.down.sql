@@ -0,0 +1,6 @@
+-- 20260420090000_create_organizations.down.sql
+
+DROP INDEX IF EXISTS idx_appointments_organization_id;
+ALTER TABLE appointments DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,7 @@
+-- 20260427090000_add_appointment_hosts.down.sql
+
+DROP INDEX IF EXISTS idx_bookings_host_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS host_id;
+ALTER TABLE appointments DROP COLUMN IF EXISTS last_host_id;
+ALTER TABLE appointments DROP COLUMN IF EXISTS host_assignment;
+DROP TABLE IF EXISTS appointment_hosts;
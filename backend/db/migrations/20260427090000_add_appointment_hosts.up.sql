@@ -0,0 +1,19 @@
+-- 20260427090000_add_appointment_hosts.up.sql
+
+CREATE TABLE IF NOT EXISTS appointment_hosts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    priority INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_appointment_hosts_appointment_user ON appointment_hosts(appointment_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_appointment_hosts_user_id ON appointment_hosts(user_id);
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS host_assignment VARCHAR(16) NOT NULL DEFAULT 'round_robin'
+    CHECK (host_assignment IN ('round_robin', 'least_busy', 'priority'));
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS last_host_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS host_id UUID REFERENCES users(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_bookings_host_id ON bookings(host_id);
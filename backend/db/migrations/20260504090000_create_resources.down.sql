@@ -0,0 +1,4 @@
+-- 20260504090000_create_resources.down.sql
+
+DROP TABLE IF EXISTS appointment_resources;
+DROP TABLE IF EXISTS resources;
@@ -0,0 +1,27 @@
+-- 20260504090000_create_resources.up.sql
+
+CREATE TABLE IF NOT EXISTS resources (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    capacity INTEGER NOT NULL DEFAULT 1 CHECK (capacity >= 1),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_resources_owner_id ON resources(owner_id);
+CREATE INDEX IF NOT EXISTS idx_resources_organization_id ON resources(organization_id);
+CREATE INDEX IF NOT EXISTS idx_resources_deleted_at ON resources(deleted_at);
+
+CREATE TABLE IF NOT EXISTS appointment_resources (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    resource_id UUID NOT NULL REFERENCES resources(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_appointment_resources_appointment_resource ON appointment_resources(appointment_id, resource_id);
+CREATE INDEX IF NOT EXISTS idx_appointment_resources_resource_id ON appointment_resources(resource_id);
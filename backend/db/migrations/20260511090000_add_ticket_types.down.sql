@@ -0,0 +1,6 @@
+-- 20260511090000_add_ticket_types.down.sql
+
+ALTER TABLE waitlist_entries DROP COLUMN IF EXISTS ticket_type;
+DROP INDEX IF EXISTS idx_bookings_appointment_ticket_type;
+ALTER TABLE bookings DROP COLUMN IF EXISTS ticket_type;
+ALTER TABLE appointments DROP COLUMN IF EXISTS ticket_types;
@@ -0,0 +1,8 @@
+-- 20260511090000_add_ticket_types.up.sql
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS ticket_types JSONB;
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS ticket_type VARCHAR(64) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_bookings_appointment_ticket_type ON bookings(appointment_id, ticket_type);
+
+ALTER TABLE waitlist_entries ADD COLUMN IF NOT EXISTS ticket_type VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+-- 20260518090000_add_payments.down.sql
+
+DROP TABLE IF EXISTS payments;
+ALTER TABLE bookings DROP COLUMN IF EXISTS payment_status;
+ALTER TABLE bookings DROP COLUMN IF EXISTS currency;
+ALTER TABLE bookings DROP COLUMN IF EXISTS amount_due;
+ALTER TABLE appointments DROP COLUMN IF EXISTS refund_cutoff_hours;
+ALTER TABLE appointments DROP COLUMN IF EXISTS currency;
+ALTER TABLE appointments DROP COLUMN IF EXISTS price;
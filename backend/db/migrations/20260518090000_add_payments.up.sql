@@ -0,0 +1,31 @@
+-- 20260518090000_add_payments.up.sql
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS price BIGINT NOT NULL DEFAULT 0 CHECK (price >= 0);
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS refund_cutoff_hours INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS amount_due BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS payment_status VARCHAR(16) NOT NULL DEFAULT ''
+    CHECK (payment_status IN ('', 'due', 'paid', 'refunded'));
+
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_code VARCHAR(255) NOT NULL,
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    provider VARCHAR(32) NOT NULL,
+    intent_id VARCHAR(255) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'succeeded', 'failed', 'refunded')),
+    refund_id VARCHAR(255) NOT NULL DEFAULT '',
+    paid_at TIMESTAMP WITH TIME ZONE,
+    refunded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_intent_id ON payments(intent_id);
+CREATE INDEX IF NOT EXISTS idx_payments_booking_code ON payments(booking_code);
+CREATE INDEX IF NOT EXISTS idx_payments_appointment_id ON payments(appointment_id);
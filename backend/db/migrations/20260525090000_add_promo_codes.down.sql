@@ -0,0 +1,9 @@
+-- 20260525090000_add_promo_codes.down.sql
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS discount_amount;
+ALTER TABLE bookings DROP COLUMN IF EXISTS discount_value;
+ALTER TABLE bookings DROP COLUMN IF EXISTS discount_type;
+ALTER TABLE bookings DROP COLUMN IF EXISTS promo_code;
+DROP TABLE IF EXISTS promo_code_redemptions;
+DROP TABLE IF EXISTS promo_code_appointments;
+DROP TABLE IF EXISTS promo_codes;
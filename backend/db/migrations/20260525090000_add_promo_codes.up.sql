@@ -0,0 +1,51 @@
+-- 20260525090000_add_promo_codes.up.sql
+
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(64) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    discount_type VARCHAR(16) NOT NULL CHECK (discount_type IN ('percent', 'fixed')),
+    discount_value BIGINT NOT NULL CHECK (discount_value > 0),
+    currency VARCHAR(3),
+    max_redemptions INTEGER NOT NULL DEFAULT 0 CHECK (max_redemptions >= 0),
+    max_per_email INTEGER NOT NULL DEFAULT 0 CHECK (max_per_email >= 0),
+    redemptions INTEGER NOT NULL DEFAULT 0,
+    valid_from TIMESTAMP WITH TIME ZONE,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promo_codes_owner_code ON promo_codes(owner_id, code) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_promo_codes_code ON promo_codes(code);
+CREATE INDEX IF NOT EXISTS idx_promo_codes_owner_id ON promo_codes(owner_id);
+CREATE INDEX IF NOT EXISTS idx_promo_codes_deleted_at ON promo_codes(deleted_at);
+
+CREATE TABLE IF NOT EXISTS promo_code_appointments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promo_code_appointments_code_appointment ON promo_code_appointments(promo_code_id, appointment_id);
+CREATE INDEX IF NOT EXISTS idx_promo_code_appointments_appointment_id ON promo_code_appointments(appointment_id);
+
+CREATE TABLE IF NOT EXISTS promo_code_redemptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    booking_code VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    discount BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_code_redemptions_code_email ON promo_code_redemptions(promo_code_id, email);
+CREATE INDEX IF NOT EXISTS idx_promo_code_redemptions_booking_code ON promo_code_redemptions(booking_code);
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS promo_code VARCHAR(64);
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS discount_type VARCHAR(16);
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS discount_value BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS discount_amount BIGINT NOT NULL DEFAULT 0;
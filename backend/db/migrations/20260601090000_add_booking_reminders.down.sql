@@ -0,0 +1,4 @@
+-- 20260601090000_add_booking_reminders.down.sql
+
+DROP TABLE IF EXISTS booking_reminders;
+ALTER TABLE appointments DROP COLUMN IF EXISTS reminder_offsets;
@@ -0,0 +1,24 @@
+-- 20260601090000_add_booking_reminders.up.sql
+
+ALTER TABLE appointments ADD COLUMN IF NOT EXISTS reminder_offsets JSONB NOT NULL DEFAULT '[1440, 60]';
+
+CREATE TABLE IF NOT EXISTS booking_reminders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_code VARCHAR(255) NOT NULL,
+    appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+    offset_minutes INTEGER NOT NULL CHECK (offset_minutes > 0),
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    send_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'cancelled', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- One pending reminder per booking, start and offset, so a booking confirmed twice is not
+-- reminded twice.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_booking_reminders_pending ON booking_reminders(booking_code, starts_at, offset_minutes) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_booking_reminders_due ON booking_reminders(send_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_booking_reminders_booking_code ON booking_reminders(booking_code);
@@ -0,0 +1,3 @@
+-- 20260608090000_add_outbox_events.down.sql
+
+DROP TABLE IF EXISTS outbox_events;
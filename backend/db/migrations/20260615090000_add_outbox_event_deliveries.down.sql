@@ -0,0 +1,3 @@
+-- 20260615090000_add_outbox_event_deliveries.down.sql
+
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS delivered_to;
@@ -0,0 +1,4 @@
+-- 20260615090000_add_outbox_event_deliveries.up.sql
+
+-- Subscribers that have handled each event, so a retry only reaches the ones that failed.
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS delivered_to JSONB NOT NULL DEFAULT '[]';
@@ -64,6 +64,10 @@ func HandleAppError(c *gin.Context, err error) {
 		apperrors.CodeDBUniqueViolation,
 		apperrors.CodeRepoConflictError:
 		ConflictError(c, appErr.Message)
+	case apperrors.CodeBookingOutsideWindow:
+		handleError(c, 422, appErr.Code, appErr.Message)
+	case apperrors.CodeBookingPolicyViolation:
+		handleError(c, 403, appErr.Code, appErr.Message)
 	case apperrors.CodeValidationFailed,
 		apperrors.CodeInvalidVerificationCode,
 		apperrors.CodeVerificationExpired,
@@ -47,6 +47,8 @@ const (
 	CodeBookingNotFound         = "BOOKING_NOT_FOUND"
 	CodeBookingSlotUnavailable  = "BOOKING_SLOT_UNAVAILABLE"
 	CodeBookingCapacityExceeded = "BOOKING_CAPACITY_EXCEEDED"
+	CodeBookingOutsideWindow    = "BOOKING_OUTSIDE_WINDOW"
+	CodeBookingPolicyViolation  = "BOOKING_POLICY_VIOLATION"
 	CodeBanListBlocked          = "BANLIST_BLOCKED"
 
 	// -----------------------------------------------------------------
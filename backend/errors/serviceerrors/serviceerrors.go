@@ -54,6 +54,16 @@ func BookingSlotUnavailableError(message string) error {
 	return errors.NewAppError(errors.CodeBookingSlotUnavailable, "conflict", 409, message, nil)
 }
 
+// BookingOutsideWindowError returns an error for slots that are too soon or too far ahead to book.
+func BookingOutsideWindowError(message string) error {
+	return errors.NewAppError(errors.CodeBookingOutsideWindow, "validation", 422, message, nil)
+}
+
+// BookingPolicyViolationError returns an error for cancellations or changes the appointment's policy forbids.
+func BookingPolicyViolationError(message string) error {
+	return errors.NewAppError(errors.CodeBookingPolicyViolation, "forbidden", 403, message, nil)
+}
+
 // EmailAlreadyRegisteredError returns an email already registered error.
 func EmailAlreadyRegisteredError(message string) error {
 	return errors.NewAppError(errors.CodeEmailAlreadyRegistered, "conflict", 409, message, nil)
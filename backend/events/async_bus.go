@@ -0,0 +1,238 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// ErrBusClosed is returned when publishing to an AsyncEventBus that has been closed.
+var ErrBusClosed = errors.New("event bus: closed")
+
+const (
+	defaultAsyncWorkers     = 8
+	defaultAsyncQueueSize   = 256
+	defaultAsyncMaxAttempts = 3
+	defaultAsyncRetryDelay  = 500 * time.Millisecond
+)
+
+// AsyncConfig sizes an AsyncEventBus. Zero values fall back to the defaults.
+type AsyncConfig struct {
+	// Workers is how many handlers run at once.
+	Workers int
+	// QueueSize is how many deliveries may wait for a worker before Deliver blocks.
+	QueueSize int
+	// MaxAttempts is how many times a handler that returns an error or panics is run for one
+	// event, waiting RetryDelay before the first retry and doubling the wait after each.
+	MaxAttempts int
+	RetryDelay  time.Duration
+}
+
+// delivery is one event bound for one subscriber's handler. The handler's final error, or nil,
+// is passed to ack.
+type delivery struct {
+	ctx          context.Context
+	event        Event
+	subscription subscription
+	ack          Ack
+}
+
+// AsyncEventBus delivers events to their handlers on a fixed pool of workers. Each handler runs
+// in isolation: a handler that fails or panics is retried on its own without affecting the
+// others. Handlers of one event may run concurrently, and events published concurrently are not
+// guaranteed to reach a handler in the order published.
+type AsyncEventBus struct {
+	config        AsyncConfig
+	subscriptions []subscription
+	mu            sync.RWMutex
+
+	queue   chan delivery
+	pending sync.WaitGroup
+	workers sync.WaitGroup
+	closed  bool
+	quit    chan struct{}
+	once    sync.Once
+}
+
+// NewAsyncEventBus creates an AsyncEventBus and starts its workers. Close stops them.
+func NewAsyncEventBus(config AsyncConfig) *AsyncEventBus {
+	if config.Workers <= 0 {
+		config.Workers = defaultAsyncWorkers
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaultAsyncQueueSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultAsyncMaxAttempts
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = defaultAsyncRetryDelay
+	}
+
+	b := &AsyncEventBus{
+		config: config,
+		queue:  make(chan delivery, config.QueueSize),
+		quit:   make(chan struct{}),
+	}
+	for i := 0; i < config.Workers; i++ {
+		b.workers.Add(1)
+		go b.work()
+	}
+	return b
+}
+
+// Subscribe registers a handler for all events.
+func (b *AsyncEventBus) Subscribe(subscriber string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = addSubscription(b.subscriptions, subscription{subscriber: subscriber, handler: handler})
+}
+
+// SubscribeTo registers a handler for events with the given name only.
+func (b *AsyncEventBus) SubscribeTo(name, subscriber string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = addSubscription(b.subscriptions, subscription{name: name, subscriber: subscriber, handler: handler})
+}
+
+// Publish queues the event for each of its handlers and returns without waiting for them.
+func (b *AsyncEventBus) Publish(ctx context.Context, event Event) error {
+	_, err := b.Deliver(ctx, event, nil, nil)
+	return err
+}
+
+// Deliver queues the event for the handlers of the subscribers not in skip and returns how many
+// it queued without waiting for them; ack is called from the workers as each handler finishes, so it must not
+// block. Handlers run with ctx's values but not its cancellation. When the queue is full Deliver
+// waits for room, giving up on a cancelled ctx only before the first handler is queued, so an
+// event is never handed to only some of them. It returns ErrBusClosed once Close has been
+// called.
+func (b *AsyncEventBus) Deliver(ctx context.Context, event Event, skip []string, ack Ack) (int, error) {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return 0, ErrBusClosed
+	}
+	subs := deliveriesFor(b.subscriptions, event.Name, skip)
+	// Counted while Close is held off, so Close waits for these deliveries to be queued and run.
+	b.pending.Add(len(subs))
+	b.mu.RUnlock()
+
+	handlerCtx := context.WithoutCancel(ctx)
+	for i, s := range subs {
+		d := delivery{ctx: handlerCtx, event: event, subscription: s, ack: ack}
+		if i == 0 {
+			select {
+			case b.queue <- d:
+				continue
+			case <-ctx.Done():
+				b.pending.Add(-len(subs))
+				return 0, fmt.Errorf("event bus: event %s not queued: %w", event.Name, ctx.Err())
+			case <-b.quit:
+				b.pending.Add(-len(subs))
+				return 0, ErrBusClosed
+			}
+		}
+		select {
+		case b.queue <- d:
+		case <-b.quit:
+			b.finish(d, ErrBusClosed)
+		}
+	}
+	return len(subs), nil
+}
+
+// Close stops accepting events and waits for queued and running handlers, including their
+// retries, to finish. If ctx ends first the workers are stopped without draining the rest, the
+// deliveries left over are acknowledged with ErrBusClosed, and ctx's error is returned.
+func (b *AsyncEventBus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		b.pending.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	b.once.Do(func() { close(b.quit) })
+	b.workers.Wait()
+
+	// Deliver may still be queueing events it counted before Close; fail them until every
+	// counted delivery is accounted for.
+	for {
+		select {
+		case d := <-b.queue:
+			b.finish(d, ErrBusClosed)
+		case <-drained:
+			return err
+		}
+	}
+}
+
+func (b *AsyncEventBus) work() {
+	defer b.workers.Done()
+	for {
+		select {
+		case d := <-b.queue:
+			b.finish(d, b.deliver(d))
+		case <-b.quit:
+			return
+		}
+	}
+}
+
+// finish acknowledges a delivery's outcome and stops counting it as pending.
+func (b *AsyncEventBus) finish(d delivery, err error) {
+	if err != nil {
+		log.Printf("[EventBus] handler of %s for %s failed: %v", d.subscription.subscriber, d.event.Name, err)
+	}
+	if d.ack != nil {
+		d.ack(d.subscription.subscriber, err)
+	}
+	b.pending.Done()
+}
+
+// deliver runs the handler until it succeeds or runs out of attempts, returning its last error.
+func (b *AsyncEventBus) deliver(d delivery) error {
+	delay := b.config.RetryDelay
+	for attempt := 1; ; attempt++ {
+		err := runHandler(d)
+		if err == nil {
+			return nil
+		}
+		if attempt >= b.config.MaxAttempts {
+			return fmt.Errorf("failed after %d attempts: %w", attempt, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-b.quit:
+			timer.Stop()
+			return fmt.Errorf("abandoned at shutdown: %w", err)
+		}
+		delay *= 2
+	}
+}
+
+// runHandler calls the handler, turning a panic into an error.
+func runHandler(d delivery) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return d.subscription.handler(d.ctx, d.event)
+}
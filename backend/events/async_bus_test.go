@@ -0,0 +1,334 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ackRecorder collects the outcome Deliver acknowledges for each subscriber.
+type ackRecorder struct {
+	mu      sync.Mutex
+	results map[string]error
+}
+
+func newAckRecorder() *ackRecorder {
+	return &ackRecorder{results: make(map[string]error)}
+}
+
+func (r *ackRecorder) ack(subscriber string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results[subscriber] = err
+}
+
+func (r *ackRecorder) snapshot() map[string]error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	results := make(map[string]error, len(r.results))
+	for subscriber, err := range r.results {
+		results[subscriber] = err
+	}
+	return results
+}
+
+func TestAsyncEventBusRoutesTopics(t *testing.T) {
+	bus := NewAsyncEventBus(AsyncConfig{Workers: 2})
+	var mu sync.Mutex
+	var received []string
+	record := func(prefix string) EventHandler {
+		return func(ctx context.Context, event Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, prefix+":"+event.Name)
+			return nil
+		}
+	}
+	bus.Subscribe("all", record("all"))
+	bus.SubscribeTo("topic.a", "a", record("a"))
+
+	if err := bus.Publish(context.Background(), Event{Name: "topic.a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := bus.Publish(context.Background(), Event{Name: "topic.b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(received) != 3 {
+		t.Fatalf("expected 3 deliveries, got %v", received)
+	}
+	for _, delivery := range received {
+		if delivery == "a:topic.b" {
+			t.Fatalf("topic handler received another topic: %v", received)
+		}
+	}
+}
+
+func TestAsyncEventBusRetriesFailingHandlers(t *testing.T) {
+	bus := NewAsyncEventBus(AsyncConfig{Workers: 1, MaxAttempts: 3, RetryDelay: time.Millisecond})
+	var failing, panicking, healthy atomic.Int32
+	bus.SubscribeTo("topic.test", "failing", func(ctx context.Context, event Event) error {
+		if failing.Add(1) < 3 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	bus.SubscribeTo("topic.test", "panicking", func(ctx context.Context, event Event) error {
+		panicking.Add(1)
+		panic("handler bug")
+	})
+	bus.SubscribeTo("topic.test", "healthy", func(ctx context.Context, event Event) error {
+		healthy.Add(1)
+		return nil
+	})
+
+	acks := newAckRecorder()
+	if _, err := bus.Deliver(context.Background(), Event{Name: "topic.test"}, nil, acks.ack); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if failing.Load() != 3 {
+		t.Fatalf("expected failing handler to succeed on attempt 3, got %d attempts", failing.Load())
+	}
+	if panicking.Load() != 3 {
+		t.Fatalf("expected panicking handler to be retried 3 times, got %d", panicking.Load())
+	}
+	if healthy.Load() != 1 {
+		t.Fatalf("expected healthy handler to run once, got %d", healthy.Load())
+	}
+	// Only the panicking handler never succeeded, so only it is acknowledged with an error.
+	results := acks.snapshot()
+	if len(results) != 3 || results["failing"] != nil || results["healthy"] != nil || results["panicking"] == nil {
+		t.Fatalf("unexpected acknowledgements: %v", results)
+	}
+}
+
+func TestAsyncEventBusPublishDoesNotWaitForHandlers(t *testing.T) {
+	bus := NewAsyncEventBus(AsyncConfig{Workers: 1})
+	release := make(chan struct{})
+	var handled atomic.Int32
+	bus.SubscribeTo("topic.test", "slow", func(ctx context.Context, event Event) error {
+		<-release
+		handled.Add(1)
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := bus.Publish(context.Background(), Event{Name: "topic.test"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if handled.Load() != 0 {
+		t.Fatalf("expected publish to return before handlers ran, got %d handled", handled.Load())
+	}
+
+	close(release)
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handled.Load() != 3 {
+		t.Fatalf("expected 3 events handled, got %d", handled.Load())
+	}
+}
+
+func TestAsyncEventBusDeliverSkipsSubscribersAndAcksEach(t *testing.T) {
+	bus := NewAsyncEventBus(AsyncConfig{Workers: 2})
+	var handled atomic.Int32
+	publishCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, subscriber := range []string{"email", "reminders", "payments"} {
+		bus.SubscribeTo("topic.test", subscriber, func(ctx context.Context, event Event) error {
+			// Cancelling the publisher's context once the event is queued does not stop the
+			// remaining handlers from receiving it.
+			cancel()
+			time.Sleep(5 * time.Millisecond)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			handled.Add(1)
+			return nil
+		})
+	}
+
+	acks := newAckRecorder()
+	queued, err := bus.Deliver(publishCtx, Event{Name: "topic.test"}, []string{"reminders"}, acks.ack)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if queued != 2 || handled.Load() != 2 {
+		t.Fatalf("expected 2 handlers queued and run, got %d queued and %d run", queued, handled.Load())
+	}
+	results := acks.snapshot()
+	if _, skipped := results["reminders"]; skipped || len(results) != 2 || results["email"] != nil || results["payments"] != nil {
+		t.Fatalf("unexpected acknowledgements: %v", results)
+	}
+}
+
+func TestAsyncEventBusPublishWithCancelledContextQueuesNothing(t *testing.T) {
+	bus := NewAsyncEventBus(AsyncConfig{Workers: 1, QueueSize: 1})
+	defer bus.Close(context.Background())
+	release := make(chan struct{})
+	var handled atomic.Int32
+	bus.SubscribeTo("topic.block", "blocker", func(ctx context.Context, event Event) error {
+		<-release
+		return nil
+	})
+	bus.SubscribeTo("topic.test", "counter", func(ctx context.Context, event Event) error {
+		handled.Add(1)
+		return nil
+	})
+
+	// Occupy the worker and fill the queue so the next publish has to wait for room.
+	for i := 0; i < 2; i++ {
+		if err := bus.Publish(context.Background(), Event{Name: "topic.block"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	deadline := time.Now().Add(time.Second)
+	for len(bus.queue) < 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bus.Publish(ctx, Event{Name: "topic.test"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	close(release)
+	if handled.Load() != 0 {
+		t.Fatalf("expected the unqueued event not to be handled, got %d", handled.Load())
+	}
+}
+
+func TestAsyncEventBusCloseDrainsAndRejectsNewEvents(t *testing.T) {
+	bus := NewAsyncEventBus(AsyncConfig{Workers: 1})
+	var handled atomic.Int32
+	bus.Subscribe("counter", func(ctx context.Context, event Event) error {
+		time.Sleep(10 * time.Millisecond)
+		handled.Add(1)
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := bus.Publish(context.Background(), Event{Name: "topic.test"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if handled.Load() != 3 {
+		t.Fatalf("expected 3 events handled before close returned, got %d", handled.Load())
+	}
+	if err := bus.Publish(context.Background(), Event{Name: "topic.test"}); !errors.Is(err, ErrBusClosed) {
+		t.Fatalf("expected ErrBusClosed, got %v", err)
+	}
+}
+
+func TestAsyncEventBusCloseGivesUpAtDeadline(t *testing.T) {
+	bus := NewAsyncEventBus(AsyncConfig{Workers: 1, MaxAttempts: 5, RetryDelay: time.Hour})
+	bus.Subscribe("failing", func(ctx context.Context, event Event) error {
+		return errors.New("boom")
+	})
+	var mu sync.Mutex
+	var outcomes []error
+	ack := func(subscriber string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		outcomes = append(outcomes, err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := bus.Deliver(context.Background(), Event{Name: "topic.test"}, nil, ack); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bus.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	// Both the retrying and the still queued event are acknowledged as failed by the time Close
+	// returns.
+	if len(outcomes) != 2 || outcomes[0] == nil || outcomes[1] == nil {
+		t.Fatalf("expected both undelivered events to fail, got %v", outcomes)
+	}
+}
+
+func TestAsyncEventBusCloseDoesNotWaitOnBlockedPublishers(t *testing.T) {
+	bus := NewAsyncEventBus(AsyncConfig{Workers: 1, QueueSize: 1})
+	bus.Subscribe("slow", func(ctx context.Context, event Event) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	var acked atomic.Int32
+	ack := func(subscriber string, err error) { acked.Add(1) }
+
+	// One event runs, one waits in the queue and the third blocks its publisher on the full queue.
+	for i := 0; i < 2; i++ {
+		if _, err := bus.Deliver(context.Background(), Event{Name: "topic.test"}, nil, ack); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		deadline := time.Now().Add(time.Second)
+		for len(bus.queue) > 0 && i == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	published := make(chan error, 1)
+	go func() {
+		_, err := bus.Deliver(context.Background(), Event{Name: "topic.test"}, nil, ack)
+		published <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		closed <- bus.Close(ctx)
+	}()
+	select {
+	case err := <-closed:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("close deadlocked behind a blocked publisher")
+	}
+
+	// Every event Deliver accepted is acknowledged once, whether or not it ran.
+	want := int32(2)
+	if err := <-published; err == nil {
+		want++
+	}
+	if got := acked.Load(); got != want {
+		t.Fatalf("expected %d acknowledgements, got %d", want, got)
+	}
+}
+
+func TestAsyncEventBusRejectsDuplicateSubscriptions(t *testing.T) {
+	bus := NewAsyncEventBus(AsyncConfig{})
+	defer bus.Close(context.Background())
+	bus.SubscribeTo("topic.a", "email", func(ctx context.Context, event Event) error { return nil })
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a second handler for the same subscriber and event to panic")
+		}
+	}()
+	bus.Subscribe("email", func(ctx context.Context, event Event) error { return nil })
+}
@@ -3,51 +3,101 @@ package events
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 )
 
 // EventHandler is a function that handles an event.
 type EventHandler func(ctx context.Context, event Event) error
 
-// EventBus defines the interface for an event bus.
+// Ack receives the outcome of delivering an event to one subscriber: nil once its handler
+// succeeded, or the error it finally failed with.
+type Ack func(subscriber string, err error)
+
+// EventBus defines the interface for an event bus. Handlers are registered under the name of
+// their subscriber, at most one per subscriber and event, so deliveries can be told apart.
 type EventBus interface {
+	// Publish delivers the event to all of its subscribers.
 	Publish(ctx context.Context, event Event) error
-	Subscribe(handler EventHandler)
+	// Deliver delivers the event to its subscribers other than those in skip and returns how
+	// many that was. ack, if set, is called once with the outcome for each of them, possibly
+	// before Deliver returns. When Deliver returns an error the event was not delivered and ack
+	// is not called.
+	Deliver(ctx context.Context, event Event, skip []string, ack Ack) (int, error)
+	Subscribe(subscriber string, handler EventHandler)
+	SubscribeTo(name, subscriber string, handler EventHandler)
+}
+
+// subscription is a handler registered by subscriber for events called name, or for all events
+// when name is empty.
+type subscription struct {
+	name       string
+	subscriber string
+	handler    EventHandler
+}
+
+// matches reports whether the subscription receives events called name.
+func (s subscription) matches(name string) bool {
+	return s.name == "" || s.name == name
+}
+
+// addSubscription appends s to subs, panicking if its subscriber already has a handler for any
+// of the same events.
+func addSubscription(subs []subscription, s subscription) []subscription {
+	for _, existing := range subs {
+		if existing.subscriber == s.subscriber && (existing.name == "" || s.name == "" || existing.name == s.name) {
+			panic(fmt.Sprintf("event bus: subscriber %q already handles %q", s.subscriber, existing.name))
+		}
+	}
+	return append(subs, s)
+}
+
+// deliveriesFor returns the subscriptions for events called name, leaving out the subscribers in
+// skip.
+func deliveriesFor(subs []subscription, name string, skip []string) []subscription {
+	var matched []subscription
+	for _, s := range subs {
+		if s.matches(name) && !slices.Contains(skip, s.subscriber) {
+			matched = append(matched, s)
+		}
+	}
+	return matched
 }
 
 // SyncEventBus is a simple synchronous event bus implementation.
 type SyncEventBus struct {
-	handlers []EventHandler
-	mu       sync.RWMutex
+	subscriptions []subscription
+	mu            sync.RWMutex
 }
 
 // NewSyncEventBus creates a new SyncEventBus.
 func NewSyncEventBus() *SyncEventBus {
-	return &SyncEventBus{
-		handlers: make([]EventHandler, 0),
-	}
+	return &SyncEventBus{}
 }
 
 // Subscribe registers a handler for all events.
-func (b *SyncEventBus) Subscribe(handler EventHandler) {
+func (b *SyncEventBus) Subscribe(subscriber string, handler EventHandler) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	b.handlers = append(b.handlers, handler)
+	b.subscriptions = addSubscription(b.subscriptions, subscription{subscriber: subscriber, handler: handler})
+}
+
+// SubscribeTo registers a handler for events with the given name only.
+func (b *SyncEventBus) SubscribeTo(name, subscriber string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = addSubscription(b.subscriptions, subscription{name: name, subscriber: subscriber, handler: handler})
 }
 
 // Publish publishes an event to all subscribers.
 // In this synchronous implementation, it blocks until all handlers return.
 func (b *SyncEventBus) Publish(ctx context.Context, event Event) error {
-	b.mu.RLock()
-	handlers := append([]EventHandler(nil), b.handlers...)
-	b.mu.RUnlock()
-
 	var errs []error
-	for _, handler := range handlers {
-		if err := handler(ctx, event); err != nil {
+	_, _ = b.Deliver(ctx, event, nil, func(subscriber string, err error) {
+		if err != nil {
 			errs = append(errs, err)
 		}
-	}
+	})
 
 	if len(errs) > 0 {
 		return fmt.Errorf("event bus: %d handlers failed for event %s: %v", len(errs), event.Name, errs)
@@ -55,3 +105,18 @@ func (b *SyncEventBus) Publish(ctx context.Context, event Event) error {
 
 	return nil
 }
+
+// Deliver runs the handlers one after another and acknowledges each before returning.
+func (b *SyncEventBus) Deliver(ctx context.Context, event Event, skip []string, ack Ack) (int, error) {
+	b.mu.RLock()
+	subs := deliveriesFor(b.subscriptions, event.Name, skip)
+	b.mu.RUnlock()
+
+	for _, s := range subs {
+		err := s.handler(ctx, event)
+		if ack != nil {
+			ack(s.subscriber, err)
+		}
+	}
+	return len(subs), nil
+}
@@ -10,12 +10,12 @@ func TestSyncEventBusPublishesToSubscribers(t *testing.T) {
 	bus := NewSyncEventBus()
 	var received []string
 
-	bus.Subscribe(func(ctx context.Context, event Event) error {
+	bus.Subscribe("a", func(ctx context.Context, event Event) error {
 		value, _ := event.Data.(string)
 		received = append(received, "a:"+value)
 		return nil
 	})
-	bus.Subscribe(func(ctx context.Context, event Event) error {
+	bus.Subscribe("b", func(ctx context.Context, event Event) error {
 		value, _ := event.Data.(string)
 		received = append(received, "b:"+value)
 		return nil
@@ -32,7 +32,7 @@ func TestSyncEventBusPublishesToSubscribers(t *testing.T) {
 
 func TestSyncEventBusReturnsErrors(t *testing.T) {
 	bus := NewSyncEventBus()
-	bus.Subscribe(func(ctx context.Context, event Event) error {
+	bus.Subscribe("failing", func(ctx context.Context, event Event) error {
 		return errors.New("boom")
 	})
 
@@ -40,3 +40,19 @@ func TestSyncEventBusReturnsErrors(t *testing.T) {
 		t.Fatalf("expected error, got nil")
 	}
 }
+
+func TestSyncEventBusSubscribeTo(t *testing.T) {
+	bus := NewSyncEventBus()
+	var received []string
+	bus.SubscribeTo("topic.a", "a", func(ctx context.Context, event Event) error {
+		received = append(received, event.Name)
+		return nil
+	})
+
+	_ = bus.Publish(context.Background(), Event{Name: "topic.a"})
+	_ = bus.Publish(context.Background(), Event{Name: "topic.b"})
+
+	if len(received) != 1 || received[0] != "topic.a" {
+		t.Fatalf("expected only topic.a, got %v", received)
+	}
+}
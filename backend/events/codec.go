@@ -0,0 +1,88 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// payloadCodec converts an event's Data to the payload it is stored as, and a stored payload
+// back to Data of the type it was published with.
+type payloadCodec struct {
+	encode func(data interface{}) (interface{}, error)
+	decode func(payload []byte) (interface{}, error)
+}
+
+var bookingEventCodec = payloadCodec{
+	encode: func(data interface{}) (interface{}, error) {
+		d, ok := data.(BookingEventData)
+		if !ok {
+			return nil, fmt.Errorf("expected BookingEventData, got %T", data)
+		}
+		return newBookingEventPayload(d), nil
+	},
+	decode: func(payload []byte) (interface{}, error) {
+		var p bookingEventPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, err
+		}
+		return p.data(), nil
+	},
+}
+
+var appointmentEventCodec = payloadCodec{
+	encode: func(data interface{}) (interface{}, error) {
+		d, ok := data.(AppointmentEventData)
+		if !ok {
+			return nil, fmt.Errorf("expected AppointmentEventData, got %T", data)
+		}
+		return newAppointmentEventPayload(d), nil
+	},
+	decode: func(payload []byte) (interface{}, error) {
+		var p appointmentEventPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, err
+		}
+		return p.data(), nil
+	},
+}
+
+// payloadCodecs is how each event's Data is stored. Events stored through the outbox must be
+// listed here.
+var payloadCodecs = map[string]payloadCodec{
+	EventBookingCreated:     bookingEventCodec,
+	EventBookingCancelled:   bookingEventCodec,
+	EventBookingUpdated:     bookingEventCodec,
+	EventBookingRejected:    bookingEventCodec,
+	EventBookingConfirmed:   bookingEventCodec,
+	EventBookingPromoted:    bookingEventCodec,
+	EventAppointmentCreated: appointmentEventCodec,
+	EventAppointmentUpdated: appointmentEventCodec,
+	EventAppointmentDeleted: appointmentEventCodec,
+}
+
+// Encode serializes the event's Data as JSON for storage, through the event's stored payload
+// type rather than the entities it carries.
+func Encode(event Event) ([]byte, error) {
+	codec, ok := payloadCodecs[event.Name]
+	if !ok {
+		return nil, fmt.Errorf("events: unknown event %q", event.Name)
+	}
+	payload, err := codec.encode(event.Data)
+	if err != nil {
+		return nil, fmt.Errorf("events: encode %s: %w", event.Name, err)
+	}
+	return json.Marshal(payload)
+}
+
+// Decode rebuilds an event stored with Encode, with Data of the type it was published with.
+func Decode(name string, payload []byte) (Event, error) {
+	codec, ok := payloadCodecs[name]
+	if !ok {
+		return Event{}, fmt.Errorf("events: unknown event %q", name)
+	}
+	data, err := codec.decode(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("events: decode %s: %w", name, err)
+	}
+	return Event{Name: name, Data: data}, nil
+}
@@ -0,0 +1,92 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/m13ha/asiko/models/entities"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	ownerID := uuid.New()
+	event := Event{Name: EventBookingCancelled, Data: BookingEventData{
+		Booking: &entities.Booking{
+			BookingCode: "BK1",
+			Email:       "guest@example.com",
+			DeviceID:    "device-1",
+			PromoCode:   "SPRING",
+			Discount:    entities.Discount{Type: entities.DiscountPercent, Value: 20},
+			Appointment: entities.Appointment{Title: "Consultation", TimeZone: "Europe/Berlin"},
+		},
+		OwnerID:          ownerID,
+		AppointmentTitle: "Consultation",
+		CancelledByOwner: true,
+	}}
+
+	payload, err := Encode(event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded, err := Decode(event.Name, payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, ok := decoded.Data.(BookingEventData)
+	if !ok {
+		t.Fatalf("expected BookingEventData, got %T", decoded.Data)
+	}
+	if decoded.Name != EventBookingCancelled || data.OwnerID != ownerID || !data.CancelledByOwner {
+		t.Fatalf("event not preserved: %+v", decoded)
+	}
+	if data.Booking == nil || data.Booking.BookingCode != "BK1" || data.Booking.Email != "guest@example.com" {
+		t.Fatalf("booking not preserved: %+v", data.Booking)
+	}
+	// Fields hidden from the API are still delivered to handlers.
+	if data.Booking.DeviceID != "device-1" || data.Booking.Discount != (entities.Discount{Type: entities.DiscountPercent, Value: 20}) {
+		t.Fatalf("hidden booking fields not preserved: %+v", data.Booking)
+	}
+	if data.Booking.Appointment.Title != "Consultation" || data.Booking.Appointment.TimeZone != "Europe/Berlin" {
+		t.Fatalf("preloaded appointment not preserved: %+v", data.Booking.Appointment)
+	}
+}
+
+func TestEncodeDecodeAppointmentEvent(t *testing.T) {
+	appointmentID := uuid.New()
+	event := Event{Name: EventAppointmentUpdated, Data: AppointmentEventData{
+		Appointment:    &entities.Appointment{ID: appointmentID, Title: "Workshop", AppCode: "AP1"},
+		RecipientEmail: "owner@example.com",
+	}}
+
+	payload, err := Encode(event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded, err := Decode(event.Name, payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, ok := decoded.Data.(AppointmentEventData)
+	if !ok {
+		t.Fatalf("expected AppointmentEventData, got %T", decoded.Data)
+	}
+	if data.Appointment == nil || data.Appointment.ID != appointmentID || data.Appointment.Title != "Workshop" || data.RecipientEmail != "owner@example.com" {
+		t.Fatalf("event not preserved: %+v", data)
+	}
+}
+
+func TestEncodeRejectsMismatchedData(t *testing.T) {
+	if _, err := Encode(Event{Name: EventBookingCreated, Data: AppointmentEventData{}}); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
+
+func TestEncodeRejectsUnknownEvents(t *testing.T) {
+	if _, err := Encode(Event{Name: "topic.unknown", Data: "payload"}); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if _, err := Decode("topic.unknown", []byte(`"payload"`)); err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+}
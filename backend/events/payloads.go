@@ -0,0 +1,272 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m13ha/asiko/models/entities"
+)
+
+// Stored payloads are what the outbox keeps for each event in place of its Data. They list every
+// field handlers are given, including entity fields hidden from the API such as a booking's
+// discount, device and appointment, so an event reaches handlers from the outbox as it was
+// published.
+
+// bookingEventPayload stores BookingEventData.
+type bookingEventPayload struct {
+	Booking          *bookingPayload `json:"booking"`
+	OwnerID          uuid.UUID       `json:"owner_id"`
+	AppointmentTitle string          `json:"appointment_title"`
+	RecipientEmail   string          `json:"recipient_email"`
+	RecipientName    string          `json:"recipient_name"`
+	CancelledByOwner bool            `json:"cancelled_by_owner"`
+}
+
+// appointmentEventPayload stores AppointmentEventData.
+type appointmentEventPayload struct {
+	Appointment      *appointmentPayload `json:"appointment"`
+	OwnerID          uuid.UUID           `json:"owner_id"`
+	AppointmentTitle string              `json:"appointment_title"`
+	RecipientEmail   string              `json:"recipient_email"`
+	RecipientName    string              `json:"recipient_name"`
+}
+
+// bookingPayload is a booking and, when it was loaded, its appointment.
+type bookingPayload struct {
+	ID                  uuid.UUID            `json:"id"`
+	AppointmentID       uuid.UUID            `json:"appointment_id"`
+	Appointment         *appointmentPayload  `json:"appointment,omitempty"`
+	AppCode             string               `json:"app_code"`
+	UserID              *uuid.UUID           `json:"user_id,omitempty"`
+	Name                string               `json:"name"`
+	Email               string               `json:"email"`
+	Phone               string               `json:"phone"`
+	Date                time.Time            `json:"date"`
+	StartTime           time.Time            `json:"start_time"`
+	EndTime             time.Time            `json:"end_time"`
+	Available           bool                 `json:"available"`
+	IsSlot              bool                 `json:"is_slot"`
+	Capacity            int                  `json:"capacity"`
+	SeatsBooked         int                  `json:"seats_booked"`
+	AttendeeCount       int                  `json:"attendee_count"`
+	CreatedAt           time.Time            `json:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at"`
+	BookingCode         string               `json:"booking_code"`
+	NotificationStatus  string               `json:"notification_status"`
+	NotificationChannel string               `json:"notification_channel"`
+	Status              string               `json:"status"`
+	Description         string               `json:"description"`
+	DeviceID            string               `json:"device_id"`
+	RescheduleCount     int                  `json:"reschedule_count"`
+	FormAnswers         entities.FormAnswers `json:"form_answers,omitempty"`
+	CheckedInAt         *time.Time           `json:"checked_in_at,omitempty"`
+	Blocked             bool                 `json:"blocked"`
+	HostConflict        bool                 `json:"host_conflict"`
+	HostID              *uuid.UUID           `json:"host_id,omitempty"`
+	TicketType          string               `json:"ticket_type"`
+	AmountDue           int64                `json:"amount_due"`
+	Currency            string               `json:"currency"`
+	PaymentStatus       string               `json:"payment_status"`
+	PromoCode           string               `json:"promo_code"`
+	Discount            entities.Discount    `json:"discount"`
+	DiscountAmount      int64                `json:"discount_amount"`
+}
+
+// appointmentPayload is the part of an appointment handlers use: what identifies it, who owns
+// it, and what guests are told about it.
+type appointmentPayload struct {
+	ID             uuid.UUID                  `json:"id"`
+	AppCode        string                     `json:"app_code"`
+	Title          string                     `json:"title"`
+	Description    string                     `json:"description"`
+	Type           entities.AppointmentType   `json:"type"`
+	Status         entities.AppointmentStatus `json:"status"`
+	OwnerID        uuid.UUID                  `json:"owner_id"`
+	OrganizationID *uuid.UUID                 `json:"organization_id,omitempty"`
+	StartDate      time.Time                  `json:"start_date"`
+	EndDate        time.Time                  `json:"end_date"`
+	StartTime      time.Time                  `json:"start_time"`
+	EndTime        time.Time                  `json:"end_time"`
+	TimeZone       string                     `json:"time_zone"`
+}
+
+func newBookingEventPayload(data BookingEventData) bookingEventPayload {
+	return bookingEventPayload{
+		Booking:          newBookingPayload(data.Booking),
+		OwnerID:          data.OwnerID,
+		AppointmentTitle: data.AppointmentTitle,
+		RecipientEmail:   data.RecipientEmail,
+		RecipientName:    data.RecipientName,
+		CancelledByOwner: data.CancelledByOwner,
+	}
+}
+
+func (p bookingEventPayload) data() BookingEventData {
+	return BookingEventData{
+		Booking:          p.Booking.booking(),
+		OwnerID:          p.OwnerID,
+		AppointmentTitle: p.AppointmentTitle,
+		RecipientEmail:   p.RecipientEmail,
+		RecipientName:    p.RecipientName,
+		CancelledByOwner: p.CancelledByOwner,
+	}
+}
+
+func newAppointmentEventPayload(data AppointmentEventData) appointmentEventPayload {
+	return appointmentEventPayload{
+		Appointment:      newAppointmentPayload(data.Appointment),
+		OwnerID:          data.OwnerID,
+		AppointmentTitle: data.AppointmentTitle,
+		RecipientEmail:   data.RecipientEmail,
+		RecipientName:    data.RecipientName,
+	}
+}
+
+func (p appointmentEventPayload) data() AppointmentEventData {
+	return AppointmentEventData{
+		Appointment:      p.Appointment.appointment(),
+		OwnerID:          p.OwnerID,
+		AppointmentTitle: p.AppointmentTitle,
+		RecipientEmail:   p.RecipientEmail,
+		RecipientName:    p.RecipientName,
+	}
+}
+
+func newBookingPayload(b *entities.Booking) *bookingPayload {
+	if b == nil {
+		return nil
+	}
+	p := &bookingPayload{
+		ID:                  b.ID,
+		AppointmentID:       b.AppointmentID,
+		AppCode:             b.AppCode,
+		UserID:              b.UserID,
+		Name:                b.Name,
+		Email:               b.Email,
+		Phone:               b.Phone,
+		Date:                b.Date,
+		StartTime:           b.StartTime,
+		EndTime:             b.EndTime,
+		Available:           b.Available,
+		IsSlot:              b.IsSlot,
+		Capacity:            b.Capacity,
+		SeatsBooked:         b.SeatsBooked,
+		AttendeeCount:       b.AttendeeCount,
+		CreatedAt:           b.CreatedAt,
+		UpdatedAt:           b.UpdatedAt,
+		BookingCode:         b.BookingCode,
+		NotificationStatus:  b.NotificationStatus,
+		NotificationChannel: b.NotificationChannel,
+		Status:              b.Status,
+		Description:         b.Description,
+		DeviceID:            b.DeviceID,
+		RescheduleCount:     b.RescheduleCount,
+		FormAnswers:         b.FormAnswers,
+		CheckedInAt:         b.CheckedInAt,
+		Blocked:             b.Blocked,
+		HostConflict:        b.HostConflict,
+		HostID:              b.HostID,
+		TicketType:          b.TicketType,
+		AmountDue:           b.AmountDue,
+		Currency:            b.Currency,
+		PaymentStatus:       b.PaymentStatus,
+		PromoCode:           b.PromoCode,
+		Discount:            b.Discount,
+		DiscountAmount:      b.DiscountAmount,
+	}
+	// An appointment that was not preloaded is left out rather than stored empty.
+	if b.Appointment.ID != uuid.Nil || b.Appointment.Title != "" {
+		p.Appointment = newAppointmentPayload(&b.Appointment)
+	}
+	return p
+}
+
+func (p *bookingPayload) booking() *entities.Booking {
+	if p == nil {
+		return nil
+	}
+	b := &entities.Booking{
+		ID:                  p.ID,
+		AppointmentID:       p.AppointmentID,
+		AppCode:             p.AppCode,
+		UserID:              p.UserID,
+		Name:                p.Name,
+		Email:               p.Email,
+		Phone:               p.Phone,
+		Date:                p.Date,
+		StartTime:           p.StartTime,
+		EndTime:             p.EndTime,
+		Available:           p.Available,
+		IsSlot:              p.IsSlot,
+		Capacity:            p.Capacity,
+		SeatsBooked:         p.SeatsBooked,
+		AttendeeCount:       p.AttendeeCount,
+		CreatedAt:           p.CreatedAt,
+		UpdatedAt:           p.UpdatedAt,
+		BookingCode:         p.BookingCode,
+		NotificationStatus:  p.NotificationStatus,
+		NotificationChannel: p.NotificationChannel,
+		Status:              p.Status,
+		Description:         p.Description,
+		DeviceID:            p.DeviceID,
+		RescheduleCount:     p.RescheduleCount,
+		FormAnswers:         p.FormAnswers,
+		CheckedInAt:         p.CheckedInAt,
+		Blocked:             p.Blocked,
+		HostConflict:        p.HostConflict,
+		HostID:              p.HostID,
+		TicketType:          p.TicketType,
+		AmountDue:           p.AmountDue,
+		Currency:            p.Currency,
+		PaymentStatus:       p.PaymentStatus,
+		PromoCode:           p.PromoCode,
+		Discount:            p.Discount,
+		DiscountAmount:      p.DiscountAmount,
+	}
+	if appointment := p.Appointment.appointment(); appointment != nil {
+		b.Appointment = *appointment
+	}
+	return b
+}
+
+func newAppointmentPayload(a *entities.Appointment) *appointmentPayload {
+	if a == nil {
+		return nil
+	}
+	return &appointmentPayload{
+		ID:             a.ID,
+		AppCode:        a.AppCode,
+		Title:          a.Title,
+		Description:    a.Description,
+		Type:           a.Type,
+		Status:         a.Status,
+		OwnerID:        a.OwnerID,
+		OrganizationID: a.OrganizationID,
+		StartDate:      a.StartDate,
+		EndDate:        a.EndDate,
+		StartTime:      a.StartTime,
+		EndTime:        a.EndTime,
+		TimeZone:       a.TimeZone,
+	}
+}
+
+func (p *appointmentPayload) appointment() *entities.Appointment {
+	if p == nil {
+		return nil
+	}
+	return &entities.Appointment{
+		ID:             p.ID,
+		AppCode:        p.AppCode,
+		Title:          p.Title,
+		Description:    p.Description,
+		Type:           p.Type,
+		Status:         p.Status,
+		OwnerID:        p.OwnerID,
+		OrganizationID: p.OrganizationID,
+		StartDate:      p.StartDate,
+		EndDate:        p.EndDate,
+		StartTime:      p.StartTime,
+		EndTime:        p.EndTime,
+		TimeZone:       p.TimeZone,
+	}
+}
@@ -14,6 +14,7 @@ const (
 	EventBookingUpdated   = "booking.updated"
 	EventBookingRejected  = "booking.rejected"
 	EventBookingConfirmed = "booking.confirmed"
+	EventBookingPromoted  = "booking.promoted"
 	EventAppointmentCreated = "appointment.created"
 	EventAppointmentUpdated = "appointment.updated"
 	EventAppointmentDeleted = "appointment.deleted"
@@ -38,6 +39,9 @@ type BookingEventData struct {
 	AppointmentTitle string
 	RecipientEmail   string
 	RecipientName    string
+	// CancelledByOwner marks a cancellation made by the owner or organization staff, which is
+	// refunded in full rather than only inside the appointment's refund window.
+	CancelledByOwner bool
 }
 
 type AppointmentEventData struct {
@@ -20,6 +20,7 @@ import (
 	"github.com/m13ha/asiko/api"
 	"github.com/m13ha/asiko/events"
 	"github.com/m13ha/asiko/notifications"
+	"github.com/m13ha/asiko/payments"
 	"github.com/m13ha/asiko/repository"
 	"github.com/m13ha/asiko/services"
 )
@@ -67,6 +68,16 @@ func main() {
 	userRepo := repository.NewGormUserRepository(db.DB)
 	appointmentRepo := repository.NewGormAppointmentRepository(db.DB)
 	bookingRepo := repository.NewGormBookingRepository(db.DB)
+	exceptionRepo := repository.NewGormAvailabilityExceptionRepository(db.DB)
+	waitlistRepo := repository.NewGormWaitlistRepository(db.DB)
+	slotHoldRepo := repository.NewGormSlotHoldRepository(db.DB)
+	externalCalendarRepo := repository.NewGormExternalCalendarRepository(db.DB)
+	organizationRepo := repository.NewGormOrganizationRepository(db.DB)
+	resourceRepo := repository.NewGormResourceRepository(db.DB)
+	paymentRepo := repository.NewGormPaymentRepository(db.DB)
+	promoCodeRepo := repository.NewGormPromoCodeRepository(db.DB)
+	reminderRepo := repository.NewGormReminderRepository(db.DB)
+	outboxRepo := repository.NewGormOutboxRepository(db.DB)
 	analyticsRepo := repository.NewGormAnalyticsRepository(db.DB)
 	banListRepo := repository.NewGormBanListRepository(db.DB)
 	notificationRepo := repository.NewGormNotificationRepository(db.DB)
@@ -74,7 +85,8 @@ func main() {
 	passwordResetRepo := repository.NewGormPasswordResetRepository(db.DB)
 
 	// Initialize Event Bus
-	eventBus := events.NewSyncEventBus()
+	// Subscribers run on a bounded pool of workers; Close drains them at shutdown.
+	eventBus := events.NewAsyncEventBus(events.AsyncConfig{})
 
 	// Initialize services
 	notificationService, err := notifications.NewNotificationServiceFromEnv()
@@ -82,19 +94,38 @@ func main() {
 		log.Printf("Warning: AhaSend configuration invalid: %v", err)
 	}
 	eventNotificationService := services.NewEventNotificationService(notificationRepo)
+	// Payment webhooks mark bookings paid, so the server does not start without a trustworthy
+	// provider.
+	paymentProvider, err := payments.NewPaymentProviderFromEnv()
+	if err != nil {
+		log.Fatalf("Payment provider configuration invalid: %v", err)
+	}
+	paymentService := services.NewPaymentService(paymentRepo, bookingRepo, appointmentRepo, paymentProvider, outboxRepo, db.DB)
+	reminderService := services.NewReminderService(reminderRepo, bookingRepo, appointmentRepo, notificationService, db.DB)
 
 	// Register Subscribers
 	notifications.RegisterHandlers(eventBus, notificationService, bookingRepo)
 	services.RegisterInternalHandlers(eventBus, eventNotificationService)
+	services.RegisterPaymentHandlers(eventBus, paymentService)
+	services.RegisterReminderHandlers(eventBus, reminderService)
 
 	userService := services.NewUserService(userRepo, pendingUserRepo, passwordResetRepo, notificationService)
-	appointmentService := services.NewAppointmentService(appointmentRepo, bookingRepo, userRepo, eventBus, eventNotificationService, db.DB)
-	// BookingService now uses EventBus instead of direct notification services
-	bookingService := services.NewBookingService(bookingRepo, appointmentRepo, userRepo, banListRepo, eventBus, db.DB)
+	appointmentService := services.NewAppointmentService(appointmentRepo, bookingRepo, exceptionRepo, userRepo, organizationRepo, outboxRepo, eventNotificationService, db.DB)
+	// BookingService publishes through the outbox instead of calling notification services
+	bookingService := services.NewBookingService(bookingRepo, appointmentRepo, userRepo, banListRepo, waitlistRepo, slotHoldRepo, organizationRepo, promoCodeRepo, outboxRepo, db.DB)
 	analyticsService := services.NewAnalyticsService(analyticsRepo)
 	banListService := services.NewBanListService(banListRepo)
-	statusScheduler := services.NewStatusScheduler(appointmentService, bookingService, time.Minute)
+	exceptionService := services.NewAvailabilityExceptionService(exceptionRepo, appointmentRepo, bookingRepo, organizationRepo, outboxRepo, db.DB)
+	externalCalendarService := services.NewExternalCalendarService(externalCalendarRepo, bookingRepo, db.DB)
+	organizationService := services.NewOrganizationService(organizationRepo, userRepo, db.DB)
+	resourceService := services.NewResourceService(resourceRepo, appointmentRepo, organizationRepo, db.DB)
+	promoCodeService := services.NewPromoCodeService(promoCodeRepo, appointmentRepo, organizationRepo, db.DB)
+	statusScheduler := services.NewStatusScheduler(appointmentService, bookingService, externalCalendarService, reminderService, time.Minute)
 	statusScheduler.Start(ctx)
+	// Services write events to the outbox in their transactions; the dispatcher publishes them
+	// on the event bus once committed.
+	outboxDispatcher := services.NewOutboxDispatcher(outboxRepo, eventBus, db.DB, time.Second)
+	outboxDispatcher.Start(ctx)
 
 	r := gin.Default()
 	r.Use(middleware.RequestID())
@@ -117,7 +148,7 @@ func main() {
 	})
 
 	// Register API routes
-	api.RegisterRoutes(r, userService, appointmentService, bookingService, analyticsService, banListService, eventNotificationService)
+	api.RegisterRoutes(r, userService, appointmentService, bookingService, analyticsService, banListService, eventNotificationService, exceptionService, externalCalendarService, organizationService, resourceService, paymentService, promoCodeService)
 
 	// Register Swagger documentation route
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -132,7 +163,9 @@ func main() {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
+	stopped := make(chan struct{})
 	go func() {
+		defer close(stopped)
 		<-stop
 		cancel()
 		log.Println("Shutting down server...")
@@ -142,10 +175,20 @@ func main() {
 			log.Fatalf("Server shutdown failed: %v", err)
 		}
 		log.Println("Server stopped")
+		// Let queued handlers finish. The outbox dispatcher only marks an event delivered once
+		// its handlers succeed, so events whose handlers are cut off by the drain timeout are
+		// published again on the next start.
+		drainCtx, cancelDrain := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancelDrain()
+		if err := eventBus.Close(drainCtx); err != nil {
+			log.Printf("Event bus did not drain: %v", err)
+		}
+		log.Println("Event bus stopped")
 	}()
 
 	log.Printf("Starting Server on PORT %s...", port)
 	if err := server.ListenAndServe(); err != http.ErrServerClosed {
 		log.Fatalf("Error starting server: %v", err)
 	}
+	<-stopped
 }
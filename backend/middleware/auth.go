@@ -99,6 +99,30 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware identifies the caller when a valid bearer token is present but lets
+// anonymous requests through, for routes shared by guests and appointment owners.
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString := extractToken(c.Request)
+		if tokenString == "" {
+			c.Next()
+			return
+		}
+
+		claims := &Claims{}
+		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+			return jwtKey, nil
+		})
+		if err == nil && token.Valid {
+			if uid, parseErr := uuid.Parse(claims.UserID); parseErr == nil {
+				c.Set("userUUID", uid)
+				c.Set("userID", claims.UserID)
+			}
+		}
+		c.Next()
+	}
+}
+
 func extractToken(r *http.Request) string {
 	bearerToken := r.Header.Get("Authorization")
 	if bearerToken == "" {
@@ -3,7 +3,9 @@ package entities
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"time"
+	_ "time/tzdata" // embed the IANA database so appointment time zones resolve in slim images
 
 	"github.com/google/uuid"
 	"github.com/m13ha/asiko/utils"
@@ -49,6 +51,81 @@ type Appointment struct {
 	DeletedAt         gorm.DeletedAt    `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
 	Description       string            `json:"description" gorm:"type:text"` // Additional info for the appointment
 	AttendeesBooked   int               `json:"attendees_booked" gorm:"default:0"`
+	RecurrenceRule    string            `json:"recurrence_rule,omitempty" gorm:"type:text"` // RRULE subset, e.g. FREQ=WEEKLY;BYDAY=MO,WE,FR
+	TimeZone          string            `json:"time_zone" gorm:"not null;default:'UTC'"`    // IANA zone the daily window is expressed in
+	// Availability, when set, replaces the single StartTime/EndTime window with per-weekday windows.
+	Availability WeeklyAvailability      `json:"availability,omitempty" gorm:"type:jsonb" swaggertype:"object"`
+	Exceptions   []AvailabilityException `json:"-" gorm:"foreignKey:AppointmentID"`
+	// Buffers keep the host free before and after every slot; slots are spaced so buffers never overlap.
+	PreBufferMinutes  int `json:"pre_buffer_minutes" gorm:"not null;default:0"`
+	PostBufferMinutes int `json:"post_buffer_minutes" gorm:"not null;default:0"`
+	// MinNoticeMinutes is how long before a slot starts it stops being bookable; MaxAdvanceDays
+	// is how far ahead slots open for booking (0 means no limit).
+	MinNoticeMinutes int `json:"min_notice_minutes" gorm:"not null;default:0"`
+	MaxAdvanceDays   int `json:"max_advance_days" gorm:"not null;default:0"`
+	// Guests may cancel or change a booking until the cutoff before it starts, and move it at most
+	// MaxReschedules times. Zero disables each policy; the owner and organization staff are never
+	// bound by them.
+	CancellationCutoffHours int `json:"cancellation_cutoff_hours" gorm:"not null;default:0"`
+	RescheduleCutoffHours   int `json:"reschedule_cutoff_hours" gorm:"not null;default:0"`
+	MaxReschedules          int `json:"max_reschedules" gorm:"not null;default:0"`
+	// FormFields are extra questions asked on the booking form; answers land on Booking.FormAnswers.
+	FormFields BookingForm `json:"form_fields,omitempty" gorm:"type:jsonb" swaggertype:"array,object"`
+	// TicketTypes split a group or party appointment's seats into tiers that bookings choose
+	// from. TicketSales reports the seats booked in each tier; it is filled in on the public view.
+	TicketTypes TicketTypes   `json:"ticket_types,omitempty" gorm:"type:jsonb" swaggertype:"array,object"`
+	TicketSales []TicketSales `json:"ticket_sales,omitempty" gorm:"-"`
+	// Price is what one attendee pays, in minor units of Currency (cents for USD); ticket types
+	// may set their own. Paid bookings cancelled before RefundCutoffHours ahead of the start are
+	// refunded automatically, and zero refunds any cancellation before the start.
+	Price             int64  `json:"price" gorm:"not null;default:0" example:"2500"`
+	Currency          string `json:"currency,omitempty" gorm:"type:varchar(3)" example:"USD"`
+	RefundCutoffHours int    `json:"refund_cutoff_hours" gorm:"not null;default:0"`
+	// PublishAt schedules a draft to be published by the status scheduler.
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	// NoShowGraceMinutes lets the status scheduler mark bookings no-show when nobody has checked
+	// in that long after they start. Zero leaves attendance to the owner.
+	NoShowGraceMinutes int `json:"no_show_grace_minutes" gorm:"not null;default:0"`
+	// ReminderOffsets are the minutes before a booking starts at which confirmed guests are emailed
+	// a reminder.
+	ReminderOffsets ReminderOffsets `json:"reminder_offsets" gorm:"type:jsonb;not null;default:'[1440, 60]'" swaggertype:"array,integer"`
+	// AllowParallelBookings opts the appointment out of owner-wide conflict checking: its bookings
+	// do not block the owner's other appointments and theirs do not block its slots.
+	AllowParallelBookings bool `json:"allow_parallel_bookings" gorm:"not null;default:false"`
+	// OrganizationID shares the appointment with an organization's members, who act on it
+	// according to their role.
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty" gorm:"type:uuid;index"`
+	// Hosts, when any are set, take the appointment's bookings in place of the owner; each booking
+	// goes to a free host picked by HostAssignment. LastHostID is where round-robin resumes.
+	Hosts          []AppointmentHost `json:"-" gorm:"foreignKey:AppointmentID"`
+	HostAssignment HostAssignment    `json:"host_assignment" gorm:"type:varchar(16);not null;default:'round_robin'"`
+	LastHostID     *uuid.UUID        `json:"-" gorm:"type:uuid"`
+}
+
+// Location resolves the appointment's IANA time zone, falling back to UTC when unset or unknown.
+func (a *Appointment) Location() *time.Location {
+	if a.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(a.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// LocalDate returns local midnight, in the appointment's time zone, of the day containing t.
+func (a *Appointment) LocalDate(t time.Time) time.Time {
+	return localMidnight(t, a.Location())
+}
+
+// LocalDateTime combines the calendar day of date with the wall-clock time of clock,
+// both read in the appointment's time zone, and returns the resulting instant.
+func (a *Appointment) LocalDateTime(date time.Time, clock time.Time) time.Time {
+	loc := a.Location()
+	date = date.In(loc)
+	clock = clock.In(loc)
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
 }
 
 func (a *Appointment) BeforeCreate(tx *gorm.DB) error {
@@ -72,117 +149,281 @@ func (a *Appointment) AfterCreate(tx *gorm.DB) error {
 	return tx.Create(&slots).Error
 }
 
+// GenerateBookings builds the slot rows for the appointment. Windows are laid out in the
+// appointment's local wall-clock time so they stay put across DST changes; the resulting
+// instants are stored in UTC. Loaded Exceptions are applied on top of the regular schedule.
 func (a *Appointment) GenerateBookings() []Booking {
 	var slots []Booking
-	duration := time.Duration(a.BookingDuration) * time.Minute
-	defaultCapacity := 1
-	if (a.Type == Group || a.Type == Party) && a.MaxAttendees > 0 {
-		defaultCapacity = a.MaxAttendees
-	}
 
-	startDate := a.StartDate.UTC()
-	endDate := a.EndDate.UTC()
-	startTime := a.StartTime.UTC()
-	endTime := a.EndTime.UTC()
+	loc := a.Location()
+	startDate := localMidnight(a.StartDate, loc)
+	endDate := localMidnight(a.EndDate, loc)
+	startTime := a.StartTime.In(loc)
+	endTime := a.EndTime.In(loc)
 
 	if a.Type == Party {
-		startDateTime := time.Date(
-			startDate.Year(), startDate.Month(), startDate.Day(),
-			startTime.Hour(), startTime.Minute(), 0, 0,
-			time.UTC,
-		)
-		endDateTime := time.Date(
-			endDate.Year(), endDate.Month(), endDate.Day(),
-			endTime.Hour(), endTime.Minute(), 0, 0,
-			time.UTC,
-		)
+		startDateTime := a.LocalDateTime(startDate, startTime)
+		endDateTime := a.LocalDateTime(endDate, endTime)
 		if endDateTime.After(startDateTime) {
 			slots = append(slots, Booking{
 				AppointmentID: a.ID,
 				AppCode:       a.AppCode,
-				Date:          startDate,
-				StartTime:     startDateTime,
-				EndTime:       endDateTime,
+				Date:          startDate.UTC(),
+				StartTime:     startDateTime.UTC(),
+				EndTime:       endDateTime.UTC(),
 				Available:     true,
 				IsSlot:        true,
-				Capacity:      defaultCapacity,
+				Capacity:      a.slotCapacity(),
 				SeatsBooked:   0,
 			})
 		}
 		return slots
 	}
 
-	overnight := !endTime.After(startTime)
-	appendSlots := func(date time.Time, windowStart time.Time, windowEnd time.Time) {
-		currentSlotStart := windowStart
-		for currentSlotStart.Before(windowEnd) {
-			slotEnd := currentSlotStart.Add(duration)
-			if slotEnd.After(windowEnd) {
-				break
+	overnight := !clockAfter(endTime, startTime)
+	for _, currentDate := range a.BookingDates(startDate, endDate) {
+		if len(a.Availability) > 0 {
+			for _, window := range a.Availability.WindowsFor(currentDate.Weekday()) {
+				windowStartMinutes, windowEndMinutes, err := window.Minutes()
+				if err != nil {
+					continue
+				}
+				slots = append(slots, a.windowSlots(currentDate, atMinutes(currentDate, windowStartMinutes), atMinutes(currentDate, windowEndMinutes))...)
 			}
-
-			slots = append(slots, Booking{
-				AppointmentID: a.ID,
-				AppCode:       a.AppCode,
-				Date:          date,
-				StartTime:     currentSlotStart.UTC(),
-				EndTime:       slotEnd.UTC(),
-				Available:     true,
-				IsSlot:        true,
-				Capacity:      defaultCapacity,
-				SeatsBooked:   0,
-			})
-			currentSlotStart = slotEnd
+			continue
 		}
-	}
 
-	for currentDate := startDate; !currentDate.After(endDate); currentDate = currentDate.AddDate(0, 0, 1) {
+		windowStart := a.LocalDateTime(currentDate, startTime)
 		if !overnight {
-			windowStart := time.Date(
-				currentDate.Year(), currentDate.Month(), currentDate.Day(),
-				startTime.Hour(), startTime.Minute(), 0, 0,
-				time.UTC,
-			)
-			windowEnd := time.Date(
-				currentDate.Year(), currentDate.Month(), currentDate.Day(),
-				endTime.Hour(), endTime.Minute(), 0, 0,
-				time.UTC,
-			)
-			appendSlots(currentDate, windowStart, windowEnd)
+			slots = append(slots, a.windowSlots(currentDate, windowStart, a.LocalDateTime(currentDate, endTime))...)
 			continue
 		}
 
-		windowStart := time.Date(
-			currentDate.Year(), currentDate.Month(), currentDate.Day(),
-			startTime.Hour(), startTime.Minute(), 0, 0,
-			time.UTC,
-		)
-		windowEnd := time.Date(
-			currentDate.Year(), currentDate.Month(), currentDate.Day(),
-			0, 0, 0, 0,
-			time.UTC,
-		).AddDate(0, 0, 1)
-		appendSlots(currentDate, windowStart, windowEnd)
-
 		nextDate := currentDate.AddDate(0, 0, 1)
-		if nextDate.After(endDate) {
+		slots = append(slots, a.windowSlots(currentDate, windowStart, nextDate)...)
+
+		if nextDate.After(endDate) && a.RecurrenceRule == "" {
 			continue
 		}
-		nextWindowStart := time.Date(
-			nextDate.Year(), nextDate.Month(), nextDate.Day(),
-			0, 0, 0, 0,
-			time.UTC,
-		)
-		nextWindowEnd := time.Date(
-			nextDate.Year(), nextDate.Month(), nextDate.Day(),
-			endTime.Hour(), endTime.Minute(), 0, 0,
-			time.UTC,
-		)
-		appendSlots(nextDate, nextWindowStart, nextWindowEnd)
+		slots = append(slots, a.windowSlots(nextDate, nextDate, a.LocalDateTime(nextDate, endTime))...)
+	}
+	return a.ApplyExceptions(slots, a.Exceptions)
+}
+
+// ApplyExceptions drops slots on closed days, trims shortened days to their window and adds
+// slots for extra windows that don't conflict, buffers included, with a slot already on offer.
+func (a *Appointment) ApplyExceptions(slots []Booking, exceptions []AvailabilityException) []Booking {
+	if len(exceptions) == 0 {
+		return slots
+	}
+
+	closed := make(map[int64]bool)
+	shortened := make(map[int64]AvailabilityWindow)
+	var extras []AvailabilityException
+	for _, exception := range exceptions {
+		day := a.LocalDate(exception.Date).Unix()
+		switch exception.Type {
+		case ExceptionClosed:
+			closed[day] = true
+		case ExceptionShortened:
+			shortened[day] = exception.Window()
+		case ExceptionExtra:
+			extras = append(extras, exception)
+		}
+	}
+
+	result := make([]Booking, 0, len(slots))
+	for _, slot := range slots {
+		day := a.LocalDate(slot.Date)
+		if closed[day.Unix()] {
+			continue
+		}
+		if window, ok := shortened[day.Unix()]; ok {
+			windowStart, windowEnd, err := window.Minutes()
+			if err != nil || slot.StartTime.Before(atMinutes(day, windowStart)) || slot.EndTime.After(atMinutes(day, windowEnd)) {
+				continue
+			}
+		}
+		result = append(result, slot)
+	}
+
+	for _, extra := range extras {
+		windowStart, windowEnd, err := extra.Window().Minutes()
+		if err != nil {
+			continue
+		}
+		day := a.LocalDate(extra.Date)
+		for _, candidate := range a.windowSlots(day, atMinutes(day, windowStart), atMinutes(day, windowEnd)) {
+			if !a.conflictsWithAny(candidate, result) {
+				result = append(result, candidate)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+	return result
+}
+
+// windowSlots lays slots of BookingDuration inside [windowStart, windowEnd). Each slot is
+// preceded by the pre-buffer, which must fit in the window, and followed by the post-buffer,
+// which may run past the window's end.
+func (a *Appointment) windowSlots(date time.Time, windowStart time.Time, windowEnd time.Time) []Booking {
+	var slots []Booking
+	duration := time.Duration(a.BookingDuration) * time.Minute
+	if duration <= 0 {
+		return nil
+	}
+	preBuffer, postBuffer := a.buffers()
+	currentSlotStart := windowStart.Add(preBuffer)
+	for currentSlotStart.Before(windowEnd) {
+		slotEnd := currentSlotStart.Add(duration)
+		if slotEnd.After(windowEnd) {
+			break
+		}
+
+		slots = append(slots, Booking{
+			AppointmentID: a.ID,
+			AppCode:       a.AppCode,
+			Date:          date.UTC(),
+			StartTime:     currentSlotStart.UTC(),
+			EndTime:       slotEnd.UTC(),
+			Available:     true,
+			IsSlot:        true,
+			Capacity:      a.slotCapacity(),
+			SeatsBooked:   0,
+		})
+		currentSlotStart = slotEnd.Add(postBuffer + preBuffer)
 	}
 	return slots
 }
 
+func (a *Appointment) buffers() (time.Duration, time.Duration) {
+	pre := time.Duration(max(a.PreBufferMinutes, 0)) * time.Minute
+	post := time.Duration(max(a.PostBufferMinutes, 0)) * time.Minute
+	return pre, post
+}
+
+// WithinBookingWindow reports whether a slot starting at start may be booked at now, given the
+// appointment's minimum notice and maximum advance settings.
+func (a *Appointment) WithinBookingWindow(start time.Time, now time.Time) bool {
+	if a.MinNoticeMinutes > 0 && start.Before(now.Add(time.Duration(a.MinNoticeMinutes)*time.Minute)) {
+		return false
+	}
+	if a.MaxAdvanceDays > 0 && start.After(now.AddDate(0, 0, a.MaxAdvanceDays)) {
+		return false
+	}
+	return true
+}
+
+// CanCancelAt reports whether a booking starting at start may still be cancelled at now.
+func (a *Appointment) CanCancelAt(start time.Time, now time.Time) bool {
+	return beforeCutoff(a.CancellationCutoffHours, start, now)
+}
+
+// CanRefundAt reports whether a paid booking starting at start is refunded when cancelled at now.
+func (a *Appointment) CanRefundAt(start time.Time, now time.Time) bool {
+	return now.Before(start) && beforeCutoff(a.RefundCutoffHours, start, now)
+}
+
+// PriceFor is what attendees seats of ticket cost, in minor units of Currency. Ticket types
+// without a price of their own sell at the appointment's price; a nil ticket is a plain seat.
+func (a *Appointment) PriceFor(ticket *TicketType, attendees int) int64 {
+	price := a.Price
+	if ticket != nil && ticket.Price != nil {
+		price = *ticket.Price
+	}
+	return price * int64(attendees)
+}
+
+// IsPaid reports whether any seat on the appointment costs money.
+func (a *Appointment) IsPaid() bool {
+	if a.Price > 0 {
+		return true
+	}
+	for _, ticket := range a.TicketTypes {
+		if ticket.Price != nil && *ticket.Price > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// CanRescheduleAt reports whether a booking starting at start may still be changed at now.
+func (a *Appointment) CanRescheduleAt(start time.Time, now time.Time) bool {
+	return beforeCutoff(a.RescheduleCutoffHours, start, now)
+}
+
+// ReschedulesExhausted reports whether a booking moved count times has used up its reschedules.
+func (a *Appointment) ReschedulesExhausted(count int) bool {
+	return a.MaxReschedules > 0 && count >= a.MaxReschedules
+}
+
+func beforeCutoff(hours int, start time.Time, now time.Time) bool {
+	return hours <= 0 || now.Before(start.Add(-time.Duration(hours)*time.Hour))
+}
+
+// BufferedRange widens a slot's start and end by the appointment's pre- and post-buffers.
+func (a *Appointment) BufferedRange(start time.Time, end time.Time) (time.Time, time.Time) {
+	pre, post := a.buffers()
+	return start.Add(-pre), end.Add(post)
+}
+
+// SlotsConflict reports whether two slots of this appointment overlap once buffers are included.
+func (a *Appointment) SlotsConflict(slot Booking, other Booking) bool {
+	slotStart, slotEnd := a.BufferedRange(slot.StartTime, slot.EndTime)
+	otherStart, otherEnd := a.BufferedRange(other.StartTime, other.EndTime)
+	return slotStart.Before(otherEnd) && otherStart.Before(slotEnd)
+}
+
+func (a *Appointment) slotCapacity() int {
+	if (a.Type == Group || a.Type == Party) && a.MaxAttendees > 0 {
+		return a.MaxAttendees
+	}
+	return 1
+}
+
+func (a *Appointment) conflictsWithAny(slot Booking, others []Booking) bool {
+	for _, other := range others {
+		if a.SlotsConflict(slot, other) {
+			return true
+		}
+	}
+	return false
+}
+
+func localMidnight(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// atMinutes returns the instant that is the given number of wall-clock minutes after local midnight.
+func atMinutes(date time.Time, minutes int) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, minutes, 0, 0, date.Location())
+}
+
+func clockAfter(a time.Time, b time.Time) bool {
+	return a.Hour()*60+a.Minute() > b.Hour()*60+b.Minute()
+}
+
+// BookingDates returns the days between startDate and endDate (inclusive) that carry slots:
+// every day for a plain date range, or only the occurrences of RecurrenceRule when one is set.
+func (a *Appointment) BookingDates(startDate time.Time, endDate time.Time) []time.Time {
+	if a.RecurrenceRule == "" {
+		var dates []time.Time
+		for currentDate := startDate; !currentDate.After(endDate); currentDate = currentDate.AddDate(0, 0, 1) {
+			dates = append(dates, currentDate)
+		}
+		return dates
+	}
+	rule, err := ParseRecurrenceRule(a.RecurrenceRule)
+	if err != nil {
+		return nil
+	}
+	return rule.Occurrences(startDate, endDate)
+}
+
 func isAppCodeAvailable(tx *gorm.DB, code string, table string, query string, entity interface{}) (bool, error) {
 	err := tx.Table(table).Where(query, code).First(&entity).Error
 	if err == nil {
@@ -210,6 +451,12 @@ func generateUniqueCode(tx *gorm.DB, table string, query string, entity interfac
 
 func (a *Appointment) IsBookable() (bool, string) {
 	switch a.Status {
+	case AppointmentStatusDraft:
+		return false, "appointment has not been published yet"
+	case AppointmentStatusPaused:
+		return false, "appointment is paused and not taking new bookings"
+	case AppointmentStatusArchived:
+		return false, "appointment has been archived"
 	case AppointmentStatusCanceled:
 		return false, "appointment has been canceled"
 	case AppointmentStatusCompleted:
@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HostAssignment is how bookings on an appointment with several hosts are shared between them.
+type HostAssignment string
+
+const (
+	// HostAssignmentRoundRobin hands bookings to the hosts in turn.
+	HostAssignmentRoundRobin HostAssignment = "round_robin"
+	// HostAssignmentLeastBusy picks the host with the fewest bookings this week.
+	HostAssignmentLeastBusy HostAssignment = "least_busy"
+	// HostAssignmentPriority picks the first free host in priority order.
+	HostAssignmentPriority HostAssignment = "priority"
+)
+
+// AppointmentHost makes a user one of the hosts bookings on an appointment are assigned to.
+// Lower Priority values come first.
+type AppointmentHost struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	AppointmentID uuid.UUID `json:"appointment_id" gorm:"type:uuid;not null;uniqueIndex:idx_appointment_hosts_appointment_user"`
+	UserID        uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_appointment_hosts_appointment_user;index"`
+	User          User      `json:"-" gorm:"foreignKey:UserID"`
+	Priority      int       `json:"priority" gorm:"not null;default:0"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// SortHosts orders hosts by priority, keeping the order they were added in for equal priorities.
+func SortHosts(hosts []AppointmentHost) {
+	sort.SliceStable(hosts, func(i, j int) bool {
+		if hosts[i].Priority != hosts[j].Priority {
+			return hosts[i].Priority < hosts[j].Priority
+		}
+		return hosts[i].CreatedAt.Before(hosts[j].CreatedAt)
+	})
+}
+
+// NextHost picks a host for a booking from hosts, ordered as SortHosts leaves them. free reports
+// whether a host can take the booking; weekly holds each host's bookings this week, used by the
+// least-busy strategy. lastHostID is the host the previous booking went to, used by round-robin.
+// It returns false when no host is free.
+func NextHost(strategy HostAssignment, hosts []AppointmentHost, lastHostID *uuid.UUID, weekly map[uuid.UUID]int, free func(uuid.UUID) bool) (uuid.UUID, bool) {
+	switch strategy {
+	case HostAssignmentLeastBusy:
+		var chosen uuid.UUID
+		found := false
+		for _, host := range hosts {
+			if !free(host.UserID) {
+				continue
+			}
+			if !found || weekly[host.UserID] < weekly[chosen] {
+				chosen = host.UserID
+				found = true
+			}
+		}
+		return chosen, found
+	case HostAssignmentPriority:
+		for _, host := range hosts {
+			if free(host.UserID) {
+				return host.UserID, true
+			}
+		}
+		return uuid.Nil, false
+	default:
+		start := 0
+		if lastHostID != nil {
+			for i, host := range hosts {
+				if host.UserID == *lastHostID {
+					start = i + 1
+					break
+				}
+			}
+		}
+		for i := range hosts {
+			host := hosts[(start+i)%len(hosts)]
+			if free(host.UserID) {
+				return host.UserID, true
+			}
+		}
+		return uuid.Nil, false
+	}
+}
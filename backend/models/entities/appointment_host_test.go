@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextHost(t *testing.T) {
+	added := time.Date(2026, 4, 27, 9, 0, 0, 0, time.UTC)
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	hosts := []AppointmentHost{
+		{UserID: carol, Priority: 2, CreatedAt: added},
+		{UserID: bob, Priority: 1, CreatedAt: added.Add(time.Minute)},
+		{UserID: alice, Priority: 1, CreatedAt: added},
+	}
+	SortHosts(hosts)
+	assert.Equal(t, []uuid.UUID{alice, bob, carol}, []uuid.UUID{hosts[0].UserID, hosts[1].UserID, hosts[2].UserID})
+
+	allFree := func(uuid.UUID) bool { return true }
+	busy := func(ids ...uuid.UUID) func(uuid.UUID) bool {
+		return func(id uuid.UUID) bool {
+			for _, busyID := range ids {
+				if id == busyID {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	t.Run("round robin starts with the first host", func(t *testing.T) {
+		host, ok := NextHost(HostAssignmentRoundRobin, hosts, nil, nil, allFree)
+		assert.True(t, ok)
+		assert.Equal(t, alice, host)
+	})
+
+	t.Run("round robin wraps and skips busy hosts", func(t *testing.T) {
+		host, ok := NextHost(HostAssignmentRoundRobin, hosts, &carol, nil, busy(alice))
+		assert.True(t, ok)
+		assert.Equal(t, bob, host)
+	})
+
+	t.Run("least busy picks the fewest bookings this week", func(t *testing.T) {
+		weekly := map[uuid.UUID]int{alice: 3, bob: 1, carol: 1}
+		host, ok := NextHost(HostAssignmentLeastBusy, hosts, nil, weekly, allFree)
+		assert.True(t, ok)
+		assert.Equal(t, bob, host)
+
+		host, ok = NextHost(HostAssignmentLeastBusy, hosts, nil, weekly, busy(bob))
+		assert.True(t, ok)
+		assert.Equal(t, carol, host)
+	})
+
+	t.Run("priority picks the first free host", func(t *testing.T) {
+		host, ok := NextHost(HostAssignmentPriority, hosts, &alice, nil, busy(alice))
+		assert.True(t, ok)
+		assert.Equal(t, bob, host)
+	})
+
+	t.Run("no host when all are busy", func(t *testing.T) {
+		_, ok := NextHost(HostAssignmentPriority, hosts, nil, nil, busy(alice, bob, carol))
+		assert.False(t, ok)
+	})
+}
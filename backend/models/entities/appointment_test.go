@@ -0,0 +1,195 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateBookingsKeepsLocalWindowAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	appointment := &Appointment{
+		Type:            Single,
+		BookingDuration: 60,
+		TimeZone:        "America/New_York",
+		StartDate:       time.Date(2026, 3, 7, 0, 0, 0, 0, loc),
+		EndDate:         time.Date(2026, 3, 9, 0, 0, 0, 0, loc),
+		StartTime:       time.Date(2026, 3, 7, 9, 0, 0, 0, loc),
+		EndTime:         time.Date(2026, 3, 7, 10, 0, 0, 0, loc),
+	}
+
+	slots := appointment.GenerateBookings()
+
+	require.Len(t, slots, 3)
+	// DST starts on 2026-03-08, so the UTC instant of the 09:00 slot shifts by an hour.
+	assert.Equal(t, time.Date(2026, 3, 7, 14, 0, 0, 0, time.UTC), slots[0].StartTime)
+	assert.Equal(t, time.Date(2026, 3, 8, 13, 0, 0, 0, time.UTC), slots[1].StartTime)
+	assert.Equal(t, time.Date(2026, 3, 9, 13, 0, 0, 0, time.UTC), slots[2].StartTime)
+	for _, slot := range slots {
+		assert.Equal(t, 9, slot.StartTime.In(loc).Hour())
+		assert.Equal(t, 0, slot.Date.In(loc).Hour())
+	}
+}
+
+func TestAppointmentLocationFallsBackToUTC(t *testing.T) {
+	assert.Equal(t, time.UTC, (&Appointment{}).Location())
+	assert.Equal(t, time.UTC, (&Appointment{TimeZone: "Not/AZone"}).Location())
+	assert.Equal(t, "Europe/Berlin", (&Appointment{TimeZone: "Europe/Berlin"}).Location().String())
+}
+
+func TestGenerateBookingsAppliesAvailabilityExceptions(t *testing.T) {
+	appointment := &Appointment{
+		Type:            Single,
+		BookingDuration: 60,
+		TimeZone:        "UTC",
+		StartDate:       time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:         time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC),
+		StartTime:       time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+		Exceptions: []AvailabilityException{
+			{Date: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), Type: ExceptionClosed},
+			{Date: time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), Type: ExceptionShortened, StartTime: "10:00", EndTime: "11:00"},
+			{Date: time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC), Type: ExceptionExtra, StartTime: "11:00", EndTime: "14:00"},
+		},
+	}
+
+	slots := appointment.GenerateBookings()
+
+	var starts []time.Time
+	for _, slot := range slots {
+		starts = append(starts, slot.StartTime)
+	}
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 6, 3, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 6, 3, 10, 0, 0, 0, time.UTC),
+		time.Date(2026, 6, 3, 11, 0, 0, 0, time.UTC),
+		time.Date(2026, 6, 3, 12, 0, 0, 0, time.UTC),
+		time.Date(2026, 6, 3, 13, 0, 0, 0, time.UTC),
+	}, starts)
+}
+
+func TestGenerateBookingsSpacesSlotsByBuffers(t *testing.T) {
+	appointment := &Appointment{
+		Type:              Single,
+		BookingDuration:   45,
+		PostBufferMinutes: 15,
+		TimeZone:          "UTC",
+		StartDate:         time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:           time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		StartTime:         time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC),
+		EndTime:           time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	slots := appointment.GenerateBookings()
+
+	require.Len(t, slots, 3)
+	for i, hour := range []int{9, 10, 11} {
+		assert.Equal(t, time.Date(2026, 6, 1, hour, 0, 0, 0, time.UTC), slots[i].StartTime)
+		assert.Equal(t, time.Date(2026, 6, 1, hour, 45, 0, 0, time.UTC), slots[i].EndTime)
+	}
+
+	appointment.PreBufferMinutes = 10
+	slots = appointment.GenerateBookings()
+
+	require.Len(t, slots, 2)
+	assert.Equal(t, time.Date(2026, 6, 1, 9, 10, 0, 0, time.UTC), slots[0].StartTime)
+	assert.Equal(t, time.Date(2026, 6, 1, 10, 20, 0, 0, time.UTC), slots[1].StartTime)
+	assert.True(t, appointment.SlotsConflict(slots[0], Booking{
+		StartTime: time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 6, 1, 10, 45, 0, 0, time.UTC),
+	}))
+	assert.False(t, appointment.SlotsConflict(slots[0], slots[1]))
+}
+
+func TestWithinBookingWindow(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	appointment := &Appointment{}
+
+	assert.True(t, appointment.WithinBookingWindow(now.Add(time.Minute), now))
+	assert.True(t, appointment.WithinBookingWindow(now.AddDate(1, 0, 0), now))
+
+	appointment.MinNoticeMinutes = 120
+	appointment.MaxAdvanceDays = 30
+
+	assert.False(t, appointment.WithinBookingWindow(now.Add(time.Hour), now))
+	assert.True(t, appointment.WithinBookingWindow(now.Add(2*time.Hour), now))
+	assert.True(t, appointment.WithinBookingWindow(now.AddDate(0, 0, 30), now))
+	assert.False(t, appointment.WithinBookingWindow(now.AddDate(0, 0, 31), now))
+}
+
+func TestBookingChangePolicies(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(10 * time.Hour)
+	appointment := &Appointment{}
+
+	assert.True(t, appointment.CanCancelAt(start, now))
+	assert.True(t, appointment.CanRescheduleAt(start.Add(-time.Hour), start))
+	assert.False(t, appointment.ReschedulesExhausted(10))
+
+	appointment.CancellationCutoffHours = 24
+	appointment.RescheduleCutoffHours = 6
+	appointment.MaxReschedules = 1
+
+	assert.False(t, appointment.CanCancelAt(start, now))
+	assert.True(t, appointment.CanCancelAt(start.Add(24*time.Hour), now))
+	assert.True(t, appointment.CanRescheduleAt(start, now))
+	assert.False(t, appointment.CanRescheduleAt(start, now.Add(5*time.Hour)))
+	assert.False(t, appointment.ReschedulesExhausted(0))
+	assert.True(t, appointment.ReschedulesExhausted(1))
+}
+
+func TestAppointmentPricing(t *testing.T) {
+	vipPrice, studentPrice := int64(5000), int64(0)
+	appointment := &Appointment{Price: 2000, Currency: "USD", TicketTypes: TicketTypes{
+		{Key: "general", Name: "General", Capacity: 40},
+		{Key: "vip", Name: "VIP", Capacity: 10, Price: &vipPrice},
+		{Key: "student", Name: "Student", Capacity: 10, Price: &studentPrice},
+	}}
+
+	assert.True(t, appointment.IsPaid())
+	assert.Equal(t, int64(4000), appointment.PriceFor(nil, 2))
+	assert.Equal(t, int64(2000), appointment.PriceFor(&appointment.TicketTypes[0], 1))
+	assert.Equal(t, int64(15000), appointment.PriceFor(&appointment.TicketTypes[1], 3))
+	assert.Equal(t, int64(0), appointment.PriceFor(&appointment.TicketTypes[2], 3))
+
+	appointment.Price = 0
+	appointment.TicketTypes = appointment.TicketTypes[2:]
+	assert.False(t, appointment.IsPaid())
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, appointment.CanRefundAt(now.Add(time.Hour), now))
+	assert.False(t, appointment.CanRefundAt(now, now))
+	appointment.RefundCutoffHours = 48
+	assert.False(t, appointment.CanRefundAt(now.Add(24*time.Hour), now))
+	assert.True(t, appointment.CanRefundAt(now.Add(72*time.Hour), now))
+}
+
+func TestAppointmentLifecycle(t *testing.T) {
+	assert.True(t, CanTransitionAppointmentStatus(AppointmentStatusDraft, AppointmentStatusPending))
+	assert.True(t, CanTransitionAppointmentStatus(AppointmentStatusPending, AppointmentStatusPaused))
+	assert.True(t, CanTransitionAppointmentStatus(AppointmentStatusOngoing, AppointmentStatusPaused))
+	assert.True(t, CanTransitionAppointmentStatus(AppointmentStatusPaused, AppointmentStatusPending))
+	assert.True(t, CanTransitionAppointmentStatus(AppointmentStatusCompleted, AppointmentStatusArchived))
+	assert.False(t, CanTransitionAppointmentStatus(AppointmentStatusDraft, AppointmentStatusPaused))
+	assert.False(t, CanTransitionAppointmentStatus(AppointmentStatusPending, AppointmentStatusArchived))
+	assert.False(t, CanTransitionAppointmentStatus(AppointmentStatusArchived, AppointmentStatusPending))
+
+	for status, bookable := range map[AppointmentStatus]bool{
+		AppointmentStatusDraft:     false,
+		AppointmentStatusPending:   true,
+		AppointmentStatusOngoing:   true,
+		AppointmentStatusPaused:    false,
+		AppointmentStatusCompleted: false,
+		AppointmentStatusCanceled:  false,
+		AppointmentStatusArchived:  false,
+	} {
+		ok, reason := (&Appointment{Status: status}).IsBookable()
+		assert.Equal(t, bookable, ok, status)
+		assert.Equal(t, bookable, reason == "", status)
+	}
+}
@@ -0,0 +1,178 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AvailabilityWindow is a wall-clock range within a day, e.g. 09:00–12:00. End may be "24:00".
+type AvailabilityWindow struct {
+	Start string `json:"start" example:"09:00"`
+	End   string `json:"end" example:"12:00"`
+}
+
+// WeeklyAvailability maps lowercase weekday names ("monday" … "sunday") to the windows open on
+// that day. A weekday that is missing or has no windows is closed.
+type WeeklyAvailability map[string][]AvailabilityWindow
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// Minutes returns the window bounds as minutes since midnight.
+func (w AvailabilityWindow) Minutes() (int, int, error) {
+	start, err := parseClockMinutes(w.Start)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseClockMinutes(w.End)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+func parseClockMinutes(value string) (int, error) {
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Validate checks weekday names and that each day's windows are well formed and do not overlap.
+func (w WeeklyAvailability) Validate() error {
+	for day, windows := range w {
+		if _, ok := weekdayNames[day]; !ok {
+			return fmt.Errorf("unknown weekday %q", day)
+		}
+		prevEnd := -1
+		for _, window := range sortedWindows(windows) {
+			start, end, err := window.Minutes()
+			if err != nil {
+				return fmt.Errorf("%s: %w", day, err)
+			}
+			if end <= start {
+				return fmt.Errorf("%s: window %s-%s must end after it starts", day, window.Start, window.End)
+			}
+			if start < prevEnd {
+				return fmt.Errorf("%s: windows overlap at %s", day, window.Start)
+			}
+			prevEnd = end
+		}
+	}
+	return nil
+}
+
+// Normalize lowercases weekday keys and orders each day's windows by start time.
+func (w WeeklyAvailability) Normalize() WeeklyAvailability {
+	if w == nil {
+		return nil
+	}
+	normalized := make(WeeklyAvailability, len(w))
+	for day, windows := range w {
+		key := strings.ToLower(strings.TrimSpace(day))
+		normalized[key] = append(normalized[key], windows...)
+	}
+	for day, windows := range normalized {
+		normalized[day] = sortedWindows(windows)
+	}
+	return normalized
+}
+
+// IsOpen reports whether any weekday has at least one window.
+func (w WeeklyAvailability) IsOpen() bool {
+	for _, windows := range w {
+		if len(windows) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// WindowsFor returns the windows open on the given weekday.
+func (w WeeklyAvailability) WindowsFor(day time.Weekday) []AvailabilityWindow {
+	for name, weekday := range weekdayNames {
+		if weekday == day {
+			return w[name]
+		}
+	}
+	return nil
+}
+
+// Span returns the earliest start and latest end, in minutes, across all windows.
+func (w WeeklyAvailability) Span() (int, int) {
+	first, last := -1, -1
+	for _, windows := range w {
+		for _, window := range windows {
+			start, end, err := window.Minutes()
+			if err != nil {
+				continue
+			}
+			if first == -1 || start < first {
+				first = start
+			}
+			if end > last {
+				last = end
+			}
+		}
+	}
+	return first, last
+}
+
+func sortedWindows(windows []AvailabilityWindow) []AvailabilityWindow {
+	sorted := append([]AvailabilityWindow(nil), windows...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, _ := parseClockMinutes(sorted[i].Start)
+		b, _ := parseClockMinutes(sorted[j].Start)
+		return a < b
+	})
+	return sorted
+}
+
+// Value stores the template as JSON.
+func (w WeeklyAvailability) Value() (driver.Value, error) {
+	if len(w) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(w)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the JSON template back from the database.
+func (w *WeeklyAvailability) Scan(value interface{}) error {
+	if value == nil {
+		*w = nil
+		return nil
+	}
+	var data []byte
+	switch v := value.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported availability value %T", value)
+	}
+	if len(data) == 0 {
+		*w = nil
+		return nil
+	}
+	return json.Unmarshal(data, w)
+}
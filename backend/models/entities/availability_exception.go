@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AvailabilityExceptionType string
+
+const (
+	// ExceptionClosed removes every slot on the date.
+	ExceptionClosed AvailabilityExceptionType = "closed"
+	// ExceptionShortened keeps only the slots that fit inside StartTime–EndTime.
+	ExceptionShortened AvailabilityExceptionType = "shortened"
+	// ExceptionExtra opens an additional StartTime–EndTime window on the date.
+	ExceptionExtra AvailabilityExceptionType = "extra"
+)
+
+// AvailabilityException overrides an appointment's regular schedule for a single local date.
+type AvailabilityException struct {
+	ID            uuid.UUID                 `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	AppointmentID uuid.UUID                 `json:"appointment_id" gorm:"type:uuid;not null;index"`
+	Date          time.Time                 `json:"date" gorm:"not null"` // local midnight of the affected day
+	Type          AvailabilityExceptionType `json:"type" gorm:"not null"`
+	StartTime     string                    `json:"start_time,omitempty" example:"09:00"` // HH:MM, shortened and extra only
+	EndTime       string                    `json:"end_time,omitempty" example:"12:00"`
+	Reason        string                    `json:"reason" gorm:"type:text"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	UpdatedAt     time.Time                 `json:"updated_at"`
+}
+
+// Window returns the exception's wall-clock window.
+func (e AvailabilityException) Window() AvailabilityWindow {
+	return AvailabilityWindow{Start: e.StartTime, End: e.EndTime}
+}
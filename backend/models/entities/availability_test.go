@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeeklyAvailabilityValidate(t *testing.T) {
+	valid := WeeklyAvailability{
+		"monday":   {{Start: "13:00", End: "17:00"}, {Start: "09:00", End: "12:00"}},
+		"saturday": {{Start: "10:00", End: "14:00"}},
+		"sunday":   {},
+	}
+	assert.NoError(t, valid.Validate())
+
+	assert.Error(t, WeeklyAvailability{"funday": {{Start: "09:00", End: "10:00"}}}.Validate())
+	assert.Error(t, WeeklyAvailability{"monday": {{Start: "9am", End: "10:00"}}}.Validate())
+	assert.Error(t, WeeklyAvailability{"monday": {{Start: "12:00", End: "09:00"}}}.Validate())
+	assert.Error(t, WeeklyAvailability{"monday": {{Start: "09:00", End: "12:00"}, {Start: "11:00", End: "13:00"}}}.Validate())
+}
+
+func TestWeeklyAvailabilityScanRoundTrip(t *testing.T) {
+	original := WeeklyAvailability{"friday": {{Start: "08:00", End: "24:00"}}}
+
+	value, err := original.Value()
+	require.NoError(t, err)
+
+	var scanned WeeklyAvailability
+	require.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, original, scanned)
+}
+
+func TestGenerateBookingsUsesWeeklyAvailability(t *testing.T) {
+	appointment := &Appointment{
+		Type:            Single,
+		BookingDuration: 60,
+		StartDate:       time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), // Monday
+		EndDate:         time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), // Sunday
+		Availability: WeeklyAvailability{
+			"monday":   {{Start: "09:00", End: "12:00"}, {Start: "13:00", End: "17:00"}},
+			"saturday": {{Start: "10:00", End: "14:00"}},
+		},
+	}
+
+	slots := appointment.GenerateBookings()
+
+	require.Len(t, slots, 11)
+	for _, slot := range slots {
+		assert.Contains(t, []time.Weekday{time.Monday, time.Saturday}, slot.Date.Weekday())
+		if slot.Date.Weekday() == time.Monday {
+			assert.NotEqual(t, 12, slot.StartTime.Hour(), "lunch break must stay closed")
+		}
+	}
+	assert.Equal(t, time.Date(2026, 3, 7, 10, 0, 0, 0, time.UTC), slots[7].StartTime)
+}
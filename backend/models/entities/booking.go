@@ -36,6 +36,62 @@ type Booking struct {
 	Status              string         `json:"status" gorm:"default:'active'"` // Booking status: active, cancelled, etc.
 	Description         string         `json:"description" gorm:"type:text"`   // Additional info from the booker
 	DeviceID            string         `json:"-"`
+	RescheduleCount     int            `json:"reschedule_count" gorm:"not null;default:0"`                    // Times the booking has been moved to another slot
+	FormAnswers         FormAnswers    `json:"form_answers,omitempty" gorm:"type:jsonb" swaggertype:"object"` // Answers to the appointment's custom form fields
+	// CheckedInAt records when the owner checked the guest in.
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+	// Blocked marks a slot that overlaps a busy time on one of the owner's external calendars.
+	Blocked bool `json:"blocked" gorm:"not null;default:false"`
+	// HostConflict marks a slot that overlaps a booking in another of the owner's appointments.
+	HostConflict bool `json:"host_conflict" gorm:"not null;default:false"`
+	// HostID is the appointment host the booking was assigned to, when the appointment has hosts.
+	HostID *uuid.UUID `json:"host_id,omitempty" gorm:"type:uuid;index"`
+	// TicketType is the key of the appointment ticket type the seats were bought in, if any.
+	TicketType string `json:"ticket_type,omitempty" gorm:"type:varchar(64)"`
+	// AmountDue is what the booking costs, in minor units of Currency. PaymentStatus is empty for
+	// free bookings and otherwise tracks the payment made through the payment provider.
+	AmountDue     int64  `json:"amount_due" gorm:"not null;default:0"`
+	Currency      string `json:"currency,omitempty" gorm:"type:varchar(3)"`
+	PaymentStatus string `json:"payment_status,omitempty" gorm:"type:varchar(16);not null;default:''"`
+	// PromoCode is the promo code redeemed on the booking. Its Discount is taken off every price
+	// the booking is given; DiscountAmount is how much it took off AmountDue.
+	PromoCode      string   `json:"promo_code,omitempty" gorm:"type:varchar(64)"`
+	Discount       Discount `json:"-" gorm:"embedded;embeddedPrefix:discount_"`
+	DiscountAmount int64    `json:"discount_amount,omitempty" gorm:"not null;default:0"`
+}
+
+// SetPrice records what the booking costs after its discount, due whenever that is above zero. A
+// booking that has been paid or refunded keeps the price it was paid at.
+func (a *Booking) SetPrice(amount int64, currency string) {
+	switch a.PaymentStatus {
+	case BookingPaymentPaid, BookingPaymentRefunded:
+		return
+	}
+	a.DiscountAmount = a.Discount.Off(amount)
+	a.AmountDue = amount - a.DiscountAmount
+	a.Currency = currency
+	if a.AmountDue > 0 {
+		a.PaymentStatus = BookingPaymentDue
+	} else {
+		a.PaymentStatus = ""
+	}
+}
+
+// ApplyPromoCode takes a redeemed promo code's discount off the booking's current price.
+func (a *Booking) ApplyPromoCode(code string, discount Discount) {
+	a.PromoCode = code
+	a.Discount = discount
+	a.SetPrice(a.AmountDue+a.DiscountAmount, a.Currency)
+}
+
+// ClearPrice drops the price, discount and payment status when a slot row is released.
+func (a *Booking) ClearPrice() {
+	a.AmountDue = 0
+	a.Currency = ""
+	a.PaymentStatus = ""
+	a.PromoCode = ""
+	a.Discount = Discount{}
+	a.DiscountAmount = 0
 }
 
 func (a *Booking) BeforeCreate(tx *gorm.DB) error {
@@ -70,6 +126,13 @@ func generateDeterministicBookingCode(b *Booking) string {
 	return fmt.Sprintf("%s%s", base, uuid.New().String()[:4])
 }
 
+// ReissueBookingCode gives a reused slot row a new code, so the previous holder's code no longer
+// resolves to it.
+func (b *Booking) ReissueBookingCode() {
+	base := fmt.Sprintf("BK%s%s", b.Date.Format("060102"), b.StartTime.Format("1504"))
+	b.BookingCode = fmt.Sprintf("%s%s", base, uuid.New().String()[:4])
+}
+
 func shortUUID(id uuid.UUID) string {
 	return id.String()[:4]
 }
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/m13ha/asiko/utils"
+)
+
+// CalendarUID identifies the booking's calendar event across updates and cancellation. It is
+// derived from the booking ID, except for single-appointment slot rows: those rows are reused
+// by later bookers and the booker's code moves with them when they reschedule, so the code is
+// what stays stable there.
+func (b *Booking) CalendarUID() string {
+	if b.IsSlot {
+		return strings.ToLower(b.BookingCode) + "@asiko"
+	}
+	return b.ID.String() + "@asiko"
+}
+
+// CalendarEvent describes the booking as a VEVENT titled summary. Cancelled and rejected
+// bookings are marked cancelled and bump the sequence past the last reschedule so clients apply
+// the cancellation.
+func (b *Booking) CalendarEvent(summary string) utils.ICalEvent {
+	if strings.TrimSpace(summary) == "" {
+		summary = "Booking " + b.BookingCode
+	}
+	stamp := b.UpdatedAt
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	event := utils.ICalEvent{
+		UID:         b.CalendarUID(),
+		Sequence:    b.RescheduleCount,
+		Stamp:       stamp,
+		Start:       b.StartTime,
+		End:         b.EndTime,
+		Summary:     summary,
+		Description: b.calendarDescription(),
+		Status:      utils.ICalStatusConfirmed,
+	}
+	switch strings.ToLower(b.Status) {
+	case BookingStatusCancelled, BookingStatusCanceled, BookingStatusRejected:
+		event.Status = utils.ICalStatusCancelled
+		event.Sequence++
+	case BookingStatusPending:
+		event.Status = utils.ICalStatusTentative
+	}
+	if b.Email != "" {
+		event.Attendees = []utils.ICalAddress{{Email: b.Email, Name: b.Name}}
+	}
+	return event
+}
+
+func (b *Booking) calendarDescription() string {
+	lines := []string{"Booking code: " + b.BookingCode}
+	if b.AttendeeCount > 1 && !b.IsSlot {
+		lines = append(lines, fmt.Sprintf("Attendees: %d", b.AttendeeCount))
+	}
+	if b.Description != "" {
+		lines = append(lines, b.Description)
+	}
+	return strings.Join(lines, "\n")
+}
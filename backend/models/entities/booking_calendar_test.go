@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m13ha/asiko/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookingCalendarEvent(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	booking := &Booking{
+		ID:              uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+		BookingCode:     "BK26030209002222",
+		Name:            "Guest",
+		Email:           "guest@example.com",
+		StartTime:       start,
+		EndTime:         start.Add(time.Hour),
+		Status:          BookingStatusConfirmed,
+		RescheduleCount: 1,
+	}
+
+	event := booking.CalendarEvent("Team offsite")
+	assert.Equal(t, "22222222-2222-2222-2222-222222222222@asiko", event.UID)
+	assert.Equal(t, "Team offsite", event.Summary)
+	assert.Equal(t, utils.ICalStatusConfirmed, event.Status)
+	assert.Equal(t, 1, event.Sequence)
+	assert.Equal(t, []utils.ICalAddress{{Email: "guest@example.com", Name: "Guest"}}, event.Attendees)
+
+	booking.Status = BookingStatusCancelled
+	cancelled := booking.CalendarEvent("")
+	assert.Equal(t, event.UID, cancelled.UID)
+	assert.Equal(t, utils.ICalStatusCancelled, cancelled.Status)
+	assert.Equal(t, 2, cancelled.Sequence)
+	assert.Equal(t, "Booking BK26030209002222", cancelled.Summary)
+
+	slot := &Booking{ID: uuid.New(), IsSlot: true, BookingCode: "BK26030209001111"}
+	assert.Equal(t, "bk26030209001111@asiko", slot.CalendarUID())
+}
@@ -0,0 +1,242 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type FormFieldType string
+
+const (
+	FormFieldText     FormFieldType = "text"
+	FormFieldNumber   FormFieldType = "number"
+	FormFieldSelect   FormFieldType = "select"
+	FormFieldCheckbox FormFieldType = "checkbox"
+)
+
+// FormField is an extra question the owner asks on the booking form. Key identifies the answer
+// in FormAnswers; Pattern is an optional regular expression text answers must match.
+type FormField struct {
+	Key      string        `json:"key" example:"company"`
+	Label    string        `json:"label" example:"Company name"`
+	Type     FormFieldType `json:"type" example:"text"`
+	Required bool          `json:"required"`
+	Options  []string      `json:"options,omitempty"`
+	Pattern  string        `json:"pattern,omitempty"`
+}
+
+// BookingForm is the ordered list of custom questions on an appointment's booking form.
+type BookingForm []FormField
+
+// FormAnswers holds a booker's answers keyed by FormField.Key: strings for text and select
+// fields, numbers for number fields and booleans for checkboxes.
+type FormAnswers map[string]interface{}
+
+// Validate checks that field keys are present and unique, types are known, select fields list
+// their options and patterns compile.
+func (f BookingForm) Validate() error {
+	seen := make(map[string]bool, len(f))
+	for _, field := range f {
+		key := strings.TrimSpace(field.Key)
+		if key == "" {
+			return fmt.Errorf("every field needs a key")
+		}
+		if seen[key] {
+			return fmt.Errorf("duplicate field key %q", key)
+		}
+		seen[key] = true
+
+		switch field.Type {
+		case FormFieldText, FormFieldNumber, FormFieldCheckbox:
+		case FormFieldSelect:
+			if len(field.Options) == 0 {
+				return fmt.Errorf("%s: select fields need at least one option", key)
+			}
+		default:
+			return fmt.Errorf("%s: unknown field type %q", key, field.Type)
+		}
+
+		if field.Pattern != "" {
+			if field.Type != FormFieldText {
+				return fmt.Errorf("%s: patterns only apply to text fields", key)
+			}
+			if _, err := regexp.Compile(field.Pattern); err != nil {
+				return fmt.Errorf("%s: invalid pattern: %v", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateAnswers checks answers against the form and returns them normalized: text is trimmed,
+// blank optional answers are dropped and unknown keys are rejected. A required checkbox must be
+// ticked.
+func (f BookingForm) ValidateAnswers(answers FormAnswers) (FormAnswers, error) {
+	fields := make(map[string]FormField, len(f))
+	for _, field := range f {
+		fields[field.Key] = field
+	}
+	for key := range answers {
+		if _, ok := fields[key]; !ok {
+			return nil, fmt.Errorf("unknown form field %q", key)
+		}
+	}
+
+	normalized := make(FormAnswers, len(answers))
+	for _, field := range f {
+		value, present := answers[field.Key]
+		if present && value != nil {
+			answer, err := field.normalize(value)
+			if err != nil {
+				return nil, err
+			}
+			if answer != nil {
+				normalized[field.Key] = answer
+			}
+		}
+		if field.Required && !isAnswered(normalized[field.Key]) {
+			return nil, fmt.Errorf("%s is required", field.label())
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+	return normalized, nil
+}
+
+func (field FormField) normalize(value interface{}) (interface{}, error) {
+	switch field.Type {
+	case FormFieldText:
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be text", field.label())
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return nil, nil
+		}
+		if field.Pattern != "" {
+			if matched, _ := regexp.MatchString(field.Pattern, text); !matched {
+				return nil, fmt.Errorf("%s is not in the expected format", field.label())
+			}
+		}
+		return text, nil
+	case FormFieldNumber:
+		switch n := value.(type) {
+		case float64:
+			return n, nil
+		case int:
+			return float64(n), nil
+		case json.Number:
+			parsed, err := n.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a number", field.label())
+			}
+			return parsed, nil
+		}
+		return nil, fmt.Errorf("%s must be a number", field.label())
+	case FormFieldSelect:
+		choice, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be one of the listed options", field.label())
+		}
+		if strings.TrimSpace(choice) == "" {
+			return nil, nil
+		}
+		for _, option := range field.Options {
+			if option == choice {
+				return choice, nil
+			}
+		}
+		return nil, fmt.Errorf("%s must be one of the listed options", field.label())
+	case FormFieldCheckbox:
+		checked, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s must be true or false", field.label())
+		}
+		return checked, nil
+	}
+	return nil, fmt.Errorf("%s has an unknown field type", field.label())
+}
+
+func (field FormField) label() string {
+	if field.Label != "" {
+		return field.Label
+	}
+	return field.Key
+}
+
+func isAnswered(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	}
+	return true
+}
+
+// Value stores the form as JSON.
+func (f BookingForm) Value() (driver.Value, error) {
+	if len(f) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the JSON form back from the database.
+func (f *BookingForm) Scan(value interface{}) error {
+	data, err := jsonColumnBytes(value)
+	if err != nil || data == nil {
+		*f = nil
+		return err
+	}
+	return json.Unmarshal(data, f)
+}
+
+// Value stores the answers as JSON.
+func (a FormAnswers) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the JSON answers back from the database.
+func (a *FormAnswers) Scan(value interface{}) error {
+	data, err := jsonColumnBytes(value)
+	if err != nil || data == nil {
+		*a = nil
+		return err
+	}
+	return json.Unmarshal(data, a)
+}
+
+func jsonColumnBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		if len(v) == 0 {
+			return nil, nil
+		}
+		return v, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return []byte(v), nil
+	}
+	return nil, fmt.Errorf("unsupported JSON column value %T", value)
+}
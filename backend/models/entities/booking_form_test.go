@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingFormValidate(t *testing.T) {
+	valid := BookingForm{
+		{Key: "company", Label: "Company", Type: FormFieldText, Pattern: `^[A-Za-z ]+$`},
+		{Key: "size", Type: FormFieldSelect, Options: []string{"S", "M", "L"}},
+	}
+	assert.NoError(t, valid.Validate())
+
+	assert.Error(t, BookingForm{{Key: "", Type: FormFieldText}}.Validate())
+	assert.Error(t, BookingForm{{Key: "a", Type: FormFieldText}, {Key: "a", Type: FormFieldNumber}}.Validate())
+	assert.Error(t, BookingForm{{Key: "a", Type: "date"}}.Validate())
+	assert.Error(t, BookingForm{{Key: "a", Type: FormFieldSelect}}.Validate())
+	assert.Error(t, BookingForm{{Key: "a", Type: FormFieldText, Pattern: "("}}.Validate())
+	assert.Error(t, BookingForm{{Key: "a", Type: FormFieldNumber, Pattern: `\d+`}}.Validate())
+}
+
+func TestBookingFormValidateAnswers(t *testing.T) {
+	form := BookingForm{
+		{Key: "company", Label: "Company", Type: FormFieldText, Required: true, Pattern: `^[A-Za-z ]+$`},
+		{Key: "guests", Type: FormFieldNumber},
+		{Key: "size", Type: FormFieldSelect, Options: []string{"S", "M", "L"}},
+		{Key: "terms", Label: "Terms", Type: FormFieldCheckbox, Required: true},
+	}
+
+	answers, err := form.ValidateAnswers(FormAnswers{"company": "  Acme  ", "guests": float64(3), "size": "", "terms": true})
+	require.NoError(t, err)
+	assert.Equal(t, FormAnswers{"company": "Acme", "guests": float64(3), "terms": true}, answers)
+
+	_, err = form.ValidateAnswers(FormAnswers{"terms": true})
+	assert.EqualError(t, err, "Company is required")
+	_, err = form.ValidateAnswers(FormAnswers{"company": "Acme", "terms": false})
+	assert.EqualError(t, err, "Terms is required")
+	_, err = form.ValidateAnswers(FormAnswers{"company": "Acme 42", "terms": true})
+	assert.Error(t, err)
+	_, err = form.ValidateAnswers(FormAnswers{"company": "Acme", "terms": true, "guests": "three"})
+	assert.Error(t, err)
+	_, err = form.ValidateAnswers(FormAnswers{"company": "Acme", "terms": true, "size": "XL"})
+	assert.Error(t, err)
+	_, err = form.ValidateAnswers(FormAnswers{"company": "Acme", "terms": true, "extra": "x"})
+	assert.Error(t, err)
+
+	empty, err := BookingForm(nil).ValidateAnswers(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, empty)
+}
+
+func TestFormAnswersScanRoundTrip(t *testing.T) {
+	original := FormAnswers{"company": "Acme", "guests": float64(2), "terms": true}
+
+	value, err := original.Value()
+	require.NoError(t, err)
+
+	var scanned FormAnswers
+	require.NoError(t, scanned.Scan([]byte(value.(string))))
+	assert.Equal(t, original, scanned)
+}
@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReminderStatusPending   = "pending"
+	ReminderStatusSent      = "sent"
+	ReminderStatusCancelled = "cancelled"
+	ReminderStatusFailed    = "failed"
+
+	// MaxReminderOffsets caps how many reminders one booking gets, and MaxReminderOffsetMinutes
+	// how early the first may go out (30 days).
+	MaxReminderOffsets       = 5
+	MaxReminderOffsetMinutes = 30 * 24 * 60
+)
+
+// DefaultReminderOffsets reminds guests a day and an hour before their booking starts.
+var DefaultReminderOffsets = ReminderOffsets{24 * 60, 60}
+
+// ReminderOffsets are the minutes before a booking starts at which its guest is reminded. An empty
+// list sends no reminders.
+type ReminderOffsets []int
+
+// Validate checks that every offset is between a minute and MaxReminderOffsetMinutes, and that
+// offsets are unique and no more than MaxReminderOffsets.
+func (o ReminderOffsets) Validate() error {
+	if len(o) > MaxReminderOffsets {
+		return fmt.Errorf("at most %d reminders are allowed", MaxReminderOffsets)
+	}
+	seen := make(map[int]bool, len(o))
+	for _, minutes := range o {
+		if minutes < 1 || minutes > MaxReminderOffsetMinutes {
+			return fmt.Errorf("reminders must be between 1 and %d minutes before the start", MaxReminderOffsetMinutes)
+		}
+		if seen[minutes] {
+			return fmt.Errorf("duplicate reminder %d minutes before the start", minutes)
+		}
+		seen[minutes] = true
+	}
+	return nil
+}
+
+// Normalize returns the offsets earliest reminder first.
+func (o ReminderOffsets) Normalize() ReminderOffsets {
+	sorted := append(ReminderOffsets{}, o...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	return sorted
+}
+
+// Value stores the offsets as JSON. A nil list is stored as an empty one.
+func (o ReminderOffsets) Value() (driver.Value, error) {
+	if o == nil {
+		o = ReminderOffsets{}
+	}
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the JSON offsets back from the database.
+func (o *ReminderOffsets) Scan(value interface{}) error {
+	data, err := jsonColumnBytes(value)
+	if err != nil || data == nil {
+		*o = nil
+		return err
+	}
+	return json.Unmarshal(data, o)
+}
+
+// BookingReminder is a reminder email queued for a booking, sent once its SendAt has passed. It is
+// keyed by booking code and the start time it was scheduled for, so a reminder for a booking that
+// has since moved or been cancelled is dropped instead of sent.
+type BookingReminder struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BookingCode   string     `json:"booking_code" gorm:"not null;index"`
+	AppointmentID uuid.UUID  `json:"appointment_id" gorm:"type:uuid;not null"`
+	OffsetMinutes int        `json:"offset_minutes" gorm:"not null"`
+	StartsAt      time.Time  `json:"starts_at" gorm:"not null"`
+	SendAt        time.Time  `json:"send_at" gorm:"not null;index"`
+	Status        string     `json:"status" gorm:"type:varchar(16);not null;default:'pending'"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastError     string     `json:"last_error,omitempty" gorm:"type:text"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// RemindersFor returns the pending reminders booking needs under offsets, skipping those whose
+// send time has already passed at now.
+func RemindersFor(booking *Booking, offsets ReminderOffsets, now time.Time) []BookingReminder {
+	var reminders []BookingReminder
+	for _, minutes := range offsets.Normalize() {
+		sendAt := booking.StartTime.Add(-time.Duration(minutes) * time.Minute)
+		if !sendAt.After(now) {
+			continue
+		}
+		reminders = append(reminders, BookingReminder{
+			BookingCode:   booking.BookingCode,
+			AppointmentID: booking.AppointmentID,
+			OffsetMinutes: minutes,
+			StartsAt:      booking.StartTime,
+			SendAt:        sendAt,
+			Status:        ReminderStatusPending,
+		})
+	}
+	return reminders
+}
+
+// AppliesTo reports whether the reminder should still go out for booking at now: the booking is
+// confirmed, has not moved from the start the reminder was scheduled for, and has not started.
+func (r BookingReminder) AppliesTo(booking *Booking, now time.Time) bool {
+	return booking.Status == BookingStatusConfirmed &&
+		booking.StartTime.Equal(r.StartsAt) &&
+		now.Before(booking.StartTime)
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderOffsetsValidate(t *testing.T) {
+	assert.NoError(t, ReminderOffsets{}.Validate())
+	assert.NoError(t, DefaultReminderOffsets.Validate())
+	assert.Error(t, ReminderOffsets{0}.Validate())
+	assert.Error(t, ReminderOffsets{MaxReminderOffsetMinutes + 1}.Validate())
+	assert.ErrorContains(t, ReminderOffsets{60, 60}.Validate(), "duplicate")
+	assert.ErrorContains(t, ReminderOffsets{1, 2, 3, 4, 5, 6}.Validate(), "at most")
+}
+
+func TestReminderOffsetsValue(t *testing.T) {
+	value, err := ReminderOffsets(nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", value)
+
+	var offsets ReminderOffsets
+	assert.NoError(t, offsets.Scan([]byte("[1440,60]")))
+	assert.Equal(t, ReminderOffsets{1440, 60}, offsets)
+	assert.NoError(t, offsets.Scan(nil))
+	assert.Nil(t, offsets)
+}
+
+func TestRemindersFor(t *testing.T) {
+	start := time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC)
+	booking := &Booking{BookingCode: "BK1", StartTime: start}
+
+	reminders := RemindersFor(booking, ReminderOffsets{60, 1440}, start.Add(-2*time.Hour))
+
+	assert.Len(t, reminders, 1, "the day-ahead reminder has already passed")
+	assert.Equal(t, 60, reminders[0].OffsetMinutes)
+	assert.Equal(t, start.Add(-time.Hour), reminders[0].SendAt)
+	assert.Equal(t, start, reminders[0].StartsAt)
+	assert.Equal(t, ReminderStatusPending, reminders[0].Status)
+
+	assert.Len(t, RemindersFor(booking, ReminderOffsets{60, 1440}, start.Add(-48*time.Hour)), 2)
+	assert.Empty(t, RemindersFor(booking, nil, start.Add(-48*time.Hour)))
+}
+
+func TestBookingReminderAppliesTo(t *testing.T) {
+	start := time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC)
+	reminder := BookingReminder{StartsAt: start}
+	now := start.Add(-time.Hour)
+
+	assert.True(t, reminder.AppliesTo(&Booking{Status: BookingStatusConfirmed, StartTime: start}, now))
+	assert.False(t, reminder.AppliesTo(&Booking{Status: BookingStatusCancelled, StartTime: start}, now))
+	assert.False(t, reminder.AppliesTo(&Booking{Status: BookingStatusConfirmed, StartTime: start.Add(time.Hour)}, now))
+	assert.False(t, reminder.AppliesTo(&Booking{Status: BookingStatusConfirmed, StartTime: start}, start))
+}
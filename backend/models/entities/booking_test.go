@@ -51,3 +51,54 @@ func TestBookingBeforeCreateNonSlotDefaults(t *testing.T) {
 	assert.Equal(t, BookingStatusPending, booking.Status)
 	assert.Equal(t, "BK26010214152222", booking.BookingCode)
 }
+
+func TestBookingAttendanceTransitions(t *testing.T) {
+	assert.True(t, CanTransitionBookingStatus(BookingStatusConfirmed, BookingStatusCheckedIn))
+	assert.True(t, CanTransitionBookingStatus(BookingStatusOngoing, BookingStatusNoShow))
+	assert.True(t, CanTransitionBookingStatus(BookingStatusCheckedIn, BookingStatusAttended))
+	assert.True(t, CanTransitionBookingStatus(BookingStatusNoShow, BookingStatusCheckedIn))
+	assert.True(t, CanTransitionBookingStatus(BookingStatusExpired, BookingStatusAttended))
+	assert.True(t, CanTransitionBookingStatus(BookingStatusExpired, BookingStatusNoShow))
+	assert.False(t, CanTransitionBookingStatus(BookingStatusCheckedIn, BookingStatusCancelled))
+	assert.False(t, CanTransitionBookingStatus(BookingStatusCheckedIn, BookingStatusNoShow))
+	assert.False(t, CanTransitionBookingStatus(BookingStatusAttended, BookingStatusNoShow))
+	assert.False(t, CanTransitionBookingStatus(BookingStatusExpired, BookingStatusCancelled))
+	assert.False(t, CanTransitionBookingStatus(BookingStatusCancelled, BookingStatusCheckedIn))
+}
+
+func TestBookingSetPrice(t *testing.T) {
+	booking := &Booking{}
+
+	booking.SetPrice(2500, "USD")
+	assert.Equal(t, BookingPaymentDue, booking.PaymentStatus)
+	assert.Equal(t, int64(2500), booking.AmountDue)
+
+	booking.SetPrice(0, "USD")
+	assert.Empty(t, booking.PaymentStatus)
+
+	booking.SetPrice(2500, "USD")
+	booking.PaymentStatus = BookingPaymentPaid
+	booking.SetPrice(5000, "USD")
+	assert.Equal(t, int64(2500), booking.AmountDue)
+	assert.Equal(t, BookingPaymentPaid, booking.PaymentStatus)
+
+	booking.ClearPrice()
+	assert.Empty(t, booking.PaymentStatus)
+	assert.Zero(t, booking.AmountDue)
+
+	booking.SetPrice(2500, "USD")
+	booking.ApplyPromoCode("SPRING20", Discount{Type: DiscountPercent, Value: 20})
+	assert.Equal(t, int64(2000), booking.AmountDue)
+	assert.Equal(t, int64(500), booking.DiscountAmount)
+
+	booking.SetPrice(5000, "USD")
+	assert.Equal(t, int64(4000), booking.AmountDue)
+
+	booking.ApplyPromoCode("FREE", Discount{Type: DiscountPercent, Value: 100})
+	assert.Zero(t, booking.AmountDue)
+	assert.Empty(t, booking.PaymentStatus)
+
+	booking.ClearPrice()
+	assert.Empty(t, booking.PromoCode)
+	assert.Zero(t, booking.DiscountAmount)
+}
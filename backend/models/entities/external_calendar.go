@@ -0,0 +1,310 @@
+package entities
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExternalCalendar is a calendar the owner keeps outside Asiko. Its busy times block the owner's
+// overlapping slots. Calendars with a URL are re-fetched by the status scheduler; uploaded ones
+// keep the busy times from the file until replaced or deleted.
+type ExternalCalendar struct {
+	ID           uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OwnerID      uuid.UUID   `json:"owner_id" gorm:"type:uuid;not null;index"`
+	Name         string      `json:"name" gorm:"not null"`
+	URL          string      `json:"url,omitempty"`
+	Busy         BusyPeriods `json:"busy" gorm:"type:jsonb" swaggertype:"array,object"`
+	LastSyncedAt *time.Time  `json:"last_synced_at,omitempty"`
+	SyncError    string      `json:"sync_error,omitempty" gorm:"type:text"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+// BusyPeriod is a span of time the owner is unavailable.
+type BusyPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Overlaps reports whether the period intersects [start, end).
+func (p BusyPeriod) Overlaps(start time.Time, end time.Time) bool {
+	return p.Start.Before(end) && start.Before(p.End)
+}
+
+type BusyPeriods []BusyPeriod
+
+// Overlaps reports whether any period intersects [start, end).
+func (b BusyPeriods) Overlaps(start time.Time, end time.Time) bool {
+	for _, period := range b {
+		if period.Overlaps(start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value stores the periods as JSON.
+func (b BusyPeriods) Value() (driver.Value, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the JSON periods back from the database.
+func (b *BusyPeriods) Scan(value interface{}) error {
+	data, err := jsonColumnBytes(value)
+	if err != nil || data == nil {
+		*b = nil
+		return err
+	}
+	return json.Unmarshal(data, b)
+}
+
+// icalEvent holds the VEVENT properties that decide when the owner is busy.
+type icalEvent struct {
+	start       time.Time
+	end         time.Time
+	duration    time.Duration
+	hasDuration bool
+	allDay      bool
+	rrule       string
+	exdates     []time.Time
+	transparent bool
+	cancelled   bool
+}
+
+// ParseBusyPeriods reads the VEVENTs of an iCalendar file and returns the busy periods that
+// overlap [from, to). Transparent and cancelled events are skipped, recurring events are expanded
+// with the RRULE subset appointments support, and times without a zone are taken as UTC.
+func ParseBusyPeriods(data []byte, from time.Time, to time.Time) (BusyPeriods, error) {
+	lines := unfoldICalLines(data)
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("not an iCalendar file")
+	}
+
+	var (
+		periods BusyPeriods
+		current *icalEvent
+		depth   int
+	)
+	for _, line := range lines {
+		name, params, value, ok := parseICalLine(line)
+		if !ok {
+			continue
+		}
+		switch name {
+		case "BEGIN":
+			if current != nil {
+				depth++
+			} else if strings.EqualFold(value, "VEVENT") {
+				current = &icalEvent{}
+			}
+			continue
+		case "END":
+			if current == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				continue
+			}
+			periods = append(periods, current.periods(from, to)...)
+			current = nil
+			continue
+		}
+		if current == nil || depth > 0 {
+			continue
+		}
+
+		var err error
+		switch name {
+		case "DTSTART":
+			current.start, current.allDay, err = parseICalTime(value, params)
+		case "DTEND":
+			current.end, _, err = parseICalTime(value, params)
+		case "DURATION":
+			current.duration, err = parseICalDuration(value)
+			current.hasDuration = err == nil
+		case "RRULE":
+			current.rrule = value
+		case "EXDATE":
+			for _, part := range strings.Split(value, ",") {
+				var exdate time.Time
+				if exdate, _, err = parseICalTime(part, params); err != nil {
+					break
+				}
+				current.exdates = append(current.exdates, exdate)
+			}
+		case "TRANSP":
+			current.transparent = strings.EqualFold(value, "TRANSPARENT")
+		case "STATUS":
+			current.cancelled = strings.EqualFold(value, "CANCELLED")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %v", name, value, err)
+		}
+	}
+	return periods, nil
+}
+
+func (e *icalEvent) periods(from time.Time, to time.Time) BusyPeriods {
+	if e.transparent || e.cancelled || e.start.IsZero() {
+		return nil
+	}
+
+	length := e.end.Sub(e.start)
+	switch {
+	case e.end.IsZero() && e.hasDuration:
+		length = e.duration
+	case e.end.IsZero() && e.allDay:
+		length = 24 * time.Hour
+	}
+	if length <= 0 {
+		return nil
+	}
+
+	starts := []time.Time{e.start}
+	if rule := e.recurrence(); rule != nil {
+		starts = starts[:0]
+		for _, day := range rule.Occurrences(e.start, to) {
+			starts = append(starts, time.Date(day.Year(), day.Month(), day.Day(), e.start.Hour(), e.start.Minute(), e.start.Second(), 0, e.start.Location()))
+		}
+	}
+
+	var periods BusyPeriods
+	for _, start := range starts {
+		if e.excluded(start) {
+			continue
+		}
+		period := BusyPeriod{Start: start.UTC(), End: start.Add(length).UTC()}
+		if period.Overlaps(from, to) {
+			periods = append(periods, period)
+		}
+	}
+	return periods
+}
+
+// recurrence parses the event's RRULE, ignoring WKST, which does not change the dates of the
+// supported rules. Rules outside that subset yield nil so only the first occurrence blocks time,
+// rather than failing the whole calendar.
+func (e *icalEvent) recurrence() *RecurrenceRule {
+	if e.rrule == "" {
+		return nil
+	}
+	parts := strings.Split(e.rrule, ";")
+	kept := parts[:0]
+	for _, part := range parts {
+		if !strings.HasPrefix(strings.ToUpper(part), "WKST=") {
+			kept = append(kept, part)
+		}
+	}
+	rule, err := ParseRecurrenceRule(strings.Join(kept, ";"))
+	if err != nil {
+		return nil
+	}
+	return rule
+}
+
+func (e *icalEvent) excluded(start time.Time) bool {
+	for _, exdate := range e.exdates {
+		if exdate.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// unfoldICalLines splits content into logical lines, joining folded continuation lines.
+func unfoldICalLines(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// parseICalLine splits "NAME;PARAM=VALUE:value" into its upper-cased name, parameters and value.
+func parseICalLine(line string) (string, map[string]string, string, bool) {
+	head, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", nil, "", false
+	}
+	parts := strings.Split(head, ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		if key, val, ok := strings.Cut(param, "="); ok {
+			params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, strings.TrimSpace(value), true
+}
+
+// parseICalTime parses a DATE or DATE-TIME value, honouring a TZID parameter. The boolean
+// reports an all-day DATE value.
+func parseICalTime(value string, params map[string]string) (time.Time, bool, error) {
+	loc := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+var icalDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICalDuration parses an RFC 5545 DURATION such as PT1H30M or P1D.
+func parseICalDuration(value string) (time.Duration, error) {
+	match := icalDurationPattern.FindStringSubmatch(strings.ToUpper(value))
+	if match == nil || value == "P" || value == "PT" {
+		return 0, fmt.Errorf("unsupported duration")
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(match[i+2])
+		if err != nil {
+			return 0, err
+		}
+		total += time.Duration(n) * unit
+	}
+	if match[1] == "-" {
+		total = -total
+	}
+	return total, nil
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func icsLines(lines ...string) []byte {
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func TestParseBusyPeriods(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	data := icsLines(
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:utc@example.com",
+		"DTSTART:20260302T090000Z",
+		"DTEND:20260302T100000Z",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT15M",
+		"DTSTART:20260302T084500Z",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:zoned@example.com",
+		"DTSTART;TZID=Africa/Lagos:20260303T140000",
+		"DURATION:PT30M",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:allday@example.com",
+		"DTSTART;VALUE=DATE:20260304",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:free@example.com",
+		"DTSTART:20260305T090000Z",
+		"DTEND:20260305T100000Z",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:cancelled@example.com",
+		"DTSTART:20260306T090000Z",
+		"DTEND:20260306T100000Z",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:past@example.com",
+		"DTSTART:20260201T090000Z",
+		"DTEND:20260201T100000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	)
+
+	busy, err := ParseBusyPeriods(data, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, BusyPeriods{
+		{Start: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2026, 3, 3, 13, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 3, 13, 30, 0, 0, time.UTC)},
+		{Start: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+	}, busy)
+}
+
+func TestParseBusyPeriodsExpandsRecurrence(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	data := icsLines(
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"DTSTART:20260302T090000Z",
+		"DTEND:20260302T091500Z",
+		"RRULE:FREQ=WEEKLY;WKST=MO;BYDAY=MO;CO",
+		" UNT=3",
+		"EXDATE:20260309T090000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	)
+
+	busy, err := ParseBusyPeriods(data, from, to)
+	require.NoError(t, err)
+	require.Len(t, busy, 2)
+	assert.Equal(t, time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), busy[0].Start)
+	assert.Equal(t, time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC), busy[1].Start)
+	assert.Equal(t, 15*time.Minute, busy[1].End.Sub(busy[1].Start))
+
+	assert.True(t, busy.Overlaps(busy[0].Start.Add(-time.Hour), busy[0].Start.Add(time.Minute)))
+	assert.False(t, busy.Overlaps(busy[0].End, busy[0].End.Add(time.Hour)))
+}
+
+func TestParseBusyPeriodsRejectsInvalidInput(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := ParseBusyPeriods([]byte("not a calendar"), from, from.AddDate(0, 1, 0))
+	assert.Error(t, err)
+
+	_, err = ParseBusyPeriods(icsLines("BEGIN:VCALENDAR", "BEGIN:VEVENT", "DTSTART:tomorrow", "END:VEVENT", "END:VCALENDAR"), from, from.AddDate(0, 1, 0))
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Organization lets a team share appointments. Members act on the organization's appointments
+// according to their role; the user who created an appointment keeps full control of it.
+type Organization struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name        string         `json:"name" gorm:"not null"`
+	CreatedByID uuid.UUID      `json:"created_by_id" gorm:"type:uuid;not null"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner  OrganizationRole = "owner"
+	OrganizationRoleAdmin  OrganizationRole = "admin"
+	OrganizationRoleStaff  OrganizationRole = "staff"
+	OrganizationRoleViewer OrganizationRole = "viewer"
+)
+
+// OrganizationMember grants a user a role in an organization.
+type OrganizationMember struct {
+	ID             uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID        `json:"organization_id" gorm:"type:uuid;not null;uniqueIndex:idx_organization_members_org_user"`
+	UserID         uuid.UUID        `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_organization_members_org_user;index"`
+	User           User             `json:"-" gorm:"foreignKey:UserID"`
+	Role           OrganizationRole `json:"role" gorm:"type:varchar(16);not null"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// Permission names an action guarded on an appointment or organization.
+type Permission string
+
+const (
+	// PermissionViewBookings covers reading an appointment's bookings and exporting attendees.
+	PermissionViewBookings Permission = "bookings:view"
+	// PermissionManageBookings covers confirming, rejecting, checking in and scanning bookings,
+	// and overriding the guest cancellation and reschedule policies.
+	PermissionManageBookings Permission = "bookings:manage"
+	// PermissionManageAppointments covers creating, editing, publishing, pausing, archiving and
+	// deleting appointments and their availability exceptions.
+	PermissionManageAppointments Permission = "appointments:manage"
+	// PermissionManageMembers covers adding, changing and removing non-owner members.
+	PermissionManageMembers Permission = "members:manage"
+	// PermissionManageOrganization covers renaming and deleting the organization and managing owners.
+	PermissionManageOrganization Permission = "organization:manage"
+)
+
+// rolePermissions lists what each role may do; every role includes the permissions of the roles
+// below it.
+var rolePermissions = map[OrganizationRole][]Permission{
+	OrganizationRoleViewer: {PermissionViewBookings},
+	OrganizationRoleStaff:  {PermissionViewBookings, PermissionManageBookings},
+	OrganizationRoleAdmin:  {PermissionViewBookings, PermissionManageBookings, PermissionManageAppointments, PermissionManageMembers},
+	OrganizationRoleOwner:  {PermissionViewBookings, PermissionManageBookings, PermissionManageAppointments, PermissionManageMembers, PermissionManageOrganization},
+}
+
+// Can reports whether the role grants permission.
+func (r OrganizationRole) Can(permission Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
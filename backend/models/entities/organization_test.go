@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationRolePermissions(t *testing.T) {
+	cases := []struct {
+		role    OrganizationRole
+		granted []Permission
+		denied  []Permission
+	}{
+		{OrganizationRoleOwner, []Permission{PermissionViewBookings, PermissionManageBookings, PermissionManageAppointments, PermissionManageMembers, PermissionManageOrganization}, nil},
+		{OrganizationRoleAdmin, []Permission{PermissionViewBookings, PermissionManageBookings, PermissionManageAppointments, PermissionManageMembers}, []Permission{PermissionManageOrganization}},
+		{OrganizationRoleStaff, []Permission{PermissionViewBookings, PermissionManageBookings}, []Permission{PermissionManageAppointments, PermissionManageMembers, PermissionManageOrganization}},
+		{OrganizationRoleViewer, []Permission{PermissionViewBookings}, []Permission{PermissionManageBookings, PermissionManageAppointments, PermissionManageMembers, PermissionManageOrganization}},
+		{OrganizationRole("guest"), nil, []Permission{PermissionViewBookings}},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.role), func(t *testing.T) {
+			for _, permission := range tc.granted {
+				assert.True(t, tc.role.Can(permission), permission)
+			}
+			for _, permission := range tc.denied {
+				assert.False(t, tc.role.Can(permission), permission)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusDelivered = "delivered"
+	OutboxStatusFailed    = "failed"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the change it describes, so
+// it is kept exactly when the change is. The outbox dispatcher delivers it to event bus
+// subscribers once AvailableAt has passed, retrying failed deliveries later. While it is being
+// delivered AvailableAt is pushed out by a lease, so other dispatchers leave it alone, and
+// DeliveredTo records the subscribers that have handled it so retries skip them.
+type OutboxEvent struct {
+	ID          uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name        string            `json:"name" gorm:"type:varchar(64);not null"`
+	Payload     string            `json:"payload" gorm:"type:jsonb;not null"`
+	Status      string            `json:"status" gorm:"type:varchar(16);not null;default:'pending'"`
+	Attempts    int               `json:"attempts" gorm:"not null;default:0"`
+	LastError   string            `json:"last_error,omitempty" gorm:"type:text"`
+	AvailableAt time.Time         `json:"available_at" gorm:"not null;index"`
+	DeliveredTo OutboxSubscribers `json:"delivered_to" gorm:"type:jsonb;not null;default:'[]'"`
+	DeliveredAt *time.Time        `json:"delivered_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// OutboxSubscribers are the event bus subscribers an outbox event has been delivered to.
+type OutboxSubscribers []string
+
+// Value stores the subscribers as JSON. A nil list is stored as an empty one.
+func (s OutboxSubscribers) Value() (driver.Value, error) {
+	if s == nil {
+		s = OutboxSubscribers{}
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the JSON subscribers back from the database.
+func (s *OutboxSubscribers) Scan(value interface{}) error {
+	data, err := jsonColumnBytes(value)
+	if err != nil || data == nil {
+		*s = nil
+		return err
+	}
+	return json.Unmarshal(data, s)
+}
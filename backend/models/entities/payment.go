@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Booking payment statuses. Free bookings leave Booking.PaymentStatus empty.
+const (
+	BookingPaymentDue      = "due"
+	BookingPaymentPaid     = "paid"
+	BookingPaymentRefunded = "refunded"
+)
+
+// Payment statuses track a single payment intent at the provider.
+const (
+	PaymentStatusPending   = "pending"
+	PaymentStatusSucceeded = "succeeded"
+	PaymentStatusFailed    = "failed"
+	PaymentStatusRefunded  = "refunded"
+)
+
+// Payment is one attempt to pay for a booking through the payment provider. It is keyed by
+// booking code, which follows the booking when it moves between slot rows.
+type Payment struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BookingCode   string     `json:"booking_code" gorm:"not null;index"`
+	AppointmentID uuid.UUID  `json:"appointment_id" gorm:"type:uuid;not null;index"`
+	Provider      string     `json:"provider" gorm:"type:varchar(32);not null"`
+	IntentID      string     `json:"intent_id" gorm:"not null;uniqueIndex"`
+	Amount        int64      `json:"amount" gorm:"not null"`
+	Currency      string     `json:"currency" gorm:"type:varchar(3);not null"`
+	Status        string     `json:"status" gorm:"type:varchar(16);not null;default:'pending'"`
+	RefundID      string     `json:"refund_id,omitempty"`
+	PaidAt        *time.Time `json:"paid_at,omitempty"`
+	RefundedAt    *time.Time `json:"refunded_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,117 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DiscountPercent = "percent"
+	DiscountFixed   = "fixed"
+)
+
+// Discount takes Value percent off a booking's price, or Value minor units off it for fixed
+// discounts. A fixed discount never takes the price below zero.
+type Discount struct {
+	Type  string `json:"type" gorm:"type:varchar(16)" example:"percent"`
+	Value int64  `json:"value" example:"20"`
+}
+
+// Off is how much the discount takes off amount.
+func (d Discount) Off(amount int64) int64 {
+	if amount <= 0 {
+		return 0
+	}
+	switch d.Type {
+	case DiscountPercent:
+		return amount * d.Value / 100
+	case DiscountFixed:
+		return min(d.Value, amount)
+	}
+	return 0
+}
+
+// PromoCode is an owner-managed code guests enter when booking for a discount. Codes are stored
+// upper-case and are unique per owner. A code restricted to appointments applies only to those;
+// otherwise it applies to every appointment its owner created. MaxRedemptions and MaxPerEmail
+// cap redemptions in total and per guest email, with 0 leaving them unlimited, and the code is
+// only accepted between ValidFrom and ValidUntil when they are set. Fixed discounts are in
+// minor units of Currency and only apply to appointments priced in it.
+type PromoCode struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OwnerID        uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null;index"`
+	Code           string     `json:"code" gorm:"type:varchar(64);not null" example:"SPRING20"`
+	Description    string     `json:"description"`
+	Discount       Discount   `json:"discount" gorm:"embedded;embeddedPrefix:discount_"`
+	Currency       string     `json:"currency,omitempty" gorm:"type:varchar(3)"`
+	MaxRedemptions int        `json:"max_redemptions" gorm:"not null;default:0"`
+	MaxPerEmail    int        `json:"max_per_email" gorm:"not null;default:0"`
+	Redemptions    int        `json:"redemptions" gorm:"not null;default:0"`
+	ValidFrom      *time.Time `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time `json:"valid_until,omitempty"`
+	// AppointmentIDs lists the appointments the code is restricted to; empty applies it to all
+	// of the owner's appointments.
+	AppointmentIDs []uuid.UUID            `json:"appointment_ids" gorm:"-"`
+	Appointments   []PromoCodeAppointment `json:"-" gorm:"foreignKey:PromoCodeID"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt         `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+// AfterFind lists the preloaded appointment restrictions in AppointmentIDs.
+func (p *PromoCode) AfterFind(tx *gorm.DB) error {
+	if len(p.Appointments) == 0 {
+		return nil
+	}
+	p.AppointmentIDs = make([]uuid.UUID, 0, len(p.Appointments))
+	for _, link := range p.Appointments {
+		p.AppointmentIDs = append(p.AppointmentIDs, link.AppointmentID)
+	}
+	return nil
+}
+
+// PromoCodeAppointment restricts a promo code to an appointment.
+type PromoCodeAppointment struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PromoCodeID   uuid.UUID `json:"promo_code_id" gorm:"type:uuid;not null;uniqueIndex:idx_promo_code_appointments_code_appointment"`
+	AppointmentID uuid.UUID `json:"appointment_id" gorm:"type:uuid;not null;uniqueIndex:idx_promo_code_appointments_code_appointment;index"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// PromoCodeRedemption records a booking that used a promo code. Redemptions are kept when the
+// booking is cancelled, so they keep counting against the code's limits.
+type PromoCodeRedemption struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PromoCodeID uuid.UUID `json:"promo_code_id" gorm:"type:uuid;not null;index:idx_promo_code_redemptions_code_email"`
+	BookingCode string    `json:"booking_code" gorm:"not null;index"`
+	Email       string    `json:"email" gorm:"index:idx_promo_code_redemptions_code_email"`
+	Discount    int64     `json:"discount" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NormalizePromoCode is how codes are stored and looked up.
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Redeemable reports why the code cannot be redeemed at now on an appointment priced in
+// currency: outside its validity window, out of redemptions, or for another currency.
+// Per-email limits are checked separately against recorded redemptions.
+func (p *PromoCode) Redeemable(now time.Time, currency string) (bool, string) {
+	if p.ValidFrom != nil && now.Before(*p.ValidFrom) {
+		return false, "promo code is not valid yet"
+	}
+	if p.ValidUntil != nil && !now.Before(*p.ValidUntil) {
+		return false, "promo code has expired"
+	}
+	if p.MaxRedemptions > 0 && p.Redemptions >= p.MaxRedemptions {
+		return false, "promo code has been fully redeemed"
+	}
+	if p.Discount.Type == DiscountFixed && !strings.EqualFold(p.Currency, currency) {
+		return false, "promo code does not apply to this currency"
+	}
+	return true, ""
+}
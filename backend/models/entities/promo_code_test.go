@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscountOff(t *testing.T) {
+	assert.Equal(t, int64(500), Discount{Type: DiscountPercent, Value: 20}.Off(2500))
+	assert.Equal(t, int64(2500), Discount{Type: DiscountPercent, Value: 100}.Off(2500))
+	assert.Equal(t, int64(1000), Discount{Type: DiscountFixed, Value: 1000}.Off(2500))
+	assert.Equal(t, int64(2500), Discount{Type: DiscountFixed, Value: 5000}.Off(2500))
+	assert.Zero(t, Discount{}.Off(2500))
+	assert.Zero(t, Discount{Type: DiscountFixed, Value: 1000}.Off(0))
+}
+
+func TestPromoCodeRedeemable(t *testing.T) {
+	opens := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	closes := opens.Add(7 * 24 * time.Hour)
+	promo := PromoCode{
+		Code:           "SPRING20",
+		Discount:       Discount{Type: DiscountFixed, Value: 1000},
+		Currency:       "USD",
+		MaxRedemptions: 2,
+		Redemptions:    1,
+		ValidFrom:      &opens,
+		ValidUntil:     &closes,
+	}
+
+	ok, _ := promo.Redeemable(opens, "usd")
+	assert.True(t, ok)
+
+	_, reason := promo.Redeemable(opens.Add(-time.Minute), "USD")
+	assert.Equal(t, "promo code is not valid yet", reason)
+	_, reason = promo.Redeemable(closes, "USD")
+	assert.Equal(t, "promo code has expired", reason)
+	_, reason = promo.Redeemable(opens, "EUR")
+	assert.Equal(t, "promo code does not apply to this currency", reason)
+
+	promo.Redemptions = 2
+	_, reason = promo.Redeemable(opens, "USD")
+	assert.Equal(t, "promo code has been fully redeemed", reason)
+
+	assert.Equal(t, "SPRING20", NormalizePromoCode(" spring20 "))
+}
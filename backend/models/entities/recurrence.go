@@ -0,0 +1,268 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "DAILY"
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// MaxRecurrenceHorizon bounds open-ended rules (COUNT only) so slot generation always terminates.
+const MaxRecurrenceHorizon = 2 * 365 * 24 * time.Hour
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RecurrenceDay is a BYDAY entry. Ordinal is only meaningful for MONTHLY rules
+// (e.g. 2TU = second Tuesday, -1FR = last Friday); zero means every matching weekday.
+type RecurrenceDay struct {
+	Weekday time.Weekday
+	Ordinal int
+}
+
+// RecurrenceRule is the subset of RFC 5545 RRULE supported for appointments:
+// FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL.
+type RecurrenceRule struct {
+	Frequency  RecurrenceFrequency
+	Interval   int
+	ByDay      []RecurrenceDay
+	ByMonthDay []int
+	Count      int
+	Until      time.Time
+}
+
+// ParseRecurrenceRule parses an RRULE string such as "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=12".
+// A leading "RRULE:" prefix is accepted.
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(strings.ToUpper(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("recurrence rule is empty")
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		switch key {
+		case "FREQ":
+			freq := RecurrenceFrequency(val)
+			switch freq {
+			case RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+				rule.Frequency = freq
+			default:
+				return nil, fmt.Errorf("unsupported recurrence frequency %q", val)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid recurrence interval %q", val)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid recurrence count %q", val)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseRecurrenceUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, item := range strings.Split(val, ",") {
+				day, err := parseRecurrenceDay(item)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, item := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(item)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid recurrence month day %q", item)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return nil, fmt.Errorf("recurrence rule must specify FREQ")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("recurrence rule cannot specify both COUNT and UNTIL")
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Frequency != RecurrenceMonthly {
+		return nil, fmt.Errorf("BYMONTHDAY is only supported for MONTHLY recurrence")
+	}
+	for _, day := range rule.ByDay {
+		if day.Ordinal != 0 && rule.Frequency != RecurrenceMonthly {
+			return nil, fmt.Errorf("ordinal BYDAY values are only supported for MONTHLY recurrence")
+		}
+	}
+	return rule, nil
+}
+
+func parseRecurrenceUntil(val string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid recurrence until %q", val)
+}
+
+func parseRecurrenceDay(val string) (RecurrenceDay, error) {
+	if len(val) < 2 {
+		return RecurrenceDay{}, fmt.Errorf("invalid recurrence day %q", val)
+	}
+	weekday, ok := recurrenceWeekdays[val[len(val)-2:]]
+	if !ok {
+		return RecurrenceDay{}, fmt.Errorf("invalid recurrence day %q", val)
+	}
+	day := RecurrenceDay{Weekday: weekday}
+	if prefix := val[:len(val)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return RecurrenceDay{}, fmt.Errorf("invalid recurrence day %q", val)
+		}
+		day.Ordinal = n
+	}
+	return day, nil
+}
+
+// Occurrences returns the calendar dates (midnight, in start's location) on which the rule
+// fires, beginning at start and never later than limit. UNTIL and COUNT further bound the result.
+func (r *RecurrenceRule) Occurrences(start time.Time, limit time.Time) []time.Time {
+	loc := start.Location()
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	last := time.Date(limit.Year(), limit.Month(), limit.Day(), 0, 0, 0, 0, loc)
+	if !r.Until.IsZero() {
+		until := time.Date(r.Until.Year(), r.Until.Month(), r.Until.Day(), 0, 0, 0, 0, loc)
+		if until.Before(last) {
+			last = until
+		}
+	}
+
+	var dates []time.Time
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !r.matches(first, day) {
+			continue
+		}
+		dates = append(dates, day)
+		if r.Count > 0 && len(dates) >= r.Count {
+			break
+		}
+	}
+	return dates
+}
+
+func (r *RecurrenceRule) matches(first time.Time, day time.Time) bool {
+	switch r.Frequency {
+	case RecurrenceDaily:
+		if daysBetween(first, day)%r.Interval != 0 {
+			return false
+		}
+		return r.matchesWeekday(day)
+	case RecurrenceWeekly:
+		if weeksBetween(first, day)%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == first.Weekday()
+		}
+		return r.matchesWeekday(day)
+	case RecurrenceMonthly:
+		months := (day.Year()-first.Year())*12 + int(day.Month()) - int(first.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByMonthDay) > 0 {
+			return r.matchesMonthDay(day)
+		}
+		if len(r.ByDay) > 0 {
+			return r.matchesWeekday(day)
+		}
+		return day.Day() == first.Day()
+	}
+	return false
+}
+
+func (r *RecurrenceRule) matchesWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByDay {
+		if d.Weekday != day.Weekday() {
+			continue
+		}
+		switch {
+		case d.Ordinal == 0:
+			return true
+		case d.Ordinal > 0 && (day.Day()-1)/7+1 == d.Ordinal:
+			return true
+		case d.Ordinal < 0 && (daysInMonth(day)-day.Day())/7+1 == -d.Ordinal:
+			return true
+		}
+	}
+	return false
+}
+
+func (r *RecurrenceRule) matchesMonthDay(day time.Time) bool {
+	total := daysInMonth(day)
+	for _, n := range r.ByMonthDay {
+		if n > 0 && day.Day() == n {
+			return true
+		}
+		if n < 0 && day.Day() == total+n+1 {
+			return true
+		}
+	}
+	return false
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// weeksBetween counts whole Monday-based weeks (RFC 5545 default WKST=MO) between two dates.
+func weeksBetween(from time.Time, to time.Time) int {
+	return daysBetween(startOfWeek(from), startOfWeek(to)) / 7
+}
+
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset)
+}
+
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
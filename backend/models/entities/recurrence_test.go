@@ -0,0 +1,94 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurrenceRuleWeeklyByDayWithCount(t *testing.T) {
+	rule, err := ParseRecurrenceRule("FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=5")
+	require.NoError(t, err)
+
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) // Monday
+	dates := rule.Occurrences(start, start.Add(MaxRecurrenceHorizon))
+
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC),
+	}, dates)
+}
+
+func TestRecurrenceRuleEverySecondTuesdayUntil(t *testing.T) {
+	rule, err := ParseRecurrenceRule("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU;UNTIL=20260401")
+	require.NoError(t, err)
+
+	start := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC) // Tuesday
+	dates := rule.Occurrences(start, start.AddDate(1, 0, 0))
+
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+	}, dates)
+}
+
+func TestRecurrenceRuleMonthly(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rule, err := ParseRecurrenceRule("FREQ=MONTHLY;BYMONTHDAY=1;COUNT=3")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}, rule.Occurrences(start, start.Add(MaxRecurrenceHorizon)))
+
+	rule, err = ParseRecurrenceRule("FREQ=MONTHLY;BYDAY=2TU;COUNT=2")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 1, 13, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC),
+	}, rule.Occurrences(start, start.Add(MaxRecurrenceHorizon)))
+}
+
+func TestParseRecurrenceRuleRejectsInvalidRules(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"BYDAY=MO",
+		"FREQ=YEARLY",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;COUNT=2;UNTIL=20260101",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=WEEKLY;BYDAY=2TU",
+		"FREQ=DAILY;INTERVAL=0",
+	} {
+		_, err := ParseRecurrenceRule(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestGenerateBookingsHonorsRecurrence(t *testing.T) {
+	appointment := &Appointment{
+		Type:            Single,
+		BookingDuration: 60,
+		StartDate:       time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		EndDate:         time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+		StartTime:       time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2026, 3, 2, 11, 0, 0, 0, time.UTC),
+		RecurrenceRule:  "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+	}
+
+	slots := appointment.GenerateBookings()
+
+	require.Len(t, slots, 6)
+	for _, slot := range slots {
+		weekday := slot.Date.Weekday()
+		assert.Contains(t, []time.Weekday{time.Monday, time.Wednesday, time.Friday}, weekday)
+	}
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Resource is a room or piece of equipment that appointments can require. Capacity is how many
+// bookings, across every appointment requiring it, may use the resource at the same time; the
+// bookings in one group slot or party share a single unit.
+type Resource struct {
+	ID             uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	OwnerID        uuid.UUID      `json:"owner_id" gorm:"type:uuid;not null;index"`
+	OrganizationID *uuid.UUID     `json:"organization_id,omitempty" gorm:"type:uuid;index"`
+	Name           string         `json:"name" gorm:"not null"`
+	Description    string         `json:"description"`
+	Capacity       int            `json:"capacity" gorm:"not null;default:1"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+// AppointmentResource makes every booking on an appointment use a unit of a resource.
+type AppointmentResource struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	AppointmentID uuid.UUID `json:"appointment_id" gorm:"type:uuid;not null;uniqueIndex:idx_appointment_resources_appointment_resource"`
+	ResourceID    uuid.UUID `json:"resource_id" gorm:"type:uuid;not null;uniqueIndex:idx_appointment_resources_appointment_resource;index"`
+	Resource      Resource  `json:"-" gorm:"foreignKey:ResourceID"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// HasRoomFor reports whether a booking on appointmentID starting at start can use the resource,
+// given the live bookings that overlap it. Bookings sharing an appointment and start time hold a
+// single unit, which a booking joining them reuses. Bookings with an ID in ignored, such as one
+// being moved, are left out.
+func (r Resource) HasRoomFor(appointmentID uuid.UUID, start time.Time, bookings []Booking, ignored ...uuid.UUID) bool {
+	type use struct {
+		appointmentID uuid.UUID
+		start         int64
+	}
+	skip := make(map[uuid.UUID]bool, len(ignored))
+	for _, id := range ignored {
+		skip[id] = true
+	}
+
+	inUse := make(map[use]bool)
+	for _, booking := range bookings {
+		if skip[booking.ID] {
+			continue
+		}
+		if booking.AppointmentID == appointmentID && booking.StartTime.Equal(start) {
+			return true
+		}
+		inUse[use{booking.AppointmentID, booking.StartTime.UnixNano()}] = true
+	}
+	return len(inUse) < r.Capacity
+}
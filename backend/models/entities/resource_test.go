@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceHasRoomFor(t *testing.T) {
+	start := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	yoga, pilates, barre := uuid.New(), uuid.New(), uuid.New()
+	room := Resource{Name: "Studio A", Capacity: 1}
+
+	t.Run("free when nothing overlaps", func(t *testing.T) {
+		assert.True(t, room.HasRoomFor(yoga, start, nil))
+	})
+
+	t.Run("taken by another appointment", func(t *testing.T) {
+		bookings := []Booking{{ID: uuid.New(), AppointmentID: pilates, StartTime: start.Add(-30 * time.Minute)}}
+		assert.False(t, room.HasRoomFor(yoga, start, bookings))
+	})
+
+	t.Run("joining a slot already using the resource", func(t *testing.T) {
+		bookings := []Booking{
+			{ID: uuid.New(), AppointmentID: pilates, StartTime: start},
+			{ID: uuid.New(), AppointmentID: yoga, StartTime: start},
+		}
+		assert.True(t, room.HasRoomFor(yoga, start, bookings))
+	})
+
+	t.Run("bookings in one slot share a unit", func(t *testing.T) {
+		rooms := Resource{Name: "Studios", Capacity: 2}
+		bookings := []Booking{
+			{ID: uuid.New(), AppointmentID: pilates, StartTime: start},
+			{ID: uuid.New(), AppointmentID: pilates, StartTime: start},
+		}
+		assert.True(t, rooms.HasRoomFor(yoga, start, bookings))
+
+		bookings = append(bookings, Booking{ID: uuid.New(), AppointmentID: barre, StartTime: start})
+		assert.False(t, rooms.HasRoomFor(yoga, start, bookings))
+	})
+
+	t.Run("ignored bookings are left out", func(t *testing.T) {
+		moving := uuid.New()
+		bookings := []Booking{{ID: moving, AppointmentID: yoga, StartTime: start.Add(-time.Hour)}}
+		assert.False(t, room.HasRoomFor(yoga, start, bookings))
+		assert.True(t, room.HasRoomFor(yoga, start, bookings, moving))
+	})
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SlotHoldStatusHeld     = "held"
+	SlotHoldStatusRedeemed = "redeemed"
+	SlotHoldStatusReleased = "released"
+)
+
+// SlotHold reserves seats on a slot while a guest completes checkout. The held seats are counted
+// in the slot's SeatsBooked until the hold is redeemed by a booking or released on expiry.
+type SlotHold struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Token         string    `json:"token" gorm:"uniqueIndex;not null"`
+	AppointmentID uuid.UUID `json:"appointment_id" gorm:"type:uuid;not null;index"`
+	AppCode       string    `json:"app_code" gorm:"not null"`
+	Date          time.Time `json:"date" gorm:"not null"`
+	StartTime     time.Time `json:"start_time" gorm:"not null"`
+	Seats         int       `json:"seats" gorm:"not null;default:1"`
+	Status        string    `json:"status" gorm:"not null;default:'held'"`
+	ExpiresAt     time.Time `json:"expires_at" gorm:"not null;index"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// IsActive reports whether the hold still reserves its seats at now.
+func (h *SlotHold) IsActive(now time.Time) bool {
+	return h.Status == SlotHoldStatusHeld && now.Before(h.ExpiresAt)
+}
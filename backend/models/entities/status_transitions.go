@@ -11,42 +11,95 @@ const (
 	BookingStatusCanceled  = "canceled"
 	BookingStatusRejected  = "rejected"
 	BookingStatusExpired   = "expired"
+	// Attendance: the owner checks guests in as they arrive; checked-in bookings become attended
+	// once they end, and guests who never turn up are marked no-show.
+	BookingStatusCheckedIn = "checked_in"
+	BookingStatusAttended  = "attended"
+	BookingStatusNoShow    = "no_show"
+	// BookingStatusHeld marks a slot row whose only taken seats are reserved by slot holds, so
+	// that nobody has booked it yet.
+	BookingStatusHeld = "held"
 )
 
+// Published appointments are pending until they start and ongoing while they run. Drafts have
+// their slots generated but are not bookable until published; paused appointments keep their
+// bookings but take no new ones; archived appointments are retired for good.
 const (
+	AppointmentStatusDraft     AppointmentStatus = "draft"
 	AppointmentStatusPending   AppointmentStatus = "pending"
 	AppointmentStatusOngoing   AppointmentStatus = "ongoing"
+	AppointmentStatusPaused    AppointmentStatus = "paused"
 	AppointmentStatusCompleted AppointmentStatus = "completed"
 	AppointmentStatusCanceled  AppointmentStatus = "canceled"
+	AppointmentStatusArchived  AppointmentStatus = "archived"
 )
 
 var bookingStatusTransitions = map[string]map[string]struct{}{
 	BookingStatusActive: {
 		BookingStatusCancelled: {},
 		BookingStatusRejected:  {},
+		BookingStatusCheckedIn: {},
+		BookingStatusNoShow:    {},
 	},
 	BookingStatusPending: {
 		BookingStatusConfirmed: {},
 		BookingStatusCancelled: {},
 		BookingStatusRejected:  {},
+		BookingStatusCheckedIn: {},
+		BookingStatusNoShow:    {},
 	},
 	BookingStatusConfirmed: {
 		BookingStatusCancelled: {},
 		BookingStatusRejected:  {},
+		BookingStatusCheckedIn: {},
+		BookingStatusNoShow:    {},
 	},
 	BookingStatusOngoing: {
 		BookingStatusCancelled: {},
 		BookingStatusRejected:  {},
+		BookingStatusCheckedIn: {},
+		BookingStatusNoShow:    {},
+	},
+	BookingStatusCheckedIn: {
+		BookingStatusAttended: {},
+	},
+	// Late arrivals and attendance recorded after the fact.
+	BookingStatusNoShow: {
+		BookingStatusCheckedIn: {},
+		BookingStatusAttended:  {},
+	},
+	BookingStatusExpired: {
+		BookingStatusAttended: {},
+		BookingStatusNoShow:   {},
 	},
 }
 
 var appointmentStatusTransitions = map[AppointmentStatus]map[AppointmentStatus]struct{}{
+	AppointmentStatusDraft: {
+		AppointmentStatusPending:  {},
+		AppointmentStatusCanceled: {},
+		AppointmentStatusArchived: {},
+	},
 	AppointmentStatusPending: {
+		AppointmentStatusPaused:   {},
 		AppointmentStatusCanceled: {},
 	},
 	AppointmentStatusOngoing: {
+		AppointmentStatusPaused:   {},
 		AppointmentStatusCanceled: {},
 	},
+	AppointmentStatusPaused: {
+		AppointmentStatusPending:   {},
+		AppointmentStatusOngoing:   {},
+		AppointmentStatusCompleted: {},
+		AppointmentStatusCanceled:  {},
+	},
+	AppointmentStatusCompleted: {
+		AppointmentStatusArchived: {},
+	},
+	AppointmentStatusCanceled: {
+		AppointmentStatusArchived: {},
+	},
 }
 
 func CanTransitionBookingStatus(from, to string) bool {
@@ -59,7 +112,7 @@ func CanTransitionBookingStatus(from, to string) bool {
 		BookingStatusCancelled: {},
 		BookingStatusCanceled:  {},
 		BookingStatusRejected:  {},
-		BookingStatusExpired:   {},
+		BookingStatusAttended:  {},
 	}[from]; terminal {
 		return false
 	}
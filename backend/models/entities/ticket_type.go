@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TicketType is a tier of seats on a group or party appointment, such as General or VIP. Key
+// identifies the tier on bookings. Capacity caps the seats sold in the tier per group slot, or
+// for the whole party, on top of the appointment's own capacity. MaxPerBooking caps the attendees
+// on one booking (0 leaves only the appointment's limit), and tickets sell only between
+// SalesStart and SalesEnd when they are set. Price, when set, replaces the appointment's price
+// per attendee.
+type TicketType struct {
+	Key           string     `json:"key" example:"vip"`
+	Name          string     `json:"name" example:"VIP"`
+	Capacity      int        `json:"capacity" example:"10"`
+	MaxPerBooking int        `json:"max_per_booking,omitempty" example:"2"`
+	SalesStart    *time.Time `json:"sales_start,omitempty"`
+	SalesEnd      *time.Time `json:"sales_end,omitempty"`
+	Price         *int64     `json:"price,omitempty" example:"5000"`
+}
+
+// TicketTypes is the ordered list of tiers on an appointment. An empty list sells plain seats.
+type TicketTypes []TicketType
+
+// TicketSales is how many seats of a ticket type are booked across an appointment.
+type TicketSales struct {
+	Key    string `json:"key" example:"vip"`
+	Booked int    `json:"booked" example:"4"`
+}
+
+// Validate checks that keys are present and unique, every tier has a name and room for at least
+// one attendee, prices are not negative, and sale windows end after they start.
+func (t TicketTypes) Validate() error {
+	seen := make(map[string]bool, len(t))
+	for _, ticket := range t {
+		key := strings.TrimSpace(ticket.Key)
+		if key == "" {
+			return fmt.Errorf("every ticket type needs a key")
+		}
+		if seen[key] {
+			return fmt.Errorf("duplicate ticket type key %q", key)
+		}
+		seen[key] = true
+
+		if strings.TrimSpace(ticket.Name) == "" {
+			return fmt.Errorf("%s: ticket types need a name", key)
+		}
+		if ticket.Capacity < 1 {
+			return fmt.Errorf("%s: capacity must be at least 1", key)
+		}
+		if ticket.MaxPerBooking < 0 {
+			return fmt.Errorf("%s: max per booking cannot be negative", key)
+		}
+		if ticket.Price != nil && *ticket.Price < 0 {
+			return fmt.Errorf("%s: price cannot be negative", key)
+		}
+		if ticket.SalesStart != nil && ticket.SalesEnd != nil && !ticket.SalesEnd.After(*ticket.SalesStart) {
+			return fmt.Errorf("%s: sales must end after they start", key)
+		}
+	}
+	return nil
+}
+
+// Find returns the ticket type with key.
+func (t TicketTypes) Find(key string) (*TicketType, bool) {
+	for i := range t {
+		if t[i].Key == key {
+			return &t[i], true
+		}
+	}
+	return nil, false
+}
+
+// CheckSale reports why attendees cannot buy the ticket type at now: outside its sale window or
+// over its per-booking limit. Remaining seats are checked separately against live bookings.
+func (t TicketType) CheckSale(attendees int, now time.Time) error {
+	if t.SalesStart != nil && now.Before(*t.SalesStart) {
+		return fmt.Errorf("%s tickets are not on sale yet", t.Name)
+	}
+	if t.SalesEnd != nil && !now.Before(*t.SalesEnd) {
+		return fmt.Errorf("%s ticket sales have ended", t.Name)
+	}
+	return t.CheckLimit(attendees)
+}
+
+// CheckLimit reports when attendees is over the ticket type's per-booking limit.
+func (t TicketType) CheckLimit(attendees int) error {
+	if t.MaxPerBooking > 0 && attendees > t.MaxPerBooking {
+		return fmt.Errorf("%s tickets are limited to %d per booking", t.Name, t.MaxPerBooking)
+	}
+	return nil
+}
+
+// Value stores the ticket types as JSON.
+func (t TicketTypes) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the JSON ticket types back from the database.
+func (t *TicketTypes) Scan(value interface{}) error {
+	data, err := jsonColumnBytes(value)
+	if err != nil || data == nil {
+		*t = nil
+		return err
+	}
+	return json.Unmarshal(data, t)
+}
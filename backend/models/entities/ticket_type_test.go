@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketTypesValidate(t *testing.T) {
+	opens := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	closes := opens.Add(48 * time.Hour)
+	valid := TicketTypes{
+		{Key: "general", Name: "General", Capacity: 40},
+		{Key: "vip", Name: "VIP", Capacity: 10, MaxPerBooking: 2, SalesStart: &opens, SalesEnd: &closes},
+	}
+	assert.NoError(t, valid.Validate())
+
+	assert.Error(t, TicketTypes{{Key: " ", Name: "General", Capacity: 1}}.Validate())
+	assert.Error(t, TicketTypes{{Key: "a", Name: "A", Capacity: 1}, {Key: "a", Name: "B", Capacity: 1}}.Validate())
+	assert.Error(t, TicketTypes{{Key: "a", Capacity: 1}}.Validate())
+	assert.Error(t, TicketTypes{{Key: "a", Name: "A"}}.Validate())
+	assert.Error(t, TicketTypes{{Key: "a", Name: "A", Capacity: 1, MaxPerBooking: -1}}.Validate())
+	assert.Error(t, TicketTypes{{Key: "a", Name: "A", Capacity: 1, SalesStart: &closes, SalesEnd: &opens}}.Validate())
+}
+
+func TestTicketTypeCheckSale(t *testing.T) {
+	opens := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	closes := opens.Add(48 * time.Hour)
+	vip := TicketType{Key: "vip", Name: "VIP", Capacity: 10, MaxPerBooking: 2, SalesStart: &opens, SalesEnd: &closes}
+
+	assert.NoError(t, vip.CheckSale(2, opens))
+	assert.EqualError(t, vip.CheckSale(1, opens.Add(-time.Minute)), "VIP tickets are not on sale yet")
+	assert.EqualError(t, vip.CheckSale(1, closes), "VIP ticket sales have ended")
+	assert.EqualError(t, vip.CheckSale(3, opens), "VIP tickets are limited to 2 per booking")
+
+	found, ok := TicketTypes{vip}.Find("vip")
+	assert.True(t, ok)
+	assert.Equal(t, "VIP", found.Name)
+	_, ok = TicketTypes{vip}.Find("general")
+	assert.False(t, ok)
+}
@@ -17,6 +17,8 @@ type User struct {
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 	DeletedAt      gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+	// CalendarFeedToken grants read-only access to the user's booking calendar feed.
+	CalendarFeedToken *string `json:"-" gorm:"uniqueIndex"`
 }
 
 // SetPassword hashes and sets the user's password
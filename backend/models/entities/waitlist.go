@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WaitlistStatusWaiting   = "waiting"
+	WaitlistStatusPromoted  = "promoted"
+	WaitlistStatusCancelled = "cancelled"
+	// WaitlistStatusExpired marks an entry that could no longer be promoted because its guest
+	// already held another booking on the appointment.
+	WaitlistStatusExpired = "expired"
+)
+
+// WaitlistEntry queues a guest for a full slot, or a full party, until seats are freed.
+// Entries for the same appointment and start time are promoted in the order they joined.
+type WaitlistEntry struct {
+	ID            uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	AppointmentID uuid.UUID   `json:"appointment_id" gorm:"type:uuid;not null;index"`
+	AppCode       string      `json:"app_code" gorm:"not null"`
+	Date          time.Time   `json:"date" gorm:"not null"`
+	StartTime     time.Time   `json:"start_time" gorm:"not null"`
+	EndTime       time.Time   `json:"end_time" gorm:"not null"`
+	UserID        *uuid.UUID  `json:"user_id" gorm:"type:uuid"`
+	Name          string      `json:"name"`
+	Email         string      `json:"email"`
+	Phone         string      `json:"phone"`
+	AttendeeCount int         `json:"attendee_count" gorm:"not null;default:1"`
+	Description   string      `json:"description" gorm:"type:text"`
+	FormAnswers   FormAnswers `json:"form_answers,omitempty" gorm:"type:jsonb" swaggertype:"object"`
+	TicketType    string      `json:"ticket_type,omitempty" gorm:"type:varchar(64)"`
+	Status        string      `json:"status" gorm:"not null;default:'waiting'"`
+	BookingID     *uuid.UUID  `json:"booking_id,omitempty" gorm:"type:uuid"` // Set once the entry is promoted
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
@@ -1,8 +1,11 @@
 package requests
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
 	"github.com/m13ha/asiko/models/entities"
 	"github.com/m13ha/asiko/utils"
@@ -10,15 +13,70 @@ import (
 
 type AppointmentRequest struct {
 	Title             string                     `json:"title" validate:"required"`
-	StartTime         time.Time                  `json:"start_time" validate:"required"`
-	EndTime           time.Time                  `json:"end_time" validate:"required"`
+	StartTime         time.Time                  `json:"start_time" validate:"required_without=Availability"`
+	EndTime           time.Time                  `json:"end_time" validate:"required_without=Availability"`
 	BookingDuration   int                        `json:"booking_duration" validate:"required,gt=0"`
 	StartDate         time.Time                  `json:"start_date" validate:"required"`
-	EndDate           time.Time                  `json:"end_date" validate:"required,gtefield=StartDate"`
+	EndDate           time.Time                  `json:"end_date" validate:"omitempty,gtefield=StartDate"`
 	Type              entities.AppointmentType   `json:"type" validate:"required,oneof=single group party"`
 	MaxAttendees      int                        `json:"max_attendees" validate:"gte=1"`
 	Description       string                     `json:"description"`
 	AntiScalpingLevel entities.AntiScalpingLevel `json:"anti_scalping_level,omitempty" validate:"omitempty,oneof=none standard strict"`
+	// RecurrenceRule is an RRULE subset (FREQ, INTERVAL, BYDAY, BYMONTHDAY, COUNT, UNTIL).
+	// When set, EndDate may be omitted if the rule carries COUNT or UNTIL.
+	RecurrenceRule string `json:"recurrence_rule,omitempty" example:"FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=12"`
+	// TimeZone is the IANA zone the dates and daily window are expressed in. When omitted,
+	// timestamps are normalized to UTC.
+	TimeZone string `json:"time_zone,omitempty" example:"America/New_York"`
+	// Availability is a weekly template of open windows keyed by lowercase weekday name. When
+	// set it replaces StartTime/EndTime, which are derived from the earliest and latest window.
+	Availability entities.WeeklyAvailability `json:"availability,omitempty" swaggertype:"object"`
+	// PreBufferMinutes and PostBufferMinutes reserve time before and after every slot, e.g. a
+	// 45 minute session with a 15 minute post-buffer yields slots at 09:00, 10:00, 11:00.
+	PreBufferMinutes  int `json:"pre_buffer_minutes,omitempty" validate:"gte=0,lte=1440"`
+	PostBufferMinutes int `json:"post_buffer_minutes,omitempty" validate:"gte=0,lte=1440"`
+	// MinNoticeMinutes stops slots being booked shortly before they start; MaxAdvanceDays limits
+	// how far ahead they can be booked. Zero disables either limit.
+	MinNoticeMinutes int `json:"min_notice_minutes,omitempty" validate:"gte=0" example:"120"`
+	MaxAdvanceDays   int `json:"max_advance_days,omitempty" validate:"gte=0" example:"60"`
+	// CancellationCutoffHours and RescheduleCutoffHours stop guests cancelling or changing a
+	// booking that close to its start; MaxReschedules caps how often it may be moved. Zero
+	// disables each policy. The appointment owner and organization staff can always override them.
+	CancellationCutoffHours int `json:"cancellation_cutoff_hours,omitempty" validate:"gte=0" example:"24"`
+	RescheduleCutoffHours   int `json:"reschedule_cutoff_hours,omitempty" validate:"gte=0" example:"12"`
+	MaxReschedules          int `json:"max_reschedules,omitempty" validate:"gte=0" example:"2"`
+	// FormFields are extra questions (text, number, select, checkbox) guests answer when booking.
+	FormFields entities.BookingForm `json:"form_fields,omitempty"`
+	// TicketTypes split a group or party appointment's seats into tiers, each with its own
+	// capacity, per-booking limit and optional sale window.
+	TicketTypes entities.TicketTypes `json:"ticket_types,omitempty"`
+	// Price is what one attendee pays, in minor units of Currency; ticket types may set their
+	// own. Currency is an ISO 4217 code, required once anything has a price. Paid bookings
+	// cancelled at least RefundCutoffHours before the start are refunded automatically.
+	Price             int64  `json:"price,omitempty" validate:"gte=0" example:"2500"`
+	Currency          string `json:"currency,omitempty" example:"USD"`
+	RefundCutoffHours int    `json:"refund_cutoff_hours,omitempty" validate:"gte=0" example:"48"`
+	// NoShowGraceMinutes marks bookings no-show automatically when nobody has checked in that
+	// long after they start. Zero disables automatic marking.
+	NoShowGraceMinutes int `json:"no_show_grace_minutes,omitempty" validate:"gte=0" example:"15"`
+	// ReminderOffsets are the minutes before a booking starts at which confirmed guests are emailed
+	// a reminder. Leaving it out reminds them a day and an hour ahead; an empty list sends none.
+	ReminderOffsets entities.ReminderOffsets `json:"reminder_offsets" swaggertype:"array,integer" example:"1440,60"`
+	// AllowParallelBookings lets this appointment be booked at the same time as the owner's other
+	// appointments, for events the owner runs in parallel on purpose.
+	AllowParallelBookings bool `json:"allow_parallel_bookings,omitempty"`
+	// OrganizationID shares the appointment with an organization the caller may manage
+	// appointments in.
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	// Draft creates the appointment unpublished: its slots exist but cannot be booked until it is
+	// published. A future PublishAt also creates a draft, published automatically at that time.
+	Draft     bool       `json:"draft,omitempty"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+}
+
+// PublishAppointmentRequest publishes a draft now, or schedules it when PublishAt is in the future.
+type PublishAppointmentRequest struct {
+	PublishAt *time.Time `json:"publish_at,omitempty"`
 }
 
 func (req *AppointmentRequest) Validate() error {
@@ -27,6 +85,43 @@ func (req *AppointmentRequest) Validate() error {
 		return serviceerrors.UserError("Invalid appointment data. Please check your input.")
 	}
 
+	if err := req.applyTimeZone(); err != nil {
+		return err
+	}
+	if req.Type == entities.Party && (req.PreBufferMinutes > 0 || req.PostBufferMinutes > 0) {
+		return serviceerrors.ValidationError("Party appointments cannot use buffer times.")
+	}
+	if err := req.applyAvailability(); err != nil {
+		return err
+	}
+	if err := req.FormFields.Validate(); err != nil {
+		return serviceerrors.ValidationError("Invalid form fields: " + err.Error())
+	}
+	if len(req.TicketTypes) > 0 && req.Type == entities.Single {
+		return serviceerrors.ValidationError("Ticket types are only available for group and party appointments.")
+	}
+	if err := req.TicketTypes.Validate(); err != nil {
+		return serviceerrors.ValidationError("Invalid ticket types: " + err.Error())
+	}
+	if err := req.applyCurrency(); err != nil {
+		return err
+	}
+	if req.ReminderOffsets == nil {
+		req.ReminderOffsets = entities.DefaultReminderOffsets
+	}
+	if err := req.ReminderOffsets.Validate(); err != nil {
+		return serviceerrors.ValidationError("Invalid reminders: " + err.Error())
+	}
+	req.ReminderOffsets = req.ReminderOffsets.Normalize()
+
+	if req.RecurrenceRule != "" {
+		if err := req.applyRecurrence(); err != nil {
+			return err
+		}
+	} else if req.EndDate.IsZero() {
+		return serviceerrors.UserError("Invalid appointment data. Please check your input.")
+	}
+
 	startClock := normalizeClock(req.StartTime)
 	endClock := normalizeClock(req.EndTime)
 
@@ -59,7 +154,7 @@ func (req *AppointmentRequest) Validate() error {
 	}
 
 	duration := clockWindowDuration(startClock, endClock)
-	if duration.Minutes() < float64(req.BookingDuration) {
+	if duration.Minutes() < float64(req.PreBufferMinutes+req.BookingDuration) {
 		return serviceerrors.ValidationError("Booking duration exceeds available time window.")
 	}
 
@@ -88,6 +183,123 @@ func (req *AppointmentRequest) Validate() error {
 	return nil
 }
 
+// applyTimeZone re-anchors the request's dates and times to the appointment's time zone, keeping
+// the wall-clock values the caller sent, so that every later check runs in local time.
+func (req *AppointmentRequest) applyTimeZone() error {
+	if req.TimeZone == "" {
+		req.TimeZone = "UTC"
+		req.StartDate = req.StartDate.UTC()
+		req.EndDate = req.EndDate.UTC()
+		req.StartTime = req.StartTime.UTC()
+		req.EndTime = req.EndTime.UTC()
+	}
+
+	loc, err := time.LoadLocation(req.TimeZone)
+	if err != nil {
+		return serviceerrors.ValidationError("Invalid time zone: " + req.TimeZone)
+	}
+	req.TimeZone = loc.String()
+	req.StartDate = inLocation(req.StartDate, loc)
+	if !req.EndDate.IsZero() {
+		req.EndDate = inLocation(req.EndDate, loc)
+	}
+	req.StartTime = inLocation(req.StartTime, loc)
+	req.EndTime = inLocation(req.EndTime, loc)
+	return nil
+}
+
+// applyCurrency normalizes the currency code and requires one when any seat has a price.
+func (req *AppointmentRequest) applyCurrency() error {
+	req.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+	priced := entities.Appointment{Price: req.Price, TicketTypes: req.TicketTypes}
+	if req.Currency == "" {
+		if priced.IsPaid() {
+			return serviceerrors.ValidationError("A currency is required for paid appointments.")
+		}
+		return nil
+	}
+	if len(req.Currency) != 3 || strings.Trim(req.Currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return serviceerrors.ValidationError("Currency must be a three-letter ISO 4217 code.")
+	}
+	return nil
+}
+
+// applyAvailability validates the weekly template and derives StartTime/EndTime from it so the
+// status refresher still sees when the appointment's days begin and end.
+func (req *AppointmentRequest) applyAvailability() error {
+	if len(req.Availability) == 0 {
+		req.Availability = nil
+		return nil
+	}
+	if req.Type == entities.Party {
+		return serviceerrors.ValidationError("Party appointments cannot use a weekly availability template.")
+	}
+
+	req.Availability = req.Availability.Normalize()
+	if err := req.Availability.Validate(); err != nil {
+		return serviceerrors.ValidationError("Invalid availability: " + err.Error())
+	}
+	if !req.Availability.IsOpen() {
+		return serviceerrors.ValidationError("Availability must include at least one open window.")
+	}
+	for day, windows := range req.Availability {
+		for _, window := range windows {
+			start, end, _ := window.Minutes()
+			if end-start < req.PreBufferMinutes+req.BookingDuration {
+				return serviceerrors.ValidationError(fmt.Sprintf("Booking duration exceeds the %s %s-%s window.", day, window.Start, window.End))
+			}
+		}
+	}
+
+	first, last := req.Availability.Span()
+	if last >= 24*60 {
+		last = 24*60 - 1
+	}
+	loc := req.StartDate.Location()
+	req.StartTime = time.Date(req.StartDate.Year(), req.StartDate.Month(), req.StartDate.Day(), 0, first, 0, 0, loc)
+	req.EndTime = time.Date(req.StartDate.Year(), req.StartDate.Month(), req.StartDate.Day(), 0, last, 0, 0, loc)
+	return nil
+}
+
+// applyRecurrence validates the recurrence rule and narrows StartDate/EndDate to the first
+// and last occurrence, so status refresh and slot generation see the real booking span.
+func (req *AppointmentRequest) applyRecurrence() error {
+	rule, err := entities.ParseRecurrenceRule(req.RecurrenceRule)
+	if err != nil {
+		return serviceerrors.ValidationError("Invalid recurrence rule: " + err.Error())
+	}
+	if req.Type == entities.Party {
+		return serviceerrors.ValidationError("Party appointments cannot recur.")
+	}
+
+	limit := req.EndDate
+	if limit.IsZero() {
+		if rule.Count == 0 && rule.Until.IsZero() {
+			return serviceerrors.ValidationError("Recurring appointments need an end date, COUNT or UNTIL.")
+		}
+		limit = req.StartDate.Add(entities.MaxRecurrenceHorizon)
+	}
+
+	dates := rule.Occurrences(req.StartDate, limit)
+	if len(dates) == 0 {
+		return serviceerrors.ValidationError("Recurrence rule does not produce any dates.")
+	}
+
+	first := dates[0]
+	last := dates[len(dates)-1]
+	loc := req.StartDate.Location()
+	clock := req.StartDate
+	req.StartDate = time.Date(first.Year(), first.Month(), first.Day(), clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), loc)
+	req.EndDate = time.Date(last.Year(), last.Month(), last.Day(), clock.Hour(), clock.Minute(), clock.Second(), clock.Nanosecond(), loc)
+	req.RecurrenceRule = strings.ToUpper(strings.TrimSpace(req.RecurrenceRule))
+	return nil
+}
+
+// inLocation keeps the wall-clock fields of t but interprets them in loc.
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
 func normalizeClock(t time.Time) time.Time {
 	return time.Date(2000, time.January, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
 }
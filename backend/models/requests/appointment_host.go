@@ -0,0 +1,42 @@
+package requests
+
+import (
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/utils"
+)
+
+// AppointmentHostsRequest replaces an appointment's hosts. An empty list hands bookings back to
+// the owner.
+type AppointmentHostsRequest struct {
+	Assignment entities.HostAssignment  `json:"assignment" validate:"omitempty,oneof=round_robin least_busy priority" example:"round_robin"`
+	Hosts      []AppointmentHostRequest `json:"hosts" validate:"dive"`
+}
+
+type AppointmentHostRequest struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Priority int       `json:"priority" validate:"gte=0" example:"0"`
+}
+
+func (req *AppointmentHostsRequest) Validate() error {
+	req.Assignment = entities.HostAssignment(utils.NormalizeString(string(req.Assignment)))
+	if req.Assignment == "" {
+		req.Assignment = entities.HostAssignmentRoundRobin
+	}
+	if err := utils.Validate(req); err != nil {
+		return serviceerrors.UserError("Invalid host data. Use round_robin, least_busy or priority and a user ID for each host.")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.Hosts))
+	for _, host := range req.Hosts {
+		if host.UserID == uuid.Nil {
+			return serviceerrors.ValidationError("Each host needs a user ID.")
+		}
+		if seen[host.UserID] {
+			return serviceerrors.ValidationError("Each user can only be listed as a host once.")
+		}
+		seen[host.UserID] = true
+	}
+	return nil
+}
@@ -54,3 +54,173 @@ func TestAppointmentRequestValidateRejectsPastStartTime(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Start time cannot be in the past")
 }
+
+func TestAppointmentRequestValidateDerivesEndDateFromRecurrenceCount(t *testing.T) {
+	now := time.Now().UTC()
+	next := now.AddDate(0, 0, 7)
+	startDate := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+
+	req := &AppointmentRequest{
+		Title:           "Weekly Coaching",
+		StartDate:       startDate,
+		StartTime:       time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2000, 1, 1, 10, 0, 0, 0, time.UTC),
+		BookingDuration: 60,
+		Type:            entities.Single,
+		MaxAttendees:    1,
+		RecurrenceRule:  "freq=weekly;count=4",
+	}
+
+	err := req.Validate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, startDate.AddDate(0, 0, 21), req.EndDate)
+	assert.Equal(t, "FREQ=WEEKLY;COUNT=4", req.RecurrenceRule)
+}
+
+func TestAppointmentRequestValidateRejectsUnboundedRecurrence(t *testing.T) {
+	next := time.Now().UTC().AddDate(0, 0, 7)
+	startDate := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+
+	req := &AppointmentRequest{
+		Title:           "Forever",
+		StartDate:       startDate,
+		StartTime:       time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2000, 1, 1, 10, 0, 0, 0, time.UTC),
+		BookingDuration: 60,
+		Type:            entities.Single,
+		MaxAttendees:    1,
+		RecurrenceRule:  "FREQ=DAILY",
+	}
+
+	err := req.Validate()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "need an end date")
+}
+
+func TestAppointmentRequestValidateReadsWallClockInTimeZone(t *testing.T) {
+	next := time.Now().AddDate(0, 0, 7)
+	startDate := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+
+	req := &AppointmentRequest{
+		Title:           "Local Hours",
+		StartDate:       startDate,
+		EndDate:         startDate,
+		StartTime:       time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2000, 1, 1, 17, 0, 0, 0, time.UTC),
+		BookingDuration: 60,
+		Type:            entities.Single,
+		MaxAttendees:    1,
+		TimeZone:        "Asia/Tokyo",
+	}
+
+	err := req.Validate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", req.StartTime.Location().String())
+	assert.Equal(t, 9, req.StartTime.Hour())
+	assert.Equal(t, startDate.Day(), req.StartTime.Day())
+}
+
+func TestAppointmentRequestValidateRejectsUnknownTimeZone(t *testing.T) {
+	next := time.Now().AddDate(0, 0, 7)
+	startDate := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+
+	req := &AppointmentRequest{
+		Title:           "Nowhere",
+		StartDate:       startDate,
+		EndDate:         startDate,
+		StartTime:       time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2000, 1, 1, 17, 0, 0, 0, time.UTC),
+		BookingDuration: 60,
+		Type:            entities.Single,
+		MaxAttendees:    1,
+		TimeZone:        "Mars/Olympus_Mons",
+	}
+
+	err := req.Validate()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid time zone")
+}
+
+func TestAppointmentRequestValidateDerivesWindowFromAvailability(t *testing.T) {
+	next := time.Now().UTC().AddDate(0, 0, 7)
+	startDate := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+
+	req := &AppointmentRequest{
+		Title:           "Clinic Hours",
+		StartDate:       startDate,
+		EndDate:         startDate.AddDate(0, 0, 14),
+		BookingDuration: 30,
+		Type:            entities.Single,
+		MaxAttendees:    1,
+		Availability: entities.WeeklyAvailability{
+			"Monday":   {{Start: "13:00", End: "17:00"}, {Start: "09:00", End: "12:00"}},
+			"saturday": {{Start: "10:00", End: "14:00"}},
+		},
+	}
+
+	err := req.Validate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9, req.StartTime.Hour())
+	assert.Equal(t, 17, req.EndTime.Hour())
+	assert.Equal(t, "09:00", req.Availability["monday"][0].Start)
+}
+
+func TestAppointmentRequestValidateRejectsAvailabilityShorterThanDuration(t *testing.T) {
+	next := time.Now().UTC().AddDate(0, 0, 7)
+	startDate := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+
+	req := &AppointmentRequest{
+		Title:           "Too Short",
+		StartDate:       startDate,
+		EndDate:         startDate,
+		BookingDuration: 90,
+		Type:            entities.Single,
+		MaxAttendees:    1,
+		Availability: entities.WeeklyAvailability{
+			"monday": {{Start: "09:00", End: "10:00"}},
+		},
+	}
+
+	err := req.Validate()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Booking duration exceeds")
+}
+
+func TestAppointmentRequestValidateReminderOffsets(t *testing.T) {
+	next := time.Now().UTC().AddDate(0, 0, 7)
+	startDate := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+	newRequest := func(offsets entities.ReminderOffsets) *AppointmentRequest {
+		return &AppointmentRequest{
+			Title:           "Reminders",
+			StartDate:       startDate,
+			EndDate:         startDate,
+			StartTime:       time.Date(2000, 1, 1, 9, 0, 0, 0, time.UTC),
+			EndTime:         time.Date(2000, 1, 1, 10, 0, 0, 0, time.UTC),
+			BookingDuration: 60,
+			Type:            entities.Single,
+			MaxAttendees:    1,
+			ReminderOffsets: offsets,
+		}
+	}
+
+	req := newRequest(nil)
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, entities.DefaultReminderOffsets, req.ReminderOffsets)
+
+	req = newRequest(entities.ReminderOffsets{})
+	assert.NoError(t, req.Validate())
+	assert.Empty(t, req.ReminderOffsets)
+
+	req = newRequest(entities.ReminderOffsets{30, 2880})
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, entities.ReminderOffsets{2880, 30}, req.ReminderOffsets)
+
+	err := newRequest(entities.ReminderOffsets{60, 60}).Validate()
+	assert.ErrorContains(t, err, "Invalid reminders")
+}
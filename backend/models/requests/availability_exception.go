@@ -0,0 +1,43 @@
+package requests
+
+import (
+	"time"
+
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/utils"
+)
+
+type AvailabilityExceptionRequest struct {
+	Date      string                             `json:"date" validate:"required" example:"2026-12-25"` // local date, YYYY-MM-DD
+	Type      entities.AvailabilityExceptionType `json:"type" validate:"required,oneof=closed shortened extra"`
+	StartTime string                             `json:"start_time,omitempty" example:"09:00"`
+	EndTime   string                             `json:"end_time,omitempty" example:"12:00"`
+	Reason    string                             `json:"reason,omitempty"`
+}
+
+func (req *AvailabilityExceptionRequest) Validate() error {
+	if err := utils.Validate(req); err != nil {
+		return serviceerrors.UserError("Invalid exception data. Please check your input.")
+	}
+
+	if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+		return serviceerrors.ValidationError("Invalid date format. Use YYYY-MM-DD.")
+	}
+
+	if req.Type == entities.ExceptionClosed {
+		req.StartTime = ""
+		req.EndTime = ""
+		return nil
+	}
+
+	window := entities.AvailabilityWindow{Start: req.StartTime, End: req.EndTime}
+	start, end, err := window.Minutes()
+	if err != nil {
+		return serviceerrors.ValidationError("Start and end times are required as HH:MM for shortened and extra exceptions.")
+	}
+	if end <= start {
+		return serviceerrors.ValidationError("Exception end time must be after start time.")
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package requests
+
+import (
+	"testing"
+
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailabilityExceptionRequestValidateClearsTimesForClosedDay(t *testing.T) {
+	req := &AvailabilityExceptionRequest{
+		Date:      "2026-12-25",
+		Type:      entities.ExceptionClosed,
+		StartTime: "09:00",
+		EndTime:   "12:00",
+	}
+
+	assert.NoError(t, req.Validate())
+	assert.Empty(t, req.StartTime)
+	assert.Empty(t, req.EndTime)
+}
+
+func TestAvailabilityExceptionRequestValidateRequiresWindow(t *testing.T) {
+	req := &AvailabilityExceptionRequest{Date: "2026-12-24", Type: entities.ExceptionShortened, StartTime: "09:00"}
+	assert.Error(t, req.Validate())
+
+	req = &AvailabilityExceptionRequest{Date: "2026-12-24", Type: entities.ExceptionExtra, StartTime: "14:00", EndTime: "13:00"}
+	err := req.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "end time must be after start time")
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
 	"github.com/m13ha/asiko/utils"
 )
 
@@ -18,6 +19,16 @@ type BookingRequest struct {
 	AttendeeCount int       `json:"attendee_count" validate:"gte=1"`
 	Description   string    `json:"description"`
 	DeviceToken   string    `json:"device_token,omitempty"`
+	// HoldToken redeems seats reserved through the slot hold endpoint.
+	HoldToken string `json:"hold_token,omitempty"`
+	// FormAnswers answers the appointment's custom form fields, keyed by field key.
+	FormAnswers entities.FormAnswers `json:"form_answers,omitempty" swaggertype:"object"`
+	// TicketType is the key of the ticket type to book, required when the appointment has any.
+	// A booking keeps its ticket type when it is changed.
+	TicketType string `json:"ticket_type,omitempty" example:"general"`
+	// PromoCode takes a promo code's discount off the booking's price. It is redeemed when the
+	// booking is made and ignored when changing a booking or joining the waitlist.
+	PromoCode string `json:"promo_code,omitempty" example:"SPRING20"`
 }
 
 func (req *BookingRequest) Validate() error {
@@ -0,0 +1,32 @@
+package requests
+
+import (
+	"net/url"
+	"strings"
+
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/utils"
+)
+
+// ExternalCalendarRequest registers an ICS feed whose busy times block the owner's slots.
+type ExternalCalendarRequest struct {
+	Name string `json:"name" validate:"required" example:"Work calendar"`
+	URL  string `json:"url" validate:"required" example:"https://calendar.example.com/owner.ics"`
+}
+
+func (req *ExternalCalendarRequest) Validate() error {
+	if err := utils.Validate(req); err != nil {
+		return serviceerrors.UserError("Invalid calendar data. Please check your input.")
+	}
+
+	// webcal:// is how calendar apps advertise subscribable feeds; it is plain HTTPS underneath.
+	req.URL = strings.TrimSpace(req.URL)
+	if strings.HasPrefix(strings.ToLower(req.URL), "webcal://") {
+		req.URL = "https://" + req.URL[len("webcal://"):]
+	}
+	parsed, err := url.Parse(req.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return serviceerrors.ValidationError("Calendar URL must be an http, https or webcal address.")
+	}
+	return nil
+}
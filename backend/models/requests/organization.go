@@ -0,0 +1,49 @@
+package requests
+
+import (
+	"strings"
+
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/utils"
+)
+
+type OrganizationRequest struct {
+	Name string `json:"name" validate:"required" example:"Front desk"`
+}
+
+func (req *OrganizationRequest) Validate() error {
+	req.Name = strings.TrimSpace(req.Name)
+	if err := utils.Validate(req); err != nil {
+		return serviceerrors.UserError("Invalid organization data. Please check your input.")
+	}
+	return nil
+}
+
+// OrganizationMemberRequest adds a registered user to an organization by email.
+type OrganizationMemberRequest struct {
+	Email string                    `json:"email" validate:"required,email" example:"desk@example.com"`
+	Role  entities.OrganizationRole `json:"role" validate:"required,oneof=owner admin staff viewer" example:"staff"`
+}
+
+func (req *OrganizationMemberRequest) Validate() error {
+	req.Email = utils.NormalizeEmail(req.Email)
+	req.Role = entities.OrganizationRole(utils.NormalizeString(string(req.Role)))
+	if err := utils.Validate(req); err != nil {
+		return serviceerrors.UserError("Invalid member data. Please check your input.")
+	}
+	return nil
+}
+
+// OrganizationRoleRequest changes a member's role.
+type OrganizationRoleRequest struct {
+	Role entities.OrganizationRole `json:"role" validate:"required,oneof=owner admin staff viewer" example:"admin"`
+}
+
+func (req *OrganizationRoleRequest) Validate() error {
+	req.Role = entities.OrganizationRole(utils.NormalizeString(string(req.Role)))
+	if err := utils.Validate(req); err != nil {
+		return serviceerrors.UserError("Invalid role. Use owner, admin, staff or viewer.")
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package requests
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+)
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,64}$`)
+
+// PromoCodeRequest creates or replaces a promo code. Percent discounts take 1 to 100 percent off
+// a booking; fixed ones take Value minor units of Currency off it. Zero limits leave redemptions
+// unlimited.
+type PromoCodeRequest struct {
+	Code           string            `json:"code" example:"SPRING20"`
+	Description    string            `json:"description,omitempty" example:"Spring sale"`
+	Discount       entities.Discount `json:"discount"`
+	Currency       string            `json:"currency,omitempty" example:"USD"`
+	MaxRedemptions int               `json:"max_redemptions,omitempty" example:"100"`
+	MaxPerEmail    int               `json:"max_per_email,omitempty" example:"1"`
+	ValidFrom      *time.Time        `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time        `json:"valid_until,omitempty"`
+	// AppointmentIDs restricts the code to appointments the caller may manage. Empty applies it
+	// to every appointment the caller created.
+	AppointmentIDs []uuid.UUID `json:"appointment_ids,omitempty"`
+}
+
+func (req *PromoCodeRequest) Validate() error {
+	req.Code = entities.NormalizePromoCode(req.Code)
+	req.Description = strings.TrimSpace(req.Description)
+	req.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+
+	if !promoCodePattern.MatchString(req.Code) {
+		return serviceerrors.ValidationError("Promo codes must be 3 to 64 letters, digits, dashes or underscores.")
+	}
+	switch req.Discount.Type {
+	case entities.DiscountPercent:
+		if req.Discount.Value < 1 || req.Discount.Value > 100 {
+			return serviceerrors.ValidationError("Percent discounts must be between 1 and 100.")
+		}
+		req.Currency = ""
+	case entities.DiscountFixed:
+		if req.Discount.Value < 1 {
+			return serviceerrors.ValidationError("Fixed discounts must be above zero.")
+		}
+		if len(req.Currency) != 3 || strings.Trim(req.Currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			return serviceerrors.ValidationError("Fixed discounts need a three-letter ISO 4217 currency code.")
+		}
+	default:
+		return serviceerrors.ValidationError("Discount type must be percent or fixed.")
+	}
+	if req.MaxRedemptions < 0 || req.MaxPerEmail < 0 {
+		return serviceerrors.ValidationError("Redemption limits cannot be negative.")
+	}
+	if req.ValidFrom != nil && req.ValidUntil != nil && !req.ValidUntil.After(*req.ValidFrom) {
+		return serviceerrors.ValidationError("Promo codes must stop being valid after they start.")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.AppointmentIDs))
+	for _, id := range req.AppointmentIDs {
+		if id == uuid.Nil {
+			return serviceerrors.ValidationError("Each appointment needs an ID.")
+		}
+		if seen[id] {
+			return serviceerrors.ValidationError("Each appointment can only be listed once.")
+		}
+		seen[id] = true
+	}
+	return nil
+}
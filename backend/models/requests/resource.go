@@ -0,0 +1,52 @@
+package requests
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/utils"
+)
+
+// ResourceRequest creates or replaces a room or piece of equipment. Capacity defaults to one
+// booking at a time.
+type ResourceRequest struct {
+	Name        string `json:"name" validate:"required" example:"Studio A"`
+	Description string `json:"description,omitempty" example:"Mirrored room with sound system"`
+	Capacity    int    `json:"capacity,omitempty" validate:"gte=1" example:"1"`
+	// OrganizationID shares the resource with an organization the caller may manage
+	// appointments in, so its members' appointments can require it.
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+}
+
+func (req *ResourceRequest) Validate() error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Description = strings.TrimSpace(req.Description)
+	if req.Capacity == 0 {
+		req.Capacity = 1
+	}
+	if err := utils.Validate(req); err != nil {
+		return serviceerrors.UserError("Invalid resource data. A name and a capacity of at least 1 are required.")
+	}
+	return nil
+}
+
+// AppointmentResourcesRequest replaces the resources every booking on an appointment uses. An
+// empty list books the appointment without any.
+type AppointmentResourcesRequest struct {
+	ResourceIDs []uuid.UUID `json:"resource_ids"`
+}
+
+func (req *AppointmentResourcesRequest) Validate() error {
+	seen := make(map[uuid.UUID]bool, len(req.ResourceIDs))
+	for _, id := range req.ResourceIDs {
+		if id == uuid.Nil {
+			return serviceerrors.ValidationError("Each resource needs an ID.")
+		}
+		if seen[id] {
+			return serviceerrors.ValidationError("Each resource can only be listed once.")
+		}
+		seen[id] = true
+	}
+	return nil
+}
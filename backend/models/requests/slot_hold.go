@@ -0,0 +1,24 @@
+package requests
+
+import (
+	"time"
+
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/utils"
+)
+
+// SlotHoldRequest reserves seats on a slot while the booking form is filled in. The returned
+// token is passed back as BookingRequest.HoldToken.
+type SlotHoldRequest struct {
+	AppCode   string    `json:"app_code" validate:"required"`
+	Date      time.Time `json:"date" validate:"required"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	Seats     int       `json:"seats" validate:"gte=1" example:"2"`
+}
+
+func (req *SlotHoldRequest) Validate() error {
+	if err := utils.Validate(req); err != nil {
+		return serviceerrors.UserError("Invalid hold data. Please check your input.")
+	}
+	return nil
+}
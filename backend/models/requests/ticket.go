@@ -0,0 +1,18 @@
+package requests
+
+import (
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/utils"
+)
+
+// TicketScanRequest carries the payload read from a ticket's QR code.
+type TicketScanRequest struct {
+	Ticket string `json:"ticket" validate:"required" example:"BK26010214152222.Xb3v..."`
+}
+
+func (req *TicketScanRequest) Validate() error {
+	if err := utils.Validate(req); err != nil {
+		return serviceerrors.UserError("Invalid ticket data. Please check your input.")
+	}
+	return nil
+}
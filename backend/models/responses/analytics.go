@@ -10,6 +10,8 @@ type AnalyticsResponse struct {
 	TotalBookings     int       `json:"total_bookings"`
 	TotalCancellations int      `json:"total_cancellations,omitempty"`
 	CancellationRate   float64  `json:"cancellation_rate,omitempty"`    // percent 0-100
+	TotalNoShows       int      `json:"total_no_shows,omitempty"`
+	NoShowRate         float64  `json:"no_show_rate,omitempty"` // percent 0-100 of total bookings
 	AvgBookingsPerDay  float64  `json:"avg_bookings_per_day,omitempty"` // derived from total bookings / days in range
 	StartDate         time.Time `json:"start_date"`
 	EndDate           time.Time `json:"end_date"`
@@ -8,18 +8,50 @@ import (
 )
 
 type AppointmentResponse struct {
-	ID              uuid.UUID                  `json:"id"`
-	Title           string                     `json:"title"`
-	StartTime       time.Time                  `json:"start_time"`
-	EndTime         time.Time                  `json:"end_time"`
-	StartDate       time.Time                  `json:"start_date"`
-	EndDate         time.Time                  `json:"end_date"`
-	BookingDuration int                        `json:"booking_duration"`
-	Type            entities.AppointmentType   `json:"type"`
-	MaxAttendees    int                        `json:"max_attendees"`
-	AppCode         string                     `json:"app_code"`
-	Status          entities.AppointmentStatus `json:"status"`
-	CreatedAt       time.Time                  `json:"created_at"`
-	UpdatedAt       time.Time                  `json:"updated_at"`
-	Description     string                     `json:"description"`
+	ID                      uuid.UUID                   `json:"id"`
+	Title                   string                      `json:"title"`
+	StartTime               time.Time                   `json:"start_time"`
+	EndTime                 time.Time                   `json:"end_time"`
+	StartDate               time.Time                   `json:"start_date"`
+	EndDate                 time.Time                   `json:"end_date"`
+	BookingDuration         int                         `json:"booking_duration"`
+	Type                    entities.AppointmentType    `json:"type"`
+	MaxAttendees            int                         `json:"max_attendees"`
+	AppCode                 string                      `json:"app_code"`
+	Status                  entities.AppointmentStatus  `json:"status"`
+	CreatedAt               time.Time                   `json:"created_at"`
+	UpdatedAt               time.Time                   `json:"updated_at"`
+	Description             string                      `json:"description"`
+	RecurrenceRule          string                      `json:"recurrence_rule,omitempty"`
+	TimeZone                string                      `json:"time_zone"`
+	Availability            entities.WeeklyAvailability `json:"availability,omitempty" swaggertype:"object"`
+	PreBufferMinutes        int                         `json:"pre_buffer_minutes"`
+	PostBufferMinutes       int                         `json:"post_buffer_minutes"`
+	MinNoticeMinutes        int                         `json:"min_notice_minutes"`
+	MaxAdvanceDays          int                         `json:"max_advance_days"`
+	CancellationCutoffHours int                         `json:"cancellation_cutoff_hours"`
+	RescheduleCutoffHours   int                         `json:"reschedule_cutoff_hours"`
+	MaxReschedules          int                         `json:"max_reschedules"`
+	FormFields              entities.BookingForm        `json:"form_fields,omitempty"`
+	PublishAt               *time.Time                  `json:"publish_at,omitempty"`
+	NoShowGraceMinutes      int                         `json:"no_show_grace_minutes"`
+	ReminderOffsets         entities.ReminderOffsets    `json:"reminder_offsets" swaggertype:"array,integer"`
+	AllowParallelBookings   bool                        `json:"allow_parallel_bookings"`
+	OrganizationID          *uuid.UUID                  `json:"organization_id,omitempty"`
+	HostAssignment          entities.HostAssignment     `json:"host_assignment"`
+}
+
+// AppointmentHostResponse is one of an appointment's hosts.
+type AppointmentHostResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Priority int       `json:"priority"`
+}
+
+// AppointmentHostsResponse lists an appointment's hosts in priority order with the strategy used
+// to assign bookings to them.
+type AppointmentHostsResponse struct {
+	Assignment entities.HostAssignment   `json:"assignment"`
+	Hosts      []AppointmentHostResponse `json:"hosts"`
 }
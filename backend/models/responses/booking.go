@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/m13ha/asiko/models/entities"
 )
 
 type BookingResponse struct {
@@ -25,3 +26,33 @@ type BookingResponse struct {
 	UpdatedAt     time.Time  `json:"updated_at"`
 	Description   string     `json:"description"`
 }
+
+// AvailableSlotResponse is a bookable slot. The embedded booking carries UTC instants; the
+// local fields repeat them in the appointment's time zone.
+type AvailableSlotResponse struct {
+	entities.Booking
+	TimeZone       string    `json:"time_zone"`
+	LocalDate      string    `json:"local_date" example:"2026-03-09"`
+	LocalStartTime time.Time `json:"local_start_time"`
+	LocalEndTime   time.Time `json:"local_end_time"`
+}
+
+// AvailableDateResponse is a local calendar day with at least one open slot, along with the
+// UTC instants that bound that day in the appointment's time zone.
+type AvailableDateResponse struct {
+	Date     string    `json:"date" example:"2026-03-09"`
+	TimeZone string    `json:"time_zone"`
+	StartUTC time.Time `json:"start_utc"`
+	EndUTC   time.Time `json:"end_utc"`
+}
+
+// PaymentIntentResponse is a payment the guest completes with the provider using ClientSecret.
+// The booking stays pending until the provider reports the payment through its webhook.
+type PaymentIntentResponse struct {
+	BookingCode  string `json:"booking_code"`
+	Provider     string `json:"provider" example:"fake"`
+	IntentID     string `json:"intent_id"`
+	ClientSecret string `json:"client_secret"`
+	Amount       int64  `json:"amount" example:"2500"`
+	Currency     string `json:"currency" example:"USD"`
+}
@@ -0,0 +1,17 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m13ha/asiko/models/entities"
+)
+
+// OrganizationMemberResponse is a member of an organization with their role.
+type OrganizationMemberResponse struct {
+	UserID    uuid.UUID                 `json:"user_id"`
+	Name      string                    `json:"name"`
+	Email     string                    `json:"email"`
+	Role      entities.OrganizationRole `json:"role"`
+	CreatedAt time.Time                 `json:"created_at"`
+}
@@ -14,3 +14,9 @@ type UserResponse struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
+
+// CalendarFeedResponse is the owner's read-only booking calendar subscription.
+type CalendarFeedResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url" example:"https://api.example.com/calendar/feeds/3q2-7wE....ics"`
+}
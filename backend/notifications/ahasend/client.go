@@ -24,12 +24,24 @@ type Address struct {
 	Name  string `json:"name,omitempty"`
 }
 
+// Attachment is a file sent with a message. Inline attachments are referenced from the HTML body
+// as cid:<ContentID>.
+type Attachment struct {
+	Data               string `json:"data"`
+	Base64             bool   `json:"base64"`
+	ContentType        string `json:"content_type"`
+	ContentDisposition string `json:"content_disposition,omitempty"`
+	ContentID          string `json:"content_id,omitempty"`
+	FileName           string `json:"file_name"`
+}
+
 type MessageRequest struct {
-	From        Address   `json:"from"`
-	Recipients  []Address `json:"recipients"`
-	Subject     string    `json:"subject"`
-	TextContent string    `json:"text_content,omitempty"`
-	HTMLContent string    `json:"html_content,omitempty"`
+	From        Address      `json:"from"`
+	Recipients  []Address    `json:"recipients"`
+	Subject     string       `json:"subject"`
+	TextContent string       `json:"text_content,omitempty"`
+	HTMLContent string       `json:"html_content,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
 }
 
 func NewClient(config Config) *Client {
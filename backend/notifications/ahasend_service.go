@@ -2,7 +2,9 @@ package notifications
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"html/template"
 	"os"
 	"strconv"
 	"strings"
@@ -31,6 +33,8 @@ var emailTemplates = map[string]emailTemplate{
 	"booking.cancellation": {subject: "Booking Cancellation", templatePath: "templates/booking_cancelled.html"},
 	"booking.rejection":    {subject: "Booking Rejected", templatePath: "templates/booking_rejected.html"},
 	"booking.updated":      {subject: "Booking Updated", templatePath: "templates/booking_updated.html"},
+	"booking.promoted":     {subject: "You're Off the Waitlist", templatePath: "templates/booking_promoted.html"},
+	"booking.reminder":     {subject: "Booking Reminder", templatePath: "templates/booking_reminder.html"},
 	"appointment.created":  {subject: "Appointment Created", templatePath: "templates/appointment_created.html"},
 	"appointment.updated":  {subject: "Appointment Updated", templatePath: "templates/appointment_updated.html"},
 	"appointment.deleted":  {subject: "Appointment Deleted", templatePath: "templates/appointment_deleted.html"},
@@ -82,20 +86,66 @@ func NewAhaSendService(config ahasend.Config, fromEmail, fromName string) (*AhaS
 	return &AhaSendService{publisher: publisher, fromEmail: fromEmail, fromName: fromName, enabled: config.Enabled}, nil
 }
 
-func (s *AhaSendService) sendTemplate(kind, toEmail, toName string, data interface{}) error {
+func (s *AhaSendService) sendTemplate(kind, toEmail, toName string, data interface{}, attachments ...ahasend.Attachment) error {
 	cfg, ok := emailTemplates[kind]
 	if !ok {
 		return fmt.Errorf("ahasend: unknown email template %q", kind)
 	}
-	return s.sendEmail(toEmail, toName, cfg.subject, cfg.templatePath, data)
+	return s.sendEmail(toEmail, toName, cfg.subject, cfg.templatePath, data, attachments...)
 }
 
+// ticketContentID names the inline QR code image in booking confirmation emails.
+const ticketContentID = "ticket-qr"
+
+// bookingTicketData extends a booking with the image source of its ticket QR code for templates.
+type bookingTicketData struct {
+	*entities.Booking
+	TicketImage template.URL
+}
+
+// SendBookingConfirmation includes the booking's ticket as an inline QR code to show at check-in.
 func (s *AhaSendService) SendBookingConfirmation(booking *entities.Booking) error {
-	return s.sendTemplate("booking.confirmation", booking.Email, booking.Name, booking)
+	data := bookingTicketData{Booking: booking}
+	var attachments []ahasend.Attachment
+	if ticket, err := utils.SignTicket(booking.BookingCode); err != nil {
+		log.Error().Err(err).Str("booking_code", booking.BookingCode).Msg("ahasend: ticket not signed")
+	} else if png, err := utils.TicketQRCode(ticket); err != nil {
+		log.Error().Err(err).Str("booking_code", booking.BookingCode).Msg("ahasend: ticket render failed")
+	} else {
+		data.TicketImage = template.URL("cid:" + ticketContentID)
+		attachments = append(attachments, ahasend.Attachment{
+			Data:               base64.StdEncoding.EncodeToString(png),
+			Base64:             true,
+			ContentType:        "image/png",
+			ContentDisposition: "inline",
+			ContentID:          ticketContentID,
+			FileName:           booking.BookingCode + "-ticket.png",
+		})
+	}
+	attachments = append(attachments, s.calendarAttachment(booking, utils.ICalMethodRequest))
+	return s.sendTemplate("booking.confirmation", booking.Email, booking.Name, data, attachments...)
 }
 
 func (s *AhaSendService) SendBookingCancellation(booking *entities.Booking) error {
-	return s.sendTemplate("booking.cancellation", booking.Email, booking.Name, booking)
+	return s.sendTemplate("booking.cancellation", booking.Email, booking.Name, booking, s.calendarAttachment(booking, utils.ICalMethodCancel))
+}
+
+// calendarAttachment carries the booking as an iTIP invitation or cancellation, organized by the
+// sender address, so attendees can add or remove it in their calendar in one click.
+func (s *AhaSendService) calendarAttachment(booking *entities.Booking, method string) ahasend.Attachment {
+	event := booking.CalendarEvent(booking.Appointment.Title)
+	event.Organizer = &utils.ICalAddress{Email: s.fromEmail, Name: s.fromName}
+	if method == utils.ICalMethodCancel && event.Status != utils.ICalStatusCancelled {
+		event.Status = utils.ICalStatusCancelled
+		event.Sequence++
+	}
+	ics := utils.ICalendar{Method: method, Events: []utils.ICalEvent{event}}.Encode()
+	return ahasend.Attachment{
+		Data:        base64.StdEncoding.EncodeToString(ics),
+		Base64:      true,
+		ContentType: "text/calendar; charset=utf-8; method=" + method,
+		FileName:    "invite.ics",
+	}
 }
 
 func (s *AhaSendService) SendBookingRejection(booking *entities.Booking) error {
@@ -103,7 +153,16 @@ func (s *AhaSendService) SendBookingRejection(booking *entities.Booking) error {
 }
 
 func (s *AhaSendService) SendBookingUpdated(booking *entities.Booking) error {
-	return s.sendTemplate("booking.updated", booking.Email, booking.Name, booking)
+	return s.sendTemplate("booking.updated", booking.Email, booking.Name, booking, s.calendarAttachment(booking, utils.ICalMethodRequest))
+}
+
+func (s *AhaSendService) SendBookingPromoted(booking *entities.Booking) error {
+	return s.sendTemplate("booking.promoted", booking.Email, booking.Name, booking)
+}
+
+// SendBookingReminder expects booking.Appointment to be loaded for its title.
+func (s *AhaSendService) SendBookingReminder(booking *entities.Booking) error {
+	return s.sendTemplate("booking.reminder", booking.Email, booking.Name, booking)
 }
 
 func (s *AhaSendService) SendAppointmentCreated(appointment *entities.Appointment, recipientEmail, recipientName string) error {
@@ -126,7 +185,7 @@ func (s *AhaSendService) SendPasswordResetEmail(email, code string) error {
 	return s.sendTemplate("auth.reset", email, "", map[string]string{"Code": code})
 }
 
-func (s *AhaSendService) sendEmail(toEmail, toName, subject, templatePath string, data interface{}) error {
+func (s *AhaSendService) sendEmail(toEmail, toName, subject, templatePath string, data interface{}, attachments ...ahasend.Attachment) error {
 	if s.publisher == nil || !s.enabled {
 		return fmt.Errorf("ahasend: service not configured")
 	}
@@ -154,6 +213,7 @@ func (s *AhaSendService) sendEmail(toEmail, toName, subject, templatePath string
 		Subject:     subject,
 		TextContent: subject,
 		HTMLContent: htmlContent,
+		Attachments: attachments,
 	}
 
 	return s.publisher.Publish(context.Background(), message)
@@ -8,6 +8,8 @@ type NotificationService interface {
 	SendBookingCancellation(booking *entities.Booking) error
 	SendBookingRejection(booking *entities.Booking) error
 	SendBookingUpdated(booking *entities.Booking) error
+	SendBookingPromoted(booking *entities.Booking) error
+	SendBookingReminder(booking *entities.Booking) error
 	SendAppointmentCreated(appointment *entities.Appointment, recipientEmail, recipientName string) error
 	SendAppointmentUpdated(appointment *entities.Appointment, recipientEmail, recipientName string) error
 	SendAppointmentDeleted(appointment *entities.Appointment, recipientEmail, recipientName string) error
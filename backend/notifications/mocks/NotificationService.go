@@ -84,6 +84,42 @@ func (_m *NotificationService) SendBookingUpdated(booking *entities.Booking) err
 	return r0
 }
 
+// SendBookingPromoted provides a mock function with given fields: booking
+func (_m *NotificationService) SendBookingPromoted(booking *entities.Booking) error {
+	ret := _m.Called(booking)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendBookingPromoted")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.Booking) error); ok {
+		r0 = rf(booking)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendBookingReminder provides a mock function with given fields: booking
+func (_m *NotificationService) SendBookingReminder(booking *entities.Booking) error {
+	ret := _m.Called(booking)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendBookingReminder")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.Booking) error); ok {
+		r0 = rf(booking)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendAppointmentCreated provides a mock function with given fields: appointment, recipientEmail, recipientName
 func (_m *NotificationService) SendAppointmentCreated(appointment *entities.Appointment, recipientEmail string, recipientName string) error {
 	ret := _m.Called(appointment, recipientEmail, recipientName)
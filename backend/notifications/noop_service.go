@@ -33,6 +33,16 @@ func (s *NoopService) SendBookingUpdated(booking *entities.Booking) error {
 	return nil
 }
 
+func (s *NoopService) SendBookingPromoted(booking *entities.Booking) error {
+	log.Printf("notifications: noop booking promoted for %s", booking.BookingCode)
+	return nil
+}
+
+func (s *NoopService) SendBookingReminder(booking *entities.Booking) error {
+	log.Printf("notifications: noop booking reminder for %s", booking.BookingCode)
+	return nil
+}
+
 func (s *NoopService) SendAppointmentCreated(appointment *entities.Appointment, recipientEmail, recipientName string) error {
 	log.Printf("notifications: noop appointment created for %s", appointment.Title)
 	return nil
@@ -2,6 +2,7 @@ package notifications
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
 
@@ -10,7 +11,12 @@ import (
 	"github.com/m13ha/asiko/repository"
 )
 
-// RegisterHandlers subscribes the notification service to relevant events.
+// emailSubscriber names the email handlers on the event bus. The outbox records it on the events
+// they handled, so it must not change.
+const emailSubscriber = "email"
+
+// RegisterHandlers subscribes the notification service to relevant events. Emails are sent on the
+// event bus's workers, and a failed send is returned so the bus retries it.
 func RegisterHandlers(bus events.EventBus, svc NotificationService, bookingRepo repository.BookingRepository) {
 	handlers := map[string]func(events.Event) error{
 		events.EventBookingCreated: func(event events.Event) error {
@@ -21,16 +27,11 @@ func RegisterHandlers(bus events.EventBus, svc NotificationService, bookingRepo
 			if strings.ToLower(p.Booking.Status) == entities.BookingStatusPending {
 				return nil
 			}
-			go func() {
-				if err := svc.SendBookingConfirmation(p.Booking); err != nil {
-					log.Printf("Failed to send booking confirmation: %v", err)
-					if bookingRepo != nil {
-						bookingRepo.UpdateNotificationStatus(p.Booking.ID, "failed", "email")
-					}
-				} else if bookingRepo != nil {
-					bookingRepo.UpdateNotificationStatus(p.Booking.ID, "sent", "email")
-				}
-			}()
+			err := svc.SendBookingConfirmation(withAppointmentTitle(p))
+			recordEmailStatus(bookingRepo, p.Booking, err)
+			if err != nil {
+				return fmt.Errorf("send booking confirmation: %w", err)
+			}
 			return nil
 		},
 		events.EventBookingCancelled: func(event events.Event) error {
@@ -38,16 +39,11 @@ func RegisterHandlers(bus events.EventBus, svc NotificationService, bookingRepo
 			if !ok || p.Booking == nil {
 				return nil
 			}
-			go func() {
-				if err := svc.SendBookingCancellation(p.Booking); err != nil {
-					log.Printf("Failed to send booking cancellation: %v", err)
-					if bookingRepo != nil {
-						bookingRepo.UpdateNotificationStatus(p.Booking.ID, "failed", "email")
-					}
-				} else if bookingRepo != nil {
-					bookingRepo.UpdateNotificationStatus(p.Booking.ID, "sent", "email")
-				}
-			}()
+			err := svc.SendBookingCancellation(withAppointmentTitle(p))
+			recordEmailStatus(bookingRepo, p.Booking, err)
+			if err != nil {
+				return fmt.Errorf("send booking cancellation: %w", err)
+			}
 			return nil
 		},
 		events.EventBookingRejected: func(event events.Event) error {
@@ -55,16 +51,11 @@ func RegisterHandlers(bus events.EventBus, svc NotificationService, bookingRepo
 			if !ok || p.Booking == nil {
 				return nil
 			}
-			go func() {
-				if err := svc.SendBookingRejection(p.Booking); err != nil {
-					log.Printf("Failed to send booking rejection: %v", err)
-					if bookingRepo != nil {
-						bookingRepo.UpdateNotificationStatus(p.Booking.ID, "failed", "email")
-					}
-				} else if bookingRepo != nil {
-					bookingRepo.UpdateNotificationStatus(p.Booking.ID, "sent", "email")
-				}
-			}()
+			err := svc.SendBookingRejection(p.Booking)
+			recordEmailStatus(bookingRepo, p.Booking, err)
+			if err != nil {
+				return fmt.Errorf("send booking rejection: %w", err)
+			}
 			return nil
 		},
 		events.EventBookingUpdated: func(event events.Event) error {
@@ -72,16 +63,23 @@ func RegisterHandlers(bus events.EventBus, svc NotificationService, bookingRepo
 			if !ok || p.Booking == nil {
 				return nil
 			}
-			go func() {
-				if err := svc.SendBookingUpdated(p.Booking); err != nil {
-					log.Printf("Failed to send booking updated: %v", err)
-					if bookingRepo != nil {
-						bookingRepo.UpdateNotificationStatus(p.Booking.ID, "failed", "email")
-					}
-				} else if bookingRepo != nil {
-					bookingRepo.UpdateNotificationStatus(p.Booking.ID, "sent", "email")
-				}
-			}()
+			err := svc.SendBookingUpdated(withAppointmentTitle(p))
+			recordEmailStatus(bookingRepo, p.Booking, err)
+			if err != nil {
+				return fmt.Errorf("send booking updated: %w", err)
+			}
+			return nil
+		},
+		events.EventBookingPromoted: func(event events.Event) error {
+			p, ok := event.Data.(events.BookingEventData)
+			if !ok || p.Booking == nil {
+				return nil
+			}
+			err := svc.SendBookingPromoted(p.Booking)
+			recordEmailStatus(bookingRepo, p.Booking, err)
+			if err != nil {
+				return fmt.Errorf("send booking promoted: %w", err)
+			}
 			return nil
 		},
 		events.EventBookingConfirmed: func(event events.Event) error {
@@ -89,16 +87,11 @@ func RegisterHandlers(bus events.EventBus, svc NotificationService, bookingRepo
 			if !ok || p.Booking == nil {
 				return nil
 			}
-			go func() {
-				if err := svc.SendBookingConfirmation(p.Booking); err != nil {
-					log.Printf("Failed to send booking confirmation: %v", err)
-					if bookingRepo != nil {
-						bookingRepo.UpdateNotificationStatus(p.Booking.ID, "failed", "email")
-					}
-				} else if bookingRepo != nil {
-					bookingRepo.UpdateNotificationStatus(p.Booking.ID, "sent", "email")
-				}
-			}()
+			err := svc.SendBookingConfirmation(withAppointmentTitle(p))
+			recordEmailStatus(bookingRepo, p.Booking, err)
+			if err != nil {
+				return fmt.Errorf("send booking confirmation: %w", err)
+			}
 			return nil
 		},
 		events.EventAppointmentCreated: func(event events.Event) error {
@@ -110,11 +103,9 @@ func RegisterHandlers(bus events.EventBus, svc NotificationService, bookingRepo
 				log.Printf("Skipped appointment created email: missing recipient for %s", p.Appointment.ID)
 				return nil
 			}
-			go func() {
-				if err := svc.SendAppointmentCreated(p.Appointment, p.RecipientEmail, p.RecipientName); err != nil {
-					log.Printf("Failed to send appointment created: %v", err)
-				}
-			}()
+			if err := svc.SendAppointmentCreated(p.Appointment, p.RecipientEmail, p.RecipientName); err != nil {
+				return fmt.Errorf("send appointment created: %w", err)
+			}
 			return nil
 		},
 		events.EventAppointmentUpdated: func(event events.Event) error {
@@ -126,11 +117,9 @@ func RegisterHandlers(bus events.EventBus, svc NotificationService, bookingRepo
 				log.Printf("Skipped appointment updated email: missing recipient for %s", p.Appointment.ID)
 				return nil
 			}
-			go func() {
-				if err := svc.SendAppointmentUpdated(p.Appointment, p.RecipientEmail, p.RecipientName); err != nil {
-					log.Printf("Failed to send appointment updated: %v", err)
-				}
-			}()
+			if err := svc.SendAppointmentUpdated(p.Appointment, p.RecipientEmail, p.RecipientName); err != nil {
+				return fmt.Errorf("send appointment updated: %w", err)
+			}
 			return nil
 		},
 		events.EventAppointmentDeleted: func(event events.Event) error {
@@ -142,20 +131,40 @@ func RegisterHandlers(bus events.EventBus, svc NotificationService, bookingRepo
 				log.Printf("Skipped appointment deleted email: missing recipient for %s", p.Appointment.ID)
 				return nil
 			}
-			go func() {
-				if err := svc.SendAppointmentDeleted(p.Appointment, p.RecipientEmail, p.RecipientName); err != nil {
-					log.Printf("Failed to send appointment deleted: %v", err)
-				}
-			}()
+			if err := svc.SendAppointmentDeleted(p.Appointment, p.RecipientEmail, p.RecipientName); err != nil {
+				return fmt.Errorf("send appointment deleted: %w", err)
+			}
 			return nil
 		},
 	}
 
-	bus.Subscribe(func(ctx context.Context, event events.Event) error {
-		handler, ok := handlers[event.Name]
-		if !ok {
-			return nil
-		}
-		return handler(event)
-	})
+	for name, handler := range handlers {
+		bus.SubscribeTo(name, emailSubscriber, func(ctx context.Context, event events.Event) error {
+			return handler(event)
+		})
+	}
+}
+
+// recordEmailStatus records whether the booking's email went out. A failed email is retried by
+// the event bus, which records it again.
+func recordEmailStatus(bookingRepo repository.BookingRepository, booking *entities.Booking, err error) {
+	if bookingRepo == nil {
+		return
+	}
+	status := "sent"
+	if err != nil {
+		status = "failed"
+	}
+	bookingRepo.UpdateNotificationStatus(booking.ID, status, "email")
+}
+
+// withAppointmentTitle returns the event's booking with its appointment title filled in for
+// templates and calendar attachments, leaving the published booking untouched.
+func withAppointmentTitle(p events.BookingEventData) *entities.Booking {
+	if p.Booking.Appointment.Title != "" || p.AppointmentTitle == "" {
+		return p.Booking
+	}
+	booking := *p.Booking
+	booking.Appointment.Title = p.AppointmentTitle
+	return &booking
 }
@@ -0,0 +1,36 @@
+package payments
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	ProviderFake = "fake"
+)
+
+// NewPaymentProviderFromEnv selects a payment provider based on PAYMENT_PROVIDER, defaulting to
+// the fake provider when unset. PAYMENT_WEBHOOK_SECRET signs the provider's webhooks and is
+// required, since webhooks mark bookings paid. The fake provider accepts every payment, so it is
+// refused when ENV is production.
+func NewPaymentProviderFromEnv() (PaymentProvider, error) {
+	provider := strings.ToLower(strings.TrimSpace(os.Getenv("PAYMENT_PROVIDER")))
+	if provider == "" {
+		provider = ProviderFake
+	}
+	secret := strings.TrimSpace(os.Getenv("PAYMENT_WEBHOOK_SECRET"))
+	if secret == "" {
+		return nil, fmt.Errorf("PAYMENT_WEBHOOK_SECRET is not set")
+	}
+
+	switch provider {
+	case ProviderFake:
+		if os.Getenv("ENV") == "production" {
+			return nil, fmt.Errorf("the %s payment provider cannot be used in production", ProviderFake)
+		}
+		return NewFakeProvider(secret), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment provider: %s", provider)
+	}
+}
@@ -0,0 +1,36 @@
+package payments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPaymentProviderFromEnv(t *testing.T) {
+	t.Run("fake provider outside production", func(t *testing.T) {
+		t.Setenv("ENV", "development")
+		t.Setenv("PAYMENT_PROVIDER", "")
+		t.Setenv("PAYMENT_WEBHOOK_SECRET", "whsec_test")
+
+		provider, err := NewPaymentProviderFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, ProviderFake, provider.Name())
+	})
+
+	for name, env := range map[string]map[string]string{
+		"missing webhook secret": {"ENV": "development", "PAYMENT_PROVIDER": "fake", "PAYMENT_WEBHOOK_SECRET": ""},
+		"unknown provider":       {"ENV": "development", "PAYMENT_PROVIDER": "stripe", "PAYMENT_WEBHOOK_SECRET": "whsec_test"},
+		"fake in production":     {"ENV": "production", "PAYMENT_PROVIDER": "", "PAYMENT_WEBHOOK_SECRET": "whsec_test"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			provider, err := NewPaymentProviderFromEnv()
+			assert.Error(t, err)
+			assert.Nil(t, provider)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// FakeSignatureHeader carries the hex HMAC-SHA256 of a fake provider webhook body.
+const FakeSignatureHeader = "X-Fake-Signature"
+
+// FakeProvider is an in-process provider for local development and tests. It accepts every
+// payment and refund, and trusts webhooks signed with its secret, so a payment is completed by
+// posting {"type":"payment.succeeded","intent_id":"..."} with a signature from Sign.
+type FakeProvider struct {
+	secret []byte
+
+	mu      sync.Mutex
+	refunds map[string]string
+}
+
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{secret: []byte(secret), refunds: make(map[string]string)}
+}
+
+func (p *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+func (p *FakeProvider) CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("payment amount must be positive")
+	}
+	id := "fake_pi_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	log.Printf("payments: fake intent %s for %s (%d %s)", id, req.Reference, req.Amount, req.Currency)
+	return &Intent{ID: id, ClientSecret: id + "_secret"}, nil
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if id, ok := p.refunds[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		return &Refund{ID: id}, nil
+	}
+	id := "fake_re_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if req.IdempotencyKey != "" {
+		p.refunds[req.IdempotencyKey] = id
+	}
+	log.Printf("payments: fake refund %s of %s (%d %s)", id, req.IntentID, req.Amount, req.Currency)
+	return &Refund{ID: id}, nil
+}
+
+func (p *FakeProvider) ParseWebhook(payload []byte, header http.Header) (*WebhookEvent, error) {
+	if len(p.secret) == 0 {
+		// Anyone can compute an HMAC with an empty key.
+		return nil, fmt.Errorf("webhook secret is not configured")
+	}
+	signature, err := hex.DecodeString(header.Get(FakeSignatureHeader))
+	if err != nil || !hmac.Equal(signature, p.mac(payload)) {
+		return nil, fmt.Errorf("invalid webhook signature")
+	}
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("malformed webhook: %w", err)
+	}
+	if event.IntentID == "" {
+		return nil, fmt.Errorf("webhook has no intent_id")
+	}
+	return &event, nil
+}
+
+// Sign returns the FakeSignatureHeader value for a webhook body.
+func (p *FakeProvider) Sign(payload []byte) string {
+	return hex.EncodeToString(p.mac(payload))
+}
+
+func (p *FakeProvider) mac(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,55 @@
+package payments
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeProviderWebhookSignature(t *testing.T) {
+	provider := NewFakeProvider("whsec_test")
+	payload := []byte(`{"type":"payment.succeeded","intent_id":"fake_pi_1"}`)
+
+	header := http.Header{}
+	header.Set(FakeSignatureHeader, provider.Sign(payload))
+	event, err := provider.ParseWebhook(payload, header)
+	require.NoError(t, err)
+	assert.Equal(t, EventPaymentSucceeded, event.Type)
+	assert.Equal(t, "fake_pi_1", event.IntentID)
+
+	header.Set(FakeSignatureHeader, NewFakeProvider("other").Sign(payload))
+	_, err = provider.ParseWebhook(payload, header)
+	assert.EqualError(t, err, "invalid webhook signature")
+
+	_, err = provider.ParseWebhook(payload, http.Header{})
+	assert.Error(t, err)
+
+	// An empty secret would let anyone sign webhooks.
+	unkeyed := NewFakeProvider("")
+	header.Set(FakeSignatureHeader, unkeyed.Sign(payload))
+	_, err = unkeyed.ParseWebhook(payload, header)
+	assert.EqualError(t, err, "webhook secret is not configured")
+}
+
+func TestFakeProviderIntents(t *testing.T) {
+	provider := NewFakeProvider("whsec_test")
+
+	intent, err := provider.CreateIntent(context.Background(), IntentRequest{Reference: "BK1", Amount: 2500, Currency: "USD"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, intent.ID)
+	assert.NotEmpty(t, intent.ClientSecret)
+
+	_, err = provider.CreateIntent(context.Background(), IntentRequest{Reference: "BK1", Currency: "USD"})
+	assert.Error(t, err)
+
+	refund, err := provider.Refund(context.Background(), RefundRequest{IntentID: intent.ID, Amount: 2500, Currency: "USD", IdempotencyKey: "refund_1"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, refund.ID)
+
+	retried, err := provider.Refund(context.Background(), RefundRequest{IntentID: intent.ID, Amount: 2500, Currency: "USD", IdempotencyKey: "refund_1"})
+	require.NoError(t, err)
+	assert.Equal(t, refund.ID, retried.ID, "a retried refund must not be issued twice")
+}
@@ -0,0 +1,61 @@
+package payments
+
+import (
+	"context"
+	"net/http"
+)
+
+// Webhook event types reported by providers.
+const (
+	EventPaymentSucceeded = "payment.succeeded"
+	EventPaymentFailed    = "payment.failed"
+)
+
+// PaymentProvider takes payments for bookings. Amounts are in minor units of an ISO 4217
+// currency, e.g. cents for USD.
+type PaymentProvider interface {
+	// Name identifies the provider on stored payments.
+	Name() string
+	// CreateIntent starts a payment the guest completes with the returned client secret.
+	CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error)
+	// Refund returns a completed payment in full.
+	Refund(ctx context.Context, req RefundRequest) (*Refund, error)
+	// ParseWebhook verifies a webhook's signature and decodes the event it carries.
+	ParseWebhook(payload []byte, header http.Header) (*WebhookEvent, error)
+}
+
+// IntentRequest describes a payment to start. Reference is the booking code.
+type IntentRequest struct {
+	Reference   string
+	Amount      int64
+	Currency    string
+	Email       string
+	Description string
+}
+
+// Intent is a payment waiting for the guest to complete it.
+type Intent struct {
+	ID           string
+	ClientSecret string
+}
+
+// RefundRequest refunds Amount of the payment made through IntentID. Providers return the
+// refund already issued for an IdempotencyKey instead of refunding again, so a request may be
+// retried safely.
+type RefundRequest struct {
+	IntentID       string
+	Amount         int64
+	Currency       string
+	IdempotencyKey string
+}
+
+// Refund is a refund issued by the provider.
+type Refund struct {
+	ID string
+}
+
+// WebhookEvent reports a change to a payment intent.
+type WebhookEvent struct {
+	Type     string `json:"type"`
+	IntentID string `json:"intent_id"`
+}
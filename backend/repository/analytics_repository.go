@@ -15,6 +15,7 @@ type AnalyticsRepository interface {
 	GetBookingsPerDay(userID uuid.UUID, startDate, endDate time.Time) ([]DateCount, error)
 	GetUserCancellationCount(userID uuid.UUID, startDate, endDate time.Time) (int64, error)
 	GetCancellationsPerDay(userID uuid.UUID, startDate, endDate time.Time) ([]DateCount, error)
+	GetUserNoShowCount(userID uuid.UUID, startDate, endDate time.Time) (int64, error)
 }
 
 type gormAnalyticsRepository struct {
@@ -59,12 +60,15 @@ func (r *gormAnalyticsRepository) GetUserBookingCount(userID uuid.UUID, startDat
 		JOIN appointments ON bookings.appointment_id = appointments.id
 		WHERE appointments.owner_id = ? 
 			AND (bookings.created_at BETWEEN ? AND ? OR bookings.date BETWEEN ? AND ?)
-			AND LOWER(bookings.status) IN (?, ?, ?, ?)
+			AND LOWER(bookings.status) IN (?, ?, ?, ?, ?, ?, ?)
 	`, userID, startDate, endDate, startDate, endDate,
 		entities.BookingStatusPending,
 		entities.BookingStatusOngoing,
 		entities.BookingStatusConfirmed,
 		entities.BookingStatusExpired,
+		entities.BookingStatusCheckedIn,
+		entities.BookingStatusAttended,
+		entities.BookingStatusNoShow,
 	).Scan(&res).Error
 
 	if err != nil {
@@ -107,7 +111,7 @@ func (r *gormAnalyticsRepository) GetBookingsPerDay(userID uuid.UUID, startDate,
 					ELSE bookings.created_at
 				END
 			) BETWEEN ? AND ?
-			AND LOWER(bookings.status) IN (?, ?, ?, ?)
+			AND LOWER(bookings.status) IN (?, ?, ?, ?, ?, ?, ?)
 		GROUP BY 1
 		ORDER BY 1
 	`, userID, startDate, endDate,
@@ -115,6 +119,9 @@ func (r *gormAnalyticsRepository) GetBookingsPerDay(userID uuid.UUID, startDate,
 		entities.BookingStatusOngoing,
 		entities.BookingStatusConfirmed,
 		entities.BookingStatusExpired,
+		entities.BookingStatusCheckedIn,
+		entities.BookingStatusAttended,
+		entities.BookingStatusNoShow,
 	).Scan(&rows).Error
 	if err != nil {
 		return nil, repoerrors.InternalError("failed to get bookings per day: " + err.Error())
@@ -177,3 +184,32 @@ func (r *gormAnalyticsRepository) GetCancellationsPerDay(userID uuid.UUID, start
 	}
 	return rows, nil
 }
+
+// GetUserNoShowCount counts attendees marked no-show for bookings dated within the range.
+func (r *gormAnalyticsRepository) GetUserNoShowCount(userID uuid.UUID, startDate, endDate time.Time) (int64, error) {
+	type result struct {
+		Total int64
+	}
+	var res result
+
+	err := r.db.Raw(`
+		SELECT COALESCE(SUM(
+			CASE
+				WHEN appointments.type = 'single' AND bookings.is_slot = TRUE THEN 1
+				WHEN bookings.is_slot = FALSE THEN bookings.attendee_count
+				ELSE 0
+			END
+		), 0) as total
+		FROM bookings
+		JOIN appointments ON bookings.appointment_id = appointments.id
+		WHERE appointments.owner_id = ?
+			AND bookings.date BETWEEN ? AND ?
+			AND bookings.status = ?
+	`, userID, startDate, endDate, entities.BookingStatusNoShow).Scan(&res).Error
+
+	if err != nil {
+		return 0, repoerrors.InternalError("failed to get user no-show count: " + err.Error())
+	}
+
+	return res.Total, nil
+}
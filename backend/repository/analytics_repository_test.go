@@ -93,3 +93,21 @@ func TestGetCancellationsPerDay(t *testing.T) {
 	assert.Len(t, rows, 2)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGetUserNoShowCount(t *testing.T) {
+	gdb, mock := setupMockDB(t)
+	repo := NewGormAnalyticsRepository(gdb)
+
+	userID := uuid.New()
+	start := time.Now().Add(-24 * time.Hour)
+	end := time.Now()
+
+	mock.ExpectQuery(`(?s)SELECT.*FROM bookings.*JOIN appointments.*bookings\.date BETWEEN.*bookings\.status = \$4`).
+		WithArgs(userID, start, end, "no_show").
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(int64(2)))
+
+	count, err := repo.GetUserNoShowCount(userID, start, end)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
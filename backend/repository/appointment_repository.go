@@ -19,11 +19,18 @@ type AppointmentRepository interface {
 	GetAppointmentsByOwnerIDQuery(ctx context.Context, req *http.Request, ownerID uuid.UUID, statuses []entities.AppointmentStatus) paginate.Page
 	FindAppointmentByAppCode(appCode string) (*entities.Appointment, error)
 	FindAndLock(appCode string, tx *gorm.DB) (*entities.Appointment, error)
-	FindByIDAndOwner(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (*entities.Appointment, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Appointment, error)
 	Update(appointment *entities.Appointment) error
 	UpdateStatus(ctx context.Context, appointmentID uuid.UUID, status entities.AppointmentStatus) error
+	PublishScheduledAppointments(ctx context.Context, now time.Time) (int64, error)
 	MarkAppointmentsOngoing(ctx context.Context, now time.Time) (int64, error)
 	MarkAppointmentsCompleted(ctx context.Context, now time.Time) (int64, error)
+	ListHosts(appointmentID uuid.UUID) ([]entities.AppointmentHost, error)
+	ReplaceHosts(appointmentID uuid.UUID, hosts []entities.AppointmentHost) error
+	SetLastHost(appointmentID uuid.UUID, hostID uuid.UUID) error
+	ListResources(appointmentID uuid.UUID) ([]entities.Resource, error)
+	ReplaceResources(appointmentID uuid.UUID, resourceIDs []uuid.UUID) error
+	FindAndLockResources(appointmentID uuid.UUID) ([]entities.Resource, error)
 	WithTx(tx *gorm.DB) AppointmentRepository
 }
 
@@ -46,9 +53,14 @@ func (r *gormAppointmentRepository) Create(appointment *entities.Appointment) er
 	return nil
 }
 
+// GetAppointmentsByOwnerIDQuery pages through the appointments the user created or that are shared
+// with an organization they belong to.
 func (r *gormAppointmentRepository) GetAppointmentsByOwnerIDQuery(ctx context.Context, req *http.Request, ownerID uuid.UUID, statuses []entities.AppointmentStatus) paginate.Page {
 	pg := paginate.New()
-	db := r.db.WithContext(ctx).Model(&entities.Appointment{}).Where("owner_id = ?", ownerID).Order("created_at DESC")
+	memberOf := r.db.Model(&entities.OrganizationMember{}).Select("organization_id").Where("user_id = ?", ownerID)
+	db := r.db.WithContext(ctx).Model(&entities.Appointment{}).
+		Where("owner_id = ? OR organization_id IN (?)", ownerID, memberOf).
+		Order("created_at DESC")
 	if len(statuses) > 0 {
 		values := make([]string, 0, len(statuses))
 		for _, status := range statuses {
@@ -87,13 +99,13 @@ func (r *gormAppointmentRepository) FindAndLock(appCode string, tx *gorm.DB) (*e
 	return &appointment, nil
 }
 
-func (r *gormAppointmentRepository) FindByIDAndOwner(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (*entities.Appointment, error) {
+func (r *gormAppointmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Appointment, error) {
 	var appointment entities.Appointment
-	if err := r.db.WithContext(ctx).Where("id = ? AND owner_id = ?", id, ownerID).First(&appointment).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&appointment).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, repoerrors.NotFoundError("appointment not found or not owned by user")
+			return nil, repoerrors.NotFoundError("appointment not found")
 		}
-		return nil, repoerrors.InternalError("failed to find appointment by id and owner: " + err.Error())
+		return nil, repoerrors.InternalError("failed to find appointment by id: " + err.Error())
 	}
 	return &appointment, nil
 }
@@ -121,6 +133,21 @@ func (r *gormAppointmentRepository) UpdateStatus(ctx context.Context, appointmen
 	return nil
 }
 
+// PublishScheduledAppointments publishes drafts whose PublishAt has passed.
+func (r *gormAppointmentRepository) PublishScheduledAppointments(ctx context.Context, now time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&entities.Appointment{}).
+		Where("status = ?", entities.AppointmentStatusDraft).
+		Where("publish_at IS NOT NULL AND publish_at <= ?", now).
+		Updates(map[string]interface{}{
+			"status":     entities.AppointmentStatusPending,
+			"updated_at": now,
+		})
+	if res.Error != nil {
+		return 0, repoerrors.InternalError("failed to publish scheduled appointments: " + res.Error.Error())
+	}
+	return res.RowsAffected, nil
+}
+
 func (r *gormAppointmentRepository) MarkAppointmentsOngoing(ctx context.Context, now time.Time) (int64, error) {
 	res := r.db.WithContext(ctx).Model(&entities.Appointment{}).
 		Where("status = ?", entities.AppointmentStatusPending).
@@ -138,7 +165,7 @@ func (r *gormAppointmentRepository) MarkAppointmentsOngoing(ctx context.Context,
 func (r *gormAppointmentRepository) MarkAppointmentsCompleted(ctx context.Context, now time.Time) (int64, error) {
 	deadlineExpr := "date_trunc('day', end_date) + (end_time - date_trunc('day', end_time))"
 	res := r.db.WithContext(ctx).Model(&entities.Appointment{}).
-		Where("status IN ?", []entities.AppointmentStatus{entities.AppointmentStatusPending, entities.AppointmentStatusOngoing}).
+		Where("status IN ?", []entities.AppointmentStatus{entities.AppointmentStatusPending, entities.AppointmentStatusOngoing, entities.AppointmentStatusPaused}).
 		Where(deadlineExpr+" < ?", now).
 		Updates(map[string]interface{}{
 			"status":     entities.AppointmentStatusCompleted,
@@ -149,3 +176,86 @@ func (r *gormAppointmentRepository) MarkAppointmentsCompleted(ctx context.Contex
 	}
 	return res.RowsAffected, nil
 }
+
+// ListHosts returns the appointment's hosts with their users loaded, in priority order.
+func (r *gormAppointmentRepository) ListHosts(appointmentID uuid.UUID) ([]entities.AppointmentHost, error) {
+	var hosts []entities.AppointmentHost
+	err := r.db.Preload("User").
+		Where("appointment_id = ?", appointmentID).
+		Order("priority ASC, created_at ASC").
+		Find(&hosts).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to list appointment hosts: " + err.Error())
+	}
+	return hosts, nil
+}
+
+// ReplaceHosts swaps the appointment's hosts for hosts.
+func (r *gormAppointmentRepository) ReplaceHosts(appointmentID uuid.UUID, hosts []entities.AppointmentHost) error {
+	if err := r.db.Where("appointment_id = ?", appointmentID).Delete(&entities.AppointmentHost{}).Error; err != nil {
+		return repoerrors.InternalError("failed to clear appointment hosts: " + err.Error())
+	}
+	if len(hosts) == 0 {
+		return nil
+	}
+	if err := r.db.Omit("User").Create(&hosts).Error; err != nil {
+		return repoerrors.InternalError("failed to save appointment hosts: " + err.Error())
+	}
+	return nil
+}
+
+// SetLastHost records the host the appointment's latest booking was assigned to.
+func (r *gormAppointmentRepository) SetLastHost(appointmentID uuid.UUID, hostID uuid.UUID) error {
+	if err := r.db.Model(&entities.Appointment{}).Where("id = ?", appointmentID).Update("last_host_id", hostID).Error; err != nil {
+		return repoerrors.InternalError("failed to record last host: " + err.Error())
+	}
+	return nil
+}
+
+// ListResources returns the resources the appointment requires, by name.
+func (r *gormAppointmentRepository) ListResources(appointmentID uuid.UUID) ([]entities.Resource, error) {
+	var resources []entities.Resource
+	err := r.db.
+		Joins("JOIN appointment_resources ON appointment_resources.resource_id = resources.id").
+		Where("appointment_resources.appointment_id = ?", appointmentID).
+		Order("resources.name ASC").
+		Find(&resources).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to list appointment resources: " + err.Error())
+	}
+	return resources, nil
+}
+
+// ReplaceResources swaps the resources the appointment requires for resourceIDs.
+func (r *gormAppointmentRepository) ReplaceResources(appointmentID uuid.UUID, resourceIDs []uuid.UUID) error {
+	if err := r.db.Where("appointment_id = ?", appointmentID).Delete(&entities.AppointmentResource{}).Error; err != nil {
+		return repoerrors.InternalError("failed to clear appointment resources: " + err.Error())
+	}
+	if len(resourceIDs) == 0 {
+		return nil
+	}
+	links := make([]entities.AppointmentResource, 0, len(resourceIDs))
+	for _, id := range resourceIDs {
+		links = append(links, entities.AppointmentResource{AppointmentID: appointmentID, ResourceID: id})
+	}
+	if err := r.db.Omit("Resource").Create(&links).Error; err != nil {
+		return repoerrors.InternalError("failed to save appointment resources: " + err.Error())
+	}
+	return nil
+}
+
+// FindAndLockResources locks the resources the appointment requires, in ID order so concurrent
+// bookings on appointments sharing them queue up instead of deadlocking.
+func (r *gormAppointmentRepository) FindAndLockResources(appointmentID uuid.UUID) ([]entities.Resource, error) {
+	var resources []entities.Resource
+	err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "resources"}}).
+		Joins("JOIN appointment_resources ON appointment_resources.resource_id = resources.id").
+		Where("appointment_resources.appointment_id = ?", appointmentID).
+		Order("resources.id ASC").
+		Find(&resources).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to lock appointment resources: " + err.Error())
+	}
+	return resources, nil
+}
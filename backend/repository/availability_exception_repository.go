@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"gorm.io/gorm"
+)
+
+type AvailabilityExceptionRepository interface {
+	Create(exception *entities.AvailabilityException) error
+	Update(exception *entities.AvailabilityException) error
+	Delete(id uuid.UUID) error
+	FindByID(appointmentID uuid.UUID, id uuid.UUID) (*entities.AvailabilityException, error)
+	ListByAppointment(appointmentID uuid.UUID) ([]entities.AvailabilityException, error)
+	WithTx(tx *gorm.DB) AvailabilityExceptionRepository
+}
+
+type gormAvailabilityExceptionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAvailabilityExceptionRepository(db *gorm.DB) AvailabilityExceptionRepository {
+	return &gormAvailabilityExceptionRepository{db: db}
+}
+
+func (r *gormAvailabilityExceptionRepository) WithTx(tx *gorm.DB) AvailabilityExceptionRepository {
+	return &gormAvailabilityExceptionRepository{db: tx}
+}
+
+func (r *gormAvailabilityExceptionRepository) Create(exception *entities.AvailabilityException) error {
+	if err := r.db.Create(exception).Error; err != nil {
+		return repoerrors.InternalError("failed to create availability exception: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormAvailabilityExceptionRepository) Update(exception *entities.AvailabilityException) error {
+	if err := r.db.Save(exception).Error; err != nil {
+		return repoerrors.InternalError("failed to update availability exception: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormAvailabilityExceptionRepository) Delete(id uuid.UUID) error {
+	if err := r.db.Where("id = ?", id).Delete(&entities.AvailabilityException{}).Error; err != nil {
+		return repoerrors.InternalError("failed to delete availability exception: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormAvailabilityExceptionRepository) FindByID(appointmentID uuid.UUID, id uuid.UUID) (*entities.AvailabilityException, error) {
+	var exception entities.AvailabilityException
+	if err := r.db.Where("id = ? AND appointment_id = ?", id, appointmentID).First(&exception).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("availability exception not found")
+		}
+		return nil, repoerrors.InternalError("failed to find availability exception: " + err.Error())
+	}
+	return &exception, nil
+}
+
+func (r *gormAvailabilityExceptionRepository) ListByAppointment(appointmentID uuid.UUID) ([]entities.AvailabilityException, error) {
+	var exceptions []entities.AvailabilityException
+	if err := r.db.Where("appointment_id = ?", appointmentID).Order("date ASC, created_at ASC").Find(&exceptions).Error; err != nil {
+		return nil, repoerrors.InternalError("failed to list availability exceptions: " + err.Error())
+	}
+	return exceptions, nil
+}
@@ -23,6 +23,10 @@ type BookingRepository interface {
 	Update(booking *entities.Booking) error
 	GetBookingsByAppCode(ctx context.Context, req *http.Request, appCode string, available bool) paginate.Page
 	GetBookingsByUserID(ctx context.Context, req *http.Request, userID uuid.UUID, statuses []string) paginate.Page
+	GetBookingsByHostID(ctx context.Context, req *http.Request, hostID uuid.UUID, statuses []string) paginate.Page
+	CountTicketSales(appointmentID uuid.UUID, start *time.Time) (map[string]int, error)
+	ListResourceBookingsBetween(resourceID uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error)
+	ListHostBookingsBetween(hostIDs []uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error)
 	GetAvailableSlots(ctx context.Context, req *http.Request, appCode string) paginate.Page
 	GetAvailableSlotsByDay(ctx context.Context, req *http.Request, appCode string, date time.Time) paginate.Page
 	GetBookingByCode(bookingCode string) (*entities.Booking, error)
@@ -32,8 +36,18 @@ type BookingRepository interface {
 	HasActiveBookings(appointmentID uuid.UUID) (bool, error)
 	GetActiveBookingsForAppointment(appointmentID uuid.UUID) ([]entities.Booking, error)
 	DeleteSlotsByAppointmentID(appointmentID uuid.UUID) error
+	GetBookingsBetweenDates(appointmentID uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error)
+	DeleteSlot(id uuid.UUID) error
 	MarkBookingsOngoing(ctx context.Context, now time.Time) (int64, error)
 	MarkBookingsExpired(ctx context.Context, now time.Time) (int64, error)
+	MarkBookingsNoShow(ctx context.Context, now time.Time) (int64, error)
+	GetOwnerCalendarBookings(ownerID uuid.UUID, since time.Time) ([]entities.Booking, error)
+	MarkBookingsAttended(ctx context.Context, now time.Time) (int64, error)
+	CheckIn(id uuid.UUID, fromStatus string, toStatus string, at time.Time) (bool, error)
+	ListOwnerUpcomingSlots(ownerID uuid.UUID, now time.Time) ([]entities.Booking, error)
+	SetSlotsBlocked(ids []uuid.UUID, blocked bool) error
+	ListOwnerSlotsBetween(ownerID uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error)
+	SetSlotsHostConflict(ids []uuid.UUID, conflict bool) error
 	UpdateNotificationStatus(id uuid.UUID, status string, channel string) error
 	GetAvailableDates(ctx context.Context, appCode string) ([]time.Time, error)
 	WithTx(tx *gorm.DB) BookingRepository
@@ -60,7 +74,7 @@ func (r *gormBookingRepository) Create(booking *entities.Booking) error {
 
 func (r *gormBookingRepository) FindAvailableSlot(appCode string, date time.Time, startTime time.Time) (*entities.Booking, error) {
 	var slot entities.Booking
-	if err := r.db.Where("app_code = ? AND date = ? AND start_time = ? AND available = true AND is_slot = true AND seats_booked < capacity", appCode, date, startTime).
+	if err := r.db.Where("app_code = ? AND date = ? AND start_time = ? AND available = true AND blocked = false AND host_conflict = false AND is_slot = true AND seats_booked < capacity", appCode, date, startTime).
 		First(&slot).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, repoerrors.NotFoundError("no available slot found")
@@ -75,7 +89,7 @@ func (r *gormBookingRepository) FindAndLockAvailableSlot(appCode string, date ti
 	var slot entities.Booking
 	if err := r.db.
 		Clauses(clause.Locking{Strength: "UPDATE"}).
-		Where("app_code = ? AND date = ? AND start_time = ? AND available = true AND is_slot = true AND seats_booked < capacity", appCode, date, startTime).
+		Where("app_code = ? AND date = ? AND start_time = ? AND available = true AND blocked = false AND host_conflict = false AND is_slot = true AND seats_booked < capacity", appCode, date, startTime).
 		First(&slot).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, repoerrors.NotFoundError("no available slot found for locking")
@@ -85,11 +99,14 @@ func (r *gormBookingRepository) FindAndLockAvailableSlot(appCode string, date ti
 	return &slot, nil
 }
 
+// FindAndLockSlot locks the slot row at the given start whatever its state. Availability
+// exceptions keep one slot row per start, so should an older row remain the newest one wins.
 func (r *gormBookingRepository) FindAndLockSlot(appCode string, date time.Time, startTime time.Time) (*entities.Booking, error) {
 	var slot entities.Booking
 	if err := r.db.
 		Clauses(clause.Locking{Strength: "UPDATE"}).
 		Where("app_code = ? AND date = ? AND start_time = ? AND is_slot = true", appCode, date, startTime).
+		Order("created_at DESC").
 		First(&slot).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, repoerrors.NotFoundError("slot not found for locking")
@@ -146,11 +163,123 @@ func (r *gormBookingRepository) GetBookingsByUserID(ctx context.Context, req *ht
 	return pg.With(db).Request(request).Response(&[]entities.Booking{})
 }
 
+// GetBookingsByHostID pages through the bookings assigned to the host, newest first.
+func (r *gormBookingRepository) GetBookingsByHostID(ctx context.Context, req *http.Request, hostID uuid.UUID, statuses []string) paginate.Page {
+	pg := paginate.New()
+	db := r.db.WithContext(ctx).Model(&entities.Booking{}).
+		Where("host_id = ? AND (is_slot = false OR seats_booked > 0)", hostID).
+		Order("start_time DESC")
+
+	if len(statuses) > 0 {
+		db = db.Where("status IN ?", statuses)
+	}
+	var request interface{}
+	if req != nil {
+		request = req
+	} else {
+		request = &paginate.Request{}
+	}
+	return pg.With(db).Request(request).Response(&[]entities.Booking{})
+}
+
+// ListHostBookingsBetween returns the bookings assigned to any of hostIDs that overlap [from, to),
+// leaving out cancelled and rejected ones and released slots.
+func (r *gormBookingRepository) ListHostBookingsBetween(hostIDs []uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error) {
+	if len(hostIDs) == 0 {
+		return nil, nil
+	}
+	var bookings []entities.Booking
+	err := r.db.
+		Where("host_id IN ? AND start_time < ? AND end_time > ?", hostIDs, to, from).
+		Where("is_slot = false OR seats_booked > 0").
+		Where("status NOT IN ?", []string{
+			entities.BookingStatusCancelled,
+			entities.BookingStatusCanceled,
+			entities.BookingStatusRejected,
+		}).
+		Order("start_time ASC").
+		Find(&bookings).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to list host bookings: " + err.Error())
+	}
+	return bookings, nil
+}
+
+// CountTicketSales sums the attendees of the appointment's live bookings per ticket type, only
+// counting bookings that start at start when it is set.
+func (r *gormBookingRepository) CountTicketSales(appointmentID uuid.UUID, start *time.Time) (map[string]int, error) {
+	var rows []struct {
+		TicketType string
+		Booked     int
+	}
+	query := r.db.Model(&entities.Booking{}).
+		Select("ticket_type, COALESCE(SUM(attendee_count), 0) AS booked").
+		Where("appointment_id = ? AND is_slot = false AND ticket_type <> ''", appointmentID).
+		Where("status NOT IN ?", []string{
+			entities.BookingStatusCancelled,
+			entities.BookingStatusCanceled,
+			entities.BookingStatusRejected,
+		})
+	if start != nil {
+		query = query.Where("start_time = ?", *start)
+	}
+	if err := query.Group("ticket_type").Scan(&rows).Error; err != nil {
+		return nil, repoerrors.InternalError("failed to count ticket sales: " + err.Error())
+	}
+	sales := make(map[string]int, len(rows))
+	for _, row := range rows {
+		sales[row.TicketType] = row.Booked
+	}
+	return sales, nil
+}
+
+// ListResourceBookingsBetween returns the live bookings overlapping [from, to) on appointments
+// that require the resource, including held seats on slots.
+func (r *gormBookingRepository) ListResourceBookingsBetween(resourceID uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error) {
+	var bookings []entities.Booking
+	err := r.db.
+		Joins("JOIN appointment_resources ON appointment_resources.appointment_id = bookings.appointment_id").
+		Where("appointment_resources.resource_id = ?", resourceID).
+		Where("bookings.start_time < ? AND bookings.end_time > ?", to, from).
+		Where("bookings.is_slot = false OR bookings.seats_booked > 0").
+		Where("bookings.status NOT IN ?", []string{
+			entities.BookingStatusCancelled,
+			entities.BookingStatusCanceled,
+			entities.BookingStatusRejected,
+		}).
+		Order("bookings.start_time ASC").
+		Find(&bookings).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to list resource bookings: " + err.Error())
+	}
+	return bookings, nil
+}
+
+// withinBookingWindow keeps slots that start after the appointment's minimum notice and no later
+// than its maximum advance window. It expects bookings to be joined with appointments.
+func withinBookingWindow(now time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Where("bookings.start_time >= ? + appointments.min_notice_minutes * INTERVAL '1 minute'", now).
+			Where("(appointments.max_advance_days = 0 OR bookings.start_time <= ? + appointments.max_advance_days * INTERVAL '1 day')", now)
+	}
+}
+
+// openForBooking keeps slots of appointments that currently accept bookings, hiding drafts and
+// paused, finished or archived appointments as well as slots blocked by the owner's external
+// calendars or by their bookings elsewhere. It expects bookings to be joined with appointments.
+func openForBooking(db *gorm.DB) *gorm.DB {
+	return db.
+		Where("appointments.status IN ?", []entities.AppointmentStatus{entities.AppointmentStatusPending, entities.AppointmentStatusOngoing}).
+		Where("bookings.blocked = false AND bookings.host_conflict = false")
+}
+
 func (r *gormBookingRepository) GetAvailableSlots(ctx context.Context, req *http.Request, appCode string) paginate.Page {
 	pg := paginate.New()
 	db := r.db.WithContext(ctx).Model(&entities.Booking{}).
 		Joins("JOIN appointments ON bookings.appointment_id = appointments.id").
 		Where("bookings.app_code = ? AND bookings.available = true AND bookings.is_slot = true", appCode).
+		Scopes(withinBookingWindow(time.Now()), openForBooking).
 		Where("(appointments.type != 'party' AND bookings.seats_booked < bookings.capacity) OR (appointments.type = 'party' AND appointments.attendees_booked < appointments.max_attendees)").
 		Order("bookings.date ASC, bookings.start_time ASC").
 		Select("bookings.*")
@@ -168,7 +297,8 @@ func (r *gormBookingRepository) GetAvailableSlotsByDay(ctx context.Context, req
 	now := time.Now()
 	db := r.db.WithContext(ctx).Model(&entities.Booking{}).
 		Joins("JOIN appointments ON bookings.appointment_id = appointments.id").
-		Where("bookings.app_code = ? AND bookings.date >= ? AND bookings.date < ? AND bookings.start_time >= ? AND bookings.available = true AND bookings.is_slot = true", appCode, date, date.AddDate(0, 0, 1), now).
+		Where("bookings.app_code = ? AND bookings.date >= ? AND bookings.date < ? AND bookings.available = true AND bookings.is_slot = true", appCode, date, date.AddDate(0, 0, 1)).
+		Scopes(withinBookingWindow(now), openForBooking).
 		Where("(appointments.type != 'party' AND bookings.seats_booked < bookings.capacity) OR (appointments.type = 'party' AND appointments.attendees_booked < appointments.max_attendees)").
 		Order("bookings.start_time ASC").
 		Select("bookings.*")
@@ -218,6 +348,7 @@ func (r *gormBookingRepository) FindActiveBookingByEmail(appointmentID uuid.UUID
 		entities.BookingStatusOngoing,
 		entities.BookingStatusPending,
 		entities.BookingStatusConfirmed,
+		entities.BookingStatusCheckedIn,
 	}).
 		First(&booking).Error
 	if err != nil {
@@ -236,6 +367,7 @@ func (r *gormBookingRepository) FindActiveBookingByPhone(appointmentID uuid.UUID
 		entities.BookingStatusOngoing,
 		entities.BookingStatusPending,
 		entities.BookingStatusConfirmed,
+		entities.BookingStatusCheckedIn,
 	}).
 		First(&booking).Error
 	if err != nil {
@@ -254,6 +386,7 @@ func (r *gormBookingRepository) FindActiveBookingByDevice(appointmentID uuid.UUI
 		entities.BookingStatusOngoing,
 		entities.BookingStatusPending,
 		entities.BookingStatusConfirmed,
+		entities.BookingStatusCheckedIn,
 	}).
 		First(&booking).Error
 	if err != nil {
@@ -271,6 +404,7 @@ func (r *gormBookingRepository) HasActiveBookings(appointmentID uuid.UUID) (bool
 		entities.BookingStatusOngoing,
 		entities.BookingStatusPending,
 		entities.BookingStatusConfirmed,
+		entities.BookingStatusCheckedIn,
 	}
 	var count int64
 	err := r.db.Model(&entities.Booking{}).
@@ -288,6 +422,7 @@ func (r *gormBookingRepository) GetActiveBookingsForAppointment(appointmentID uu
 		entities.BookingStatusOngoing,
 		entities.BookingStatusPending,
 		entities.BookingStatusConfirmed,
+		entities.BookingStatusCheckedIn,
 	}
 	var bookings []entities.Booking
 	err := r.db.
@@ -299,6 +434,93 @@ func (r *gormBookingRepository) GetActiveBookingsForAppointment(appointmentID uu
 	return bookings, nil
 }
 
+// GetOwnerCalendarBookings returns the booked reservations on the owner's appointments that end
+// after since, with their appointment loaded. Pending, cancelled and rejected bookings are left
+// out, as are held slots and slot rows other than those of single appointments, which hold the
+// booking itself.
+func (r *gormBookingRepository) GetOwnerCalendarBookings(ownerID uuid.UUID, since time.Time) ([]entities.Booking, error) {
+	var bookings []entities.Booking
+	err := r.db.
+		Joins("JOIN appointments ON appointments.id = bookings.appointment_id").
+		Where("appointments.owner_id = ? AND bookings.end_time >= ?", ownerID, since).
+		Where("(bookings.is_slot = false OR (appointments.type = ? AND bookings.available = false))", entities.Single).
+		Where("bookings.status NOT IN ?", []string{
+			entities.BookingStatusPending,
+			entities.BookingStatusCancelled,
+			entities.BookingStatusCanceled,
+			entities.BookingStatusRejected,
+			entities.BookingStatusHeld,
+		}).
+		Preload("Appointment").
+		Order("bookings.start_time ASC").
+		Find(&bookings).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to get owner calendar bookings: " + err.Error())
+	}
+	return bookings, nil
+}
+
+// ListOwnerUpcomingSlots returns the slot rows of the owner's appointments that have not ended yet.
+func (r *gormBookingRepository) ListOwnerUpcomingSlots(ownerID uuid.UUID, now time.Time) ([]entities.Booking, error) {
+	var slots []entities.Booking
+	err := r.db.
+		Joins("JOIN appointments ON appointments.id = bookings.appointment_id").
+		Where("appointments.owner_id = ? AND bookings.is_slot = true AND bookings.end_time > ?", ownerID, now).
+		Order("bookings.start_time ASC").
+		Select("bookings.*").
+		Find(&slots).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to list owner slots: " + err.Error())
+	}
+	return slots, nil
+}
+
+// SetSlotsBlocked sets the blocked flag on the given slot rows.
+func (r *gormBookingRepository) SetSlotsBlocked(ids []uuid.UUID, blocked bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := r.db.Model(&entities.Booking{}).
+		Where("id IN ? AND is_slot = true", ids).
+		Update("blocked", blocked).Error
+	if err != nil {
+		return repoerrors.InternalError("failed to update blocked slots: " + err.Error())
+	}
+	return nil
+}
+
+// ListOwnerSlotsBetween returns the slot rows overlapping [from, to) in the owner's appointments
+// that take part in conflict checking, with their appointment loaded.
+func (r *gormBookingRepository) ListOwnerSlotsBetween(ownerID uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error) {
+	var slots []entities.Booking
+	err := r.db.
+		Joins("JOIN appointments ON appointments.id = bookings.appointment_id").
+		Where("appointments.owner_id = ? AND appointments.allow_parallel_bookings = false", ownerID).
+		Where("bookings.is_slot = true AND bookings.start_time < ? AND bookings.end_time > ?", to, from).
+		Preload("Appointment").
+		Order("bookings.start_time ASC").
+		Select("bookings.*").
+		Find(&slots).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to list owner slots: " + err.Error())
+	}
+	return slots, nil
+}
+
+// SetSlotsHostConflict sets the host conflict flag on the given slot rows.
+func (r *gormBookingRepository) SetSlotsHostConflict(ids []uuid.UUID, conflict bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := r.db.Model(&entities.Booking{}).
+		Where("id IN ? AND is_slot = true", ids).
+		Update("host_conflict", conflict).Error
+	if err != nil {
+		return repoerrors.InternalError("failed to update host conflict slots: " + err.Error())
+	}
+	return nil
+}
+
 func (r *gormBookingRepository) DeleteSlotsByAppointmentID(appointmentID uuid.UUID) error {
 	if err := r.db.Where("appointment_id = ? AND is_slot = true", appointmentID).Delete(&entities.Booking{}).Error; err != nil {
 		return repoerrors.InternalError("failed to delete appointment slots: " + err.Error())
@@ -306,6 +528,26 @@ func (r *gormBookingRepository) DeleteSlotsByAppointmentID(appointmentID uuid.UU
 	return nil
 }
 
+// GetBookingsBetweenDates returns slot and reservation rows whose date falls in [from, to).
+func (r *gormBookingRepository) GetBookingsBetweenDates(appointmentID uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error) {
+	var bookings []entities.Booking
+	err := r.db.
+		Where("appointment_id = ? AND date >= ? AND date < ?", appointmentID, from, to).
+		Order("start_time ASC").
+		Find(&bookings).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to get bookings between dates: " + err.Error())
+	}
+	return bookings, nil
+}
+
+func (r *gormBookingRepository) DeleteSlot(id uuid.UUID) error {
+	if err := r.db.Where("id = ? AND is_slot = true", id).Delete(&entities.Booking{}).Error; err != nil {
+		return repoerrors.InternalError("failed to delete slot: " + err.Error())
+	}
+	return nil
+}
+
 func (r *gormBookingRepository) MarkBookingsOngoing(ctx context.Context, now time.Time) (int64, error) {
 	startExpr := "date_trunc('day', date) + (start_time - date_trunc('day', start_time))"
 	endExpr := "date_trunc('day', date) + (end_time - date_trunc('day', end_time))"
@@ -349,6 +591,67 @@ func (r *gormBookingRepository) MarkBookingsExpired(ctx context.Context, now tim
 	return res.RowsAffected, nil
 }
 
+// MarkBookingsNoShow marks bookings nobody checked in for as no-show once their appointment's
+// NoShowGraceMinutes have passed since the start. Appointments without a grace period are skipped.
+// Only rows a guest booked count: reservations, and single slot rows with a booker. Group slot
+// rows and slots that were only held are left alone.
+func (r *gormBookingRepository) MarkBookingsNoShow(ctx context.Context, now time.Time) (int64, error) {
+	startExpr := "date_trunc('day', bookings.date) + (bookings.start_time - date_trunc('day', bookings.start_time))"
+	res := r.db.WithContext(ctx).Model(&entities.Booking{}).
+		Where("available = ?", false).
+		Where("status IN ?", []string{
+			entities.BookingStatusActive,
+			entities.BookingStatusOngoing,
+			entities.BookingStatusConfirmed,
+			entities.BookingStatusPending,
+		}).
+		Where("EXISTS (SELECT 1 FROM appointments WHERE appointments.id = bookings.appointment_id"+
+			" AND appointments.no_show_grace_minutes > 0"+
+			" AND (bookings.is_slot = false OR (appointments.type = ? AND bookings.seats_booked > 0))"+
+			" AND "+startExpr+" + appointments.no_show_grace_minutes * INTERVAL '1 minute' <= ?)", entities.Single, now).
+		Updates(map[string]interface{}{
+			"status":     entities.BookingStatusNoShow,
+			"updated_at": now,
+		})
+	if res.Error != nil {
+		return 0, repoerrors.InternalError("failed to mark bookings no-show: " + res.Error.Error())
+	}
+	return res.RowsAffected, nil
+}
+
+// MarkBookingsAttended settles checked-in bookings as attended once they have ended.
+func (r *gormBookingRepository) MarkBookingsAttended(ctx context.Context, now time.Time) (int64, error) {
+	endExpr := "date_trunc('day', date) + (end_time - date_trunc('day', end_time))"
+	res := r.db.WithContext(ctx).Model(&entities.Booking{}).
+		Where("status = ?", entities.BookingStatusCheckedIn).
+		Where(endExpr+" < ?", now).
+		Updates(map[string]interface{}{
+			"status":     entities.BookingStatusAttended,
+			"updated_at": now,
+		})
+	if res.Error != nil {
+		return 0, repoerrors.InternalError("failed to mark bookings attended: " + res.Error.Error())
+	}
+	return res.RowsAffected, nil
+}
+
+// CheckIn moves a booking that has not been checked in yet from fromStatus to toStatus, stamping
+// it checked in at at. It reports false when the booking was checked in or changed meanwhile, so
+// concurrent check-ins of one booking admit it only once.
+func (r *gormBookingRepository) CheckIn(id uuid.UUID, fromStatus string, toStatus string, at time.Time) (bool, error) {
+	res := r.db.Model(&entities.Booking{}).
+		Where("id = ? AND status = ? AND checked_in_at IS NULL", id, fromStatus).
+		Updates(map[string]interface{}{
+			"status":        toStatus,
+			"checked_in_at": at,
+			"updated_at":    at,
+		})
+	if res.Error != nil {
+		return false, repoerrors.InternalError("failed to check in booking: " + res.Error.Error())
+	}
+	return res.RowsAffected == 1, nil
+}
+
 func (r *gormBookingRepository) UpdateNotificationStatus(id uuid.UUID, status string, channel string) error {
 	if err := r.db.Model(&entities.Booking{}).Where("id = ?", id).Updates(map[string]interface{}{
 		"notification_status":  status,
@@ -365,7 +668,8 @@ func (r *gormBookingRepository) GetAvailableDates(ctx context.Context, appCode s
 	err := r.db.WithContext(ctx).Model(&entities.Booking{}).
 		Joins("JOIN appointments ON bookings.appointment_id = appointments.id").
 		Distinct("bookings.date").
-		Where("bookings.app_code = ? AND bookings.start_time >= ? AND bookings.available = true AND bookings.is_slot = true", appCode, now).
+		Where("bookings.app_code = ? AND bookings.available = true AND bookings.is_slot = true", appCode).
+		Scopes(withinBookingWindow(now), openForBooking).
 		Where("(appointments.type != 'party' AND bookings.seats_booked < bookings.capacity) OR (appointments.type = 'party' AND appointments.attendees_booked < appointments.max_attendees)").
 		Order("bookings.date ASC").
 		Pluck("bookings.date", &dates).Error
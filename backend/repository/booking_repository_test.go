@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkBookingsNoShowSkipsUnbookedSlotRows(t *testing.T) {
+	gdb, mock := setupMockDB(t)
+	repo := NewGormBookingRepository(gdb)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`(?s)UPDATE "bookings" SET .*status IN .*bookings\.is_slot = false OR \(appointments\.type = \$\d+ AND bookings\.seats_booked > 0\)`).
+		WithArgs(entities.BookingStatusNoShow, now, false,
+			entities.BookingStatusActive, entities.BookingStatusOngoing, entities.BookingStatusConfirmed, entities.BookingStatusPending,
+			entities.Single, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	marked, err := repo.MarkBookingsNoShow(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), marked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
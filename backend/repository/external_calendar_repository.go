@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"gorm.io/gorm"
+)
+
+type ExternalCalendarRepository interface {
+	Create(calendar *entities.ExternalCalendar) error
+	Update(calendar *entities.ExternalCalendar) error
+	Delete(id uuid.UUID) error
+	FindByID(ownerID uuid.UUID, id uuid.UUID) (*entities.ExternalCalendar, error)
+	ListByOwner(ownerID uuid.UUID) ([]entities.ExternalCalendar, error)
+	ListAll() ([]entities.ExternalCalendar, error)
+	WithTx(tx *gorm.DB) ExternalCalendarRepository
+}
+
+type gormExternalCalendarRepository struct {
+	db *gorm.DB
+}
+
+func NewGormExternalCalendarRepository(db *gorm.DB) ExternalCalendarRepository {
+	return &gormExternalCalendarRepository{db: db}
+}
+
+func (r *gormExternalCalendarRepository) WithTx(tx *gorm.DB) ExternalCalendarRepository {
+	return &gormExternalCalendarRepository{db: tx}
+}
+
+func (r *gormExternalCalendarRepository) Create(calendar *entities.ExternalCalendar) error {
+	if err := r.db.Create(calendar).Error; err != nil {
+		return repoerrors.InternalError("failed to create external calendar: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormExternalCalendarRepository) Update(calendar *entities.ExternalCalendar) error {
+	if err := r.db.Save(calendar).Error; err != nil {
+		return repoerrors.InternalError("failed to update external calendar: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormExternalCalendarRepository) Delete(id uuid.UUID) error {
+	if err := r.db.Where("id = ?", id).Delete(&entities.ExternalCalendar{}).Error; err != nil {
+		return repoerrors.InternalError("failed to delete external calendar: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormExternalCalendarRepository) FindByID(ownerID uuid.UUID, id uuid.UUID) (*entities.ExternalCalendar, error) {
+	var calendar entities.ExternalCalendar
+	if err := r.db.Where("id = ? AND owner_id = ?", id, ownerID).First(&calendar).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("external calendar not found")
+		}
+		return nil, repoerrors.InternalError("failed to find external calendar: " + err.Error())
+	}
+	return &calendar, nil
+}
+
+func (r *gormExternalCalendarRepository) ListByOwner(ownerID uuid.UUID) ([]entities.ExternalCalendar, error) {
+	var calendars []entities.ExternalCalendar
+	if err := r.db.Where("owner_id = ?", ownerID).Order("created_at ASC").Find(&calendars).Error; err != nil {
+		return nil, repoerrors.InternalError("failed to list external calendars: " + err.Error())
+	}
+	return calendars, nil
+}
+
+// ListAll returns every external calendar grouped by owner, for the periodic sync.
+func (r *gormExternalCalendarRepository) ListAll() ([]entities.ExternalCalendar, error) {
+	var calendars []entities.ExternalCalendar
+	if err := r.db.Order("owner_id ASC, created_at ASC").Find(&calendars).Error; err != nil {
+		return nil, repoerrors.InternalError("failed to list external calendars: " + err.Error())
+	}
+	return calendars, nil
+}
@@ -74,6 +74,36 @@ func (_m *AppointmentRepository) FindAndLock(appCode string, tx *gorm.DB) (*enti
 	return r0, r1
 }
 
+// FindAndLockResources provides a mock function with given fields: appointmentID
+func (_m *AppointmentRepository) FindAndLockResources(appointmentID uuid.UUID) ([]entities.Resource, error) {
+	ret := _m.Called(appointmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAndLockResources")
+	}
+
+	var r0 []entities.Resource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]entities.Resource, error)); ok {
+		return rf(appointmentID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []entities.Resource); ok {
+		r0 = rf(appointmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Resource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(appointmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindAppointmentByAppCode provides a mock function with given fields: appCode
 func (_m *AppointmentRepository) FindAppointmentByAppCode(appCode string) (*entities.Appointment, error) {
 	ret := _m.Called(appCode)
@@ -104,29 +134,29 @@ func (_m *AppointmentRepository) FindAppointmentByAppCode(appCode string) (*enti
 	return r0, r1
 }
 
-// FindByIDAndOwner provides a mock function with given fields: ctx, id, ownerID
-func (_m *AppointmentRepository) FindByIDAndOwner(ctx context.Context, id uuid.UUID, ownerID uuid.UUID) (*entities.Appointment, error) {
-	ret := _m.Called(ctx, id, ownerID)
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *AppointmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Appointment, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindByIDAndOwner")
+		panic("no return value specified for FindByID")
 	}
 
 	var r0 *entities.Appointment
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*entities.Appointment, error)); ok {
-		return rf(ctx, id, ownerID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entities.Appointment, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *entities.Appointment); ok {
-		r0 = rf(ctx, id, ownerID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entities.Appointment); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Appointment)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
-		r1 = rf(ctx, id, ownerID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -152,6 +182,66 @@ func (_m *AppointmentRepository) GetAppointmentsByOwnerIDQuery(ctx context.Conte
 	return r0
 }
 
+// ListHosts provides a mock function with given fields: appointmentID
+func (_m *AppointmentRepository) ListHosts(appointmentID uuid.UUID) ([]entities.AppointmentHost, error) {
+	ret := _m.Called(appointmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHosts")
+	}
+
+	var r0 []entities.AppointmentHost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]entities.AppointmentHost, error)); ok {
+		return rf(appointmentID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []entities.AppointmentHost); ok {
+		r0 = rf(appointmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.AppointmentHost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(appointmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListResources provides a mock function with given fields: appointmentID
+func (_m *AppointmentRepository) ListResources(appointmentID uuid.UUID) ([]entities.Resource, error) {
+	ret := _m.Called(appointmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListResources")
+	}
+
+	var r0 []entities.Resource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]entities.Resource, error)); ok {
+		return rf(appointmentID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []entities.Resource); ok {
+		r0 = rf(appointmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Resource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(appointmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MarkAppointmentsCompleted provides a mock function with given fields: ctx, now
 func (_m *AppointmentRepository) MarkAppointmentsCompleted(ctx context.Context, now time.Time) (int64, error) {
 	ret := _m.Called(ctx, now)
@@ -208,6 +298,88 @@ func (_m *AppointmentRepository) MarkAppointmentsOngoing(ctx context.Context, no
 	return r0, r1
 }
 
+// PublishScheduledAppointments provides a mock function with given fields: ctx, now
+func (_m *AppointmentRepository) PublishScheduledAppointments(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishScheduledAppointments")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceHosts provides a mock function with given fields: appointmentID, hosts
+func (_m *AppointmentRepository) ReplaceHosts(appointmentID uuid.UUID, hosts []entities.AppointmentHost) error {
+	ret := _m.Called(appointmentID, hosts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceHosts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []entities.AppointmentHost) error); ok {
+		r0 = rf(appointmentID, hosts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplaceResources provides a mock function with given fields: appointmentID, resourceIDs
+func (_m *AppointmentRepository) ReplaceResources(appointmentID uuid.UUID, resourceIDs []uuid.UUID) error {
+	ret := _m.Called(appointmentID, resourceIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceResources")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID) error); ok {
+		r0 = rf(appointmentID, resourceIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLastHost provides a mock function with given fields: appointmentID, hostID
+func (_m *AppointmentRepository) SetLastHost(appointmentID uuid.UUID, hostID uuid.UUID) error {
+	ret := _m.Called(appointmentID, hostID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLastHost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(appointmentID, hostID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: appointment
 func (_m *AppointmentRepository) Update(appointment *entities.Appointment) error {
 	ret := _m.Called(appointment)
@@ -26,6 +26,64 @@ type BookingRepository struct {
 	mock.Mock
 }
 
+// CheckIn provides a mock function with given fields: id, fromStatus, toStatus, at
+func (_m *BookingRepository) CheckIn(id uuid.UUID, fromStatus string, toStatus string, at time.Time) (bool, error) {
+	ret := _m.Called(id, fromStatus, toStatus, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckIn")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string, time.Time) (bool, error)); ok {
+		return rf(id, fromStatus, toStatus, at)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string, time.Time) bool); ok {
+		r0 = rf(id, fromStatus, toStatus, at)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, string, string, time.Time) error); ok {
+		r1 = rf(id, fromStatus, toStatus, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountTicketSales provides a mock function with given fields: appointmentID, start
+func (_m *BookingRepository) CountTicketSales(appointmentID uuid.UUID, start *time.Time) (map[string]int, error) {
+	ret := _m.Called(appointmentID, start)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountTicketSales")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *time.Time) (map[string]int, error)); ok {
+		return rf(appointmentID, start)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *time.Time) map[string]int); ok {
+		r0 = rf(appointmentID, start)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, *time.Time) error); ok {
+		r1 = rf(appointmentID, start)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: booking
 func (_m *BookingRepository) Create(booking *entities.Booking) error {
 	ret := _m.Called(booking)
@@ -44,6 +102,42 @@ func (_m *BookingRepository) Create(booking *entities.Booking) error {
 	return r0
 }
 
+// DeleteSlot provides a mock function with given fields: id
+func (_m *BookingRepository) DeleteSlot(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSlot")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSlotsByAppointmentID provides a mock function with given fields: appointmentID
+func (_m *BookingRepository) DeleteSlotsByAppointmentID(appointmentID uuid.UUID) error {
+	ret := _m.Called(appointmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSlotsByAppointmentID")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(appointmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindActiveBookingByDevice provides a mock function with given fields: appointmentID, deviceID
 func (_m *BookingRepository) FindActiveBookingByDevice(appointmentID uuid.UUID, deviceID string) (*entities.Booking, error) {
 	ret := _m.Called(appointmentID, deviceID)
@@ -134,27 +228,29 @@ func (_m *BookingRepository) FindActiveBookingByPhone(appointmentID uuid.UUID, p
 	return r0, r1
 }
 
-// HasActiveBookings provides a mock function with given fields: appointmentID
-func (_m *BookingRepository) HasActiveBookings(appointmentID uuid.UUID) (bool, error) {
-	ret := _m.Called(appointmentID)
+// FindAndLockAvailableSlot provides a mock function with given fields: appCode, date, startTime
+func (_m *BookingRepository) FindAndLockAvailableSlot(appCode string, date time.Time, startTime time.Time) (*entities.Booking, error) {
+	ret := _m.Called(appCode, date, startTime)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HasActiveBookings")
+		panic("no return value specified for FindAndLockAvailableSlot")
 	}
 
-	var r0 bool
+	var r0 *entities.Booking
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) (bool, error)); ok {
-		return rf(appointmentID)
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) (*entities.Booking, error)); ok {
+		return rf(appCode, date, startTime)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
-		r0 = rf(appointmentID)
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) *entities.Booking); ok {
+		r0 = rf(appCode, date, startTime)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Booking)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(appointmentID)
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
+		r1 = rf(appCode, date, startTime)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -162,29 +258,29 @@ func (_m *BookingRepository) HasActiveBookings(appointmentID uuid.UUID) (bool, e
 	return r0, r1
 }
 
-// GetActiveBookingsForAppointment provides a mock function with given fields: appointmentID
-func (_m *BookingRepository) GetActiveBookingsForAppointment(appointmentID uuid.UUID) ([]entities.Booking, error) {
-	ret := _m.Called(appointmentID)
+// FindAndLockSlot provides a mock function with given fields: appCode, date, startTime
+func (_m *BookingRepository) FindAndLockSlot(appCode string, date time.Time, startTime time.Time) (*entities.Booking, error) {
+	ret := _m.Called(appCode, date, startTime)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetActiveBookingsForAppointment")
+		panic("no return value specified for FindAndLockSlot")
 	}
 
-	var r0 []entities.Booking
+	var r0 *entities.Booking
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]entities.Booking, error)); ok {
-		return rf(appointmentID)
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) (*entities.Booking, error)); ok {
+		return rf(appCode, date, startTime)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID) []entities.Booking); ok {
-		r0 = rf(appointmentID)
+	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) *entities.Booking); ok {
+		r0 = rf(appCode, date, startTime)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]entities.Booking)
+			r0 = ret.Get(0).(*entities.Booking)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(appointmentID)
+	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
+		r1 = rf(appCode, date, startTime)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -192,30 +288,12 @@ func (_m *BookingRepository) GetActiveBookingsForAppointment(appointmentID uuid.
 	return r0, r1
 }
 
-// DeleteSlotsByAppointmentID provides a mock function with given fields: appointmentID
-func (_m *BookingRepository) DeleteSlotsByAppointmentID(appointmentID uuid.UUID) error {
-	ret := _m.Called(appointmentID)
-
-	if len(ret) == 0 {
-		panic("no return value specified for DeleteSlotsByAppointmentID")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
-		r0 = rf(appointmentID)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// FindAndLockAvailableSlot provides a mock function with given fields: appCode, date, startTime
-func (_m *BookingRepository) FindAndLockAvailableSlot(appCode string, date time.Time, startTime time.Time) (*entities.Booking, error) {
+// FindAvailableSlot provides a mock function with given fields: appCode, date, startTime
+func (_m *BookingRepository) FindAvailableSlot(appCode string, date time.Time, startTime time.Time) (*entities.Booking, error) {
 	ret := _m.Called(appCode, date, startTime)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindAndLockAvailableSlot")
+		panic("no return value specified for FindAvailableSlot")
 	}
 
 	var r0 *entities.Booking
@@ -240,29 +318,29 @@ func (_m *BookingRepository) FindAndLockAvailableSlot(appCode string, date time.
 	return r0, r1
 }
 
-// FindAndLockSlot provides a mock function with given fields: appCode, date, startTime
-func (_m *BookingRepository) FindAndLockSlot(appCode string, date time.Time, startTime time.Time) (*entities.Booking, error) {
-	ret := _m.Called(appCode, date, startTime)
+// GetActiveBookingsForAppointment provides a mock function with given fields: appointmentID
+func (_m *BookingRepository) GetActiveBookingsForAppointment(appointmentID uuid.UUID) ([]entities.Booking, error) {
+	ret := _m.Called(appointmentID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindAndLockSlot")
+		panic("no return value specified for GetActiveBookingsForAppointment")
 	}
 
-	var r0 *entities.Booking
+	var r0 []entities.Booking
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) (*entities.Booking, error)); ok {
-		return rf(appCode, date, startTime)
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]entities.Booking, error)); ok {
+		return rf(appointmentID)
 	}
-	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) *entities.Booking); ok {
-		r0 = rf(appCode, date, startTime)
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []entities.Booking); ok {
+		r0 = rf(appointmentID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.Booking)
+			r0 = ret.Get(0).([]entities.Booking)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
-		r1 = rf(appCode, date, startTime)
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(appointmentID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -270,29 +348,29 @@ func (_m *BookingRepository) FindAndLockSlot(appCode string, date time.Time, sta
 	return r0, r1
 }
 
-// FindAvailableSlot provides a mock function with given fields: appCode, date, startTime
-func (_m *BookingRepository) FindAvailableSlot(appCode string, date time.Time, startTime time.Time) (*entities.Booking, error) {
-	ret := _m.Called(appCode, date, startTime)
+// GetAvailableDates provides a mock function with given fields: ctx, appCode
+func (_m *BookingRepository) GetAvailableDates(ctx context.Context, appCode string) ([]time.Time, error) {
+	ret := _m.Called(ctx, appCode)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindAvailableSlot")
+		panic("no return value specified for GetAvailableDates")
 	}
 
-	var r0 *entities.Booking
+	var r0 []time.Time
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) (*entities.Booking, error)); ok {
-		return rf(appCode, date, startTime)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]time.Time, error)); ok {
+		return rf(ctx, appCode)
 	}
-	if rf, ok := ret.Get(0).(func(string, time.Time, time.Time) *entities.Booking); ok {
-		r0 = rf(appCode, date, startTime)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []time.Time); ok {
+		r0 = rf(ctx, appCode)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.Booking)
+			r0 = ret.Get(0).([]time.Time)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, time.Time, time.Time) error); ok {
-		r1 = rf(appCode, date, startTime)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, appCode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -366,6 +444,36 @@ func (_m *BookingRepository) GetBookingByCode(bookingCode string) (*entities.Boo
 	return r0, r1
 }
 
+// GetBookingsBetweenDates provides a mock function with given fields: appointmentID, from, to
+func (_m *BookingRepository) GetBookingsBetweenDates(appointmentID uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error) {
+	ret := _m.Called(appointmentID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookingsBetweenDates")
+	}
+
+	var r0 []entities.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time, time.Time) ([]entities.Booking, error)); ok {
+		return rf(appointmentID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time, time.Time) []entities.Booking); ok {
+		r0 = rf(appointmentID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(appointmentID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBookingsByAppCode provides a mock function with given fields: ctx, req, appCode, available
 func (_m *BookingRepository) GetBookingsByAppCode(ctx context.Context, req *http.Request, appCode string, available bool) paginate.Page {
 	ret := _m.Called(ctx, req, appCode, available)
@@ -384,6 +492,24 @@ func (_m *BookingRepository) GetBookingsByAppCode(ctx context.Context, req *http
 	return r0
 }
 
+// GetBookingsByHostID provides a mock function with given fields: ctx, req, hostID, statuses
+func (_m *BookingRepository) GetBookingsByHostID(ctx context.Context, req *http.Request, hostID uuid.UUID, statuses []string) paginate.Page {
+	ret := _m.Called(ctx, req, hostID, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookingsByHostID")
+	}
+
+	var r0 paginate.Page
+	if rf, ok := ret.Get(0).(func(context.Context, *http.Request, uuid.UUID, []string) paginate.Page); ok {
+		r0 = rf(ctx, req, hostID, statuses)
+	} else {
+		r0 = ret.Get(0).(paginate.Page)
+	}
+
+	return r0
+}
+
 // GetBookingsByUserID provides a mock function with given fields: ctx, req, userID, statuses
 func (_m *BookingRepository) GetBookingsByUserID(ctx context.Context, req *http.Request, userID uuid.UUID, statuses []string) paginate.Page {
 	ret := _m.Called(ctx, req, userID, statuses)
@@ -402,12 +528,190 @@ func (_m *BookingRepository) GetBookingsByUserID(ctx context.Context, req *http.
 	return r0
 }
 
-// MarkBookingsOngoing provides a mock function with given fields: ctx, now
-func (_m *BookingRepository) MarkBookingsOngoing(ctx context.Context, now time.Time) (int64, error) {
+// GetOwnerCalendarBookings provides a mock function with given fields: ownerID, since
+func (_m *BookingRepository) GetOwnerCalendarBookings(ownerID uuid.UUID, since time.Time) ([]entities.Booking, error) {
+	ret := _m.Called(ownerID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOwnerCalendarBookings")
+	}
+
+	var r0 []entities.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) ([]entities.Booking, error)); ok {
+		return rf(ownerID, since)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) []entities.Booking); ok {
+		r0 = rf(ownerID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time) error); ok {
+		r1 = rf(ownerID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasActiveBookings provides a mock function with given fields: appointmentID
+func (_m *BookingRepository) HasActiveBookings(appointmentID uuid.UUID) (bool, error) {
+	ret := _m.Called(appointmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasActiveBookings")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (bool, error)); ok {
+		return rf(appointmentID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) bool); ok {
+		r0 = rf(appointmentID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(appointmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHostBookingsBetween provides a mock function with given fields: hostIDs, from, to
+func (_m *BookingRepository) ListHostBookingsBetween(hostIDs []uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error) {
+	ret := _m.Called(hostIDs, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHostBookingsBetween")
+	}
+
+	var r0 []entities.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]uuid.UUID, time.Time, time.Time) ([]entities.Booking, error)); ok {
+		return rf(hostIDs, from, to)
+	}
+	if rf, ok := ret.Get(0).(func([]uuid.UUID, time.Time, time.Time) []entities.Booking); ok {
+		r0 = rf(hostIDs, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(hostIDs, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOwnerSlotsBetween provides a mock function with given fields: ownerID, from, to
+func (_m *BookingRepository) ListOwnerSlotsBetween(ownerID uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error) {
+	ret := _m.Called(ownerID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOwnerSlotsBetween")
+	}
+
+	var r0 []entities.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time, time.Time) ([]entities.Booking, error)); ok {
+		return rf(ownerID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time, time.Time) []entities.Booking); ok {
+		r0 = rf(ownerID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(ownerID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOwnerUpcomingSlots provides a mock function with given fields: ownerID, now
+func (_m *BookingRepository) ListOwnerUpcomingSlots(ownerID uuid.UUID, now time.Time) ([]entities.Booking, error) {
+	ret := _m.Called(ownerID, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOwnerUpcomingSlots")
+	}
+
+	var r0 []entities.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) ([]entities.Booking, error)); ok {
+		return rf(ownerID, now)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) []entities.Booking); ok {
+		r0 = rf(ownerID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time) error); ok {
+		r1 = rf(ownerID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListResourceBookingsBetween provides a mock function with given fields: resourceID, from, to
+func (_m *BookingRepository) ListResourceBookingsBetween(resourceID uuid.UUID, from time.Time, to time.Time) ([]entities.Booking, error) {
+	ret := _m.Called(resourceID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListResourceBookingsBetween")
+	}
+
+	var r0 []entities.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time, time.Time) ([]entities.Booking, error)); ok {
+		return rf(resourceID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time, time.Time) []entities.Booking); ok {
+		r0 = rf(resourceID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = rf(resourceID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkBookingsAttended provides a mock function with given fields: ctx, now
+func (_m *BookingRepository) MarkBookingsAttended(ctx context.Context, now time.Time) (int64, error) {
 	ret := _m.Called(ctx, now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MarkBookingsOngoing")
+		panic("no return value specified for MarkBookingsAttended")
 	}
 
 	var r0 int64
@@ -458,17 +762,73 @@ func (_m *BookingRepository) MarkBookingsExpired(ctx context.Context, now time.T
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: booking
-func (_m *BookingRepository) Update(booking *entities.Booking) error {
-	ret := _m.Called(booking)
+// MarkBookingsNoShow provides a mock function with given fields: ctx, now
+func (_m *BookingRepository) MarkBookingsNoShow(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for MarkBookingsNoShow")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkBookingsOngoing provides a mock function with given fields: ctx, now
+func (_m *BookingRepository) MarkBookingsOngoing(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkBookingsOngoing")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSlotsBlocked provides a mock function with given fields: ids, blocked
+func (_m *BookingRepository) SetSlotsBlocked(ids []uuid.UUID, blocked bool) error {
+	ret := _m.Called(ids, blocked)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSlotsBlocked")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*entities.Booking) error); ok {
-		r0 = rf(booking)
+	if rf, ok := ret.Get(0).(func([]uuid.UUID, bool) error); ok {
+		r0 = rf(ids, blocked)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -476,17 +836,17 @@ func (_m *BookingRepository) Update(booking *entities.Booking) error {
 	return r0
 }
 
-// UpdateNotificationStatus provides a mock function with given fields: id, status, channel
-func (_m *BookingRepository) UpdateNotificationStatus(id uuid.UUID, status string, channel string) error {
-	ret := _m.Called(id, status, channel)
+// SetSlotsHostConflict provides a mock function with given fields: ids, conflict
+func (_m *BookingRepository) SetSlotsHostConflict(ids []uuid.UUID, conflict bool) error {
+	ret := _m.Called(ids, conflict)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateNotificationStatus")
+		panic("no return value specified for SetSlotsHostConflict")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string) error); ok {
-		r0 = rf(id, status, channel)
+	if rf, ok := ret.Get(0).(func([]uuid.UUID, bool) error); ok {
+		r0 = rf(ids, conflict)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -494,34 +854,40 @@ func (_m *BookingRepository) UpdateNotificationStatus(id uuid.UUID, status strin
 	return r0
 }
 
-// GetAvailableDates provides a mock function with given fields: ctx, appCode
-func (_m *BookingRepository) GetAvailableDates(ctx context.Context, appCode string) ([]time.Time, error) {
-	ret := _m.Called(ctx, appCode)
+// Update provides a mock function with given fields: booking
+func (_m *BookingRepository) Update(booking *entities.Booking) error {
+	ret := _m.Called(booking)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAvailableDates")
+		panic("no return value specified for Update")
 	}
 
-	var r0 []time.Time
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]time.Time, error)); ok {
-		return rf(ctx, appCode)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []time.Time); ok {
-		r0 = rf(ctx, appCode)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.Booking) error); ok {
+		r0 = rf(booking)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]time.Time)
-		}
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, appCode)
+	return r0
+}
+
+// UpdateNotificationStatus provides a mock function with given fields: id, status, channel
+func (_m *BookingRepository) UpdateNotificationStatus(id uuid.UUID, status string, channel string) error {
+	ret := _m.Called(id, status, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string, string) error); ok {
+		r0 = rf(id, status, channel)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // WithTx provides a mock function with given fields: tx
@@ -60,6 +60,36 @@ func (_m *UserRepository) FindByEmail(email string) (*entities.User, error) {
 	return r0, r1
 }
 
+// FindByCalendarFeedToken provides a mock function with given fields: token
+func (_m *UserRepository) FindByCalendarFeedToken(token string) (*entities.User, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByCalendarFeedToken")
+	}
+
+	var r0 *entities.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*entities.User, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *entities.User); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByID provides a mock function with given fields: id
 func (_m *UserRepository) FindByID(id string) (*entities.User, error) {
 	ret := _m.Called(id)
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"gorm.io/gorm"
+)
+
+type OrganizationRepository interface {
+	Create(organization *entities.Organization) error
+	Update(organization *entities.Organization) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*entities.Organization, error)
+	ListForUser(userID uuid.UUID) ([]entities.Organization, error)
+	AddMember(member *entities.OrganizationMember) error
+	UpdateMember(member *entities.OrganizationMember) error
+	RemoveMember(organizationID uuid.UUID, userID uuid.UUID) error
+	FindMember(organizationID uuid.UUID, userID uuid.UUID) (*entities.OrganizationMember, error)
+	ListMembers(organizationID uuid.UUID) ([]entities.OrganizationMember, error)
+	CountMembersWithRole(organizationID uuid.UUID, role entities.OrganizationRole) (int64, error)
+	WithTx(tx *gorm.DB) OrganizationRepository
+}
+
+type gormOrganizationRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &gormOrganizationRepository{db: db}
+}
+
+func (r *gormOrganizationRepository) WithTx(tx *gorm.DB) OrganizationRepository {
+	return &gormOrganizationRepository{db: tx}
+}
+
+func (r *gormOrganizationRepository) Create(organization *entities.Organization) error {
+	if err := r.db.Create(organization).Error; err != nil {
+		return repoerrors.InternalError("failed to create organization: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormOrganizationRepository) Update(organization *entities.Organization) error {
+	if err := r.db.Save(organization).Error; err != nil {
+		return repoerrors.InternalError("failed to update organization: " + err.Error())
+	}
+	return nil
+}
+
+// Delete removes the organization and its memberships. Its appointments and resources stay with
+// their creators, no longer shared, and the appointments lose every host but their creator.
+func (r *gormOrganizationRepository) Delete(id uuid.UUID) error {
+	shared := r.db.Model(&entities.Appointment{}).Select("id").Where("organization_id = ?", id)
+	err := r.db.
+		Where("appointment_id IN (?)", shared).
+		Where("user_id <> (SELECT owner_id FROM appointments WHERE appointments.id = appointment_hosts.appointment_id)").
+		Delete(&entities.AppointmentHost{}).Error
+	if err != nil {
+		return repoerrors.InternalError("failed to remove organization hosts: " + err.Error())
+	}
+	if err := r.db.Model(&entities.Appointment{}).Where("organization_id = ?", id).Update("organization_id", nil).Error; err != nil {
+		return repoerrors.InternalError("failed to unshare organization appointments: " + err.Error())
+	}
+	if err := r.db.Model(&entities.Resource{}).Where("organization_id = ?", id).Update("organization_id", nil).Error; err != nil {
+		return repoerrors.InternalError("failed to unshare organization resources: " + err.Error())
+	}
+	if err := r.db.Where("organization_id = ?", id).Delete(&entities.OrganizationMember{}).Error; err != nil {
+		return repoerrors.InternalError("failed to delete organization members: " + err.Error())
+	}
+	if err := r.db.Where("id = ?", id).Delete(&entities.Organization{}).Error; err != nil {
+		return repoerrors.InternalError("failed to delete organization: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormOrganizationRepository) FindByID(id uuid.UUID) (*entities.Organization, error) {
+	var organization entities.Organization
+	if err := r.db.Where("id = ?", id).First(&organization).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("organization not found")
+		}
+		return nil, repoerrors.InternalError("failed to find organization: " + err.Error())
+	}
+	return &organization, nil
+}
+
+// ListForUser returns the organizations the user is a member of.
+func (r *gormOrganizationRepository) ListForUser(userID uuid.UUID) ([]entities.Organization, error) {
+	var organizations []entities.Organization
+	err := r.db.
+		Joins("JOIN organization_members ON organization_members.organization_id = organizations.id").
+		Where("organization_members.user_id = ?", userID).
+		Order("organizations.name ASC").
+		Find(&organizations).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to list organizations: " + err.Error())
+	}
+	return organizations, nil
+}
+
+func (r *gormOrganizationRepository) AddMember(member *entities.OrganizationMember) error {
+	if err := r.db.Create(member).Error; err != nil {
+		return repoerrors.InternalError("failed to add organization member: " + err.Error())
+	}
+	return nil
+}
+
+// UpdateMember saves the member's role.
+func (r *gormOrganizationRepository) UpdateMember(member *entities.OrganizationMember) error {
+	if err := r.db.Model(member).Update("role", member.Role).Error; err != nil {
+		return repoerrors.InternalError("failed to update organization member: " + err.Error())
+	}
+	return nil
+}
+
+// RemoveMember takes the user out of the organization and off the hosts of its appointments they
+// did not create.
+func (r *gormOrganizationRepository) RemoveMember(organizationID uuid.UUID, userID uuid.UUID) error {
+	shared := r.db.Model(&entities.Appointment{}).Select("id").Where("organization_id = ? AND owner_id <> ?", organizationID, userID)
+	if err := r.db.Where("user_id = ? AND appointment_id IN (?)", userID, shared).Delete(&entities.AppointmentHost{}).Error; err != nil {
+		return repoerrors.InternalError("failed to remove member from hosts: " + err.Error())
+	}
+	if err := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).Delete(&entities.OrganizationMember{}).Error; err != nil {
+		return repoerrors.InternalError("failed to remove organization member: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormOrganizationRepository) FindMember(organizationID uuid.UUID, userID uuid.UUID) (*entities.OrganizationMember, error) {
+	var member entities.OrganizationMember
+	if err := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("organization member not found")
+		}
+		return nil, repoerrors.InternalError("failed to find organization member: " + err.Error())
+	}
+	return &member, nil
+}
+
+func (r *gormOrganizationRepository) ListMembers(organizationID uuid.UUID) ([]entities.OrganizationMember, error) {
+	var members []entities.OrganizationMember
+	if err := r.db.Preload("User").Where("organization_id = ?", organizationID).Order("created_at ASC").Find(&members).Error; err != nil {
+		return nil, repoerrors.InternalError("failed to list organization members: " + err.Error())
+	}
+	return members, nil
+}
+
+func (r *gormOrganizationRepository) CountMembersWithRole(organizationID uuid.UUID, role entities.OrganizationRole) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entities.OrganizationMember{}).Where("organization_id = ? AND role = ?", organizationID, role).Count(&count).Error; err != nil {
+		return 0, repoerrors.InternalError("failed to count organization members: " + err.Error())
+	}
+	return count, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository interface {
+	Create(event *entities.OutboxEvent) error
+	FindAndLockDue(now time.Time, limit int) ([]entities.OutboxEvent, error)
+	Update(event *entities.OutboxEvent) error
+	DeleteDeliveredBefore(before time.Time) (int64, error)
+	WithTx(tx *gorm.DB) OutboxRepository
+}
+
+type gormOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &gormOutboxRepository{db: db}
+}
+
+func (r *gormOutboxRepository) WithTx(tx *gorm.DB) OutboxRepository {
+	return &gormOutboxRepository{db: tx}
+}
+
+func (r *gormOutboxRepository) Create(event *entities.OutboxEvent) error {
+	if err := r.db.Create(event).Error; err != nil {
+		return repoerrors.InternalError("failed to store event: " + err.Error())
+	}
+	return nil
+}
+
+// FindAndLockDue finds up to limit pending events available at now, oldest first, and locks them
+// until the transaction ends. Events locked by another dispatcher are skipped rather than waited
+// on.
+func (r *gormOutboxRepository) FindAndLockDue(now time.Time, limit int) ([]entities.OutboxEvent, error) {
+	var outboxEvents []entities.OutboxEvent
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND available_at <= ?", entities.OutboxStatusPending, now).
+		Order("available_at ASC, created_at ASC").
+		Limit(limit).
+		Find(&outboxEvents).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to find pending events: " + err.Error())
+	}
+	return outboxEvents, nil
+}
+
+func (r *gormOutboxRepository) Update(event *entities.OutboxEvent) error {
+	if err := r.db.Save(event).Error; err != nil {
+		return repoerrors.InternalError("failed to update event: " + err.Error())
+	}
+	return nil
+}
+
+// DeleteDeliveredBefore removes events delivered before the cutoff. Failed events are kept for
+// inspection.
+func (r *gormOutboxRepository) DeleteDeliveredBefore(before time.Time) (int64, error) {
+	result := r.db.Where("status = ? AND delivered_at < ?", entities.OutboxStatusDelivered, before).
+		Delete(&entities.OutboxEvent{})
+	if result.Error != nil {
+		return 0, repoerrors.InternalError("failed to clean up delivered events: " + result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentRepository interface {
+	Create(payment *entities.Payment) error
+	Update(payment *entities.Payment) error
+	FindAndLockByIntentID(intentID string) (*entities.Payment, error)
+	FindAndLockSucceeded(bookingCode string) (*entities.Payment, error)
+	WithTx(tx *gorm.DB) PaymentRepository
+}
+
+type gormPaymentRepository struct {
+	db *gorm.DB
+}
+
+func NewGormPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &gormPaymentRepository{db: db}
+}
+
+func (r *gormPaymentRepository) WithTx(tx *gorm.DB) PaymentRepository {
+	return &gormPaymentRepository{db: tx}
+}
+
+func (r *gormPaymentRepository) Create(payment *entities.Payment) error {
+	if err := r.db.Create(payment).Error; err != nil {
+		return repoerrors.InternalError("failed to create payment: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormPaymentRepository) Update(payment *entities.Payment) error {
+	if err := r.db.Save(payment).Error; err != nil {
+		return repoerrors.InternalError("failed to update payment: " + err.Error())
+	}
+	return nil
+}
+
+// FindAndLockByIntentID locks the payment made through a provider intent, so concurrent
+// deliveries of the same webhook are applied once.
+func (r *gormPaymentRepository) FindAndLockByIntentID(intentID string) (*entities.Payment, error) {
+	var payment entities.Payment
+	err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("intent_id = ?", intentID).
+		First(&payment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("payment not found")
+		}
+		return nil, repoerrors.InternalError("failed to find payment: " + err.Error())
+	}
+	return &payment, nil
+}
+
+// FindAndLockSucceeded locks the completed, unrefunded payment for a booking.
+func (r *gormPaymentRepository) FindAndLockSucceeded(bookingCode string) (*entities.Payment, error) {
+	var payment entities.Payment
+	err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("booking_code = ? AND status = ?", bookingCode, entities.PaymentStatusSucceeded).
+		Order("paid_at DESC").
+		First(&payment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("no completed payment for booking")
+		}
+		return nil, repoerrors.InternalError("failed to find payment: " + err.Error())
+	}
+	return &payment, nil
+}
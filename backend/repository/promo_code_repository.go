@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromoCodeRepository interface {
+	Create(promo *entities.PromoCode) error
+	Update(promo *entities.PromoCode) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*entities.PromoCode, error)
+	ListByOwner(ownerID uuid.UUID) ([]entities.PromoCode, error)
+	ReplaceAppointments(promoID uuid.UUID, appointmentIDs []uuid.UUID) error
+	FindAndLockForAppointment(code string, appointment *entities.Appointment) (*entities.PromoCode, error)
+	CountRedemptionsByEmail(promoID uuid.UUID, email string) (int64, error)
+	CreateRedemption(redemption *entities.PromoCodeRedemption) error
+	WithTx(tx *gorm.DB) PromoCodeRepository
+}
+
+type gormPromoCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewGormPromoCodeRepository(db *gorm.DB) PromoCodeRepository {
+	return &gormPromoCodeRepository{db: db}
+}
+
+func (r *gormPromoCodeRepository) WithTx(tx *gorm.DB) PromoCodeRepository {
+	return &gormPromoCodeRepository{db: tx}
+}
+
+func translatePromoCodeError(err error, prefix string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_promo_codes_owner_code" {
+		return repoerrors.ConflictError("you already have a promo code with this code")
+	}
+	return repoerrors.InternalError(prefix + ": " + err.Error())
+}
+
+func (r *gormPromoCodeRepository) Create(promo *entities.PromoCode) error {
+	if err := r.db.Omit("Appointments").Create(promo).Error; err != nil {
+		return translatePromoCodeError(err, "failed to create promo code")
+	}
+	return nil
+}
+
+func (r *gormPromoCodeRepository) Update(promo *entities.PromoCode) error {
+	if err := r.db.Omit("Appointments").Save(promo).Error; err != nil {
+		return translatePromoCodeError(err, "failed to update promo code")
+	}
+	return nil
+}
+
+// Delete removes the promo code and its appointment restrictions. Its redemptions are kept.
+func (r *gormPromoCodeRepository) Delete(id uuid.UUID) error {
+	if err := r.db.Where("promo_code_id = ?", id).Delete(&entities.PromoCodeAppointment{}).Error; err != nil {
+		return repoerrors.InternalError("failed to remove promo code restrictions: " + err.Error())
+	}
+	if err := r.db.Where("id = ?", id).Delete(&entities.PromoCode{}).Error; err != nil {
+		return repoerrors.InternalError("failed to delete promo code: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormPromoCodeRepository) FindByID(id uuid.UUID) (*entities.PromoCode, error) {
+	var promo entities.PromoCode
+	if err := r.db.Preload("Appointments").Where("id = ?", id).First(&promo).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("promo code not found")
+		}
+		return nil, repoerrors.InternalError("failed to find promo code: " + err.Error())
+	}
+	return &promo, nil
+}
+
+func (r *gormPromoCodeRepository) ListByOwner(ownerID uuid.UUID) ([]entities.PromoCode, error) {
+	var promos []entities.PromoCode
+	err := r.db.Preload("Appointments").
+		Where("owner_id = ?", ownerID).
+		Order("created_at DESC").
+		Find(&promos).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to list promo codes: " + err.Error())
+	}
+	return promos, nil
+}
+
+// ReplaceAppointments swaps the appointments the promo code is restricted to for appointmentIDs.
+func (r *gormPromoCodeRepository) ReplaceAppointments(promoID uuid.UUID, appointmentIDs []uuid.UUID) error {
+	if err := r.db.Where("promo_code_id = ?", promoID).Delete(&entities.PromoCodeAppointment{}).Error; err != nil {
+		return repoerrors.InternalError("failed to clear promo code restrictions: " + err.Error())
+	}
+	if len(appointmentIDs) == 0 {
+		return nil
+	}
+	links := make([]entities.PromoCodeAppointment, 0, len(appointmentIDs))
+	for _, id := range appointmentIDs {
+		links = append(links, entities.PromoCodeAppointment{PromoCodeID: promoID, AppointmentID: id})
+	}
+	if err := r.db.Create(&links).Error; err != nil {
+		return repoerrors.InternalError("failed to save promo code restrictions: " + err.Error())
+	}
+	return nil
+}
+
+// FindAndLockForAppointment finds the promo code guests of appointment can redeem with code and
+// locks it until the transaction ends: one restricted to the appointment, or else an
+// unrestricted one of the appointment's owner.
+func (r *gormPromoCodeRepository) FindAndLockForAppointment(code string, appointment *entities.Appointment) (*entities.PromoCode, error) {
+	code = entities.NormalizePromoCode(code)
+	restricted := r.db.Model(&entities.PromoCodeAppointment{}).Select("promo_code_id").Where("appointment_id = ?", appointment.ID)
+	anyRestriction := r.db.Model(&entities.PromoCodeAppointment{}).Select("1").Where("promo_code_appointments.promo_code_id = promo_codes.id")
+
+	var promos []entities.PromoCode
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("code = ? AND id IN (?)", code, restricted).
+		Order("created_at ASC").
+		Limit(1).
+		Find(&promos).Error
+	if err == nil && len(promos) == 0 {
+		err = r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("code = ? AND owner_id = ? AND NOT EXISTS (?)", code, appointment.OwnerID, anyRestriction).
+			Limit(1).
+			Find(&promos).Error
+	}
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to find and lock promo code: " + err.Error())
+	}
+	if len(promos) == 0 {
+		return nil, repoerrors.NotFoundError("promo code not found")
+	}
+	return &promos[0], nil
+}
+
+func (r *gormPromoCodeRepository) CountRedemptionsByEmail(promoID uuid.UUID, email string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.PromoCodeRedemption{}).
+		Where("promo_code_id = ? AND email = ?", promoID, email).
+		Count(&count).Error
+	if err != nil {
+		return 0, repoerrors.InternalError("failed to count promo code redemptions: " + err.Error())
+	}
+	return count, nil
+}
+
+func (r *gormPromoCodeRepository) CreateRedemption(redemption *entities.PromoCodeRedemption) error {
+	if err := r.db.Create(redemption).Error; err != nil {
+		return repoerrors.InternalError("failed to record promo code redemption: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"time"
+
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReminderRepository interface {
+	CreateBatch(reminders []entities.BookingReminder) error
+	CancelPending(bookingCode string) (int64, error)
+	FindAndLockNextDue(now time.Time) (*entities.BookingReminder, error)
+	Update(reminder *entities.BookingReminder) error
+	WithTx(tx *gorm.DB) ReminderRepository
+}
+
+type gormReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewGormReminderRepository(db *gorm.DB) ReminderRepository {
+	return &gormReminderRepository{db: db}
+}
+
+func (r *gormReminderRepository) WithTx(tx *gorm.DB) ReminderRepository {
+	return &gormReminderRepository{db: tx}
+}
+
+// CreateBatch queues reminders, skipping any already pending for the same booking, start and
+// offset.
+func (r *gormReminderRepository) CreateBatch(reminders []entities.BookingReminder) error {
+	if len(reminders) == 0 {
+		return nil
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&reminders).Error; err != nil {
+		return repoerrors.InternalError("failed to schedule reminders: " + err.Error())
+	}
+	return nil
+}
+
+// CancelPending cancels the reminders still waiting to go out for the booking.
+func (r *gormReminderRepository) CancelPending(bookingCode string) (int64, error) {
+	result := r.db.Model(&entities.BookingReminder{}).
+		Where("booking_code = ? AND status = ?", bookingCode, entities.ReminderStatusPending).
+		Update("status", entities.ReminderStatusCancelled)
+	if result.Error != nil {
+		return 0, repoerrors.InternalError("failed to cancel reminders: " + result.Error.Error())
+	}
+	return result.RowsAffected, nil
+}
+
+// FindAndLockNextDue finds the earliest pending reminder due at now and locks it until the
+// transaction ends. Reminders locked by another worker are skipped rather than waited on.
+func (r *gormReminderRepository) FindAndLockNextDue(now time.Time) (*entities.BookingReminder, error) {
+	var reminders []entities.BookingReminder
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND send_at <= ?", entities.ReminderStatusPending, now).
+		Order("send_at ASC").
+		Limit(1).
+		Find(&reminders).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to find due reminders: " + err.Error())
+	}
+	if len(reminders) == 0 {
+		return nil, repoerrors.NotFoundError("no reminders due")
+	}
+	return &reminders[0], nil
+}
+
+func (r *gormReminderRepository) Update(reminder *entities.BookingReminder) error {
+	if err := r.db.Save(reminder).Error; err != nil {
+		return repoerrors.InternalError("failed to update reminder: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"gorm.io/gorm"
+)
+
+type ResourceRepository interface {
+	Create(resource *entities.Resource) error
+	Update(resource *entities.Resource) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*entities.Resource, error)
+	ListForUser(userID uuid.UUID) ([]entities.Resource, error)
+	WithTx(tx *gorm.DB) ResourceRepository
+}
+
+type gormResourceRepository struct {
+	db *gorm.DB
+}
+
+func NewGormResourceRepository(db *gorm.DB) ResourceRepository {
+	return &gormResourceRepository{db: db}
+}
+
+func (r *gormResourceRepository) WithTx(tx *gorm.DB) ResourceRepository {
+	return &gormResourceRepository{db: tx}
+}
+
+func (r *gormResourceRepository) Create(resource *entities.Resource) error {
+	if err := r.db.Create(resource).Error; err != nil {
+		return repoerrors.InternalError("failed to create resource: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormResourceRepository) Update(resource *entities.Resource) error {
+	if err := r.db.Save(resource).Error; err != nil {
+		return repoerrors.InternalError("failed to update resource: " + err.Error())
+	}
+	return nil
+}
+
+// Delete removes the resource and stops every appointment from requiring it.
+func (r *gormResourceRepository) Delete(id uuid.UUID) error {
+	if err := r.db.Where("resource_id = ?", id).Delete(&entities.AppointmentResource{}).Error; err != nil {
+		return repoerrors.InternalError("failed to remove resource from appointments: " + err.Error())
+	}
+	if err := r.db.Where("id = ?", id).Delete(&entities.Resource{}).Error; err != nil {
+		return repoerrors.InternalError("failed to delete resource: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormResourceRepository) FindByID(id uuid.UUID) (*entities.Resource, error) {
+	var resource entities.Resource
+	if err := r.db.Where("id = ?", id).First(&resource).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("resource not found")
+		}
+		return nil, repoerrors.InternalError("failed to find resource: " + err.Error())
+	}
+	return &resource, nil
+}
+
+// ListForUser returns the resources the user owns or that are shared with an organization they
+// are a member of.
+func (r *gormResourceRepository) ListForUser(userID uuid.UUID) ([]entities.Resource, error) {
+	var resources []entities.Resource
+	memberOf := r.db.Model(&entities.OrganizationMember{}).Select("organization_id").Where("user_id = ?", userID)
+	err := r.db.
+		Where("owner_id = ? OR organization_id IN (?)", userID, memberOf).
+		Order("name ASC").
+		Find(&resources).Error
+	if err != nil {
+		return nil, repoerrors.InternalError("failed to list resources: " + err.Error())
+	}
+	return resources, nil
+}
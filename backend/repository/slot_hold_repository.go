@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"time"
+
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SlotHoldRepository interface {
+	Create(hold *entities.SlotHold) error
+	Update(hold *entities.SlotHold) error
+	FindByTokenAndLock(token string) (*entities.SlotHold, error)
+	FindExpiredAndLock(now time.Time, limit int) ([]entities.SlotHold, error)
+	WithTx(tx *gorm.DB) SlotHoldRepository
+}
+
+type gormSlotHoldRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSlotHoldRepository(db *gorm.DB) SlotHoldRepository {
+	return &gormSlotHoldRepository{db: db}
+}
+
+func (r *gormSlotHoldRepository) WithTx(tx *gorm.DB) SlotHoldRepository {
+	return &gormSlotHoldRepository{db: tx}
+}
+
+func (r *gormSlotHoldRepository) Create(hold *entities.SlotHold) error {
+	if err := r.db.Create(hold).Error; err != nil {
+		return repoerrors.InternalError("failed to create slot hold: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormSlotHoldRepository) Update(hold *entities.SlotHold) error {
+	if err := r.db.Save(hold).Error; err != nil {
+		return repoerrors.InternalError("failed to update slot hold: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormSlotHoldRepository) FindByTokenAndLock(token string) (*entities.SlotHold, error) {
+	var hold entities.SlotHold
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token = ?", token).
+		First(&hold).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("slot hold not found")
+		}
+		return nil, repoerrors.InternalError("failed to find slot hold: " + err.Error())
+	}
+	return &hold, nil
+}
+
+// FindExpiredAndLock locks up to limit holds that expired before now and still reserve seats.
+// Rows locked by a concurrent redemption are skipped.
+func (r *gormSlotHoldRepository) FindExpiredAndLock(now time.Time, limit int) ([]entities.SlotHold, error) {
+	var holds []entities.SlotHold
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND expires_at <= ?", entities.SlotHoldStatusHeld, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&holds).Error; err != nil {
+		return nil, repoerrors.InternalError("failed to find expired slot holds: " + err.Error())
+	}
+	return holds, nil
+}
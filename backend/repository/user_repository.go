@@ -10,6 +10,7 @@ type UserRepository interface {
 	FindByEmail(email string) (*entities.User, error)
 	FindByPhone(phone string) (*entities.User, error)
 	FindByID(id string) (*entities.User, error)
+	FindByCalendarFeedToken(token string) (*entities.User, error)
 	Create(user *entities.User) error
 	Update(user *entities.User) error
 }
@@ -55,6 +56,17 @@ func (r *gormUserRepository) FindByID(id string) (*entities.User, error) {
 	return &user, nil
 }
 
+func (r *gormUserRepository) FindByCalendarFeedToken(token string) (*entities.User, error) {
+	var user entities.User
+	if err := r.db.Where("calendar_feed_token = ?", token).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("calendar feed not found")
+		}
+		return nil, repoerrors.InternalError("failed to find user by calendar feed token: " + err.Error())
+	}
+	return &user, nil
+}
+
 func (r *gormUserRepository) Create(user *entities.User) error {
 	if err := r.db.Create(user).Error; err != nil {
 		return repoerrors.InternalError("failed to create user: " + err.Error())
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WaitlistRepository interface {
+	Create(entry *entities.WaitlistEntry) error
+	Update(entry *entities.WaitlistEntry) error
+	FindWaitingByEmail(appointmentID uuid.UUID, startTime time.Time, email string) (*entities.WaitlistEntry, error)
+	FindNextAndLock(appointmentID uuid.UUID, startTime time.Time) (*entities.WaitlistEntry, error)
+	WithTx(tx *gorm.DB) WaitlistRepository
+}
+
+type gormWaitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewGormWaitlistRepository(db *gorm.DB) WaitlistRepository {
+	return &gormWaitlistRepository{db: db}
+}
+
+func (r *gormWaitlistRepository) WithTx(tx *gorm.DB) WaitlistRepository {
+	return &gormWaitlistRepository{db: tx}
+}
+
+func (r *gormWaitlistRepository) Create(entry *entities.WaitlistEntry) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		return repoerrors.InternalError("failed to create waitlist entry: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormWaitlistRepository) Update(entry *entities.WaitlistEntry) error {
+	if err := r.db.Save(entry).Error; err != nil {
+		return repoerrors.InternalError("failed to update waitlist entry: " + err.Error())
+	}
+	return nil
+}
+
+func (r *gormWaitlistRepository) FindWaitingByEmail(appointmentID uuid.UUID, startTime time.Time, email string) (*entities.WaitlistEntry, error) {
+	var entry entities.WaitlistEntry
+	if err := r.db.
+		Where("appointment_id = ? AND start_time = ? AND email = ? AND status = ?", appointmentID, startTime, email, entities.WaitlistStatusWaiting).
+		First(&entry).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("waitlist entry not found")
+		}
+		return nil, repoerrors.InternalError("failed to find waitlist entry: " + err.Error())
+	}
+	return &entry, nil
+}
+
+// FindNextAndLock locks the oldest waiting entry for a start time so concurrent releases cannot
+// promote it twice.
+func (r *gormWaitlistRepository) FindNextAndLock(appointmentID uuid.UUID, startTime time.Time) (*entities.WaitlistEntry, error) {
+	var entry entities.WaitlistEntry
+	if err := r.db.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("appointment_id = ? AND start_time = ? AND status = ?", appointmentID, startTime, entities.WaitlistStatusWaiting).
+		Order("created_at ASC").
+		First(&entry).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repoerrors.NotFoundError("no waitlist entry found")
+		}
+		return nil, repoerrors.InternalError("failed to find next waitlist entry: " + err.Error())
+	}
+	return &entry, nil
+}
@@ -29,7 +29,7 @@ func main() {
 	}
 
 	var appointment entities.Appointment
-	if err := db.DB.Where("app_code = ?", appCode).First(&appointment).Error; err != nil {
+	if err := db.DB.Preload("Exceptions").Where("app_code = ?", appCode).First(&appointment).Error; err != nil {
 		log.Fatalf("Appointment not found: %v", err)
 	}
 
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/repository"
+)
+
+// appointmentAccess decides what a user may do on an appointment. The user who created it may do
+// anything; members of the organization it is shared with may do what their role allows.
+type appointmentAccess struct {
+	appointmentRepo repository.AppointmentRepository
+	orgRepo         repository.OrganizationRepository
+}
+
+// authorize returns a forbidden error unless userID holds permission on the appointment.
+func (a appointmentAccess) authorize(appointment *entities.Appointment, userID uuid.UUID, permission entities.Permission) error {
+	if appointment.OwnerID == userID {
+		return nil
+	}
+	if appointment.OrganizationID == nil {
+		return serviceerrors.ForbiddenError("you are not the owner of this appointment")
+	}
+	_, err := a.authorizeOrganization(*appointment.OrganizationID, userID, permission)
+	return err
+}
+
+// allows reports whether userID holds permission on the appointment, for callers that relax a
+// rule rather than refuse the action.
+func (a appointmentAccess) allows(appointment *entities.Appointment, userID uuid.UUID, permission entities.Permission) bool {
+	return a.authorize(appointment, userID, permission) == nil
+}
+
+// authorizeOrganization returns the user's membership when their role grants permission in the
+// organization, and a forbidden error otherwise.
+func (a appointmentAccess) authorizeOrganization(organizationID uuid.UUID, userID uuid.UUID, permission entities.Permission) (*entities.OrganizationMember, error) {
+	member, err := a.orgRepo.FindMember(organizationID, userID)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, serviceerrors.ForbiddenError("you are not a member of this organization")
+		}
+		return nil, serviceerrors.FromError(err)
+	}
+	if !member.Role.Can(permission) {
+		return nil, serviceerrors.ForbiddenError("your organization role does not allow this action")
+	}
+	return member, nil
+}
+
+// findAppointment loads an appointment by ID for an action guarded by permission.
+func (a appointmentAccess) findAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, permission entities.Permission) (*entities.Appointment, error) {
+	appointment, err := a.appointmentRepo.FindByID(ctx, appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.authorize(appointment, userID, permission); err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
@@ -54,6 +54,11 @@ func (s *analyticsServiceImpl) GetUserAnalytics(userID uuid.UUID, startDate, end
 		return nil, serviceerrors.FromError(err)
 	}
 
+	noShowCount, err := s.analyticsRepo.GetUserNoShowCount(userID, timeRange.Start, timeRange.End)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+
 	days := math.Floor(timeRange.End.Sub(timeRange.Start).Hours()/24) + 1
 	if days < 1 {
 		days = 1
@@ -66,12 +71,19 @@ func (s *analyticsServiceImpl) GetUserAnalytics(userID uuid.UUID, startDate, end
 		cancellationRate = (float64(cancellationCount) / denominator) * 100
 	}
 
+	noShowRate := 0.0
+	if bookingCount > 0 {
+		noShowRate = (float64(noShowCount) / float64(bookingCount)) * 100
+	}
+
 	// Build response
 	resp := &responses.AnalyticsResponse{
 		TotalAppointments:   int(appointmentCount),
 		TotalBookings:       int(bookingCount),
 		TotalCancellations:  int(cancellationCount),
 		CancellationRate:    cancellationRate,
+		TotalNoShows:        int(noShowCount),
+		NoShowRate:          noShowRate,
 		AvgBookingsPerDay:   avgBookingsPerDay,
 		StartDate:           timeRange.Start,
 		EndDate:             timeRange.End,
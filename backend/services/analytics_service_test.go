@@ -42,6 +42,7 @@ func TestGetUserAnalytics(t *testing.T) {
 				mockRepo.On("GetBookingsPerDay", userID, start, end).Return([]repository.DateCount{}, nil)
 				mockRepo.On("GetUserCancellationCount", userID, start, end).Return(int64(3), nil)
 				mockRepo.On("GetCancellationsPerDay", userID, start, end).Return([]repository.DateCount{}, nil)
+				mockRepo.On("GetUserNoShowCount", userID, start, end).Return(int64(1), nil)
 			},
 			expectedError: "",
 		},
@@ -115,6 +116,7 @@ func TestGetUserAnalytics_DetailedSuccess(t *testing.T) {
 	mockRepo.On("GetUserCancellationCount", userID, start, end).Return(int64(4), nil)
 	cancellationsPerDay := []repository.DateCount{{Date: "2025-01-05", Count: 1}, {Date: "2025-01-06", Count: 2}}
 	mockRepo.On("GetCancellationsPerDay", userID, start, end).Return(cancellationsPerDay, nil)
+	mockRepo.On("GetUserNoShowCount", userID, start, end).Return(int64(3), nil)
 
 	svc := services.NewAnalyticsService(mockRepo)
 	resp, err := svc.GetUserAnalytics(userID, startDate, endDate)
@@ -124,6 +126,8 @@ func TestGetUserAnalytics_DetailedSuccess(t *testing.T) {
 	assert.Equal(t, 5, resp.TotalAppointments)
 	assert.Equal(t, 12, resp.TotalBookings)
 	assert.Equal(t, 4, resp.TotalCancellations)
+	assert.Equal(t, 3, resp.TotalNoShows)
+	assert.InDelta(t, 25.0, resp.NoShowRate, 0.001)
 	assert.Len(t, resp.BookingsPerDay, 2)
 	assert.Len(t, resp.CancellationsPerDay, 2)
 
@@ -22,24 +22,29 @@ import (
 type appointmentServiceImpl struct {
 	appointmentRepo          repository.AppointmentRepository
 	bookingRepo              repository.BookingRepository
+	exceptionRepo            repository.AvailabilityExceptionRepository
 	userRepo                 repository.UserRepository
-	eventBus                events.EventBus
+	outboxRepo               repository.OutboxRepository
 	eventNotificationService EventNotificationService
+	access                   appointmentAccess
 	db                       *gorm.DB
 }
 
 type StatusRefreshSummary struct {
+	Published        int64
 	PendingToOngoing int64
 	Completed        int64
 }
 
-func NewAppointmentService(appointmentRepo repository.AppointmentRepository, bookingRepo repository.BookingRepository, userRepo repository.UserRepository, eventBus events.EventBus, eventNotificationService EventNotificationService, db *gorm.DB) AppointmentService {
+func NewAppointmentService(appointmentRepo repository.AppointmentRepository, bookingRepo repository.BookingRepository, exceptionRepo repository.AvailabilityExceptionRepository, userRepo repository.UserRepository, orgRepo repository.OrganizationRepository, outboxRepo repository.OutboxRepository, eventNotificationService EventNotificationService, db *gorm.DB) AppointmentService {
 	return &appointmentServiceImpl{
 		appointmentRepo:          appointmentRepo,
 		bookingRepo:              bookingRepo,
+		exceptionRepo:            exceptionRepo,
 		userRepo:                 userRepo,
-		eventBus:                 eventBus,
+		outboxRepo:               outboxRepo,
 		eventNotificationService: eventNotificationService,
+		access:                   appointmentAccess{appointmentRepo: appointmentRepo, orgRepo: orgRepo},
 		db:                       db,
 	}
 }
@@ -48,44 +53,72 @@ func (s *appointmentServiceImpl) CreateAppointment(req requests.AppointmentReque
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
+	if req.OrganizationID != nil {
+		if _, err := s.access.authorizeOrganization(*req.OrganizationID, userId, entities.PermissionManageAppointments); err != nil {
+			return nil, err
+		}
+	}
+
+	status := entities.AppointmentStatusPending
+	var publishAt *time.Time
+	if req.PublishAt != nil && req.PublishAt.After(time.Now()) {
+		at := req.PublishAt.UTC()
+		publishAt = &at
+		status = entities.AppointmentStatusDraft
+	} else if req.Draft {
+		status = entities.AppointmentStatusDraft
+	}
 
 	appointment := &entities.Appointment{
-		Title:             req.Title,
-		StartTime:         req.StartTime,
-		EndTime:           req.EndTime,
-		StartDate:         req.StartDate,
-		EndDate:           req.EndDate,
-		BookingDuration:   req.BookingDuration,
-		Type:              entities.AppointmentType(utils.NormalizeString(fmt.Sprintf("%v", req.Type))),
-		MaxAttendees:      req.MaxAttendees,
-		OwnerID:           userId,
-		Description:       req.Description,
-		AntiScalpingLevel: req.AntiScalpingLevel,
-		Status:            entities.AppointmentStatusPending,
-	}
-
-	if err := s.appointmentRepo.Create(appointment); err != nil {
+		Title:                   req.Title,
+		StartTime:               req.StartTime,
+		EndTime:                 req.EndTime,
+		StartDate:               req.StartDate,
+		EndDate:                 req.EndDate,
+		BookingDuration:         req.BookingDuration,
+		Type:                    entities.AppointmentType(utils.NormalizeString(fmt.Sprintf("%v", req.Type))),
+		MaxAttendees:            req.MaxAttendees,
+		OwnerID:                 userId,
+		Description:             req.Description,
+		AntiScalpingLevel:       req.AntiScalpingLevel,
+		RecurrenceRule:          req.RecurrenceRule,
+		TimeZone:                req.TimeZone,
+		Availability:            req.Availability,
+		PreBufferMinutes:        req.PreBufferMinutes,
+		PostBufferMinutes:       req.PostBufferMinutes,
+		MinNoticeMinutes:        req.MinNoticeMinutes,
+		MaxAdvanceDays:          req.MaxAdvanceDays,
+		CancellationCutoffHours: req.CancellationCutoffHours,
+		RescheduleCutoffHours:   req.RescheduleCutoffHours,
+		MaxReschedules:          req.MaxReschedules,
+		FormFields:              req.FormFields,
+		TicketTypes:             req.TicketTypes,
+		Price:                   req.Price,
+		Currency:                req.Currency,
+		RefundCutoffHours:       req.RefundCutoffHours,
+		PublishAt:               publishAt,
+		NoShowGraceMinutes:      req.NoShowGraceMinutes,
+		ReminderOffsets:         req.ReminderOffsets,
+		AllowParallelBookings:   req.AllowParallelBookings,
+		OrganizationID:          req.OrganizationID,
+		Status:                  status,
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.appointmentRepo.WithTx(tx).Create(appointment); err != nil {
+			return err
+		}
+		return enqueueEvent(s.outboxRepo, tx, s.appointmentEvent(events.EventAppointmentCreated, appointment))
+	})
+	if err != nil {
 		log.Printf("[CreateAppointment] DB error: %v", err)
 		return nil, serviceerrors.FromError(err)
 	}
 
-	payload := events.AppointmentEventData{
-		Appointment:      appointment,
-		OwnerID:          appointment.OwnerID,
-		AppointmentTitle: appointment.Title,
-	}
-	if owner, ownerErr := s.userRepo.FindByID(appointment.OwnerID.String()); ownerErr == nil {
-		payload.RecipientEmail = owner.Email
-		payload.RecipientName = owner.Name
-	}
-	if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventAppointmentCreated, Data: payload}); pubErr != nil {
-		log.Printf("Failed to publish appointment created event: %v", pubErr)
-	}
-
 	return appointment, nil
 }
 
-func (s *appointmentServiceImpl) UpdateAppointment(ctx context.Context, appointmentID uuid.UUID, ownerID uuid.UUID, req requests.AppointmentRequest) (*entities.Appointment, error) {
+func (s *appointmentServiceImpl) UpdateAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.AppointmentRequest) (*entities.Appointment, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -93,10 +126,15 @@ func (s *appointmentServiceImpl) UpdateAppointment(ctx context.Context, appointm
 		return nil, err
 	}
 
-	appointment, err := s.appointmentRepo.FindByIDAndOwner(ctx, appointmentID, ownerID)
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments)
 	if err != nil {
 		return nil, err
 	}
+	if req.OrganizationID != nil && (appointment.OrganizationID == nil || *req.OrganizationID != *appointment.OrganizationID) {
+		if _, err := s.access.authorizeOrganization(*req.OrganizationID, userID, entities.PermissionManageAppointments); err != nil {
+			return nil, err
+		}
+	}
 
 	hasBookings, err := s.bookingRepo.HasActiveBookings(appointment.ID)
 	if err != nil {
@@ -120,6 +158,25 @@ func (s *appointmentServiceImpl) UpdateAppointment(ctx context.Context, appointm
 		appointment.MaxAttendees = req.MaxAttendees
 		appointment.Description = req.Description
 		appointment.AntiScalpingLevel = req.AntiScalpingLevel
+		appointment.RecurrenceRule = req.RecurrenceRule
+		appointment.TimeZone = req.TimeZone
+		appointment.Availability = req.Availability
+		appointment.PreBufferMinutes = req.PreBufferMinutes
+		appointment.PostBufferMinutes = req.PostBufferMinutes
+		appointment.MinNoticeMinutes = req.MinNoticeMinutes
+		appointment.MaxAdvanceDays = req.MaxAdvanceDays
+		appointment.CancellationCutoffHours = req.CancellationCutoffHours
+		appointment.RescheduleCutoffHours = req.RescheduleCutoffHours
+		appointment.MaxReschedules = req.MaxReschedules
+		appointment.FormFields = req.FormFields
+		appointment.TicketTypes = req.TicketTypes
+		appointment.Price = req.Price
+		appointment.Currency = req.Currency
+		appointment.RefundCutoffHours = req.RefundCutoffHours
+		appointment.NoShowGraceMinutes = req.NoShowGraceMinutes
+		appointment.ReminderOffsets = req.ReminderOffsets
+		appointment.AllowParallelBookings = req.AllowParallelBookings
+		appointment.OrganizationID = req.OrganizationID
 		appointment.AttendeesBooked = 0
 
 		if err := appRepo.Update(appointment); err != nil {
@@ -130,41 +187,34 @@ func (s *appointmentServiceImpl) UpdateAppointment(ctx context.Context, appointm
 			return err
 		}
 
+		exceptions, err := s.exceptionRepo.WithTx(tx).ListByAppointment(appointment.ID)
+		if err != nil {
+			return err
+		}
+		appointment.Exceptions = exceptions
+
 		slots := appointment.GenerateBookings()
 		if len(slots) > 0 {
 			if err := tx.Create(&slots).Error; err != nil {
 				return err
 			}
 		}
-		return nil
+		return enqueueEvent(s.outboxRepo, tx, s.appointmentEvent(events.EventAppointmentUpdated, appointment))
 	})
 
 	if err != nil {
 		return nil, serviceerrors.FromError(err)
 	}
 
-	payload := events.AppointmentEventData{
-		Appointment:      appointment,
-		OwnerID:          appointment.OwnerID,
-		AppointmentTitle: appointment.Title,
-	}
-	if owner, ownerErr := s.userRepo.FindByID(appointment.OwnerID.String()); ownerErr == nil {
-		payload.RecipientEmail = owner.Email
-		payload.RecipientName = owner.Name
-	}
-	if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventAppointmentUpdated, Data: payload}); pubErr != nil {
-		log.Printf("Failed to publish appointment updated event: %v", pubErr)
-	}
-
 	return appointment, nil
 }
 
-func (s *appointmentServiceImpl) DeleteAppointment(ctx context.Context, appointmentID uuid.UUID, ownerID uuid.UUID) (*entities.Appointment, error) {
+func (s *appointmentServiceImpl) DeleteAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	appointment, err := s.appointmentRepo.FindByIDAndOwner(ctx, appointmentID, ownerID)
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments)
 	if err != nil {
 		return nil, err
 	}
@@ -222,6 +272,9 @@ func (s *appointmentServiceImpl) DeleteAppointment(ctx context.Context, appointm
 				if err := bookRepo.Update(booking); err != nil {
 					return err
 				}
+				if err := syncHostConflicts(bookRepo, appointment.OwnerID, booking.StartTime, booking.EndTime); err != nil {
+					return err
+				}
 				notifyBookings = append(notifyBookings, *booking)
 			default:
 				slot, err := bookRepo.FindAndLockSlot(booking.AppCode, booking.Date, booking.StartTime)
@@ -257,26 +310,31 @@ func (s *appointmentServiceImpl) DeleteAppointment(ctx context.Context, appointm
 			return err
 		}
 
-		return nil
+		for i := range notifyBookings {
+			payload := events.BookingEventData{
+				Booking:          &notifyBookings[i],
+				OwnerID:          appointment.OwnerID,
+				AppointmentTitle: appointment.Title,
+				RecipientEmail:   notifyBookings[i].Email,
+				RecipientName:    notifyBookings[i].Name,
+				CancelledByOwner: true,
+			}
+			if err := enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingCancelled, Data: payload}); err != nil {
+				return err
+			}
+		}
+		return enqueueEvent(s.outboxRepo, tx, s.appointmentEvent(events.EventAppointmentDeleted, appointment))
 	})
 
 	if err != nil {
 		return nil, serviceerrors.FromError(err)
 	}
 
-	for _, booking := range notifyBookings {
-		payload := events.BookingEventData{
-			Booking:          &booking,
-			OwnerID:          appointment.OwnerID,
-			AppointmentTitle: appointment.Title,
-			RecipientEmail:   booking.Email,
-			RecipientName:    booking.Name,
-		}
-		if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventBookingCancelled, Data: payload}); pubErr != nil {
-			log.Printf("Failed to publish booking cancelled event: %v", pubErr)
-		}
-	}
+	return appointment, nil
+}
 
+// appointmentEvent builds the event name about appointment, addressed to its owner.
+func (s *appointmentServiceImpl) appointmentEvent(name string, appointment *entities.Appointment) events.Event {
 	payload := events.AppointmentEventData{
 		Appointment:      appointment,
 		OwnerID:          appointment.OwnerID,
@@ -286,11 +344,7 @@ func (s *appointmentServiceImpl) DeleteAppointment(ctx context.Context, appointm
 		payload.RecipientEmail = owner.Email
 		payload.RecipientName = owner.Name
 	}
-	if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventAppointmentDeleted, Data: payload}); pubErr != nil {
-		log.Printf("Failed to publish appointment deleted event: %v", pubErr)
-	}
-
-	return appointment, nil
+	return events.Event{Name: name, Data: payload}
 }
 
 func (s *appointmentServiceImpl) GetAllAppointmentsCreatedByUser(userID string, r *http.Request, statuses []entities.AppointmentStatus) paginate.Page {
@@ -307,11 +361,11 @@ func (s *appointmentServiceImpl) GetAllAppointmentsCreatedByUser(userID string,
 	return s.appointmentRepo.GetAppointmentsByOwnerIDQuery(ctx, r, uid, statuses)
 }
 
-func (s *appointmentServiceImpl) CancelAppointment(ctx context.Context, appointmentID uuid.UUID, ownerID uuid.UUID) (*entities.Appointment, error) {
+func (s *appointmentServiceImpl) CancelAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	appointment, err := s.appointmentRepo.FindByIDAndOwner(ctx, appointmentID, ownerID)
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments)
 	if err != nil {
 		return nil, err
 	}
@@ -334,8 +388,79 @@ func (s *appointmentServiceImpl) CancelAppointment(ctx context.Context, appointm
 	appointment.Status = entities.AppointmentStatusCanceled
 
 	message := fmt.Sprintf("Appointment '%s' was canceled.", appointment.Title)
-	s.eventNotificationService.CreateEventNotification(ownerID, "APPOINTMENT_CANCELED", message, appointment.ID)
+	s.eventNotificationService.CreateEventNotification(appointment.OwnerID, "APPOINTMENT_CANCELED", message, appointment.ID)
+
+	return appointment, nil
+}
+
+// PublishAppointment makes a draft bookable. A PublishAt in the future schedules publishing
+// instead, leaving the draft for the status scheduler to publish at that time.
+func (s *appointmentServiceImpl) PublishAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.PublishAppointmentRequest) (*entities.Appointment, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status != entities.AppointmentStatusDraft {
+		return nil, serviceerrors.ConflictError("only draft appointments can be published")
+	}
+
+	if req.PublishAt != nil && req.PublishAt.After(time.Now()) {
+		at := req.PublishAt.UTC()
+		appointment.PublishAt = &at
+	} else {
+		appointment.PublishAt = nil
+		appointment.Status = entities.AppointmentStatusPending
+	}
+	if err := s.appointmentRepo.Update(appointment); err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+// PauseAppointment stops new bookings without touching existing ones.
+func (s *appointmentServiceImpl) PauseAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error) {
+	return s.transitionAppointment(ctx, appointmentID, userID, func(*entities.Appointment) entities.AppointmentStatus {
+		return entities.AppointmentStatusPaused
+	}, "only published appointments can be paused")
+}
 
+// ResumeAppointment reopens a paused appointment for booking, as ongoing if it has already started.
+func (s *appointmentServiceImpl) ResumeAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error) {
+	return s.transitionAppointment(ctx, appointmentID, userID, func(appointment *entities.Appointment) entities.AppointmentStatus {
+		if appointment.Status == entities.AppointmentStatusPaused && !time.Now().Before(appointment.StartTime) {
+			return entities.AppointmentStatusOngoing
+		}
+		return entities.AppointmentStatusPending
+	}, "only paused appointments can be resumed")
+}
+
+// ArchiveAppointment retires a draft, completed or canceled appointment.
+func (s *appointmentServiceImpl) ArchiveAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error) {
+	return s.transitionAppointment(ctx, appointmentID, userID, func(*entities.Appointment) entities.AppointmentStatus {
+		return entities.AppointmentStatusArchived
+	}, "only draft, completed or canceled appointments can be archived")
+}
+
+func (s *appointmentServiceImpl) transitionAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, target func(*entities.Appointment) entities.AppointmentStatus, conflictMessage string) (*entities.Appointment, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments)
+	if err != nil {
+		return nil, err
+	}
+
+	to := target(appointment)
+	if !entities.CanTransitionAppointmentStatus(appointment.Status, to) {
+		return nil, serviceerrors.ConflictError(conflictMessage)
+	}
+	if err := s.appointmentRepo.UpdateStatus(ctx, appointment.ID, to); err != nil {
+		return nil, err
+	}
+	appointment.Status = to
 	return appointment, nil
 }
 
@@ -348,7 +473,13 @@ func (s *appointmentServiceImpl) RefreshStatuses(ctx context.Context, now time.T
 	}
 
 	var summary StatusRefreshSummary
-	updated, err := s.appointmentRepo.MarkAppointmentsOngoing(ctx, now)
+	updated, err := s.appointmentRepo.PublishScheduledAppointments(ctx, now)
+	if err != nil {
+		return summary, err
+	}
+	summary.Published = updated
+
+	updated, err = s.appointmentRepo.MarkAppointmentsOngoing(ctx, now)
 	if err != nil {
 		return summary, err
 	}
@@ -368,5 +499,16 @@ func (s *appointmentServiceImpl) GetAppointmentByAppCode(appCode string) (*entit
 	if err != nil {
 		return nil, err
 	}
+	if len(appointment.TicketTypes) > 0 {
+		sales, err := s.bookingRepo.CountTicketSales(appointment.ID, nil)
+		if err != nil {
+			return nil, serviceerrors.FromError(err)
+		}
+		// Every ticket type is listed, in the owner's order, including unsold ones.
+		appointment.TicketSales = make([]entities.TicketSales, 0, len(appointment.TicketTypes))
+		for _, ticket := range appointment.TicketTypes {
+			appointment.TicketSales = append(appointment.TicketSales, entities.TicketSales{Key: ticket.Key, Booked: sales[ticket.Key]})
+		}
+	}
 	return appointment, nil
 }
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/models/responses"
+	"gorm.io/gorm"
+)
+
+func (s *appointmentServiceImpl) ListHosts(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*responses.AppointmentHostsResponse, error) {
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionViewBookings)
+	if err != nil {
+		return nil, err
+	}
+	return s.hostsResponse(appointment)
+}
+
+// SetHosts replaces the appointment's hosts and assignment strategy. Hosts must be the owner or
+// members of the organization the appointment is shared with. Existing bookings keep their host.
+func (s *appointmentServiceImpl) SetHosts(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.AppointmentHostsRequest) (*responses.AppointmentHostsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]entities.AppointmentHost, 0, len(req.Hosts))
+	for _, host := range req.Hosts {
+		if err := s.checkHostEligible(appointment, host.UserID); err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, entities.AppointmentHost{AppointmentID: appointment.ID, UserID: host.UserID, Priority: host.Priority})
+	}
+
+	appointment.HostAssignment = req.Assignment
+	appointment.LastHostID = nil
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		appRepo := s.appointmentRepo.WithTx(tx)
+		if err := appRepo.Update(appointment); err != nil {
+			return err
+		}
+		return appRepo.ReplaceHosts(appointment.ID, hosts)
+	})
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return s.hostsResponse(appointment)
+}
+
+func (s *appointmentServiceImpl) checkHostEligible(appointment *entities.Appointment, hostID uuid.UUID) error {
+	if hostID == appointment.OwnerID {
+		return nil
+	}
+	if appointment.OrganizationID == nil {
+		return serviceerrors.ValidationError("share the appointment with an organization to add other hosts")
+	}
+	if _, err := s.access.orgRepo.FindMember(*appointment.OrganizationID, hostID); err != nil {
+		if isRepoNotFound(err) {
+			return serviceerrors.ValidationError("hosts must be members of the appointment's organization")
+		}
+		return serviceerrors.FromError(err)
+	}
+	return nil
+}
+
+func (s *appointmentServiceImpl) hostsResponse(appointment *entities.Appointment) (*responses.AppointmentHostsResponse, error) {
+	hosts, err := s.appointmentRepo.ListHosts(appointment.ID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	entities.SortHosts(hosts)
+
+	assignment := appointment.HostAssignment
+	if assignment == "" {
+		assignment = entities.HostAssignmentRoundRobin
+	}
+	response := &responses.AppointmentHostsResponse{
+		Assignment: assignment,
+		Hosts:      make([]responses.AppointmentHostResponse, 0, len(hosts)),
+	}
+	for _, host := range hosts {
+		response.Hosts = append(response.Hosts, responses.AppointmentHostResponse{
+			UserID:   host.UserID,
+			Name:     host.User.Name,
+			Email:    host.User.Email,
+			Priority: host.Priority,
+		})
+	}
+	return response, nil
+}
@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
 	"github.com/m13ha/asiko/events"
 	"github.com/m13ha/asiko/models/entities"
 	"github.com/m13ha/asiko/models/requests"
@@ -15,28 +17,17 @@ import (
 	servicemocks "github.com/m13ha/asiko/services/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
-type MockEventBus struct {
-	mock.Mock
-}
-
-func (m *MockEventBus) Publish(ctx context.Context, event events.Event) error {
-	args := m.Called(ctx, event)
-	return args.Error(0)
-}
-
-func (m *MockEventBus) Subscribe(handler events.EventHandler) {
-	m.Called(handler)
-}
-
 func TestCreateAppointment(t *testing.T) {
 	userID := uuid.New()
 
 	testCases := []struct {
 		name          string
 		request       requests.AppointmentRequest
-		setupMock     func(mockRepo *repomocks.AppointmentRepository, mockEventBus *MockEventBus, mockEventNotificationService *servicemocks.EventNotificationService)
+		setupMock     func(mockRepo *repomocks.AppointmentRepository, mockOutboxRepo *repomocks.OutboxRepository, mockEventNotificationService *servicemocks.EventNotificationService, sqlMock sqlmock.Sqlmock)
 		expectedError string
 	}{
 		{
@@ -51,11 +42,13 @@ func TestCreateAppointment(t *testing.T) {
 				Type:            entities.Single,
 				MaxAttendees:    1,
 			},
-			setupMock: func(mockRepo *repomocks.AppointmentRepository, mockEventBus *MockEventBus, mockEventNotificationService *servicemocks.EventNotificationService) {
+			setupMock: func(mockRepo *repomocks.AppointmentRepository, mockOutboxRepo *repomocks.OutboxRepository, mockEventNotificationService *servicemocks.EventNotificationService, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
 				mockRepo.On("Create", mock.AnythingOfType("*entities.Appointment")).Return(nil).Once()
-				mockEventBus.On("Publish", mock.Anything, mock.MatchedBy(func(event events.Event) bool {
+				mockOutboxRepo.On("Create", outboxEvent(func(event events.Event) bool {
 					return event.Name == events.EventAppointmentCreated
 				})).Return(nil).Once()
+				sqlMock.ExpectCommit()
 			},
 			expectedError: "",
 		},
@@ -64,7 +57,7 @@ func TestCreateAppointment(t *testing.T) {
 			request: requests.AppointmentRequest{
 				Title: "", // Invalid title
 			},
-			setupMock: func(mockRepo *repomocks.AppointmentRepository, mockEventBus *MockEventBus, mockEventNotificationService *servicemocks.EventNotificationService) {
+			setupMock: func(mockRepo *repomocks.AppointmentRepository, mockOutboxRepo *repomocks.OutboxRepository, mockEventNotificationService *servicemocks.EventNotificationService, sqlMock sqlmock.Sqlmock) {
 			},
 			expectedError: "USER_ERROR: Invalid appointment data. Please check your input.",
 		},
@@ -80,8 +73,10 @@ func TestCreateAppointment(t *testing.T) {
 				Type:            entities.Single,
 				MaxAttendees:    1,
 			},
-			setupMock: func(mockRepo *repomocks.AppointmentRepository, mockEventBus *MockEventBus, mockEventNotificationService *servicemocks.EventNotificationService) {
+			setupMock: func(mockRepo *repomocks.AppointmentRepository, mockOutboxRepo *repomocks.OutboxRepository, mockEventNotificationService *servicemocks.EventNotificationService, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
 				mockRepo.On("Create", mock.AnythingOfType("*entities.Appointment")).Return(fmt.Errorf("db error")).Once()
+				sqlMock.ExpectRollback()
 			},
 			expectedError: "INTERNAL_ERROR: db error (caused by: db error)",
 		},
@@ -104,11 +99,13 @@ func TestCreateAppointment(t *testing.T) {
 					MaxAttendees:    5,
 				}
 			}(),
-			setupMock: func(mockRepo *repomocks.AppointmentRepository, mockEventBus *MockEventBus, mockEventNotificationService *servicemocks.EventNotificationService) {
+			setupMock: func(mockRepo *repomocks.AppointmentRepository, mockOutboxRepo *repomocks.OutboxRepository, mockEventNotificationService *servicemocks.EventNotificationService, sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectBegin()
 				mockRepo.On("Create", mock.AnythingOfType("*entities.Appointment")).Return(nil).Once()
-				mockEventBus.On("Publish", mock.Anything, mock.MatchedBy(func(event events.Event) bool {
+				mockOutboxRepo.On("Create", outboxEvent(func(event events.Event) bool {
 					return event.Name == events.EventAppointmentCreated
 				})).Return(nil).Once()
+				sqlMock.ExpectCommit()
 			},
 			expectedError: "",
 		},
@@ -131,7 +128,7 @@ func TestCreateAppointment(t *testing.T) {
 					MaxAttendees:    50,
 				}
 			}(),
-			setupMock: func(mockRepo *repomocks.AppointmentRepository, mockEventBus *MockEventBus, mockEventNotificationService *servicemocks.EventNotificationService) {},
+			setupMock: func(mockRepo *repomocks.AppointmentRepository, mockOutboxRepo *repomocks.OutboxRepository, mockEventNotificationService *servicemocks.EventNotificationService, sqlMock sqlmock.Sqlmock) {},
 			expectedError: "VALIDATION_FAILED: Party appointments cannot span more than one day.",
 		},
 	}
@@ -140,13 +137,18 @@ func TestCreateAppointment(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Arrange
 			mockAppointmentRepo := new(repomocks.AppointmentRepository)
+			mockAppointmentRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockAppointmentRepo).Maybe()
 			mockBookingRepo := new(repomocks.BookingRepository)
 			mockUserRepo := new(repomocks.UserRepository)
-			mockEventBus := new(MockEventBus)
+			mockOutboxRepo := newMockOutboxRepo()
 			mockEventNotificationService := new(servicemocks.EventNotificationService)
 			mockUserRepo.On("FindByID", userID.String()).Return(&entities.User{ID: userID, Name: "Owner", Email: "owner@example.com"}, nil).Maybe()
-			tc.setupMock(mockAppointmentRepo, mockEventBus, mockEventNotificationService)
-			appointmentService := services.NewAppointmentService(mockAppointmentRepo, mockBookingRepo, mockUserRepo, mockEventBus, mockEventNotificationService, nil)
+			db, sqlMock, _ := sqlmock.New()
+			gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+				Conn: db,
+			}), &gorm.Config{})
+			tc.setupMock(mockAppointmentRepo, mockOutboxRepo, mockEventNotificationService, sqlMock)
+			appointmentService := services.NewAppointmentService(mockAppointmentRepo, mockBookingRepo, new(repomocks.AvailabilityExceptionRepository), mockUserRepo, new(repomocks.OrganizationRepository), mockOutboxRepo, mockEventNotificationService, gormDB)
 
 			// Act
 			appointment, err := appointmentService.CreateAppointment(tc.request, userID)
@@ -162,8 +164,9 @@ func TestCreateAppointment(t *testing.T) {
 				assert.Equal(t, tc.expectedError, err.Error())
 			}
 			mockAppointmentRepo.AssertExpectations(t)
-			mockEventBus.AssertExpectations(t)
+			mockOutboxRepo.AssertExpectations(t)
 			mockEventNotificationService.AssertExpectations(t)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
 		})
 	}
 }
@@ -176,12 +179,12 @@ func TestCancelAppointment(t *testing.T) {
 
 	mockAppointmentRepo := new(repomocks.AppointmentRepository)
 	mockEventNotificationService := new(servicemocks.EventNotificationService)
-	mockAppointmentRepo.On("FindByIDAndOwner", ctx, appointmentID, ownerID).Return(appointment, nil).Once()
+	mockAppointmentRepo.On("FindByID", ctx, appointmentID).Return(appointment, nil).Once()
 	mockAppointmentRepo.On("UpdateStatus", ctx, appointmentID, entities.AppointmentStatusCanceled).Return(nil).Once()
 	mockEventNotificationService.On("CreateEventNotification", ownerID, "APPOINTMENT_CANCELED", mock.AnythingOfType("string"), appointmentID).Return(nil).Once()
 
-	mockEventBus := new(MockEventBus)
-	svc := services.NewAppointmentService(mockAppointmentRepo, new(repomocks.BookingRepository), new(repomocks.UserRepository), mockEventBus, mockEventNotificationService, nil)
+	mockOutboxRepo := newMockOutboxRepo()
+	svc := services.NewAppointmentService(mockAppointmentRepo, new(repomocks.BookingRepository), new(repomocks.AvailabilityExceptionRepository), new(repomocks.UserRepository), new(repomocks.OrganizationRepository), mockOutboxRepo, mockEventNotificationService, nil)
 
 	result, err := svc.CancelAppointment(ctx, appointmentID, ownerID)
 
@@ -197,16 +200,105 @@ func TestRefreshStatuses(t *testing.T) {
 
 	mockAppointmentRepo := new(repomocks.AppointmentRepository)
 	mockEventNotificationService := new(servicemocks.EventNotificationService)
+	mockAppointmentRepo.On("PublishScheduledAppointments", ctx, now).Return(int64(3), nil).Once()
 	mockAppointmentRepo.On("MarkAppointmentsOngoing", ctx, now).Return(int64(2), nil).Once()
 	mockAppointmentRepo.On("MarkAppointmentsCompleted", ctx, now).Return(int64(1), nil).Once()
 
-	mockEventBus := new(MockEventBus)
-	svc := services.NewAppointmentService(mockAppointmentRepo, new(repomocks.BookingRepository), new(repomocks.UserRepository), mockEventBus, mockEventNotificationService, nil)
+	mockOutboxRepo := newMockOutboxRepo()
+	svc := services.NewAppointmentService(mockAppointmentRepo, new(repomocks.BookingRepository), new(repomocks.AvailabilityExceptionRepository), new(repomocks.UserRepository), new(repomocks.OrganizationRepository), mockOutboxRepo, mockEventNotificationService, nil)
 
 	summary, err := svc.RefreshStatuses(ctx, now)
 
 	assert.NoError(t, err)
+	assert.Equal(t, int64(3), summary.Published)
 	assert.Equal(t, int64(2), summary.PendingToOngoing)
 	assert.Equal(t, int64(1), summary.Completed)
 	mockAppointmentRepo.AssertExpectations(t)
 }
+
+func TestAppointmentLifecycleTransitions(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	newService := func(repo *repomocks.AppointmentRepository) services.AppointmentService {
+		return services.NewAppointmentService(repo, new(repomocks.BookingRepository), new(repomocks.AvailabilityExceptionRepository), new(repomocks.UserRepository), new(repomocks.OrganizationRepository), newMockOutboxRepo(), new(servicemocks.EventNotificationService), nil)
+	}
+
+	t.Run("publish draft now", func(t *testing.T) {
+		appointment := &entities.Appointment{ID: uuid.New(), OwnerID: ownerID, Status: entities.AppointmentStatusDraft}
+		repo := new(repomocks.AppointmentRepository)
+		repo.On("FindByID", ctx, appointment.ID).Return(appointment, nil).Once()
+		repo.On("Update", appointment).Return(nil).Once()
+
+		result, err := newService(repo).PublishAppointment(ctx, appointment.ID, ownerID, requests.PublishAppointmentRequest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.AppointmentStatusPending, result.Status)
+		assert.Nil(t, result.PublishAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("schedule publishing", func(t *testing.T) {
+		appointment := &entities.Appointment{ID: uuid.New(), OwnerID: ownerID, Status: entities.AppointmentStatusDraft}
+		publishAt := time.Now().Add(48 * time.Hour)
+		repo := new(repomocks.AppointmentRepository)
+		repo.On("FindByID", ctx, appointment.ID).Return(appointment, nil).Once()
+		repo.On("Update", appointment).Return(nil).Once()
+
+		result, err := newService(repo).PublishAppointment(ctx, appointment.ID, ownerID, requests.PublishAppointmentRequest{PublishAt: &publishAt})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.AppointmentStatusDraft, result.Status)
+		assert.True(t, result.PublishAt.Equal(publishAt))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("pause and resume after start", func(t *testing.T) {
+		appointment := &entities.Appointment{ID: uuid.New(), OwnerID: ownerID, Status: entities.AppointmentStatusPending, StartTime: time.Now().Add(-time.Hour)}
+		repo := new(repomocks.AppointmentRepository)
+		repo.On("FindByID", ctx, appointment.ID).Return(appointment, nil).Twice()
+		repo.On("UpdateStatus", ctx, appointment.ID, entities.AppointmentStatusPaused).Return(nil).Once()
+		repo.On("UpdateStatus", ctx, appointment.ID, entities.AppointmentStatusOngoing).Return(nil).Once()
+		svc := newService(repo)
+
+		paused, err := svc.PauseAppointment(ctx, appointment.ID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, entities.AppointmentStatusPaused, paused.Status)
+
+		resumed, err := svc.ResumeAppointment(ctx, appointment.ID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, entities.AppointmentStatusOngoing, resumed.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("archive rejects live appointments", func(t *testing.T) {
+		appointment := &entities.Appointment{ID: uuid.New(), OwnerID: ownerID, Status: entities.AppointmentStatusPending}
+		repo := new(repomocks.AppointmentRepository)
+		repo.On("FindByID", ctx, appointment.ID).Return(appointment, nil).Once()
+
+		_, err := newService(repo).ArchiveAppointment(ctx, appointment.ID, ownerID)
+
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSetHostsRequiresOrganizationMembers(t *testing.T) {
+	ctx := context.Background()
+	ownerID, memberID, outsiderID, orgID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	appointment := &entities.Appointment{ID: uuid.New(), OwnerID: ownerID, OrganizationID: &orgID}
+	repo := new(repomocks.AppointmentRepository)
+	orgRepo := new(repomocks.OrganizationRepository)
+	repo.On("FindByID", ctx, appointment.ID).Return(appointment, nil).Once()
+	orgRepo.On("FindMember", orgID, memberID).Return(&entities.OrganizationMember{OrganizationID: orgID, UserID: memberID, Role: entities.OrganizationRoleStaff}, nil).Once()
+	orgRepo.On("FindMember", orgID, outsiderID).Return(nil, repoerrors.NotFoundError("organization member not found")).Once()
+	svc := services.NewAppointmentService(repo, new(repomocks.BookingRepository), new(repomocks.AvailabilityExceptionRepository), new(repomocks.UserRepository), orgRepo, newMockOutboxRepo(), new(servicemocks.EventNotificationService), nil)
+
+	_, err := svc.SetHosts(ctx, appointment.ID, ownerID, requests.AppointmentHostsRequest{
+		Assignment: entities.HostAssignmentLeastBusy,
+		Hosts:      []requests.AppointmentHostRequest{{UserID: ownerID}, {UserID: memberID}, {UserID: outsiderID}},
+	})
+
+	assert.Error(t, err)
+	repo.AssertNotCalled(t, "ReplaceHosts", mock.Anything, mock.Anything)
+	orgRepo.AssertExpectations(t)
+}
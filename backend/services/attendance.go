@@ -0,0 +1,81 @@
+package services
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+)
+
+// CheckInBooking records that the guest has arrived. Bookings that have already ended are marked
+// attended straight away, which lets owners record attendance after the fact or correct a no-show.
+func (s *bookingServiceImpl) CheckInBooking(bookingCode string, userID uuid.UUID) (*entities.Booking, error) {
+	booking, err := s.findManagedBooking(bookingCode, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.checkIn(booking)
+}
+
+func (s *bookingServiceImpl) checkIn(booking *entities.Booking) (*entities.Booking, error) {
+	now := time.Now()
+	status := entities.BookingStatusCheckedIn
+	if booking.EndTime.Before(now) {
+		status = entities.BookingStatusAttended
+	}
+	if !entities.CanTransitionBookingStatus(booking.Status, status) {
+		return nil, serviceerrors.ConflictError("booking cannot be checked in in its current status")
+	}
+
+	checkedIn, err := s.bookingRepo.CheckIn(booking.ID, booking.Status, status, now)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	if !checkedIn {
+		return nil, serviceerrors.ConflictError("booking has already been checked in")
+	}
+	booking.Status = status
+	booking.CheckedInAt = &now
+	return booking, nil
+}
+
+// MarkNoShow records that the guest never turned up. It is only allowed once the booking has
+// started.
+func (s *bookingServiceImpl) MarkNoShow(bookingCode string, userID uuid.UUID) (*entities.Booking, error) {
+	booking, err := s.findManagedBooking(bookingCode, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.StartTime.After(time.Now()) {
+		return nil, serviceerrors.ConflictError("bookings cannot be marked no-show before they start")
+	}
+	if !entities.CanTransitionBookingStatus(booking.Status, entities.BookingStatusNoShow) {
+		return nil, serviceerrors.ConflictError("booking cannot be marked no-show in its current status")
+	}
+
+	booking.Status = entities.BookingStatusNoShow
+	booking.CheckedInAt = nil
+	if err := s.bookingRepo.Update(booking); err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return booking, nil
+}
+
+// findManagedBooking loads a booking for an action that requires managing its appointment's bookings.
+func (s *bookingServiceImpl) findManagedBooking(bookingCode string, userID uuid.UUID) (*entities.Booking, error) {
+	booking, err := s.GetBookingByCode(bookingCode)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment, err := s.appointmentRepo.FindAppointmentByAppCode(booking.AppCode)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	if err := s.access.authorize(appointment, userID, entities.PermissionManageBookings); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
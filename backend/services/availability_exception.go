@@ -0,0 +1,406 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/events"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/repository"
+	"gorm.io/gorm"
+)
+
+type availabilityExceptionServiceImpl struct {
+	exceptionRepo   repository.AvailabilityExceptionRepository
+	appointmentRepo repository.AppointmentRepository
+	bookingRepo     repository.BookingRepository
+	outboxRepo      repository.OutboxRepository
+	access          appointmentAccess
+	db              *gorm.DB
+}
+
+func NewAvailabilityExceptionService(exceptionRepo repository.AvailabilityExceptionRepository, appointmentRepo repository.AppointmentRepository, bookingRepo repository.BookingRepository, orgRepo repository.OrganizationRepository, outboxRepo repository.OutboxRepository, db *gorm.DB) AvailabilityExceptionService {
+	return &availabilityExceptionServiceImpl{
+		exceptionRepo:   exceptionRepo,
+		appointmentRepo: appointmentRepo,
+		bookingRepo:     bookingRepo,
+		outboxRepo:      outboxRepo,
+		access:          appointmentAccess{appointmentRepo: appointmentRepo, orgRepo: orgRepo},
+		db:              db,
+	}
+}
+
+func (s *availabilityExceptionServiceImpl) ListExceptions(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) ([]entities.AvailabilityException, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments)
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := s.exceptionRepo.ListByAppointment(appointment.ID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return exceptions, nil
+}
+
+func (s *availabilityExceptionServiceImpl) CreateException(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.AvailabilityExceptionRequest) (*entities.AvailabilityException, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments)
+	if err != nil {
+		return nil, err
+	}
+	day, err := exceptionDate(appointment, req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	exception := &entities.AvailabilityException{
+		AppointmentID: appointment.ID,
+		Date:          day.UTC(),
+		Type:          req.Type,
+		StartTime:     req.StartTime,
+		EndTime:       req.EndTime,
+		Reason:        req.Reason,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		exRepo := s.exceptionRepo.WithTx(tx)
+
+		if _, err := s.appointmentRepo.WithTx(tx).FindAndLock(appointment.AppCode, tx); err != nil {
+			return err
+		}
+		existing, err := exRepo.ListByAppointment(appointment.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkExceptionConflict(appointment, existing, exception); err != nil {
+			return err
+		}
+		if err := exRepo.Create(exception); err != nil {
+			return err
+		}
+
+		cancelled, err := s.resyncDates(tx, appointment, day)
+		if err != nil {
+			return err
+		}
+		return s.enqueueCancellations(tx, appointment, cancelled)
+	})
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+
+	return exception, nil
+}
+
+func (s *availabilityExceptionServiceImpl) UpdateException(ctx context.Context, appointmentID uuid.UUID, exceptionID uuid.UUID, userID uuid.UUID, req requests.AvailabilityExceptionRequest) (*entities.AvailabilityException, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments)
+	if err != nil {
+		return nil, err
+	}
+	day, err := exceptionDate(appointment, req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	var exception *entities.AvailabilityException
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		exRepo := s.exceptionRepo.WithTx(tx)
+
+		if _, err := s.appointmentRepo.WithTx(tx).FindAndLock(appointment.AppCode, tx); err != nil {
+			return err
+		}
+		exception, err = exRepo.FindByID(appointment.ID, exceptionID)
+		if err != nil {
+			return err
+		}
+		previousDay := appointment.LocalDate(exception.Date)
+
+		exception.Date = day.UTC()
+		exception.Type = req.Type
+		exception.StartTime = req.StartTime
+		exception.EndTime = req.EndTime
+		exception.Reason = req.Reason
+
+		existing, err := exRepo.ListByAppointment(appointment.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkExceptionConflict(appointment, existing, exception); err != nil {
+			return err
+		}
+		if err := exRepo.Update(exception); err != nil {
+			return err
+		}
+
+		cancelled, err := s.resyncDates(tx, appointment, previousDay, day)
+		if err != nil {
+			return err
+		}
+		return s.enqueueCancellations(tx, appointment, cancelled)
+	})
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+
+	return exception, nil
+}
+
+func (s *availabilityExceptionServiceImpl) DeleteException(ctx context.Context, appointmentID uuid.UUID, exceptionID uuid.UUID, userID uuid.UUID) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	appointment, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		exRepo := s.exceptionRepo.WithTx(tx)
+
+		if _, err := s.appointmentRepo.WithTx(tx).FindAndLock(appointment.AppCode, tx); err != nil {
+			return err
+		}
+		exception, err := exRepo.FindByID(appointment.ID, exceptionID)
+		if err != nil {
+			return err
+		}
+		if err := exRepo.Delete(exception.ID); err != nil {
+			return err
+		}
+
+		cancelled, err := s.resyncDates(tx, appointment, appointment.LocalDate(exception.Date))
+		if err != nil {
+			return err
+		}
+		return s.enqueueCancellations(tx, appointment, cancelled)
+	})
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+
+	return nil
+}
+
+// resyncDates reconciles the stored slot rows of each day with the schedule after exceptions.
+// Slots that are no longer offered are removed, or cancelled when they already hold a booking;
+// newly offered slots are created, reusing a single slot's row when one is stored for the time.
+// It returns the bookings that were cancelled.
+func (s *availabilityExceptionServiceImpl) resyncDates(tx *gorm.DB, appointment *entities.Appointment, days ...time.Time) ([]entities.Booking, error) {
+	bookRepo := s.bookingRepo.WithTx(tx)
+
+	exceptions, err := s.exceptionRepo.WithTx(tx).ListByAppointment(appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+	appointment.Exceptions = exceptions
+	schedule := appointment.GenerateBookings()
+
+	var cancelled []entities.Booking
+	seen := make(map[int64]bool)
+	for _, day := range days {
+		if seen[day.Unix()] {
+			continue
+		}
+		seen[day.Unix()] = true
+
+		desired := make(map[slotKey]entities.Booking)
+		for _, slot := range schedule {
+			if slot.Date.Equal(day) {
+				desired[keyOf(slot)] = slot
+			}
+		}
+
+		rows, err := bookRepo.GetBookingsBetweenDates(appointment.ID, day.UTC(), day.AddDate(0, 0, 1).UTC())
+		if err != nil {
+			return nil, err
+		}
+
+		reservations := make(map[slotKey][]*entities.Booking)
+		for i := range rows {
+			if !rows[i].IsSlot && isActiveBookingStatus(rows[i].Status) {
+				reservations[keyOf(rows[i])] = append(reservations[keyOf(rows[i])], &rows[i])
+			}
+		}
+
+		// Every stored slot row claims its time, so a slot is never created twice; a live row
+		// takes precedence over one closed by an earlier exception.
+		live := make(map[slotKey]bool)
+		for i := range rows {
+			if rows[i].IsSlot && (isActiveBookingStatus(rows[i].Status) || rows[i].Available) {
+				live[keyOf(rows[i])] = true
+			}
+		}
+
+		stored := make(map[slotKey]bool)
+		for i := range rows {
+			slot := &rows[i]
+			if !slot.IsSlot {
+				continue
+			}
+			key := keyOf(*slot)
+			if _, keep := desired[key]; keep {
+				if !live[key] && !stored[key] && reopenSlot(slot) {
+					if err := bookRepo.Update(slot); err != nil {
+						return nil, err
+					}
+				}
+				stored[key] = true
+				continue
+			}
+			if !isActiveBookingStatus(slot.Status) {
+				// A single slot keeps the booking that last held it; close it rather than
+				// deleting that booking.
+				if slot.Available {
+					slot.Available = false
+					if err := bookRepo.Update(slot); err != nil {
+						return nil, err
+					}
+				}
+				continue
+			}
+
+			for _, reservation := range reservations[key] {
+				reservation.Status = entities.BookingStatusCancelled
+				if err := bookRepo.Update(reservation); err != nil {
+					return nil, err
+				}
+				cancelled = append(cancelled, *reservation)
+			}
+
+			if appointment.Type == entities.Single && slot.SeatsBooked > 0 {
+				slot.Status = entities.BookingStatusCancelled
+				slot.Available = false
+				if err := bookRepo.Update(slot); err != nil {
+					return nil, err
+				}
+				cancelled = append(cancelled, *slot)
+				continue
+			}
+			if err := bookRepo.DeleteSlot(slot.ID); err != nil {
+				return nil, err
+			}
+		}
+		for key := range stored {
+			delete(desired, key)
+		}
+
+		missing := make([]entities.Booking, 0, len(desired))
+		for _, slot := range desired {
+			missing = append(missing, slot)
+		}
+		sort.Slice(missing, func(i, j int) bool {
+			return missing[i].StartTime.Before(missing[j].StartTime)
+		})
+		for i := range missing {
+			if err := bookRepo.Create(&missing[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return cancelled, nil
+}
+
+// reopenSlot makes a single slot closed by an earlier exception bookable again, in the same
+// state a guest's cancellation leaves it in. It reports whether the slot changed.
+func reopenSlot(slot *entities.Booking) bool {
+	status := strings.ToLower(strings.TrimSpace(slot.Status))
+	if slot.Available || (status != entities.BookingStatusCancelled && status != entities.BookingStatusCanceled) {
+		return false
+	}
+	slot.SeatsBooked = 0
+	slot.NormalizeState()
+	return true
+}
+
+// enqueueCancellations records a cancellation event in tx for each booking the exceptions
+// cancelled.
+func (s *availabilityExceptionServiceImpl) enqueueCancellations(tx *gorm.DB, appointment *entities.Appointment, bookings []entities.Booking) error {
+	for i := range bookings {
+		booking := bookings[i]
+		payload := events.BookingEventData{
+			Booking:          &booking,
+			OwnerID:          appointment.OwnerID,
+			AppointmentTitle: appointment.Title,
+			RecipientEmail:   booking.Email,
+			RecipientName:    booking.Name,
+			CancelledByOwner: true,
+		}
+		if err := enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingCancelled, Data: payload}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exceptionDate parses a YYYY-MM-DD string as a day in the appointment's time zone and checks
+// that it falls within the appointment's date range.
+func exceptionDate(appointment *entities.Appointment, value string) (time.Time, error) {
+	if appointment.Type == entities.Party {
+		return time.Time{}, serviceerrors.ValidationError("party appointments do not support availability exceptions")
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, appointment.Location())
+	if err != nil {
+		return time.Time{}, serviceerrors.ValidationError("Invalid date format. Use YYYY-MM-DD.")
+	}
+	if day.Before(appointment.LocalDate(appointment.StartDate)) || day.After(appointment.LocalDate(appointment.EndDate)) {
+		return time.Time{}, serviceerrors.ValidationError("exception date must fall within the appointment's date range")
+	}
+	return day, nil
+}
+
+// checkExceptionConflict allows any number of extra windows per day but only one closed or
+// shortened exception.
+func checkExceptionConflict(appointment *entities.Appointment, existing []entities.AvailabilityException, candidate *entities.AvailabilityException) error {
+	if candidate.Type == entities.ExceptionExtra {
+		return nil
+	}
+	day := appointment.LocalDate(candidate.Date)
+	for _, other := range existing {
+		if other.ID == candidate.ID || other.Type == entities.ExceptionExtra {
+			continue
+		}
+		if appointment.LocalDate(other.Date).Equal(day) {
+			return serviceerrors.ConflictError("a closed or shortened exception already exists for this date")
+		}
+	}
+	return nil
+}
+
+type slotKey struct {
+	start int64
+	end   int64
+}
+
+func keyOf(b entities.Booking) slotKey {
+	return slotKey{start: b.StartTime.Unix(), end: b.EndTime.Unix()}
+}
+
+func isActiveBookingStatus(status string) bool {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case entities.BookingStatusCancelled, entities.BookingStatusCanceled, entities.BookingStatusRejected, entities.BookingStatusExpired,
+		entities.BookingStatusAttended, entities.BookingStatusNoShow:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	repomocks "github.com/m13ha/asiko/repository/mocks"
+	services "github.com/m13ha/asiko/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestAvailabilityExceptionsKeepOneSlotPerStart(t *testing.T) {
+	ownerID := uuid.New()
+	day := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	appointment := &entities.Appointment{
+		ID: uuid.New(), AppCode: "SGL123", OwnerID: ownerID, Type: entities.Single, BookingDuration: 60, MaxAttendees: 1, TimeZone: "UTC",
+		StartDate: day, EndDate: day, StartTime: start, EndTime: start.Add(time.Hour),
+	}
+
+	setup := func(rows []entities.Booking) (services.AvailabilityExceptionService, *repomocks.AvailabilityExceptionRepository, *repomocks.BookingRepository, sqlmock.Sqlmock) {
+		mockExceptionRepo := new(repomocks.AvailabilityExceptionRepository)
+		mockExceptionRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockExceptionRepo).Maybe()
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		mockAppointmentRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockAppointmentRepo).Maybe()
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Maybe()
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+
+		mockAppointmentRepo.On("FindByID", mock.Anything, appointment.ID).Return(appointment, nil).Once()
+		mockAppointmentRepo.On("FindAndLock", "SGL123", mock.AnythingOfType("*gorm.DB")).Return(appointment, nil).Once()
+		mockBookingRepo.On("GetBookingsBetweenDates", appointment.ID, day, day.AddDate(0, 0, 1)).Return(rows, nil).Once()
+
+		svc := services.NewAvailabilityExceptionService(mockExceptionRepo, mockAppointmentRepo, mockBookingRepo, new(repomocks.OrganizationRepository), newMockOutboxRepo(), gormDB)
+		return svc, mockExceptionRepo, mockBookingRepo, sqlMock
+	}
+
+	t.Run("closing a day closes a slot freed by a cancellation", func(t *testing.T) {
+		// The guest cancelled, so the slot row is open again but keeps their booking.
+		freed := entities.Booking{ID: uuid.New(), BookingCode: "BK1", AppCode: "SGL123", Date: day, StartTime: start, EndTime: start.Add(time.Hour), IsSlot: true, Capacity: 1, Available: true, Status: entities.BookingStatusCancelled}
+		svc, mockExceptionRepo, mockBookingRepo, sqlMock := setup([]entities.Booking{freed})
+
+		sqlMock.ExpectBegin()
+		mockExceptionRepo.On("ListByAppointment", appointment.ID).Return(nil, nil).Once()
+		mockExceptionRepo.On("Create", mock.AnythingOfType("*entities.AvailabilityException")).Return(nil).Once()
+		mockExceptionRepo.On("ListByAppointment", appointment.ID).Return([]entities.AvailabilityException{{AppointmentID: appointment.ID, Date: day, Type: entities.ExceptionClosed}}, nil).Once()
+		mockBookingRepo.On("Update", mock.MatchedBy(func(b *entities.Booking) bool {
+			return b.ID == freed.ID && !b.Available && b.Status == entities.BookingStatusCancelled
+		})).Return(nil).Once()
+		sqlMock.ExpectCommit()
+
+		_, err := svc.CreateException(context.Background(), appointment.ID, ownerID, requests.AvailabilityExceptionRequest{Date: "2026-06-01", Type: entities.ExceptionClosed})
+
+		assert.NoError(t, err)
+		mockBookingRepo.AssertExpectations(t)
+		mockBookingRepo.AssertNotCalled(t, "DeleteSlot", mock.Anything)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("reopening a day reuses the slot it cancelled", func(t *testing.T) {
+		exception := entities.AvailabilityException{ID: uuid.New(), AppointmentID: appointment.ID, Date: day, Type: entities.ExceptionClosed}
+		closed := entities.Booking{ID: uuid.New(), BookingCode: "BK1", AppCode: "SGL123", Date: day, StartTime: start, EndTime: start.Add(time.Hour), IsSlot: true, Capacity: 1, SeatsBooked: 1, Status: entities.BookingStatusCancelled}
+		svc, mockExceptionRepo, mockBookingRepo, sqlMock := setup([]entities.Booking{closed})
+
+		sqlMock.ExpectBegin()
+		mockExceptionRepo.On("FindByID", appointment.ID, exception.ID).Return(&exception, nil).Once()
+		mockExceptionRepo.On("Delete", exception.ID).Return(nil).Once()
+		mockExceptionRepo.On("ListByAppointment", appointment.ID).Return(nil, nil).Once()
+		mockBookingRepo.On("Update", mock.MatchedBy(func(b *entities.Booking) bool {
+			return b.ID == closed.ID && b.Available && b.SeatsBooked == 0
+		})).Return(nil).Once()
+		sqlMock.ExpectCommit()
+
+		err := svc.DeleteException(context.Background(), appointment.ID, exception.ID, ownerID)
+
+		assert.NoError(t, err)
+		mockBookingRepo.AssertExpectations(t)
+		mockBookingRepo.AssertNotCalled(t, "Create", mock.Anything)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/m13ha/asiko/middleware"
 	"github.com/m13ha/asiko/models/entities"
 	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/models/responses"
 	"github.com/m13ha/asiko/repository"
 	"github.com/m13ha/asiko/utils"
 	"github.com/morkid/paginate"
@@ -26,17 +27,24 @@ type bookingServiceImpl struct {
 	appointmentRepo repository.AppointmentRepository
 	userRepo        repository.UserRepository
 	banListRepo     repository.BanListRepository
-	eventBus        events.EventBus
+	waitlistRepo    repository.WaitlistRepository
+	holdRepo        repository.SlotHoldRepository
+	promoRepo       repository.PromoCodeRepository
+	outboxRepo      repository.OutboxRepository
+	access          appointmentAccess
 	db              *gorm.DB
 }
 
 type BookingStatusRefreshSummary struct {
-	Ongoing int64
-	Expired int64
+	Ongoing  int64
+	NoShow   int64
+	Attended int64
+	Expired  int64
 }
 
-func NewBookingService(bookingRepo repository.BookingRepository, appointmentRepo repository.AppointmentRepository, userRepo repository.UserRepository, banListRepo repository.BanListRepository, eventBus events.EventBus, db *gorm.DB) BookingService {
-	return &bookingServiceImpl{bookingRepo: bookingRepo, appointmentRepo: appointmentRepo, userRepo: userRepo, banListRepo: banListRepo, eventBus: eventBus, db: db}
+func NewBookingService(bookingRepo repository.BookingRepository, appointmentRepo repository.AppointmentRepository, userRepo repository.UserRepository, banListRepo repository.BanListRepository, waitlistRepo repository.WaitlistRepository, holdRepo repository.SlotHoldRepository, orgRepo repository.OrganizationRepository, promoRepo repository.PromoCodeRepository, outboxRepo repository.OutboxRepository, db *gorm.DB) BookingService {
+	access := appointmentAccess{appointmentRepo: appointmentRepo, orgRepo: orgRepo}
+	return &bookingServiceImpl{bookingRepo: bookingRepo, appointmentRepo: appointmentRepo, userRepo: userRepo, banListRepo: banListRepo, waitlistRepo: waitlistRepo, holdRepo: holdRepo, promoRepo: promoRepo, outboxRepo: outboxRepo, access: access, db: db}
 }
 
 func isRepoNotFound(err error) bool {
@@ -44,11 +52,23 @@ func isRepoNotFound(err error) bool {
 	return appErr != nil && appErr.Code == appErrors.CodeRepoNotFoundError
 }
 
-func sameDay(a time.Time, b time.Time) bool {
+// canOverridePolicies reports whether userIDStr identifies someone allowed to manage the
+// appointment's bookings, who is not bound by the guest cancellation and reschedule policies.
+func (s *bookingServiceImpl) canOverridePolicies(appointment *entities.Appointment, userIDStr string) bool {
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return false
+	}
+	return s.access.allows(appointment, userID, entities.PermissionManageBookings)
+}
+
+func sameDay(a time.Time, b time.Time, loc *time.Location) bool {
+	a, b = a.In(loc), b.In(loc)
 	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
 }
 
-func sameClock(a time.Time, b time.Time) bool {
+func sameClock(a time.Time, b time.Time, loc *time.Location) bool {
+	a, b = a.In(loc), b.In(loc)
 	return a.Hour() == b.Hour() && a.Minute() == b.Minute() && a.Second() == b.Second() && a.Nanosecond() == b.Nanosecond()
 }
 
@@ -130,14 +150,30 @@ func (s *bookingServiceImpl) BookAppointment(req requests.BookingRequest, userID
 	}
 
 	if appointment.Type == entities.Party {
-		if !sameDay(req.Date, appointment.StartDate) {
+		loc := appointment.Location()
+		if !sameDay(req.Date, appointment.StartDate, loc) {
 			return nil, serviceerrors.ValidationError("party bookings must use the appointment start date")
 		}
-		if !sameClock(req.StartTime, appointment.StartTime) || !sameClock(req.EndTime, appointment.EndTime) {
+		if !sameClock(req.StartTime, appointment.StartTime, loc) || !sameClock(req.EndTime, appointment.EndTime, loc) {
 			return nil, serviceerrors.ValidationError("party booking times must match the appointment window")
 		}
 	}
 
+	if req.FormAnswers, err = validateFormAnswers(appointment, req.FormAnswers); err != nil {
+		return nil, err
+	}
+	if _, err := checkTicketSale(appointment, req.TicketType, req.AttendeeCount, time.Now()); err != nil {
+		return nil, err
+	}
+
+	slotStart := req.StartTime
+	if appointment.Type == entities.Party {
+		slotStart = appointment.LocalDateTime(appointment.StartDate, appointment.StartTime)
+	}
+	if err := checkBookingWindow(appointment, slotStart); err != nil {
+		return nil, err
+	}
+
 	// --- 3. Get Booker's Info ---
 	var user *entities.User
 	var bookingEmail string
@@ -197,33 +233,43 @@ func (s *bookingServiceImpl) bookPartyAppointment(req requests.BookingRequest, u
 		if lockedAppointment.AttendeesBooked+req.AttendeeCount > lockedAppointment.MaxAttendees {
 			return serviceerrors.BookingCapacityExceededError("not enough capacity for this party")
 		}
+		ticket, err := checkTicketSale(lockedAppointment, req.TicketType, req.AttendeeCount, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := reserveTickets(bookRepo, lockedAppointment, ticket, req.AttendeeCount, partyStart(lockedAppointment)); err != nil {
+			return err
+		}
 
-		startDateTime := time.Date(
-			lockedAppointment.StartDate.Year(), lockedAppointment.StartDate.Month(), lockedAppointment.StartDate.Day(),
-			lockedAppointment.StartTime.Hour(), lockedAppointment.StartTime.Minute(), 0, 0,
-			time.UTC,
-		)
-		endDateTime := time.Date(
-			lockedAppointment.EndDate.Year(), lockedAppointment.EndDate.Month(), lockedAppointment.EndDate.Day(),
-			lockedAppointment.EndTime.Hour(), lockedAppointment.EndTime.Minute(), 0, 0,
-			time.UTC,
-		)
+		startDateTime := lockedAppointment.LocalDateTime(lockedAppointment.StartDate, lockedAppointment.StartTime)
+		endDateTime := lockedAppointment.LocalDateTime(lockedAppointment.EndDate, lockedAppointment.EndTime)
+		if err := reserveResources(appRepo, bookRepo, lockedAppointment.ID, startDateTime.UTC(), endDateTime.UTC()); err != nil {
+			return err
+		}
+		hostID, err := assignHost(appRepo, bookRepo, lockedAppointment, startDateTime.UTC(), endDateTime.UTC(), nil, uuid.Nil)
+		if err != nil {
+			return err
+		}
 
 		booking = &entities.Booking{
 			AppointmentID: lockedAppointment.ID,
 			AppCode:       lockedAppointment.AppCode,
-			Date:          lockedAppointment.StartDate.UTC(),
-			StartTime:     startDateTime,
-			EndTime:       endDateTime,
+			Date:          lockedAppointment.LocalDate(lockedAppointment.StartDate).UTC(),
+			StartTime:     startDateTime.UTC(),
+			EndTime:       endDateTime.UTC(),
 			Available:     false,
 			IsSlot:        false,
 			Capacity:      req.AttendeeCount,
 			SeatsBooked:   req.AttendeeCount,
 			AttendeeCount: req.AttendeeCount,
 			Description:   req.Description,
+			FormAnswers:   req.FormAnswers,
 			Status:        status,
 			DeviceID:      deviceID,
+			HostID:        hostID,
+			TicketType:    ticketKey(ticket),
 		}
+		booking.SetPrice(lockedAppointment.PriceFor(ticket, req.AttendeeCount), lockedAppointment.Currency)
 
 		if user != nil {
 			booking.UserID = &user.ID
@@ -240,16 +286,23 @@ func (s *bookingServiceImpl) bookPartyAppointment(req requests.BookingRequest, u
 			booking.Phone = req.Phone
 		}
 
+		promo, err := redeemPromoCode(s.promoRepo, tx, lockedAppointment, booking, req.PromoCode, time.Now())
+		if err != nil {
+			return err
+		}
+
 		if err := bookRepo.Create(booking); err != nil {
 			return err
 		}
+		if err := recordRedemption(s.promoRepo, tx, promo, booking); err != nil {
+			return err
+		}
 
 		lockedAppointment.AttendeesBooked += req.AttendeeCount
-		return appRepo.Update(lockedAppointment)
-	})
+		if err := appRepo.Update(lockedAppointment); err != nil {
+			return err
+		}
 
-	if err == nil {
-		// Publish event
 		payload := events.BookingEventData{
 			Booking:          booking,
 			OwnerID:          appointment.OwnerID,
@@ -257,10 +310,8 @@ func (s *bookingServiceImpl) bookPartyAppointment(req requests.BookingRequest, u
 			RecipientEmail:   booking.Email,
 			RecipientName:    booking.Name,
 		}
-		if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventBookingCreated, Data: payload}); pubErr != nil {
-			log.Printf("Failed to publish booking created event: %v", pubErr)
-		}
-	}
+		return enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingCreated, Data: payload})
+	})
 
 	return booking, err
 }
@@ -281,9 +332,9 @@ func (s *bookingServiceImpl) bookSlotAppointment(req requests.BookingRequest, us
 				return serviceerrors.ConflictError(reason)
 			}
 
-			lockedSlot, err := bookRepo.FindAndLockAvailableSlot(req.AppCode, req.Date, req.StartTime)
+			lockedSlot, err := s.lockSlotForBooking(tx, bookRepo, req)
 			if err != nil {
-				return serviceerrors.BookingSlotUnavailableError("no available slot found")
+				return err
 			}
 
 			remaining := lockedSlot.Capacity - lockedSlot.SeatsBooked
@@ -294,6 +345,20 @@ func (s *bookingServiceImpl) bookSlotAppointment(req requests.BookingRequest, us
 			if req.AttendeeCount > appointment.MaxAttendees {
 				return serviceerrors.ValidationError("attendee count exceeds maximum allowed")
 			}
+			ticket, err := checkTicketSale(lockedAppointment, req.TicketType, req.AttendeeCount, time.Now())
+			if err != nil {
+				return err
+			}
+			if err := reserveTickets(bookRepo, lockedAppointment, ticket, req.AttendeeCount, lockedSlot.StartTime); err != nil {
+				return err
+			}
+			if err := reserveResources(appRepo, bookRepo, lockedAppointment.ID, lockedSlot.StartTime, lockedSlot.EndTime); err != nil {
+				return err
+			}
+			hostID, err := assignHost(appRepo, bookRepo, lockedAppointment, lockedSlot.StartTime, lockedSlot.EndTime, nil, uuid.Nil)
+			if err != nil {
+				return err
+			}
 
 			reservation = &entities.Booking{
 				AppointmentID: lockedSlot.AppointmentID,
@@ -307,9 +372,13 @@ func (s *bookingServiceImpl) bookSlotAppointment(req requests.BookingRequest, us
 				SeatsBooked:   req.AttendeeCount,
 				AttendeeCount: req.AttendeeCount,
 				Description:   req.Description,
+				FormAnswers:   req.FormAnswers,
 				DeviceID:      deviceID,
 				Status:        status,
+				HostID:        hostID,
+				TicketType:    ticketKey(ticket),
 			}
+			reservation.SetPrice(lockedAppointment.PriceFor(ticket, req.AttendeeCount), lockedAppointment.Currency)
 
 			if user != nil {
 				reservation.UserID = &user.ID
@@ -324,10 +393,18 @@ func (s *bookingServiceImpl) bookSlotAppointment(req requests.BookingRequest, us
 				reservation.Phone = req.Phone
 			}
 
+			promo, err := redeemPromoCode(s.promoRepo, tx, lockedAppointment, reservation, req.PromoCode, time.Now())
+			if err != nil {
+				return err
+			}
+
 			if err := bookRepo.Create(reservation); err != nil {
 				log.Printf("[bookSlot] failed to create reservation: %v", err)
 				return serviceerrors.FromError(err)
 			}
+			if err := recordRedemption(s.promoRepo, tx, promo, reservation); err != nil {
+				return err
+			}
 
 			lockedSlot.SeatsBooked += req.AttendeeCount
 			lockedSlot.NormalizeState()
@@ -342,25 +419,20 @@ func (s *bookingServiceImpl) bookSlotAppointment(req requests.BookingRequest, us
 				return serviceerrors.FromError(err)
 			}
 
-			return nil
+			payload := events.BookingEventData{
+				Booking:          reservation,
+				OwnerID:          appointment.OwnerID,
+				AppointmentTitle: appointment.Title,
+				RecipientEmail:   reservation.Email,
+				RecipientName:    reservation.Name,
+			}
+			return enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingCreated, Data: payload})
 		})
 
 		if err != nil {
 			return nil, err
 		}
 
-		// Publish event
-		payload := events.BookingEventData{
-			Booking:          reservation,
-			OwnerID:          appointment.OwnerID,
-			AppointmentTitle: appointment.Title,
-			RecipientEmail:   reservation.Email,
-			RecipientName:    reservation.Name,
-		}
-		if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventBookingCreated, Data: payload}); pubErr != nil {
-			log.Printf("Failed to publish booking created event: %v", pubErr)
-		}
-
 		return reservation, nil
 	}
 
@@ -378,9 +450,19 @@ func (s *bookingServiceImpl) bookSlotAppointment(req requests.BookingRequest, us
 			return serviceerrors.ConflictError(reason)
 		}
 
-		lockedSlot, err := bookRepo.FindAndLockAvailableSlot(req.AppCode, req.Date, req.StartTime)
+		lockedSlot, err := s.lockSlotForBooking(tx, bookRepo, req)
+		if err != nil {
+			return err
+		}
+		if err := checkHostAvailable(bookRepo, lockedAppointment, lockedSlot); err != nil {
+			return err
+		}
+		if err := reserveResources(appRepo, bookRepo, lockedAppointment.ID, lockedSlot.StartTime, lockedSlot.EndTime); err != nil {
+			return err
+		}
+		hostID, err := assignHost(appRepo, bookRepo, lockedAppointment, lockedSlot.StartTime, lockedSlot.EndTime, nil, uuid.Nil)
 		if err != nil {
-			return serviceerrors.BookingSlotUnavailableError("no available slot found")
+			return err
 		}
 
 		// Populate user info
@@ -401,25 +483,35 @@ func (s *bookingServiceImpl) bookSlotAppointment(req requests.BookingRequest, us
 
 		lockedSlot.SeatsBooked = lockedSlot.Capacity
 		lockedSlot.Description = req.Description
+		lockedSlot.FormAnswers = req.FormAnswers
 		lockedSlot.DeviceID = deviceID
 		lockedSlot.Status = status
+		lockedSlot.HostID = hostID
+		lockedSlot.ClearPrice()
+		lockedSlot.SetPrice(lockedAppointment.PriceFor(nil, 1), lockedAppointment.Currency)
+		if lockedAppointment.IsPaid() {
+			// Payments are filed by booking code, so a reused slot row must not inherit the
+			// code an earlier guest paid under.
+			lockedSlot.ReissueBookingCode()
+		}
 		lockedSlot.NormalizeState()
+		promo, err := redeemPromoCode(s.promoRepo, tx, lockedAppointment, lockedSlot, req.PromoCode, time.Now())
+		if err != nil {
+			return err
+		}
 
 		if err := bookRepo.Update(lockedSlot); err != nil {
 			log.Printf("[bookSlot] DB error: %v", err)
 			return serviceerrors.FromError(err)
 		}
+		if err := recordRedemption(s.promoRepo, tx, promo, lockedSlot); err != nil {
+			return err
+		}
+		if err := syncHostConflicts(bookRepo, lockedAppointment.OwnerID, lockedSlot.StartTime, lockedSlot.EndTime); err != nil {
+			return serviceerrors.FromError(err)
+		}
 
 		slot = lockedSlot
-		return nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err == nil {
-		// Publish event
 		payload := events.BookingEventData{
 			Booking:          slot,
 			OwnerID:          appointment.OwnerID,
@@ -427,9 +519,11 @@ func (s *bookingServiceImpl) bookSlotAppointment(req requests.BookingRequest, us
 			RecipientEmail:   slot.Email,
 			RecipientName:    slot.Name,
 		}
-		if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventBookingCreated, Data: payload}); pubErr != nil {
-			log.Printf("Failed to publish booking created event: %v", pubErr)
-		}
+		return enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingCreated, Data: payload})
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return slot, nil
@@ -446,7 +540,14 @@ func (s *bookingServiceImpl) BookGuestAppointment(req requests.BookingRequest) (
 }
 
 // GetAllBookingsForAppointment returns all bookings for a specific appointment with pagination
-func (s *bookingServiceImpl) GetAllBookingsForAppointment(ctx context.Context, req *http.Request, appcode string) (paginate.Page, error) {
+func (s *bookingServiceImpl) GetAllBookingsForAppointment(ctx context.Context, req *http.Request, appcode string, userID uuid.UUID) (paginate.Page, error) {
+	appointment, err := s.appointmentRepo.FindAppointmentByAppCode(appcode)
+	if err != nil {
+		return paginate.Page{}, serviceerrors.FromError(err)
+	}
+	if err := s.access.authorize(appointment, userID, entities.PermissionViewBookings); err != nil {
+		return paginate.Page{}, err
+	}
 	return s.bookingRepo.GetBookingsByAppCode(ctx, req, appcode, false), nil
 }
 
@@ -459,6 +560,11 @@ func (s *bookingServiceImpl) GetUserBookings(ctx context.Context, req *http.Requ
 	return s.bookingRepo.GetBookingsByUserID(ctx, req, uid, statuses), nil
 }
 
+// GetHostBookings returns the bookings assigned to the user as one of an appointment's hosts.
+func (s *bookingServiceImpl) GetHostBookings(ctx context.Context, req *http.Request, hostID uuid.UUID, statuses []string) (paginate.Page, error) {
+	return s.bookingRepo.GetBookingsByHostID(ctx, req, hostID, statuses), nil
+}
+
 func (s *bookingServiceImpl) RefreshBookingStatuses(ctx context.Context, now time.Time) (BookingStatusRefreshSummary, error) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -474,6 +580,18 @@ func (s *bookingServiceImpl) RefreshBookingStatuses(ctx context.Context, now tim
 	}
 	summary.Ongoing = updated
 
+	updated, err = s.bookingRepo.MarkBookingsNoShow(ctx, now)
+	if err != nil {
+		return summary, err
+	}
+	summary.NoShow = updated
+
+	updated, err = s.bookingRepo.MarkBookingsAttended(ctx, now)
+	if err != nil {
+		return summary, err
+	}
+	summary.Attended = updated
+
 	updated, err = s.bookingRepo.MarkBookingsExpired(ctx, now)
 	if err != nil {
 		return summary, err
@@ -489,12 +607,21 @@ func (s *bookingServiceImpl) GetAvailableSlots(req *http.Request, appcode string
 	if req != nil {
 		ctx = req.Context()
 	}
-	return s.bookingRepo.GetAvailableSlots(ctx, req, appcode), nil
+	appointment, err := s.appointmentRepo.FindAppointmentByAppCode(appcode)
+	if err != nil {
+		return paginate.Page{}, serviceerrors.FromError(err)
+	}
+	return localizeSlotPage(s.bookingRepo.GetAvailableSlots(ctx, req, appcode), appointment), nil
 }
 
-// GetAvailableSlotsByDay returns available slots for an appointment on a specific day with pagination
+// GetAvailableSlotsByDay returns available slots for an appointment on a specific day with pagination.
+// The day is interpreted in the appointment's time zone.
 func (s *bookingServiceImpl) GetAvailableSlotsByDay(req *http.Request, appcode string, dateStr string) (paginate.Page, error) {
-	parsedDate, err := time.Parse("2006-01-02", dateStr)
+	appointment, err := s.appointmentRepo.FindAppointmentByAppCode(appcode)
+	if err != nil {
+		return paginate.Page{}, serviceerrors.FromError(err)
+	}
+	parsedDate, err := time.ParseInLocation("2006-01-02", dateStr, appointment.Location())
 	if err != nil {
 		return paginate.Page{}, serviceerrors.ValidationError("Invalid date format. Use YYYY-MM-DD.")
 	}
@@ -502,21 +629,59 @@ func (s *bookingServiceImpl) GetAvailableSlotsByDay(req *http.Request, appcode s
 	if req != nil {
 		ctx = req.Context()
 	}
-	return s.bookingRepo.GetAvailableSlotsByDay(ctx, req, appcode, parsedDate), nil
+	return localizeSlotPage(s.bookingRepo.GetAvailableSlotsByDay(ctx, req, appcode, parsedDate), appointment), nil
 }
 
-// GetAvailableDates returns a list of distinct dates with available slots
-func (s *bookingServiceImpl) GetAvailableDates(ctx context.Context, appcode string) ([]string, error) {
+// GetAvailableDates returns the distinct local dates with available slots
+func (s *bookingServiceImpl) GetAvailableDates(ctx context.Context, appcode string) ([]responses.AvailableDateResponse, error) {
+	appointment, err := s.appointmentRepo.FindAppointmentByAppCode(appcode)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
 	dates, err := s.bookingRepo.GetAvailableDates(ctx, appcode)
 	if err != nil {
 		return nil, serviceerrors.FromError(err)
 	}
 
-	dateStrings := make([]string, len(dates))
-	for i, date := range dates {
-		dateStrings[i] = date.Format("2006-01-02")
+	loc := appointment.Location()
+	result := make([]responses.AvailableDateResponse, 0, len(dates))
+	for _, date := range dates {
+		day := appointment.LocalDate(date)
+		if len(result) > 0 && result[len(result)-1].Date == day.Format("2006-01-02") {
+			continue
+		}
+		result = append(result, responses.AvailableDateResponse{
+			Date:     day.Format("2006-01-02"),
+			TimeZone: loc.String(),
+			StartUTC: day.UTC(),
+			EndUTC:   day.AddDate(0, 0, 1).UTC(),
+		})
 	}
-	return dateStrings, nil
+	return result, nil
+}
+
+// localizeSlotPage swaps the raw slot rows of a page for responses carrying local times.
+func localizeSlotPage(page paginate.Page, appointment *entities.Appointment) paginate.Page {
+	slots, ok := page.Items.(*[]entities.Booking)
+	if !ok || slots == nil {
+		return page
+	}
+	loc := appointment.Location()
+	items := make([]responses.AvailableSlotResponse, 0, len(*slots))
+	for _, slot := range *slots {
+		slot.Date = slot.Date.UTC()
+		slot.StartTime = slot.StartTime.UTC()
+		slot.EndTime = slot.EndTime.UTC()
+		items = append(items, responses.AvailableSlotResponse{
+			Booking:        slot,
+			TimeZone:       loc.String(),
+			LocalDate:      slot.Date.In(loc).Format("2006-01-02"),
+			LocalStartTime: slot.StartTime.In(loc),
+			LocalEndTime:   slot.EndTime.In(loc),
+		})
+	}
+	page.Items = items
+	return page
 }
 
 // GetBookingByCode retrieves a booking by its permanent booking_code
@@ -528,30 +693,54 @@ func (s *bookingServiceImpl) GetBookingByCode(bookingCode string) (*entities.Boo
 	return booking, nil
 }
 
-// UpdateBookingByCode allows rescheduling a booking if the new slot is available
-func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req requests.BookingRequest) (*entities.Booking, error) {
+// UpdateBookingByCode allows rescheduling a booking if the new slot is available. userIDStr is the
+// caller's ID, or empty for guests; the appointment owner bypasses the reschedule policy.
+func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req requests.BookingRequest, userIDStr string) (*entities.Booking, error) {
 	booking, err := s.GetBookingByCode(bookingCode)
 	if err != nil {
 		return nil, err
 	}
-	if strings.ToLower(booking.Status) == entities.BookingStatusOngoing {
+	switch strings.ToLower(booking.Status) {
+	case entities.BookingStatusOngoing:
 		return nil, serviceerrors.ConflictError("ongoing bookings cannot be rescheduled")
+	case entities.BookingStatusCheckedIn, entities.BookingStatusAttended, entities.BookingStatusNoShow:
+		return nil, serviceerrors.ConflictError("bookings with recorded attendance cannot be rescheduled")
 	}
 
 	appointment, appErr := s.appointmentRepo.FindAppointmentByAppCode(booking.AppCode)
 	if appErr != nil {
 		return nil, serviceerrors.FromError(appErr)
 	}
+	ownerOverride := s.canOverridePolicies(appointment, userIDStr)
+	if !ownerOverride && !appointment.CanRescheduleAt(booking.StartTime, time.Now()) {
+		return nil, serviceerrors.BookingPolicyViolationError(fmt.Sprintf("bookings can no longer be changed within %d hours of the start time", appointment.RescheduleCutoffHours))
+	}
+	if booking.PaymentStatus == entities.BookingPaymentPaid && (req.AppCode != booking.AppCode || (!booking.IsSlot && req.AttendeeCount != booking.AttendeeCount)) {
+		return nil, serviceerrors.ValidationError("paid bookings cannot change their attendee count or appointment; cancel and book again")
+	}
+	if booking.PromoCode != "" && req.AppCode != booking.AppCode {
+		return nil, serviceerrors.ValidationError("bookings made with a promo code cannot be moved to another appointment")
+	}
 	if appointment.Type == entities.Party {
 		if req.AppCode != booking.AppCode {
 			return nil, serviceerrors.ValidationError("party bookings cannot be moved to another appointment")
 		}
-		if !sameDay(req.Date, appointment.StartDate) || !sameClock(req.StartTime, appointment.StartTime) || !sameClock(req.EndTime, appointment.EndTime) {
+		loc := appointment.Location()
+		if !sameDay(req.Date, appointment.StartDate, loc) || !sameClock(req.StartTime, appointment.StartTime, loc) || !sameClock(req.EndTime, appointment.EndTime, loc) {
 			return nil, serviceerrors.ValidationError("party booking times must match the appointment window")
 		}
 		if req.AttendeeCount < 1 {
 			return nil, serviceerrors.ValidationError("attendee count must be at least 1")
 		}
+		if err := checkBookingWindow(appointment, appointment.LocalDateTime(appointment.StartDate, appointment.StartTime)); err != nil {
+			return nil, err
+		}
+		answers := booking.FormAnswers
+		if req.FormAnswers != nil {
+			if answers, err = validateFormAnswers(appointment, req.FormAnswers); err != nil {
+				return nil, err
+			}
+		}
 		err = s.db.Transaction(func(tx *gorm.DB) error {
 			appRepo := s.appointmentRepo.WithTx(tx)
 			bookRepo := s.bookingRepo.WithTx(tx)
@@ -564,6 +753,13 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 			if lockedAppointment.AttendeesBooked+delta > lockedAppointment.MaxAttendees {
 				return serviceerrors.BookingCapacityExceededError("not enough capacity for this party")
 			}
+			ticket, err := checkTicketChange(lockedAppointment, booking.TicketType, req.AttendeeCount, false)
+			if err != nil {
+				return err
+			}
+			if err := reserveTickets(bookRepo, lockedAppointment, ticket, delta, partyStart(lockedAppointment)); err != nil {
+				return err
+			}
 			lockedAppointment.AttendeesBooked += delta
 			if err := appRepo.Update(lockedAppointment); err != nil {
 				return err
@@ -571,26 +767,25 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 
 			booking.AttendeeCount = req.AttendeeCount
 			booking.Description = req.Description
+			booking.FormAnswers = answers
+			booking.SetPrice(lockedAppointment.PriceFor(ticket, req.AttendeeCount), lockedAppointment.Currency)
 			if updateErr := bookRepo.Update(booking); updateErr != nil {
 				return updateErr
 			}
 
-			return nil
+			payload := events.BookingEventData{
+				Booking:          booking,
+				OwnerID:          appointment.OwnerID,
+				AppointmentTitle: appointment.Title,
+				RecipientEmail:   booking.Email,
+				RecipientName:    booking.Name,
+			}
+			return enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingUpdated, Data: payload})
 		})
 		if err != nil {
 			return nil, serviceerrors.FromError(err)
 		}
 
-		payload := events.BookingEventData{
-			Booking:          booking,
-			OwnerID:          appointment.OwnerID,
-			AppointmentTitle: appointment.Title,
-			RecipientEmail:   booking.Email,
-			RecipientName:    booking.Name,
-		}
-		if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventBookingUpdated, Data: payload}); pubErr != nil {
-			log.Printf("Failed to publish booking updated event: %v", pubErr)
-		}
 		return booking, nil
 	}
 
@@ -603,6 +798,36 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 		currentCount = booking.Capacity
 	}
 
+	targetAppointment := appointment
+	if req.AppCode != booking.AppCode {
+		targetAppointment, appErr = s.appointmentRepo.FindAppointmentByAppCode(req.AppCode)
+		if appErr != nil {
+			return nil, serviceerrors.FromError(appErr)
+		}
+	}
+	// Answers are kept unless new ones are sent, but must still satisfy the target's form.
+	answers := booking.FormAnswers
+	if req.FormAnswers != nil || targetAppointment.ID != appointment.ID {
+		provided := req.FormAnswers
+		if provided == nil {
+			provided = booking.FormAnswers
+		}
+		if answers, err = validateFormAnswers(targetAppointment, provided); err != nil {
+			return nil, err
+		}
+	}
+	if booking.AppCode != req.AppCode || !booking.Date.Equal(req.Date) || !booking.StartTime.Equal(req.StartTime) {
+		if !ownerOverride && appointment.ReschedulesExhausted(booking.RescheduleCount) {
+			return nil, serviceerrors.BookingPolicyViolationError(fmt.Sprintf("bookings can be rescheduled at most %d times", appointment.MaxReschedules))
+		}
+		if ok, reason := targetAppointment.IsBookable(); !ok {
+			return nil, serviceerrors.ConflictError(reason)
+		}
+		if err := checkBookingWindow(targetAppointment, req.StartTime); err != nil {
+			return nil, err
+		}
+	}
+
 	wasConfirmed := strings.ToLower(booking.Status) == entities.BookingStatusConfirmed
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		bookRepo := s.bookingRepo.WithTx(tx)
@@ -614,10 +839,21 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 			if slotErr != nil {
 				return slotErr
 			}
+			req.EndTime = slot.EndTime
 			delta := req.AttendeeCount - currentCount
 			if delta > 0 && slot.SeatsBooked+delta > slot.Capacity {
 				return capacityErr
 			}
+			if !booking.IsSlot {
+				ticket, ticketErr := checkTicketChange(appointment, booking.TicketType, req.AttendeeCount, false)
+				if ticketErr != nil {
+					return ticketErr
+				}
+				if ticketErr := reserveTickets(bookRepo, appointment, ticket, delta, slot.StartTime); ticketErr != nil {
+					return ticketErr
+				}
+				booking.SetPrice(appointment.PriceFor(ticket, req.AttendeeCount), appointment.Currency)
+			}
 			slot.SeatsBooked += delta
 			slot.NormalizeState()
 			if !booking.IsSlot {
@@ -643,6 +879,26 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 			if slotErr != nil {
 				return slotErr
 			}
+			if newSlot.Blocked || newSlot.HostConflict {
+				return serviceerrors.BookingSlotUnavailableError("slot conflicts with the owner's calendar")
+			}
+			req.EndTime = newSlot.EndTime
+			if conflictErr := checkBufferConflicts(bookRepo, targetAppointment, newSlot, booking); conflictErr != nil {
+				return conflictErr
+			}
+
+			appRepo := s.appointmentRepo.WithTx(tx)
+			vacated := []uuid.UUID{booking.ID}
+			if !booking.IsSlot && oldSlot.SeatsBooked <= booking.AttendeeCount {
+				vacated = append(vacated, oldSlot.ID)
+			}
+			if resourceErr := reserveResources(appRepo, bookRepo, targetAppointment.ID, newSlot.StartTime, newSlot.EndTime, vacated...); resourceErr != nil {
+				return resourceErr
+			}
+			hostID, hostErr := assignHost(appRepo, bookRepo, targetAppointment, newSlot.StartTime, newSlot.EndTime, booking.HostID, booking.ID)
+			if hostErr != nil {
+				return hostErr
+			}
 
 			if booking.IsSlot {
 				if newSlot.SeatsBooked+req.AttendeeCount > newSlot.Capacity {
@@ -661,9 +917,13 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 				oldSlot.Email = ""
 				oldSlot.Phone = ""
 				oldSlot.Description = ""
+				oldSlot.FormAnswers = nil
 				oldSlot.DeviceID = ""
+				oldSlot.RescheduleCount = 0
+				oldSlot.HostID = nil
 				oldSlot.Status = entities.BookingStatusActive
 				oldSlot.SeatsBooked = 0
+				oldSlot.ClearPrice()
 				oldSlot.NormalizeState()
 				if updateErr := bookRepo.Update(oldSlot); updateErr != nil {
 					return updateErr
@@ -675,9 +935,19 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 				newSlot.Email = booking.Email
 				newSlot.Phone = booking.Phone
 				newSlot.Description = req.Description
+				newSlot.FormAnswers = answers
 				newSlot.DeviceID = booking.DeviceID
+				newSlot.RescheduleCount = booking.RescheduleCount + 1
+				newSlot.HostID = hostID
 				newSlot.SeatsBooked = req.AttendeeCount
 				newSlot.AttendeeCount = req.AttendeeCount
+				newSlot.AmountDue = booking.AmountDue
+				newSlot.Currency = booking.Currency
+				newSlot.PaymentStatus = booking.PaymentStatus
+				newSlot.PromoCode = booking.PromoCode
+				newSlot.Discount = booking.Discount
+				newSlot.DiscountAmount = booking.DiscountAmount
+				newSlot.SetPrice(targetAppointment.PriceFor(nil, 1), targetAppointment.Currency)
 				newSlot.Available = false
 				if wasConfirmed {
 					newSlot.Status = entities.BookingStatusPending
@@ -694,6 +964,16 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 				if updateErr := bookRepo.Update(oldSlot); updateErr != nil {
 					return updateErr
 				}
+				if appointment.Type == entities.Single {
+					if syncErr := syncHostConflicts(bookRepo, appointment.OwnerID, oldSlot.StartTime, oldSlot.EndTime); syncErr != nil {
+						return syncErr
+					}
+				}
+				if targetAppointment.Type == entities.Single {
+					if syncErr := syncHostConflicts(bookRepo, targetAppointment.OwnerID, newSlot.StartTime, newSlot.EndTime); syncErr != nil {
+						return syncErr
+					}
+				}
 
 				booking = newSlot
 			} else {
@@ -711,6 +991,14 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 				if newSlot.SeatsBooked+req.AttendeeCount > newSlot.Capacity {
 					return capacityErr
 				}
+				ticket, ticketErr := checkTicketChange(targetAppointment, booking.TicketType, req.AttendeeCount, targetAppointment.ID != appointment.ID)
+				if ticketErr != nil {
+					return ticketErr
+				}
+				if ticketErr := reserveTickets(bookRepo, targetAppointment, ticket, req.AttendeeCount, newSlot.StartTime); ticketErr != nil {
+					return ticketErr
+				}
+				booking.SetPrice(targetAppointment.PriceFor(ticket, req.AttendeeCount), targetAppointment.Currency)
 				newSlot.SeatsBooked += req.AttendeeCount
 				newSlot.NormalizeState()
 				if newSlot.SeatsBooked > 0 {
@@ -721,6 +1009,7 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 				if updateErr := bookRepo.Update(newSlot); updateErr != nil {
 					return updateErr
 				}
+				booking.HostID = hostID
 			}
 		}
 
@@ -731,9 +1020,13 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 			booking.EndTime = req.EndTime
 			booking.AttendeeCount = req.AttendeeCount
 			booking.Description = req.Description
+			booking.FormAnswers = answers
 			booking.Available = false
-			if !sameSlot && wasConfirmed {
-				booking.Status = entities.BookingStatusPending
+			if !sameSlot {
+				booking.RescheduleCount++
+				if wasConfirmed {
+					booking.Status = entities.BookingStatusPending
+				}
 			}
 
 			if updateErr := bookRepo.Update(booking); updateErr != nil {
@@ -741,30 +1034,72 @@ func (s *bookingServiceImpl) UpdateBookingByCode(bookingCode string, req request
 			}
 		}
 
-		return nil
+		payload := events.BookingEventData{
+			Booking:          booking,
+			OwnerID:          appointment.OwnerID,
+			AppointmentTitle: appointment.Title,
+			RecipientEmail:   booking.Email,
+			RecipientName:    booking.Name,
+		}
+		return enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingUpdated, Data: payload})
 	})
 
 	if err != nil {
 		return nil, serviceerrors.FromError(err)
 	}
 
-	// Publish update event
-	payload := events.BookingEventData{
-		Booking:          booking,
-		OwnerID:          appointment.OwnerID,
-		AppointmentTitle: appointment.Title,
-		RecipientEmail:   booking.Email,
-		RecipientName:    booking.Name,
+	return booking, nil
+}
+
+// checkBookingWindow rejects slots starting inside the appointment's minimum notice or beyond its
+// maximum advance window.
+func checkBookingWindow(appointment *entities.Appointment, start time.Time) error {
+	now := time.Now()
+	if appointment.WithinBookingWindow(start, now) {
+		return nil
 	}
-	if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventBookingUpdated, Data: payload}); pubErr != nil {
-		log.Printf("Failed to publish booking updated event: %v", pubErr)
+	if appointment.MaxAdvanceDays <= 0 || !start.After(now.AddDate(0, 0, appointment.MaxAdvanceDays)) {
+		return serviceerrors.BookingOutsideWindowError(fmt.Sprintf("slots must be booked at least %d minutes in advance", appointment.MinNoticeMinutes))
 	}
+	return serviceerrors.BookingOutsideWindowError(fmt.Sprintf("slots cannot be booked more than %d days in advance", appointment.MaxAdvanceDays))
+}
 
-	return booking, nil
+// validateFormAnswers checks answers against the appointment's custom form fields and returns them
+// normalized for storage.
+func validateFormAnswers(appointment *entities.Appointment, answers entities.FormAnswers) (entities.FormAnswers, error) {
+	normalized, err := appointment.FormFields.ValidateAnswers(answers)
+	if err != nil {
+		return nil, serviceerrors.ValidationError("invalid form answers: " + err.Error())
+	}
+	return normalized, nil
 }
 
-// CancelBookingByCode cancels a booking by booking_code
-func (s *bookingServiceImpl) CancelBookingByCode(bookingCode string) (*entities.Booking, error) {
+// checkBufferConflicts rejects a move into slot when, with the appointment's buffers applied, it
+// would overlap another occupied slot. Rows belonging to the booking being moved are ignored.
+func checkBufferConflicts(bookRepo repository.BookingRepository, appointment *entities.Appointment, slot *entities.Booking, moving *entities.Booking) error {
+	if appointment.PreBufferMinutes <= 0 && appointment.PostBufferMinutes <= 0 {
+		return nil
+	}
+	day := appointment.LocalDate(slot.Date)
+	rows, err := bookRepo.GetBookingsBetweenDates(appointment.ID, day.AddDate(0, 0, -1).UTC(), day.AddDate(0, 0, 2).UTC())
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if row.ID == moving.ID || row.StartTime.Equal(slot.StartTime) || !isActiveBookingStatus(row.Status) {
+			continue
+		}
+		occupied := !row.IsSlot || (appointment.Type == entities.Single && row.SeatsBooked > 0)
+		if occupied && appointment.SlotsConflict(*slot, row) {
+			return serviceerrors.BookingSlotUnavailableError("slot conflicts with another booking once buffer time is included")
+		}
+	}
+	return nil
+}
+
+// CancelBookingByCode cancels a booking by booking_code. userIDStr is the caller's ID, or empty for
+// guests; the appointment owner bypasses the cancellation cutoff.
+func (s *bookingServiceImpl) CancelBookingByCode(bookingCode string, userIDStr string) (*entities.Booking, error) {
 	booking, err := s.GetBookingByCode(bookingCode)
 	if err != nil {
 		return nil, err
@@ -778,7 +1113,27 @@ func (s *bookingServiceImpl) CancelBookingByCode(bookingCode string) (*entities.
 	if !entities.CanTransitionBookingStatus(booking.Status, entities.BookingStatusCancelled) {
 		return nil, serviceerrors.ConflictError("booking cannot be cancelled in its current status")
 	}
+	ownerOverride := s.canOverridePolicies(appointment, userIDStr)
+	if !ownerOverride && !appointment.CanCancelAt(booking.StartTime, time.Now()) {
+		return nil, serviceerrors.BookingPolicyViolationError(fmt.Sprintf("bookings can no longer be cancelled within %d hours of the start time", appointment.CancellationCutoffHours))
+	}
 
+	var promoted []*entities.Booking
+	// publish records the cancellation, and the waitlist bookings it made room for, in tx.
+	publish := func(tx *gorm.DB) error {
+		payload := events.BookingEventData{
+			Booking:          booking,
+			OwnerID:          appointment.OwnerID,
+			AppointmentTitle: appointment.Title,
+			RecipientEmail:   booking.Email,
+			RecipientName:    booking.Name,
+			CancelledByOwner: ownerOverride,
+		}
+		if err := enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingCancelled, Data: payload}); err != nil {
+			return err
+		}
+		return s.enqueuePromotions(tx, appointment, promoted)
+	}
 	if appointment.Type == entities.Party {
 		err := s.db.Transaction(func(tx *gorm.DB) error {
 			appRepo := s.appointmentRepo.WithTx(tx)
@@ -789,17 +1144,20 @@ func (s *bookingServiceImpl) CancelBookingByCode(bookingCode string) (*entities.
 				return err
 			}
 
-			lockedAppointment.AttendeesBooked -= booking.AttendeeCount
-			if err := appRepo.Update(lockedAppointment); err != nil {
-				return err
-			}
-
+			// The booking is released first so its tickets can go to the waitlist.
 			booking.Status = entities.BookingStatusCancelled
 			if err := bookRepo.Update(booking); err != nil {
 				return err
 			}
 
-			return nil
+			lockedAppointment.AttendeesBooked -= booking.AttendeeCount
+			if promoted, err = s.promoteFromWaitlist(tx, lockedAppointment, nil); err != nil {
+				return err
+			}
+			if err := appRepo.Update(lockedAppointment); err != nil {
+				return err
+			}
+			return publish(tx)
 		})
 
 		if err != nil {
@@ -820,6 +1178,18 @@ func (s *bookingServiceImpl) CancelBookingByCode(bookingCode string) (*entities.
 				decrement = booking.Capacity
 			}
 			slot.SeatsBooked -= decrement
+			if appointment.Type == entities.Group {
+				// The reservation is released first so its tickets can go to the waitlist.
+				booking.Available = true
+				booking.Status = entities.BookingStatusCancelled
+				if updateErr := bookRepo.Update(booking); updateErr != nil {
+					return updateErr
+				}
+				var promoteErr error
+				if promoted, promoteErr = s.promoteFromWaitlist(tx, appointment, slot); promoteErr != nil {
+					return promoteErr
+				}
+			}
 			slot.NormalizeState()
 			if appointment.Type == entities.Group {
 				if slot.SeatsBooked > 0 {
@@ -832,13 +1202,28 @@ func (s *bookingServiceImpl) CancelBookingByCode(bookingCode string) (*entities.
 				return updateErr
 			}
 
-			booking.Available = true
-			booking.Status = entities.BookingStatusCancelled
-			if updateErr := bookRepo.Update(booking); updateErr != nil {
-				return updateErr
+			if appointment.Type != entities.Group {
+				booking.Available = true
+				booking.Status = entities.BookingStatusCancelled
+				if updateErr := bookRepo.Update(booking); updateErr != nil {
+					return updateErr
+				}
+			}
+
+			if appointment.Type == entities.Single {
+				// The slot row is the booking itself; promote into a copy so the caller keeps
+				// the released booking's details.
+				freed := *booking
+				var promoteErr error
+				if promoted, promoteErr = s.promoteFromWaitlist(tx, appointment, &freed); promoteErr != nil {
+					return promoteErr
+				}
+				if syncErr := syncHostConflicts(bookRepo, appointment.OwnerID, booking.StartTime, booking.EndTime); syncErr != nil {
+					return syncErr
+				}
 			}
 
-			return nil
+			return publish(tx)
 		})
 
 		if err != nil {
@@ -847,24 +1232,10 @@ func (s *bookingServiceImpl) CancelBookingByCode(bookingCode string) (*entities.
 		}
 	}
 
-	if err == nil {
-		// Publish cancellation event
-		payload := events.BookingEventData{
-			Booking:          booking,
-			OwnerID:          appointment.OwnerID,
-			AppointmentTitle: appointment.Title,
-			RecipientEmail:   booking.Email,
-			RecipientName:    booking.Name,
-		}
-		if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventBookingCancelled, Data: payload}); pubErr != nil {
-			log.Printf("Failed to publish booking cancelled event: %v", pubErr)
-		}
-	}
-
 	return booking, nil
 }
 
-func (s *bookingServiceImpl) RejectBooking(bookingCode string, ownerID uuid.UUID) (*entities.Booking, error) {
+func (s *bookingServiceImpl) RejectBooking(bookingCode string, userID uuid.UUID) (*entities.Booking, error) {
 	booking, err := s.GetBookingByCode(bookingCode)
 	if err != nil {
 		return nil, err
@@ -875,14 +1246,29 @@ func (s *bookingServiceImpl) RejectBooking(bookingCode string, ownerID uuid.UUID
 		return nil, serviceerrors.FromError(err)
 	}
 
-	if appointment.OwnerID != ownerID {
-		return nil, serviceerrors.ForbiddenError("you are not the owner of this appointment")
+	if err := s.access.authorize(appointment, userID, entities.PermissionManageBookings); err != nil {
+		return nil, err
 	}
 
 	if !entities.CanTransitionBookingStatus(booking.Status, entities.BookingStatusRejected) {
 		return nil, serviceerrors.ConflictError("booking cannot be rejected in its current status")
 	}
 
+	var promoted []*entities.Booking
+	// publish records the rejection, and the waitlist bookings it made room for, in tx.
+	publish := func(tx *gorm.DB) error {
+		payload := events.BookingEventData{
+			Booking:          booking,
+			OwnerID:          appointment.OwnerID,
+			AppointmentTitle: appointment.Title,
+			RecipientEmail:   booking.Email,
+			RecipientName:    booking.Name,
+		}
+		if err := enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingRejected, Data: payload}); err != nil {
+			return err
+		}
+		return s.enqueuePromotions(tx, appointment, promoted)
+	}
 	if appointment.Type == entities.Party {
 		err := s.db.Transaction(func(tx *gorm.DB) error {
 			appRepo := s.appointmentRepo.WithTx(tx)
@@ -893,17 +1279,20 @@ func (s *bookingServiceImpl) RejectBooking(bookingCode string, ownerID uuid.UUID
 				return err
 			}
 
-			lockedAppointment.AttendeesBooked -= booking.AttendeeCount
-			if err := appRepo.Update(lockedAppointment); err != nil {
-				return err
-			}
-
+			// The booking is released first so its tickets can go to the waitlist.
 			booking.Status = entities.BookingStatusRejected
 			if err := bookRepo.Update(booking); err != nil {
 				return err
 			}
 
-			return nil
+			lockedAppointment.AttendeesBooked -= booking.AttendeeCount
+			if promoted, err = s.promoteFromWaitlist(tx, lockedAppointment, nil); err != nil {
+				return err
+			}
+			if err := appRepo.Update(lockedAppointment); err != nil {
+				return err
+			}
+			return publish(tx)
 		})
 
 		if err != nil {
@@ -924,6 +1313,18 @@ func (s *bookingServiceImpl) RejectBooking(bookingCode string, ownerID uuid.UUID
 				decrement = booking.Capacity
 			}
 			slot.SeatsBooked -= decrement
+			if appointment.Type == entities.Group {
+				// The reservation is released first so its tickets can go to the waitlist.
+				booking.Available = true
+				booking.Status = entities.BookingStatusRejected
+				if updateErr := bookRepo.Update(booking); updateErr != nil {
+					return updateErr
+				}
+				var promoteErr error
+				if promoted, promoteErr = s.promoteFromWaitlist(tx, appointment, slot); promoteErr != nil {
+					return promoteErr
+				}
+			}
 			slot.NormalizeState()
 			if appointment.Type == entities.Group {
 				if slot.SeatsBooked > 0 {
@@ -936,25 +1337,44 @@ func (s *bookingServiceImpl) RejectBooking(bookingCode string, ownerID uuid.UUID
 				return updateErr
 			}
 
-			if booking.IsSlot {
-				booking.UserID = nil
-				booking.Name = ""
-				booking.Email = ""
-				booking.Phone = ""
-				booking.Description = ""
-				booking.DeviceID = ""
-				booking.SeatsBooked = 0
-				booking.Status = entities.BookingStatusActive
-				booking.NormalizeState()
-			} else {
-				booking.Available = true
-				booking.Status = entities.BookingStatusRejected
+			if appointment.Type != entities.Group {
+				if booking.IsSlot {
+					booking.UserID = nil
+					booking.Name = ""
+					booking.Email = ""
+					booking.Phone = ""
+					booking.Description = ""
+					booking.FormAnswers = nil
+					booking.DeviceID = ""
+					booking.RescheduleCount = 0
+					booking.HostID = nil
+					booking.SeatsBooked = 0
+					booking.Status = entities.BookingStatusActive
+					booking.ClearPrice()
+					booking.NormalizeState()
+				} else {
+					booking.Available = true
+					booking.Status = entities.BookingStatusRejected
+				}
+				if updateErr := bookRepo.Update(booking); updateErr != nil {
+					return updateErr
+				}
 			}
-			if updateErr := bookRepo.Update(booking); updateErr != nil {
-				return updateErr
+
+			if appointment.Type == entities.Single {
+				// The slot row is the booking itself; promote into a copy so the caller keeps
+				// the released booking's details.
+				freed := *booking
+				var promoteErr error
+				if promoted, promoteErr = s.promoteFromWaitlist(tx, appointment, &freed); promoteErr != nil {
+					return promoteErr
+				}
+				if syncErr := syncHostConflicts(bookRepo, appointment.OwnerID, booking.StartTime, booking.EndTime); syncErr != nil {
+					return syncErr
+				}
 			}
 
-			return nil
+			return publish(tx)
 		})
 
 		if err != nil {
@@ -963,24 +1383,10 @@ func (s *bookingServiceImpl) RejectBooking(bookingCode string, ownerID uuid.UUID
 		}
 	}
 
-	if err == nil {
-		// Publish rejection event
-		payload := events.BookingEventData{
-			Booking:          booking,
-			OwnerID:          appointment.OwnerID,
-			AppointmentTitle: appointment.Title,
-			RecipientEmail:   booking.Email,
-			RecipientName:    booking.Name,
-		}
-		if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventBookingRejected, Data: payload}); pubErr != nil {
-			log.Printf("Failed to publish booking rejected event: %v", pubErr)
-		}
-	}
-
 	return booking, nil
 }
 
-func (s *bookingServiceImpl) ConfirmBooking(bookingCode string, ownerID uuid.UUID) (*entities.Booking, error) {
+func (s *bookingServiceImpl) ConfirmBooking(bookingCode string, userID uuid.UUID) (*entities.Booking, error) {
 	booking, err := s.GetBookingByCode(bookingCode)
 	if err != nil {
 		return nil, err
@@ -991,29 +1397,35 @@ func (s *bookingServiceImpl) ConfirmBooking(bookingCode string, ownerID uuid.UUI
 		return nil, serviceerrors.FromError(err)
 	}
 
-	if appointment.OwnerID != ownerID {
-		return nil, serviceerrors.ForbiddenError("you are not the owner of this appointment")
+	if err := s.access.authorize(appointment, userID, entities.PermissionManageBookings); err != nil {
+		return nil, err
 	}
 
 	if !entities.CanTransitionBookingStatus(booking.Status, entities.BookingStatusConfirmed) {
 		return nil, serviceerrors.ConflictError("booking cannot be confirmed in its current status")
 	}
+	if booking.PaymentStatus == entities.BookingPaymentDue {
+		return nil, serviceerrors.ConflictError("booking is awaiting payment")
+	}
 
 	booking.Available = false
 	booking.Status = entities.BookingStatusConfirmed
-	if err := s.bookingRepo.Update(booking); err != nil {
-		return nil, serviceerrors.FromError(err)
-	}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.bookingRepo.WithTx(tx).Update(booking); err != nil {
+			return err
+		}
 
-	payload := events.BookingEventData{
-		Booking:          booking,
-		OwnerID:          appointment.OwnerID,
-		AppointmentTitle: appointment.Title,
-		RecipientEmail:   booking.Email,
-		RecipientName:    booking.Name,
-	}
-	if pubErr := s.eventBus.Publish(context.Background(), events.Event{Name: events.EventBookingConfirmed, Data: payload}); pubErr != nil {
-		log.Printf("Failed to publish booking confirmed event: %v", pubErr)
+		payload := events.BookingEventData{
+			Booking:          booking,
+			OwnerID:          appointment.OwnerID,
+			AppointmentTitle: appointment.Title,
+			RecipientEmail:   booking.Email,
+			RecipientName:    booking.Name,
+		}
+		return enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingConfirmed, Data: payload})
+	})
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
 	}
 
 	return booking, nil
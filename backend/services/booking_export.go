@@ -0,0 +1,94 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+)
+
+var attendeeExportHeader = []string{"booking_code", "name", "email", "phone", "date", "start_time", "end_time", "attendee_count", "status", "description"}
+
+// ExportAttendees renders the appointment's active bookings as CSV, one row per booking, with a
+// column per custom form field after the standard attendee columns. Times are written in the
+// appointment's time zone.
+func (s *bookingServiceImpl) ExportAttendees(appCode string, userID uuid.UUID) ([]byte, error) {
+	appointment, err := s.appointmentRepo.FindAppointmentByAppCode(appCode)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	if err := s.access.authorize(appointment, userID, entities.PermissionViewBookings); err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.bookingRepo.GetActiveBookingsForAppointment(appointment.ID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	sort.SliceStable(bookings, func(i, j int) bool {
+		return bookings[i].StartTime.Before(bookings[j].StartTime)
+	})
+
+	header := append([]string(nil), attendeeExportHeader...)
+	for _, field := range appointment.FormFields {
+		header = append(header, field.Key)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, serviceerrors.InternalError("failed to write attendee export")
+	}
+
+	loc := appointment.Location()
+	for _, booking := range bookings {
+		attendees := booking.AttendeeCount
+		if booking.IsSlot && attendees < 1 {
+			attendees = booking.SeatsBooked
+		}
+		row := []string{
+			booking.BookingCode,
+			booking.Name,
+			booking.Email,
+			booking.Phone,
+			booking.Date.In(loc).Format("2006-01-02"),
+			booking.StartTime.In(loc).Format(time.RFC3339),
+			booking.EndTime.In(loc).Format(time.RFC3339),
+			strconv.Itoa(attendees),
+			booking.Status,
+			booking.Description,
+		}
+		for _, field := range appointment.FormFields {
+			row = append(row, formatFormAnswer(booking.FormAnswers[field.Key]))
+		}
+		if err := w.Write(row); err != nil {
+			return nil, serviceerrors.InternalError("failed to write attendee export")
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, serviceerrors.InternalError("failed to write attendee export")
+	}
+	return buf.Bytes(), nil
+}
+
+func formatFormAnswer(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
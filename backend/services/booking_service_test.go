@@ -15,6 +15,7 @@ import (
 	"github.com/m13ha/asiko/models/requests"
 	repomocks "github.com/m13ha/asiko/repository/mocks"
 	services "github.com/m13ha/asiko/services"
+	"github.com/m13ha/asiko/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/driver/postgres"
@@ -46,10 +47,12 @@ func TestBookAppointment(t *testing.T) {
 		// Arrange
 		mockAppointmentRepo := new(repomocks.AppointmentRepository)
 		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoHosts(mockAppointmentRepo)
+		stubNoResources(mockAppointmentRepo)
 		mockBookingRepo := new(repomocks.BookingRepository)
 		mockUserRepo := new(repomocks.UserRepository)
 		mockBanListRepo := new(repomocks.BanListRepository)
-		mockEventBus := new(MockEventBus)
+		mockOutboxRepo := newMockOutboxRepo()
 		stubBanListNotFound(mockBanListRepo)
 
 		db, sqlMock, _ := sqlmock.New()
@@ -57,7 +60,7 @@ func TestBookAppointment(t *testing.T) {
 			Conn: db,
 		}), &gorm.Config{})
 
-		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, gormDB)
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
 
 		slot := newSlot()
 		req := requests.BookingRequest{AppCode: "SLOT123", Name: "Test", Email: "test@test.com", Date: slot.Date, StartTime: slot.StartTime, EndTime: slot.EndTime, AttendeeCount: 2}
@@ -75,7 +78,7 @@ func TestBookAppointment(t *testing.T) {
 		sqlMock.ExpectCommit()
 
 		// Event Bus Expectations
-		mockEventBus.On("Publish", mock.Anything, mock.MatchedBy(func(event events.Event) bool {
+		mockOutboxRepo.On("Create", outboxEvent(func(event events.Event) bool {
 			return event.Name == events.EventBookingCreated
 		})).Return(nil).Once()
 
@@ -93,23 +96,25 @@ func TestBookAppointment(t *testing.T) {
 		mockAppointmentRepo.AssertExpectations(t)
 		mockBookingRepo.AssertExpectations(t)
 		mockUserRepo.AssertExpectations(t)
-		mockEventBus.AssertExpectations(t)
+		mockOutboxRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure - Group Capacity Exceeded", func(t *testing.T) {
 		mockAppointmentRepo := new(repomocks.AppointmentRepository)
 		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoHosts(mockAppointmentRepo)
+		stubNoResources(mockAppointmentRepo)
 		mockBookingRepo := new(repomocks.BookingRepository)
 		mockUserRepo := new(repomocks.UserRepository)
 		mockBanListRepo := new(repomocks.BanListRepository)
-		mockEventBus := new(MockEventBus)
+		mockOutboxRepo := newMockOutboxRepo()
 		stubBanListNotFound(mockBanListRepo)
 		db, sqlMock, _ := sqlmock.New()
 		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
 			Conn: db,
 		}), &gorm.Config{})
 
-		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, gormDB)
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
 
 		req := requests.BookingRequest{AppCode: "SLOT123", Name: "Test", Email: "test@test.com", Date: slotDate, StartTime: slotStart, EndTime: slotEnd, AttendeeCount: 4}
 		mockAppointmentRepo.On("FindAppointmentByAppCode", "SLOT123").Return(appSlot, nil).Once()
@@ -137,14 +142,14 @@ func TestBookAppointment(t *testing.T) {
 		mockBookingRepo := new(repomocks.BookingRepository)
 		mockUserRepo := new(repomocks.UserRepository)
 		mockBanListRepo := new(repomocks.BanListRepository)
-		mockEventBus := new(MockEventBus)
+		mockOutboxRepo := newMockOutboxRepo()
 		stubBanListNotFound(mockBanListRepo)
 		db, _, _ := sqlmock.New()
 		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
 			Conn: db,
 		}), &gorm.Config{})
 
-		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, gormDB)
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
 		validReq := requests.BookingRequest{AppCode: "NOTFOUND", Name: "Guest User", Email: "guest@example.com", Date: time.Now(), StartTime: time.Now(), EndTime: time.Now(), AttendeeCount: 1}
 		mockAppointmentRepo.On("FindAppointmentByAppCode", "NOTFOUND").Return(nil, fmt.Errorf("not found")).Once()
 
@@ -161,17 +166,19 @@ func TestBookAppointment(t *testing.T) {
 		// Arrange
 		mockAppointmentRepo := new(repomocks.AppointmentRepository)
 		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoHosts(mockAppointmentRepo)
+		stubNoResources(mockAppointmentRepo)
 		mockBookingRepo := new(repomocks.BookingRepository)
 		mockUserRepo := new(repomocks.UserRepository)
 		mockBanListRepo := new(repomocks.BanListRepository)
-		mockEventBus := new(MockEventBus)
+		mockOutboxRepo := newMockOutboxRepo()
 		stubBanListNotFound(mockBanListRepo)
 		db, sqlMock, _ := sqlmock.New()
 		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
 			Conn: db,
 		}), &gorm.Config{})
 
-		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, gormDB)
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
 		slot := newSlot()
 		validReq := requests.BookingRequest{AppCode: "SLOT123", Name: "Guest User", Email: "guest@example.com", Date: slot.Date, StartTime: slot.StartTime, EndTime: slot.EndTime, AttendeeCount: 1}
 		mockAppointmentRepo.On("FindAppointmentByAppCode", "SLOT123").Return(appSlot, nil).Once()
@@ -198,18 +205,22 @@ func TestRefreshBookingStatuses(t *testing.T) {
 	mockBookingRepo := new(repomocks.BookingRepository)
 	mockUserRepo := new(repomocks.UserRepository)
 	mockBanListRepo := new(repomocks.BanListRepository)
-	mockEventBus := new(MockEventBus)
+	mockOutboxRepo := newMockOutboxRepo()
 
-	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, nil)
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, nil)
 
 	now := time.Now()
 	mockBookingRepo.On("MarkBookingsOngoing", mock.Anything, now).Return(int64(2), nil).Once()
+	mockBookingRepo.On("MarkBookingsNoShow", mock.Anything, now).Return(int64(3), nil).Once()
+	mockBookingRepo.On("MarkBookingsAttended", mock.Anything, now).Return(int64(4), nil).Once()
 	mockBookingRepo.On("MarkBookingsExpired", mock.Anything, now).Return(int64(1), nil).Once()
 
 	summary, err := bookingService.RefreshBookingStatuses(context.Background(), now)
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(2), summary.Ongoing)
+	assert.Equal(t, int64(3), summary.NoShow)
+	assert.Equal(t, int64(4), summary.Attended)
 	assert.Equal(t, int64(1), summary.Expired)
 	mockBookingRepo.AssertExpectations(t)
 }
@@ -218,6 +229,16 @@ func stubAppointmentWithTx(repo *repomocks.AppointmentRepository) {
 	repo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(repo)
 }
 
+// stubNoHosts leaves the appointment without hosts, so bookings stay with its owner.
+func stubNoHosts(repo *repomocks.AppointmentRepository) {
+	repo.On("ListHosts", mock.Anything).Return([]entities.AppointmentHost(nil), nil).Maybe()
+}
+
+// stubNoResources leaves the appointment without required resources.
+func stubNoResources(repo *repomocks.AppointmentRepository) {
+	repo.On("FindAndLockResources", mock.Anything).Return([]entities.Resource(nil), nil).Maybe()
+}
+
 func stubBanListNotFound(repo *repomocks.BanListRepository) {
 	repo.On("FindByUserAndEmail", mock.Anything, mock.Anything).
 		Return((*entities.BanListEntry)(nil), repoerrors.NotFoundError("not found"))
@@ -274,7 +295,7 @@ func TestBookAppointmentAntiScalping(t *testing.T) {
 		mockBookingRepo := new(repomocks.BookingRepository)
 		mockUserRepo := new(repomocks.UserRepository)
 		mockBanListRepo := new(repomocks.BanListRepository)
-		mockEventBus := new(MockEventBus)
+		mockOutboxRepo := newMockOutboxRepo()
 		stubBanListNotFound(mockBanListRepo)
 
 		db, _, _ := sqlmock.New()
@@ -282,7 +303,7 @@ func TestBookAppointmentAntiScalping(t *testing.T) {
 			Conn: db,
 		}), &gorm.Config{})
 
-		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, gormDB)
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
 
 		mockAppointmentRepo.On("FindAppointmentByAppCode", "STRICT123").Return(appStrict, nil).Once()
 		mockUserRepo.On("FindByID", userID.String()).Return(user, nil).Once()
@@ -303,10 +324,12 @@ func TestBookAppointmentAntiScalping(t *testing.T) {
 	t.Run("Failure - Strict - Device already exists", func(t *testing.T) {
 		mockAppointmentRepo := new(repomocks.AppointmentRepository)
 		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoHosts(mockAppointmentRepo)
+		stubNoResources(mockAppointmentRepo)
 		mockBookingRepo := new(repomocks.BookingRepository)
 		mockUserRepo := new(repomocks.UserRepository)
 		mockBanListRepo := new(repomocks.BanListRepository)
-		mockEventBus := new(MockEventBus)
+		mockOutboxRepo := newMockOutboxRepo()
 		stubBanListNotFound(mockBanListRepo)
 
 		db, _, _ := sqlmock.New()
@@ -314,7 +337,7 @@ func TestBookAppointmentAntiScalping(t *testing.T) {
 			Conn: db,
 		}), &gorm.Config{})
 
-		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, gormDB)
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
 
 		mockAppointmentRepo.On("FindAppointmentByAppCode", "STRICT123").Return(appStrict, nil).Once()
 		mockUserRepo.On("FindByID", userID.String()).Return(user, nil).Once()
@@ -332,10 +355,12 @@ func TestBookAppointmentAntiScalping(t *testing.T) {
 	t.Run("Failure - Standard - Email already exists", func(t *testing.T) {
 		mockAppointmentRepo := new(repomocks.AppointmentRepository)
 		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoHosts(mockAppointmentRepo)
+		stubNoResources(mockAppointmentRepo)
 		mockBookingRepo := new(repomocks.BookingRepository)
 		mockUserRepo := new(repomocks.UserRepository)
 		mockBanListRepo := new(repomocks.BanListRepository)
-		mockEventBus := new(MockEventBus)
+		mockOutboxRepo := newMockOutboxRepo()
 		stubBanListNotFound(mockBanListRepo)
 
 		db, _, _ := sqlmock.New()
@@ -343,7 +368,7 @@ func TestBookAppointmentAntiScalping(t *testing.T) {
 			Conn: db,
 		}), &gorm.Config{})
 
-		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, gormDB)
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
 
 		standardReq := requests.BookingRequest{
 			AppCode:       "STD123",
@@ -368,9 +393,11 @@ func TestBookAppointmentAntiScalping(t *testing.T) {
 	t.Run("Failure - Strict - Missing Device Token", func(t *testing.T) {
 		mockAppointmentRepo := new(repomocks.AppointmentRepository)
 		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoHosts(mockAppointmentRepo)
+		stubNoResources(mockAppointmentRepo)
 		mockUserRepo := new(repomocks.UserRepository)
 		mockBanListRepo := new(repomocks.BanListRepository)
-		mockEventBus := new(MockEventBus)
+		mockOutboxRepo := newMockOutboxRepo()
 		stubBanListNotFound(mockBanListRepo)
 
 		db, _, _ := sqlmock.New()
@@ -378,7 +405,7 @@ func TestBookAppointmentAntiScalping(t *testing.T) {
 			Conn: db,
 		}), &gorm.Config{})
 
-		bookingService := services.NewBookingService(nil, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, gormDB)
+		bookingService := services.NewBookingService(nil, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
 
 		missingTokenReq := requests.BookingRequest{
 			AppCode:       "STRICT123",
@@ -404,33 +431,389 @@ func TestUpdateBookingByCodeOngoingBlocked(t *testing.T) {
 	mockBookingRepo := new(repomocks.BookingRepository)
 	mockUserRepo := new(repomocks.UserRepository)
 	mockBanListRepo := new(repomocks.BanListRepository)
-	mockEventBus := new(MockEventBus)
+	mockOutboxRepo := newMockOutboxRepo()
 
-	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, nil)
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, nil)
 
 	booking := &entities.Booking{Status: entities.BookingStatusOngoing, AppCode: "APP123"}
 	mockBookingRepo.On("GetBookingByCode", "BK-ONGOING").Return(booking, nil).Once()
 
-	_, err := bookingService.UpdateBookingByCode("BK-ONGOING", requests.BookingRequest{AppCode: "APP123"})
+	_, err := bookingService.UpdateBookingByCode("BK-ONGOING", requests.BookingRequest{AppCode: "APP123"}, "")
 
 	assert.Error(t, err)
 	assert.Equal(t, "CONFLICT: ongoing bookings cannot be rescheduled", err.Error())
 	mockBookingRepo.AssertExpectations(t)
 }
 
+func TestCancelBookingByCodeEnforcesCutoff(t *testing.T) {
+	ownerID := uuid.New()
+	appointment := &entities.Appointment{ID: uuid.New(), AppCode: "APP123", Type: entities.Group, OwnerID: ownerID, CancellationCutoffHours: 24}
+	start := time.Now().Add(6 * time.Hour)
+	newBooking := func() *entities.Booking {
+		return &entities.Booking{AppCode: "APP123", Date: start, StartTime: start, AttendeeCount: 1, Status: entities.BookingStatusPending}
+	}
+
+	t.Run("guest blocked", func(t *testing.T) {
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		mockBookingRepo := new(repomocks.BookingRepository)
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), nil)
+
+		mockBookingRepo.On("GetBookingByCode", "BK-LATE").Return(newBooking(), nil).Once()
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "APP123").Return(appointment, nil).Once()
+
+		_, err := bookingService.CancelBookingByCode("BK-LATE", "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "BOOKING_POLICY_VIOLATION")
+		mockBookingRepo.AssertNotCalled(t, "WithTx", mock.Anything)
+	})
+
+	t.Run("owner override", func(t *testing.T) {
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockOutboxRepo := newMockOutboxRepo()
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		mockWaitlistRepo := new(repomocks.WaitlistRepository)
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), mockWaitlistRepo, new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+
+		booking := newBooking()
+		slot := &entities.Booking{AppCode: "APP123", Date: start, StartTime: start, IsSlot: true, Capacity: 5, SeatsBooked: 1}
+		mockWaitlistRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockWaitlistRepo).Once()
+		mockWaitlistRepo.On("FindNextAndLock", appointment.ID, start).Return(nil, repoerrors.NotFoundError("no waitlist entry found")).Once()
+		mockBookingRepo.On("GetBookingByCode", "BK-LATE").Return(booking, nil).Once()
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "APP123").Return(appointment, nil).Once()
+		sqlMock.ExpectBegin()
+		mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo)
+		mockBookingRepo.On("FindAndLockSlot", "APP123", start, start).Return(slot, nil).Once()
+		mockBookingRepo.On("Update", mock.AnythingOfType("*entities.Booking")).Return(nil).Twice()
+		sqlMock.ExpectCommit()
+		mockOutboxRepo.On("Create", outboxEvent(func(event events.Event) bool {
+			return event.Name == events.EventBookingCancelled
+		})).Return(nil).Once()
+
+		cancelled, err := bookingService.CancelBookingByCode("BK-LATE", ownerID.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookingStatusCancelled, cancelled.Status)
+		assert.Equal(t, 0, slot.SeatsBooked)
+		mockBookingRepo.AssertExpectations(t)
+		mockOutboxRepo.AssertExpectations(t)
+	})
+}
+
+func TestCancelBookingByCodePromotesWaitlist(t *testing.T) {
+	mockAppointmentRepo := new(repomocks.AppointmentRepository)
+	mockBookingRepo := new(repomocks.BookingRepository)
+	mockWaitlistRepo := new(repomocks.WaitlistRepository)
+	mockOutboxRepo := newMockOutboxRepo()
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), mockWaitlistRepo, new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+
+	appointment := &entities.Appointment{ID: uuid.New(), AppCode: "GRP123", Type: entities.Group, MaxAttendees: 3}
+	start := time.Now().Add(24 * time.Hour)
+	booking := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, AttendeeCount: 2, Status: entities.BookingStatusPending}
+	slot := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, IsSlot: true, Capacity: 3, SeatsBooked: 3}
+	first := &entities.WaitlistEntry{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, Name: "First", Email: "first@example.com", AttendeeCount: 2, Status: entities.WaitlistStatusWaiting}
+	// Needs more seats than remain once the first entry is promoted, so the queue stops here.
+	second := &entities.WaitlistEntry{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, Name: "Second", Email: "second@example.com", AttendeeCount: 1, Status: entities.WaitlistStatusWaiting}
+
+	mockBookingRepo.On("GetBookingByCode", "BK-GRP").Return(booking, nil).Once()
+	mockAppointmentRepo.On("FindAppointmentByAppCode", "GRP123").Return(appointment, nil).Once()
+	sqlMock.ExpectBegin()
+	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo)
+	mockWaitlistRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockWaitlistRepo).Once()
+	stubAppointmentWithTx(mockAppointmentRepo)
+	stubNoHosts(mockAppointmentRepo)
+	stubNoResources(mockAppointmentRepo)
+	mockBookingRepo.On("FindAndLockSlot", "GRP123", start, start).Return(slot, nil).Once()
+	mockWaitlistRepo.On("FindNextAndLock", appointment.ID, start).Return(first, nil).Once()
+	mockBookingRepo.On("FindActiveBookingByEmail", appointment.ID, mock.AnythingOfType("string")).Return(nil, repoerrors.NotFoundError("active booking not found"))
+	mockBookingRepo.On("Create", mock.MatchedBy(func(b *entities.Booking) bool {
+		return b.Email == "first@example.com" && b.AttendeeCount == 2 && b.Status == entities.BookingStatusPending
+	})).Return(nil).Once()
+	mockWaitlistRepo.On("Update", first).Return(nil).Once()
+	mockWaitlistRepo.On("FindNextAndLock", appointment.ID, start).Return(second, nil).Once()
+	mockBookingRepo.On("Update", mock.AnythingOfType("*entities.Booking")).Return(nil).Twice()
+	sqlMock.ExpectCommit()
+	mockOutboxRepo.On("Create", outboxEvent(func(event events.Event) bool {
+		return event.Name == events.EventBookingCancelled
+	})).Return(nil).Once()
+	mockOutboxRepo.On("Create", outboxEvent(func(event events.Event) bool {
+		p, ok := event.Data.(events.BookingEventData)
+		return event.Name == events.EventBookingPromoted && ok && p.RecipientEmail == "first@example.com"
+	})).Return(nil).Once()
+
+	cancelled, err := bookingService.CancelBookingByCode("BK-GRP", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.BookingStatusCancelled, cancelled.Status)
+	assert.Equal(t, entities.WaitlistStatusPromoted, first.Status)
+	assert.NotNil(t, first.BookingID)
+	assert.Equal(t, entities.WaitlistStatusWaiting, second.Status)
+	assert.Equal(t, 3, slot.SeatsBooked)
+	mockBookingRepo.AssertExpectations(t)
+	mockWaitlistRepo.AssertExpectations(t)
+	mockOutboxRepo.AssertExpectations(t)
+}
+
+func TestCancelBookingByCodeExpiresWaitlistEntriesThatAlreadyBooked(t *testing.T) {
+	mockAppointmentRepo := new(repomocks.AppointmentRepository)
+	mockBookingRepo := new(repomocks.BookingRepository)
+	mockWaitlistRepo := new(repomocks.WaitlistRepository)
+	mockOutboxRepo := newMockOutboxRepo()
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), mockWaitlistRepo, new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+
+	appointment := &entities.Appointment{ID: uuid.New(), AppCode: "GRP123", Type: entities.Group, MaxAttendees: 3}
+	start := time.Now().Add(24 * time.Hour)
+	booking := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, AttendeeCount: 2, Status: entities.BookingStatusPending}
+	slot := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, IsSlot: true, Capacity: 3, SeatsBooked: 3}
+	// Booked another time on the appointment after joining, so promoting them would break the
+	// one-active-booking-per-email rule.
+	colliding := &entities.WaitlistEntry{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, Name: "Twice", Email: "twice@example.com", AttendeeCount: 1, Status: entities.WaitlistStatusWaiting}
+	next := &entities.WaitlistEntry{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, Name: "Next", Email: "next@example.com", AttendeeCount: 1, Status: entities.WaitlistStatusWaiting}
+
+	mockBookingRepo.On("GetBookingByCode", "BK-GRP").Return(booking, nil).Once()
+	mockAppointmentRepo.On("FindAppointmentByAppCode", "GRP123").Return(appointment, nil).Once()
+	sqlMock.ExpectBegin()
+	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo)
+	mockWaitlistRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockWaitlistRepo).Once()
+	stubAppointmentWithTx(mockAppointmentRepo)
+	stubNoHosts(mockAppointmentRepo)
+	stubNoResources(mockAppointmentRepo)
+	mockBookingRepo.On("FindAndLockSlot", "GRP123", start, start).Return(slot, nil).Once()
+	mockWaitlistRepo.On("FindNextAndLock", appointment.ID, start).Return(colliding, nil).Once()
+	mockBookingRepo.On("FindActiveBookingByEmail", appointment.ID, "twice@example.com").Return(&entities.Booking{Email: "twice@example.com"}, nil).Once()
+	mockWaitlistRepo.On("Update", colliding).Return(nil).Once()
+	mockWaitlistRepo.On("FindNextAndLock", appointment.ID, start).Return(next, nil).Once()
+	mockBookingRepo.On("FindActiveBookingByEmail", appointment.ID, "next@example.com").Return(nil, repoerrors.NotFoundError("active booking not found")).Once()
+	mockBookingRepo.On("Create", mock.MatchedBy(func(b *entities.Booking) bool {
+		return b.Email == "next@example.com"
+	})).Return(nil).Once()
+	mockWaitlistRepo.On("Update", next).Return(nil).Once()
+	mockWaitlistRepo.On("FindNextAndLock", appointment.ID, start).Return(nil, repoerrors.NotFoundError("no waitlist entry found")).Once()
+	mockBookingRepo.On("Update", mock.AnythingOfType("*entities.Booking")).Return(nil).Twice()
+	sqlMock.ExpectCommit()
+	mockOutboxRepo.On("Create", mock.Anything).Return(nil).Twice()
+
+	cancelled, err := bookingService.CancelBookingByCode("BK-GRP", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.BookingStatusCancelled, cancelled.Status)
+	assert.Equal(t, entities.WaitlistStatusExpired, colliding.Status)
+	assert.Nil(t, colliding.BookingID)
+	assert.Equal(t, entities.WaitlistStatusPromoted, next.Status)
+	assert.Equal(t, 2, slot.SeatsBooked)
+	mockBookingRepo.AssertNotCalled(t, "Create", mock.MatchedBy(func(b *entities.Booking) bool {
+		return b.Email == "twice@example.com"
+	}))
+	mockWaitlistRepo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestHoldSlotReservesSeats(t *testing.T) {
+	mockAppointmentRepo := new(repomocks.AppointmentRepository)
+	mockBookingRepo := new(repomocks.BookingRepository)
+	mockHoldRepo := new(repomocks.SlotHoldRepository)
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), mockHoldRepo, new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), gormDB)
+
+	appointment := &entities.Appointment{ID: uuid.New(), AppCode: "GRP123", Type: entities.Group, MaxAttendees: 4}
+	start := time.Now().Add(24 * time.Hour)
+	slot := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, IsSlot: true, Available: true, Capacity: 4, SeatsBooked: 1}
+
+	mockAppointmentRepo.On("FindAppointmentByAppCode", "GRP123").Return(appointment, nil)
+	stubAppointmentWithTx(mockAppointmentRepo)
+	stubNoResources(mockAppointmentRepo)
+	sqlMock.ExpectBegin()
+	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo)
+	mockHoldRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockHoldRepo)
+	mockBookingRepo.On("FindAndLockAvailableSlot", "GRP123", start, start).Return(slot, nil).Once()
+	mockBookingRepo.On("Update", slot).Return(nil).Once()
+	mockHoldRepo.On("Create", mock.AnythingOfType("*entities.SlotHold")).Return(nil).Once()
+	sqlMock.ExpectCommit()
+
+	hold, err := bookingService.HoldSlot(requests.SlotHoldRequest{AppCode: "GRP123", Date: start, StartTime: start, Seats: 2})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hold.Token)
+	assert.Equal(t, 2, hold.Seats)
+	assert.True(t, hold.IsActive(time.Now()))
+	assert.Equal(t, 3, slot.SeatsBooked)
+
+	t.Run("rejects more seats than remain", func(t *testing.T) {
+		full := &entities.Booking{AppCode: "GRP123", Date: start, StartTime: start, IsSlot: true, Available: true, Capacity: 4, SeatsBooked: 3}
+		sqlMock.ExpectBegin()
+		mockBookingRepo.On("FindAndLockAvailableSlot", "GRP123", start, start).Return(full, nil).Once()
+		sqlMock.ExpectRollback()
+
+		_, err := bookingService.HoldSlot(requests.SlotHoldRequest{AppCode: "GRP123", Date: start, StartTime: start, Seats: 2})
+
+		assert.Error(t, err)
+		assert.Equal(t, 3, full.SeatsBooked)
+	})
+
+	t.Run("marks a slot nobody booked as held", func(t *testing.T) {
+		open := &entities.Booking{AppCode: "GRP123", Date: start, StartTime: start, IsSlot: true, Available: true, Capacity: 4, Status: entities.BookingStatusActive}
+		sqlMock.ExpectBegin()
+		mockBookingRepo.On("FindAndLockAvailableSlot", "GRP123", start, start).Return(open, nil).Once()
+		mockBookingRepo.On("Update", open).Return(nil).Once()
+		mockHoldRepo.On("Create", mock.AnythingOfType("*entities.SlotHold")).Return(nil).Once()
+		sqlMock.ExpectCommit()
+
+		_, err := bookingService.HoldSlot(requests.SlotHoldRequest{AppCode: "GRP123", Date: start, StartTime: start, Seats: 4})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookingStatusHeld, open.Status)
+		assert.False(t, open.Available)
+	})
+
+	assert.Equal(t, "", slot.Status)
+	mockBookingRepo.AssertExpectations(t)
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestReleaseExpiredHoldsRestoresSeats(t *testing.T) {
+	mockAppointmentRepo := new(repomocks.AppointmentRepository)
+	mockBookingRepo := new(repomocks.BookingRepository)
+	mockWaitlistRepo := new(repomocks.WaitlistRepository)
+	mockHoldRepo := new(repomocks.SlotHoldRepository)
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), mockWaitlistRepo, mockHoldRepo, new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), gormDB)
+
+	now := time.Now()
+	appointment := &entities.Appointment{ID: uuid.New(), AppCode: "GRP123", Type: entities.Group, MaxAttendees: 4}
+	start := now.Add(24 * time.Hour)
+	slot := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, IsSlot: true, Capacity: 4, SeatsBooked: 4}
+	expired := entities.SlotHold{ID: uuid.New(), Token: "expired", AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, Seats: 3, Status: entities.SlotHoldStatusHeld, ExpiresAt: now.Add(-time.Minute)}
+
+	sqlMock.ExpectBegin()
+	mockHoldRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockHoldRepo)
+	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo)
+	mockWaitlistRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockWaitlistRepo)
+	mockHoldRepo.On("FindExpiredAndLock", now, mock.AnythingOfType("int")).Return([]entities.SlotHold{expired}, nil).Once()
+	mockBookingRepo.On("FindAndLockSlot", "GRP123", start, start).Return(slot, nil).Once()
+	mockAppointmentRepo.On("FindAppointmentByAppCode", "GRP123").Return(appointment, nil).Once()
+	mockWaitlistRepo.On("FindNextAndLock", appointment.ID, start).Return(nil, repoerrors.NotFoundError("no waitlist entry")).Once()
+	mockBookingRepo.On("Update", slot).Return(nil).Once()
+	mockHoldRepo.On("Update", mock.MatchedBy(func(h *entities.SlotHold) bool {
+		return h.ID == expired.ID && h.Status == entities.SlotHoldStatusReleased
+	})).Return(nil).Once()
+	sqlMock.ExpectCommit()
+
+	released, err := bookingService.ReleaseExpiredHolds(context.Background(), now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), released)
+	assert.Equal(t, 1, slot.SeatsBooked)
+	assert.True(t, slot.Available)
+
+	t.Run("reopens a slot that was only held", func(t *testing.T) {
+		held := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "GRP123", Date: start, StartTime: start, IsSlot: true, Capacity: 4, SeatsBooked: 3, Status: entities.BookingStatusHeld}
+		sqlMock.ExpectBegin()
+		mockHoldRepo.On("FindExpiredAndLock", now, mock.AnythingOfType("int")).Return([]entities.SlotHold{expired}, nil).Once()
+		mockBookingRepo.On("FindAndLockSlot", "GRP123", start, start).Return(held, nil).Once()
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "GRP123").Return(appointment, nil).Once()
+		mockWaitlistRepo.On("FindNextAndLock", appointment.ID, start).Return(nil, repoerrors.NotFoundError("no waitlist entry")).Once()
+		mockBookingRepo.On("Update", held).Return(nil).Once()
+		mockHoldRepo.On("Update", mock.AnythingOfType("*entities.SlotHold")).Return(nil).Once()
+		sqlMock.ExpectCommit()
+
+		_, err := bookingService.ReleaseExpiredHolds(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookingStatusActive, held.Status)
+		assert.True(t, held.Available)
+	})
+
+	mockBookingRepo.AssertExpectations(t)
+	mockHoldRepo.AssertExpectations(t)
+	mockWaitlistRepo.AssertExpectations(t)
+}
+
+func TestUpdateBookingByCodeEnforcesReschedulePolicy(t *testing.T) {
+	start := time.Now().Add(48 * time.Hour)
+	newStart := start.Add(time.Hour)
+
+	for name, tc := range map[string]struct {
+		appointment *entities.Appointment
+		count       int
+	}{
+		"inside cutoff":   {appointment: &entities.Appointment{ID: uuid.New(), AppCode: "APP123", Type: entities.Group, OwnerID: uuid.New(), RescheduleCutoffHours: 72}},
+		"limit exhausted": {appointment: &entities.Appointment{ID: uuid.New(), AppCode: "APP123", Type: entities.Group, OwnerID: uuid.New(), MaxReschedules: 2}, count: 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockAppointmentRepo := new(repomocks.AppointmentRepository)
+			mockBookingRepo := new(repomocks.BookingRepository)
+			bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), nil)
+
+			booking := &entities.Booking{AppCode: "APP123", Date: start, StartTime: start, AttendeeCount: 1, Status: entities.BookingStatusPending, RescheduleCount: tc.count}
+			mockBookingRepo.On("GetBookingByCode", "BK-MOVE").Return(booking, nil).Once()
+			mockAppointmentRepo.On("FindAppointmentByAppCode", "APP123").Return(tc.appointment, nil).Once()
+
+			_, err := bookingService.UpdateBookingByCode("BK-MOVE", requests.BookingRequest{AppCode: "APP123", Date: newStart, StartTime: newStart, AttendeeCount: 1}, "")
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "BOOKING_POLICY_VIOLATION")
+			mockBookingRepo.AssertNotCalled(t, "WithTx", mock.Anything)
+		})
+	}
+}
+
+func TestUpdateBookingByCodeRejectsMoveIntoUnbookableAppointment(t *testing.T) {
+	start := time.Now().Add(48 * time.Hour)
+	current := &entities.Appointment{ID: uuid.New(), AppCode: "APP123", Type: entities.Group, OwnerID: uuid.New(), MaxAttendees: 4}
+
+	for _, status := range []entities.AppointmentStatus{entities.AppointmentStatusDraft, entities.AppointmentStatusPaused, entities.AppointmentStatusArchived} {
+		t.Run(string(status), func(t *testing.T) {
+			mockAppointmentRepo := new(repomocks.AppointmentRepository)
+			mockBookingRepo := new(repomocks.BookingRepository)
+			bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), nil)
+
+			target := &entities.Appointment{ID: uuid.New(), AppCode: "NEW123", Type: entities.Group, OwnerID: current.OwnerID, MaxAttendees: 4, Status: status}
+			booking := &entities.Booking{AppCode: "APP123", Date: start, StartTime: start, AttendeeCount: 1, Status: entities.BookingStatusPending}
+			mockBookingRepo.On("GetBookingByCode", "BK-MOVE").Return(booking, nil).Once()
+			mockAppointmentRepo.On("FindAppointmentByAppCode", "APP123").Return(current, nil).Once()
+			mockAppointmentRepo.On("FindAppointmentByAppCode", "NEW123").Return(target, nil).Once()
+
+			_, err := bookingService.UpdateBookingByCode("BK-MOVE", requests.BookingRequest{AppCode: "NEW123", Date: start, StartTime: start, AttendeeCount: 1}, "")
+
+			_, reason := target.IsBookable()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), reason)
+			mockBookingRepo.AssertNotCalled(t, "WithTx", mock.Anything)
+		})
+	}
+}
+
 func TestUpdateBookingByCodeConfirmedResetsToPending(t *testing.T) {
 	mockAppointmentRepo := new(repomocks.AppointmentRepository)
 	mockBookingRepo := new(repomocks.BookingRepository)
 	mockUserRepo := new(repomocks.UserRepository)
 	mockBanListRepo := new(repomocks.BanListRepository)
-	mockEventBus := new(MockEventBus)
+	mockOutboxRepo := newMockOutboxRepo()
 
 	db, sqlMock, _ := sqlmock.New()
 	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
 		Conn: db,
 	}), &gorm.Config{})
 
-	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, mockEventBus, gormDB)
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, mockUserRepo, mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
 
 	oldDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
 	oldStart := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
@@ -481,12 +864,16 @@ func TestUpdateBookingByCodeConfirmedResetsToPending(t *testing.T) {
 	mockAppointmentRepo.On("FindAppointmentByAppCode", "APP123").Return(&entities.Appointment{AppCode: "APP123", Type: entities.Single}, nil).Once()
 	sqlMock.ExpectBegin()
 	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Once()
+	stubAppointmentWithTx(mockAppointmentRepo)
+	stubNoHosts(mockAppointmentRepo)
+	stubNoResources(mockAppointmentRepo)
 	mockBookingRepo.On("FindAndLockSlot", "APP123", oldDate, oldStart).Return(oldSlot, nil).Once()
 	mockBookingRepo.On("FindAndLockSlot", "APP123", newDate, newStart).Return(newSlot, nil).Once()
 	mockBookingRepo.On("Update", mock.AnythingOfType("*entities.Booking")).Return(nil).Times(3)
+	mockBookingRepo.On("ListOwnerSlotsBetween", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]entities.Booking{}, nil).Twice()
 	sqlMock.ExpectCommit()
 
-	mockEventBus.On("Publish", mock.Anything, mock.MatchedBy(func(event events.Event) bool {
+	mockOutboxRepo.On("Create", outboxEvent(func(event events.Event) bool {
 		return event.Name == events.EventBookingUpdated
 	})).Return(nil).Once()
 
@@ -496,7 +883,7 @@ func TestUpdateBookingByCodeConfirmedResetsToPending(t *testing.T) {
 		StartTime:     newStart,
 		EndTime:       newEnd,
 		AttendeeCount: 1,
-	})
+	}, "")
 
 	assert.NoError(t, err)
 	assert.Equal(t, entities.BookingStatusPending, newSlot.Status)
@@ -504,7 +891,205 @@ func TestUpdateBookingByCodeConfirmedResetsToPending(t *testing.T) {
 	assert.Equal(t, updated, newSlot)
 	mockBookingRepo.AssertExpectations(t)
 	mockAppointmentRepo.AssertExpectations(t)
-	mockEventBus.AssertExpectations(t)
+	mockOutboxRepo.AssertExpectations(t)
+}
+
+func TestUpdateBookingByCodeRejectsMoveIntoBuffer(t *testing.T) {
+	mockAppointmentRepo := new(repomocks.AppointmentRepository)
+	mockBookingRepo := new(repomocks.BookingRepository)
+	mockOutboxRepo := newMockOutboxRepo()
+
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+
+	appointment := &entities.Appointment{ID: uuid.New(), AppCode: "APP123", Type: entities.Single, TimeZone: "UTC", BookingDuration: 45, PostBufferMinutes: 15}
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	oldStart := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	newStart := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	booking := &entities.Booking{ID: uuid.New(), AppCode: "APP123", Date: date, StartTime: oldStart, EndTime: oldStart.Add(45 * time.Minute), IsSlot: true, AttendeeCount: 1, Status: entities.BookingStatusPending}
+	oldSlot := *booking
+	newSlot := &entities.Booking{ID: uuid.New(), AppCode: "APP123", Date: date, StartTime: newStart, EndTime: newStart.Add(45 * time.Minute), IsSlot: true, Capacity: 1, Status: entities.BookingStatusActive, Available: true}
+	// Ends at 10:20; with its 15 minute post-buffer the host is busy until 10:35.
+	neighbour := entities.Booking{ID: uuid.New(), AppCode: "APP123", Date: date, StartTime: time.Date(2026, 1, 1, 9, 35, 0, 0, time.UTC), EndTime: time.Date(2026, 1, 1, 10, 20, 0, 0, time.UTC), IsSlot: true, Capacity: 1, SeatsBooked: 1, Status: entities.BookingStatusPending}
+
+	mockBookingRepo.On("GetBookingByCode", "BK-OLD").Return(booking, nil).Once()
+	mockAppointmentRepo.On("FindAppointmentByAppCode", "APP123").Return(appointment, nil).Once()
+	sqlMock.ExpectBegin()
+	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Once()
+	mockBookingRepo.On("FindAndLockSlot", "APP123", date, oldStart).Return(&oldSlot, nil).Once()
+	mockBookingRepo.On("FindAndLockSlot", "APP123", date, newStart).Return(newSlot, nil).Once()
+	mockBookingRepo.On("GetBookingsBetweenDates", appointment.ID, date.AddDate(0, 0, -1), date.AddDate(0, 0, 2)).Return([]entities.Booking{oldSlot, neighbour, *newSlot}, nil).Once()
+	sqlMock.ExpectRollback()
+
+	_, err := bookingService.UpdateBookingByCode("BK-OLD", requests.BookingRequest{
+		AppCode:       "APP123",
+		Date:          date,
+		StartTime:     newStart,
+		AttendeeCount: 1,
+	}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "buffer")
+	mockBookingRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockBookingRepo.AssertExpectations(t)
+	mockOutboxRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestBookAppointmentRejectsSlotsOutsideBookingWindow(t *testing.T) {
+	appointment := &entities.Appointment{ID: uuid.New(), AppCode: "WIN123", Type: entities.Single, MaxAttendees: 1, AntiScalpingLevel: entities.ScalpingNone, MinNoticeMinutes: 120, MaxAdvanceDays: 30}
+
+	for name, start := range map[string]time.Time{
+		"too soon":    time.Now().Add(time.Hour),
+		"too far out": time.Now().AddDate(0, 0, 45),
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockAppointmentRepo := new(repomocks.AppointmentRepository)
+			mockBookingRepo := new(repomocks.BookingRepository)
+			bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), nil)
+
+			mockAppointmentRepo.On("FindAppointmentByAppCode", "WIN123").Return(appointment, nil).Once()
+
+			_, err := bookingService.BookAppointment(requests.BookingRequest{AppCode: "WIN123", Name: "Guest", Email: "guest@example.com", Date: start, StartTime: start, EndTime: start.Add(30 * time.Minute), AttendeeCount: 1}, "")
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "BOOKING_OUTSIDE_WINDOW")
+			mockAppointmentRepo.AssertExpectations(t)
+			mockBookingRepo.AssertNotCalled(t, "FindAndLockAvailableSlot", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBookAppointmentValidatesFormAnswers(t *testing.T) {
+	appointment := &entities.Appointment{
+		ID: uuid.New(), AppCode: "FRM123", Type: entities.Single, MaxAttendees: 1, AntiScalpingLevel: entities.ScalpingNone,
+		FormFields: entities.BookingForm{{Key: "company", Label: "Company", Type: entities.FormFieldText, Required: true}},
+	}
+	start := time.Now().Add(24 * time.Hour)
+
+	for name, answers := range map[string]entities.FormAnswers{
+		"missing required": nil,
+		"unknown field":    {"company": "Acme", "shoe_size": "42"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockAppointmentRepo := new(repomocks.AppointmentRepository)
+			mockBookingRepo := new(repomocks.BookingRepository)
+			bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), nil)
+
+			mockAppointmentRepo.On("FindAppointmentByAppCode", "FRM123").Return(appointment, nil).Once()
+
+			_, err := bookingService.BookAppointment(requests.BookingRequest{AppCode: "FRM123", Name: "Guest", Email: "guest@example.com", Date: start, StartTime: start, EndTime: start.Add(30 * time.Minute), AttendeeCount: 1, FormAnswers: answers}, "")
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid form answers")
+			mockBookingRepo.AssertNotCalled(t, "FindAndLockAvailableSlot", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBookAppointmentStoresFormAnswersOnGroupReservation(t *testing.T) {
+	appointment := &entities.Appointment{
+		ID: uuid.New(), AppCode: "FRM123", Type: entities.Group, MaxAttendees: 5, AntiScalpingLevel: entities.ScalpingNone,
+		FormFields: entities.BookingForm{{Key: "company", Label: "Company", Type: entities.FormFieldText, Required: true}},
+	}
+	start := time.Now().Add(24 * time.Hour)
+	slot := &entities.Booking{ID: uuid.New(), AppCode: "FRM123", Available: true, IsSlot: true, Capacity: 5, Date: start, StartTime: start, EndTime: start.Add(time.Hour)}
+
+	mockAppointmentRepo := new(repomocks.AppointmentRepository)
+	stubAppointmentWithTx(mockAppointmentRepo)
+	stubNoHosts(mockAppointmentRepo)
+	stubNoResources(mockAppointmentRepo)
+	mockBookingRepo := new(repomocks.BookingRepository)
+	mockBanListRepo := new(repomocks.BanListRepository)
+	stubBanListNotFound(mockBanListRepo)
+	mockOutboxRepo := newMockOutboxRepo()
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+
+	mockAppointmentRepo.On("FindAppointmentByAppCode", "FRM123").Return(appointment, nil).Once()
+	mockAppointmentRepo.On("FindAndLock", "FRM123", mock.AnythingOfType("*gorm.DB")).Return(appointment, nil).Once()
+	sqlMock.ExpectBegin()
+	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Once()
+	mockBookingRepo.On("FindAndLockAvailableSlot", "FRM123", slot.Date, slot.StartTime).Return(slot, nil).Once()
+	mockBookingRepo.On("Create", mock.MatchedBy(func(b *entities.Booking) bool {
+		return !b.IsSlot && b.FormAnswers["company"] == "Acme"
+	})).Return(nil).Once()
+	mockBookingRepo.On("Update", slot).Return(nil).Once()
+	sqlMock.ExpectCommit()
+	mockOutboxRepo.On("Create", mock.Anything).Return(nil).Once()
+
+	booking, err := bookingService.BookAppointment(requests.BookingRequest{AppCode: "FRM123", Name: "Guest", Email: "guest@example.com", Date: slot.Date, StartTime: slot.StartTime, EndTime: slot.EndTime, AttendeeCount: 2, FormAnswers: entities.FormAnswers{"company": "Acme"}}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.FormAnswers{"company": "Acme"}, booking.FormAnswers)
+	assert.Nil(t, slot.FormAnswers)
+	mockBookingRepo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestExportAttendeesIncludesFormAnswers(t *testing.T) {
+	ownerID := uuid.New()
+	appointment := &entities.Appointment{
+		ID: uuid.New(), AppCode: "FRM123", OwnerID: ownerID, Type: entities.Group,
+		FormFields: entities.BookingForm{
+			{Key: "company", Type: entities.FormFieldText},
+			{Key: "guests", Type: entities.FormFieldNumber},
+		},
+	}
+	start := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	bookings := []entities.Booking{{
+		BookingCode: "BK1", Name: "Ada", Email: "ada@example.com", Date: start, StartTime: start, EndTime: start.Add(time.Hour),
+		AttendeeCount: 2, Status: entities.BookingStatusConfirmed, FormAnswers: entities.FormAnswers{"company": "Acme", "guests": float64(2)},
+	}}
+
+	mockAppointmentRepo := new(repomocks.AppointmentRepository)
+	mockBookingRepo := new(repomocks.BookingRepository)
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), nil)
+	mockAppointmentRepo.On("FindAppointmentByAppCode", "FRM123").Return(appointment, nil)
+	mockBookingRepo.On("GetActiveBookingsForAppointment", appointment.ID).Return(bookings, nil).Once()
+
+	data, err := bookingService.ExportAttendees("FRM123", ownerID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "booking_code,name,email,phone,date,start_time,end_time,attendee_count,status,description,company,guests\n"+
+		"BK1,Ada,ada@example.com,,2026-03-09,2026-03-09T09:00:00Z,2026-03-09T10:00:00Z,2,confirmed,,Acme,2\n", string(data))
+
+	_, err = bookingService.ExportAttendees("FRM123", uuid.New())
+	assert.Error(t, err)
+	mockBookingRepo.AssertExpectations(t)
+}
+
+func TestGetAvailableDatesUsesAppointmentTimeZone(t *testing.T) {
+	mockAppointmentRepo := new(repomocks.AppointmentRepository)
+	mockBookingRepo := new(repomocks.BookingRepository)
+	bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), nil)
+
+	loc, _ := time.LoadLocation("America/New_York")
+	appointment := &entities.Appointment{AppCode: "TZ123", TimeZone: "America/New_York"}
+	localMidnight := time.Date(2026, 3, 8, 0, 0, 0, 0, loc)
+
+	mockAppointmentRepo.On("FindAppointmentByAppCode", "TZ123").Return(appointment, nil).Once()
+	mockBookingRepo.On("GetAvailableDates", mock.Anything, "TZ123").Return([]time.Time{localMidnight.UTC()}, nil).Once()
+
+	dates, err := bookingService.GetAvailableDates(context.Background(), "TZ123")
+
+	assert.NoError(t, err)
+	if assert.Len(t, dates, 1) {
+		assert.Equal(t, "2026-03-08", dates[0].Date)
+		assert.Equal(t, "America/New_York", dates[0].TimeZone)
+		assert.Equal(t, time.Date(2026, 3, 8, 5, 0, 0, 0, time.UTC), dates[0].StartUTC)
+		// DST starts that night, so the local day is only 23 hours long.
+		assert.Equal(t, time.Date(2026, 3, 9, 4, 0, 0, 0, time.UTC), dates[0].EndUTC)
+	}
+	mockAppointmentRepo.AssertExpectations(t)
+	mockBookingRepo.AssertExpectations(t)
 }
 
 // TestBanListService tests the ban list functionality
@@ -554,3 +1139,680 @@ func TestBanListService(t *testing.T) {
 		mockBanListRepo.AssertExpectations(t)
 	})
 }
+
+func TestBookingAttendance(t *testing.T) {
+	ownerID := uuid.New()
+	appointment := &entities.Appointment{ID: uuid.New(), AppCode: "APP123", Type: entities.Group, OwnerID: ownerID}
+	now := time.Now()
+
+	setup := func(booking *entities.Booking) (services.BookingService, *repomocks.BookingRepository) {
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		mockBookingRepo.On("GetBookingByCode", booking.BookingCode).Return(booking, nil).Once()
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "APP123").Return(appointment, nil).Once()
+		svc := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), nil)
+		return svc, mockBookingRepo
+	}
+
+	t.Run("check in before the end", func(t *testing.T) {
+		booking := &entities.Booking{BookingCode: "BK1", AppCode: "APP123", Status: entities.BookingStatusConfirmed, StartTime: now.Add(-10 * time.Minute), EndTime: now.Add(20 * time.Minute)}
+		svc, repo := setup(booking)
+		repo.On("CheckIn", booking.ID, entities.BookingStatusConfirmed, entities.BookingStatusCheckedIn, mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+
+		result, err := svc.CheckInBooking("BK1", ownerID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookingStatusCheckedIn, result.Status)
+		assert.NotNil(t, result.CheckedInAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("late check in marks attended", func(t *testing.T) {
+		booking := &entities.Booking{BookingCode: "BK2", AppCode: "APP123", Status: entities.BookingStatusNoShow, StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour)}
+		svc, repo := setup(booking)
+		repo.On("CheckIn", booking.ID, entities.BookingStatusNoShow, entities.BookingStatusAttended, mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+
+		result, err := svc.CheckInBooking("BK2", ownerID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookingStatusAttended, result.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("only the owner checks in", func(t *testing.T) {
+		booking := &entities.Booking{BookingCode: "BK3", AppCode: "APP123", Status: entities.BookingStatusConfirmed, EndTime: now.Add(time.Hour)}
+		svc, _ := setup(booking)
+
+		_, err := svc.CheckInBooking("BK3", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not the owner")
+	})
+
+	t.Run("cancelled bookings cannot check in", func(t *testing.T) {
+		booking := &entities.Booking{BookingCode: "BK4", AppCode: "APP123", Status: entities.BookingStatusCancelled, EndTime: now.Add(time.Hour)}
+		svc, repo := setup(booking)
+
+		_, err := svc.CheckInBooking("BK4", ownerID)
+
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "CheckIn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("mark no-show after start", func(t *testing.T) {
+		checkedIn := now.Add(-5 * time.Minute)
+		booking := &entities.Booking{BookingCode: "BK5", AppCode: "APP123", Status: entities.BookingStatusExpired, StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour), CheckedInAt: &checkedIn}
+		svc, repo := setup(booking)
+		repo.On("Update", booking).Return(nil).Once()
+
+		result, err := svc.MarkNoShow("BK5", ownerID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookingStatusNoShow, result.Status)
+		assert.Nil(t, result.CheckedInAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("no-show rejected before start", func(t *testing.T) {
+		booking := &entities.Booking{BookingCode: "BK6", AppCode: "APP123", Status: entities.BookingStatusConfirmed, StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour)}
+		svc, repo := setup(booking)
+
+		_, err := svc.MarkNoShow("BK6", ownerID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "before they start")
+		repo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func signTicket(t *testing.T, bookingCode string) string {
+	ticket, err := utils.SignTicket(bookingCode)
+	assert.NoError(t, err)
+	return ticket
+}
+
+func TestScanTicket(t *testing.T) {
+	t.Setenv("TICKET_SECRET_KEY", "ticket-secret")
+	ownerID := uuid.New()
+	appointment := &entities.Appointment{ID: uuid.New(), AppCode: "APP123", Type: entities.Party, OwnerID: ownerID}
+	now := time.Now()
+
+	setup := func(booking *entities.Booking) (services.BookingService, *repomocks.BookingRepository) {
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		mockBookingRepo.On("GetBookingByCode", booking.BookingCode).Return(booking, nil).Maybe()
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "APP123").Return(appointment, nil).Maybe()
+		svc := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), nil)
+		return svc, mockBookingRepo
+	}
+
+	t.Run("valid ticket checks the guest in", func(t *testing.T) {
+		booking := &entities.Booking{BookingCode: "BK1", AppCode: "APP123", Status: entities.BookingStatusActive, StartTime: now, EndTime: now.Add(time.Hour)}
+		svc, repo := setup(booking)
+		repo.On("CheckIn", booking.ID, entities.BookingStatusActive, entities.BookingStatusCheckedIn, mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+
+		result, err := svc.ScanTicket(requests.TicketScanRequest{Ticket: signTicket(t, "BK1")}, ownerID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookingStatusCheckedIn, result.Status)
+		assert.NotNil(t, result.CheckedInAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("duplicate scan is rejected", func(t *testing.T) {
+		checkedIn := now.Add(-time.Minute)
+		booking := &entities.Booking{BookingCode: "BK2", AppCode: "APP123", Status: entities.BookingStatusCheckedIn, StartTime: now, EndTime: now.Add(time.Hour), CheckedInAt: &checkedIn}
+		svc, repo := setup(booking)
+
+		_, err := svc.ScanTicket(requests.TicketScanRequest{Ticket: signTicket(t, "BK2")}, ownerID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already been scanned")
+		repo.AssertNotCalled(t, "CheckIn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("concurrent scan is rejected", func(t *testing.T) {
+		// Another scan checked the guest in after this one loaded the booking.
+		booking := &entities.Booking{ID: uuid.New(), BookingCode: "BK5", AppCode: "APP123", Status: entities.BookingStatusActive, StartTime: now, EndTime: now.Add(time.Hour)}
+		svc, repo := setup(booking)
+		repo.On("CheckIn", booking.ID, entities.BookingStatusActive, entities.BookingStatusCheckedIn, mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+
+		_, err := svc.ScanTicket(requests.TicketScanRequest{Ticket: signTicket(t, "BK5")}, ownerID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already been checked in")
+		assert.Nil(t, booking.CheckedInAt)
+	})
+
+	t.Run("forged ticket is rejected", func(t *testing.T) {
+		booking := &entities.Booking{BookingCode: "BK3", AppCode: "APP123", Status: entities.BookingStatusActive}
+		svc, repo := setup(booking)
+
+		_, err := svc.ScanTicket(requests.TicketScanRequest{Ticket: "BK3.forged"}, ownerID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid ticket")
+		repo.AssertNotCalled(t, "GetBookingByCode", mock.Anything)
+	})
+
+	t.Run("other owners cannot scan", func(t *testing.T) {
+		booking := &entities.Booking{BookingCode: "BK4", AppCode: "APP123", Status: entities.BookingStatusActive}
+		svc, _ := setup(booking)
+
+		_, err := svc.ScanTicket(requests.TicketScanRequest{Ticket: signTicket(t, "BK4")}, uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not the owner")
+	})
+}
+
+func TestGetCalendarFeed(t *testing.T) {
+	owner := &entities.User{ID: uuid.New(), Name: "Ada"}
+	mockBookingRepo := new(repomocks.BookingRepository)
+	mockUserRepo := new(repomocks.UserRepository)
+	svc := services.NewBookingService(mockBookingRepo, new(repomocks.AppointmentRepository), mockUserRepo, new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), newMockOutboxRepo(), nil)
+
+	start := time.Now().Add(24 * time.Hour)
+	bookings := []entities.Booking{{
+		ID:          uuid.New(),
+		BookingCode: "BK1",
+		Appointment: entities.Appointment{Title: "Workshop"},
+		StartTime:   start,
+		EndTime:     start.Add(time.Hour),
+		Status:      entities.BookingStatusConfirmed,
+	}}
+	mockUserRepo.On("FindByCalendarFeedToken", "feed-token").Return(owner, nil).Once()
+	mockBookingRepo.On("GetOwnerCalendarBookings", owner.ID, mock.AnythingOfType("time.Time")).Return(bookings, nil).Once()
+
+	feed, err := svc.GetCalendarFeed("feed-token")
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(feed), "X-WR-CALNAME:Ada bookings")
+	assert.Contains(t, string(feed), "SUMMARY:Workshop")
+	assert.Contains(t, string(feed), "UID:"+bookings[0].ID.String()+"@asiko")
+	assert.NotContains(t, string(feed), "METHOD:")
+
+	mockUserRepo.On("FindByCalendarFeedToken", "revoked").Return((*entities.User)(nil), repoerrors.NotFoundError("calendar feed not found")).Once()
+	_, err = svc.GetCalendarFeed("revoked")
+	assert.Error(t, err)
+
+	mockUserRepo.AssertExpectations(t)
+	mockBookingRepo.AssertExpectations(t)
+}
+
+func TestHostConflictsAcrossOwnerAppointments(t *testing.T) {
+	ownerID := uuid.New()
+	appointment := &entities.Appointment{ID: uuid.New(), OwnerID: ownerID, AppCode: "ONE123", Type: entities.Single, AntiScalpingLevel: entities.ScalpingNone}
+	other := &entities.Appointment{ID: uuid.New(), OwnerID: ownerID, AppCode: "TWO123", Type: entities.Single}
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+
+	t.Run("booking blocks overlapping slots elsewhere", func(t *testing.T) {
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoHosts(mockAppointmentRepo)
+		stubNoResources(mockAppointmentRepo)
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockBanListRepo := new(repomocks.BanListRepository)
+		stubBanListNotFound(mockBanListRepo)
+		mockOutboxRepo := newMockOutboxRepo()
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+
+		slot := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, Appointment: *appointment, AppCode: "ONE123", IsSlot: true, Available: true, Capacity: 1, Date: start, StartTime: start, EndTime: start.Add(time.Hour)}
+		overlapping := entities.Booking{ID: uuid.New(), AppointmentID: other.ID, Appointment: *other, IsSlot: true, Available: true, Capacity: 1, StartTime: start.Add(30 * time.Minute), EndTime: start.Add(90 * time.Minute)}
+
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "ONE123").Return(appointment, nil).Once()
+		mockAppointmentRepo.On("FindAndLock", "ONE123", mock.AnythingOfType("*gorm.DB")).Return(appointment, nil).Once()
+		sqlMock.ExpectBegin()
+		mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Once()
+		mockBookingRepo.On("FindAndLockAvailableSlot", "ONE123", start, start).Return(slot, nil).Once()
+		mockBookingRepo.On("Update", slot).Return(nil).Once()
+		mockBookingRepo.On("ListOwnerSlotsBetween", ownerID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return(func(uuid.UUID, time.Time, time.Time) []entities.Booking {
+				return []entities.Booking{*slot, overlapping}
+			}, nil).Times(3)
+		mockBookingRepo.On("SetSlotsHostConflict", []uuid.UUID{overlapping.ID}, true).Return(nil).Once()
+		mockBookingRepo.On("SetSlotsHostConflict", []uuid.UUID(nil), false).Return(nil).Once()
+		sqlMock.ExpectCommit()
+		mockOutboxRepo.On("Create", mock.Anything).Return(nil).Once()
+
+		booking, err := bookingService.BookAppointment(requests.BookingRequest{AppCode: "ONE123", Name: "Guest", Email: "guest@example.com", Date: start, StartTime: start, EndTime: start.Add(time.Hour), AttendeeCount: 1}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, booking.SeatsBooked)
+		mockBookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("cancellation releases them", func(t *testing.T) {
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockWaitlistRepo := new(repomocks.WaitlistRepository)
+		mockOutboxRepo := newMockOutboxRepo()
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), mockWaitlistRepo, new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+
+		booking := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "ONE123", BookingCode: "BK-ONE", IsSlot: true, Capacity: 1, SeatsBooked: 1, AttendeeCount: 1, Status: entities.BookingStatusPending, Date: start, StartTime: start, EndTime: start.Add(time.Hour)}
+		blocked := entities.Booking{ID: uuid.New(), AppointmentID: other.ID, Appointment: *other, IsSlot: true, Capacity: 1, HostConflict: true, StartTime: start.Add(30 * time.Minute), EndTime: start.Add(90 * time.Minute)}
+
+		mockBookingRepo.On("GetBookingByCode", "BK-ONE").Return(booking, nil).Once()
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "ONE123").Return(appointment, nil).Once()
+		sqlMock.ExpectBegin()
+		mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo)
+		mockWaitlistRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockWaitlistRepo).Once()
+		mockBookingRepo.On("FindAndLockSlot", "ONE123", start, start).Return(booking, nil).Once()
+		mockBookingRepo.On("Update", booking).Return(nil).Twice()
+		mockWaitlistRepo.On("FindNextAndLock", appointment.ID, start).Return(nil, repoerrors.NotFoundError("no waitlist entry found")).Once()
+		mockBookingRepo.On("ListOwnerSlotsBetween", ownerID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return(func(uuid.UUID, time.Time, time.Time) []entities.Booking {
+				freed := *booking
+				freed.Appointment = *appointment
+				return []entities.Booking{freed, blocked}
+			}, nil).Twice()
+		mockBookingRepo.On("SetSlotsHostConflict", []uuid.UUID(nil), true).Return(nil).Once()
+		mockBookingRepo.On("SetSlotsHostConflict", []uuid.UUID{blocked.ID}, false).Return(nil).Once()
+		sqlMock.ExpectCommit()
+		mockOutboxRepo.On("Create", mock.Anything).Return(nil).Once()
+
+		cancelled, err := bookingService.CancelBookingByCode("BK-ONE", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookingStatusCancelled, cancelled.Status)
+		mockBookingRepo.AssertExpectations(t)
+	})
+}
+
+func TestOrganizationMembersManageBookings(t *testing.T) {
+	orgID := uuid.New()
+	appointment := &entities.Appointment{ID: uuid.New(), AppCode: "APP123", Type: entities.Group, OwnerID: uuid.New(), OrganizationID: &orgID, Title: "Clinic"}
+
+	setup := func(booking *entities.Booking, member *entities.OrganizationMember, memberErr error) (services.BookingService, *repomocks.BookingRepository, *repomocks.OutboxRepository, sqlmock.Sqlmock) {
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Maybe()
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		mockOrgRepo := new(repomocks.OrganizationRepository)
+		mockOutboxRepo := newMockOutboxRepo()
+		mockBookingRepo.On("GetBookingByCode", booking.BookingCode).Return(booking, nil).Once()
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "APP123").Return(appointment, nil).Once()
+		mockOrgRepo.On("FindMember", orgID, mock.AnythingOfType("uuid.UUID")).Return(member, memberErr).Once()
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		svc := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), new(repomocks.BanListRepository), new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), mockOrgRepo, new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+		return svc, mockBookingRepo, mockOutboxRepo, sqlMock
+	}
+
+	t.Run("staff confirm bookings they did not create", func(t *testing.T) {
+		staffID := uuid.New()
+		booking := &entities.Booking{BookingCode: "BK1", AppCode: "APP123", Status: entities.BookingStatusPending}
+		svc, repo, outboxRepo, sqlMock := setup(booking, &entities.OrganizationMember{OrganizationID: orgID, UserID: staffID, Role: entities.OrganizationRoleStaff}, nil)
+		sqlMock.ExpectBegin()
+		repo.On("Update", booking).Return(nil).Once()
+		outboxRepo.On("Create", outboxEvent(func(event events.Event) bool {
+			return event.Name == events.EventBookingConfirmed
+		})).Return(nil).Once()
+		sqlMock.ExpectCommit()
+
+		result, err := svc.ConfirmBooking("BK1", staffID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookingStatusConfirmed, result.Status)
+		repo.AssertExpectations(t)
+		outboxRepo.AssertExpectations(t)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("viewers cannot reject", func(t *testing.T) {
+		viewerID := uuid.New()
+		booking := &entities.Booking{BookingCode: "BK2", AppCode: "APP123", Status: entities.BookingStatusPending}
+		svc, repo, _, _ := setup(booking, &entities.OrganizationMember{OrganizationID: orgID, UserID: viewerID, Role: entities.OrganizationRoleViewer}, nil)
+
+		_, err := svc.RejectBooking("BK2", viewerID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "role does not allow")
+		repo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("non-members cannot check in", func(t *testing.T) {
+		booking := &entities.Booking{BookingCode: "BK3", AppCode: "APP123", Status: entities.BookingStatusConfirmed, EndTime: time.Now().Add(time.Hour)}
+		svc, repo, _, _ := setup(booking, nil, repoerrors.NotFoundError("organization member not found"))
+
+		_, err := svc.CheckInBooking("BK3", uuid.New())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not a member")
+		repo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
+
+func TestBookAppointmentAssignsHost(t *testing.T) {
+	ownerID, alice, bob, carol := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	hosts := []entities.AppointmentHost{{UserID: alice, Priority: 0}, {UserID: bob, Priority: 1}, {UserID: carol, Priority: 2}}
+	busyElsewhere := func(hostID uuid.UUID) entities.Booking {
+		return entities.Booking{ID: uuid.New(), AppointmentID: uuid.New(), HostID: &hostID, Status: entities.BookingStatusConfirmed, StartTime: start, EndTime: start.Add(time.Hour)}
+	}
+
+	setup := func(busy []entities.Booking, commit bool) (services.BookingService, *repomocks.AppointmentRepository, *repomocks.BookingRepository, *repomocks.OutboxRepository, *entities.Booking) {
+		appointment := &entities.Appointment{ID: uuid.New(), OwnerID: ownerID, AppCode: "TEAM123", Title: "Intro call", Type: entities.Single, AntiScalpingLevel: entities.ScalpingNone, AllowParallelBookings: true, HostAssignment: entities.HostAssignmentRoundRobin, LastHostID: &alice}
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoResources(mockAppointmentRepo)
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockBanListRepo := new(repomocks.BanListRepository)
+		stubBanListNotFound(mockBanListRepo)
+		mockOutboxRepo := newMockOutboxRepo()
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+
+		slot := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "TEAM123", IsSlot: true, Available: true, Capacity: 1, Date: start, StartTime: start, EndTime: start.Add(time.Hour)}
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "TEAM123").Return(appointment, nil).Once()
+		mockAppointmentRepo.On("FindAndLock", "TEAM123", mock.AnythingOfType("*gorm.DB")).Return(appointment, nil).Once()
+		mockAppointmentRepo.On("ListHosts", appointment.ID).Return(hosts, nil).Once()
+		sqlMock.ExpectBegin()
+		mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Once()
+		mockBookingRepo.On("FindAndLockAvailableSlot", "TEAM123", start, start).Return(slot, nil).Once()
+		mockBookingRepo.On("ListHostBookingsBetween", []uuid.UUID{alice, bob, carol}, slot.StartTime, slot.EndTime).Return(busy, nil).Once()
+		if commit {
+			sqlMock.ExpectCommit()
+		} else {
+			sqlMock.ExpectRollback()
+		}
+		return bookingService, mockAppointmentRepo, mockBookingRepo, mockOutboxRepo, slot
+	}
+	req := requests.BookingRequest{AppCode: "TEAM123", Name: "Guest", Email: "guest@example.com", Date: start, StartTime: start, EndTime: start.Add(time.Hour), AttendeeCount: 1}
+
+	t.Run("round robin skips busy hosts", func(t *testing.T) {
+		bookingService, mockAppointmentRepo, mockBookingRepo, mockOutboxRepo, slot := setup([]entities.Booking{busyElsewhere(bob)}, true)
+		mockAppointmentRepo.On("SetLastHost", slot.AppointmentID, carol).Return(nil).Once()
+		mockBookingRepo.On("Update", slot).Return(nil).Once()
+		mockBookingRepo.On("ListOwnerSlotsBetween", ownerID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]entities.Booking{}, nil).Once()
+		mockOutboxRepo.On("Create", outboxEvent(func(event events.Event) bool {
+			p, ok := event.Data.(events.BookingEventData)
+			return event.Name == events.EventBookingCreated && ok && p.Booking.HostID != nil && *p.Booking.HostID == carol
+		})).Return(nil).Once()
+
+		booking, err := bookingService.BookAppointment(req, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, carol, *booking.HostID)
+		mockAppointmentRepo.AssertExpectations(t)
+		mockBookingRepo.AssertExpectations(t)
+		mockOutboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("fails when every host is busy", func(t *testing.T) {
+		bookingService, mockAppointmentRepo, mockBookingRepo, mockOutboxRepo, _ := setup([]entities.Booking{busyElsewhere(alice), busyElsewhere(bob), busyElsewhere(carol)}, false)
+
+		_, err := bookingService.BookAppointment(req, "")
+
+		assert.Error(t, err)
+		mockAppointmentRepo.AssertNotCalled(t, "SetLastHost", mock.Anything, mock.Anything)
+		mockBookingRepo.AssertNotCalled(t, "Update", mock.Anything)
+		mockOutboxRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestBookAppointmentReservesResources(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	room := entities.Resource{ID: uuid.New(), Name: "Studio A", Capacity: 1}
+
+	setup := func(overlapping []entities.Booking, commit bool) (services.BookingService, *repomocks.BookingRepository, *repomocks.OutboxRepository, *entities.Booking) {
+		appointment := &entities.Appointment{ID: uuid.New(), OwnerID: uuid.New(), AppCode: "ROOM123", Title: "Private lesson", Type: entities.Single, AntiScalpingLevel: entities.ScalpingNone, AllowParallelBookings: true}
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoHosts(mockAppointmentRepo)
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockBanListRepo := new(repomocks.BanListRepository)
+		stubBanListNotFound(mockBanListRepo)
+		mockOutboxRepo := newMockOutboxRepo()
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+
+		slot := &entities.Booking{ID: uuid.New(), AppointmentID: appointment.ID, AppCode: "ROOM123", IsSlot: true, Available: true, Capacity: 1, Date: start, StartTime: start, EndTime: start.Add(time.Hour)}
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "ROOM123").Return(appointment, nil).Once()
+		mockAppointmentRepo.On("FindAndLock", "ROOM123", mock.AnythingOfType("*gorm.DB")).Return(appointment, nil).Once()
+		sqlMock.ExpectBegin()
+		mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Once()
+		mockBookingRepo.On("FindAndLockAvailableSlot", "ROOM123", start, start).Return(slot, nil).Once()
+		mockAppointmentRepo.On("FindAndLockResources", appointment.ID).Return([]entities.Resource{room}, nil).Once()
+		mockBookingRepo.On("ListResourceBookingsBetween", room.ID, slot.StartTime, slot.EndTime).Return(overlapping, nil).Once()
+		if commit {
+			sqlMock.ExpectCommit()
+		} else {
+			sqlMock.ExpectRollback()
+		}
+		return bookingService, mockBookingRepo, mockOutboxRepo, slot
+	}
+	req := requests.BookingRequest{AppCode: "ROOM123", Name: "Guest", Email: "guest@example.com", Date: start, StartTime: start, EndTime: start.Add(time.Hour), AttendeeCount: 1}
+
+	t.Run("books while the resource is free", func(t *testing.T) {
+		bookingService, mockBookingRepo, mockOutboxRepo, slot := setup(nil, true)
+		mockBookingRepo.On("Update", slot).Return(nil).Once()
+		mockBookingRepo.On("ListOwnerSlotsBetween", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return([]entities.Booking{}, nil).Once()
+		mockOutboxRepo.On("Create", mock.Anything).Return(nil).Once()
+
+		booking, err := bookingService.BookAppointment(req, "")
+
+		assert.NoError(t, err)
+		assert.False(t, booking.Available)
+		mockBookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("fails when another appointment holds the resource", func(t *testing.T) {
+		elsewhere := entities.Booking{ID: uuid.New(), AppointmentID: uuid.New(), Status: entities.BookingStatusConfirmed, StartTime: start.Add(-30 * time.Minute), EndTime: start.Add(30 * time.Minute)}
+		bookingService, mockBookingRepo, mockOutboxRepo, _ := setup([]entities.Booking{elsewhere}, false)
+
+		_, err := bookingService.BookAppointment(req, "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Studio A is already booked")
+		mockBookingRepo.AssertNotCalled(t, "Update", mock.Anything)
+		mockOutboxRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestBookAppointmentEnforcesTicketTypes(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	opens := time.Now().Add(time.Hour)
+	tickets := entities.TicketTypes{
+		{Key: "general", Name: "General", Capacity: 2},
+		{Key: "vip", Name: "VIP", Capacity: 1, SalesStart: &opens},
+	}
+
+	setup := func() (services.BookingService, *repomocks.AppointmentRepository, *repomocks.BookingRepository, *repomocks.OutboxRepository, sqlmock.Sqlmock, *entities.Appointment) {
+		appointment := &entities.Appointment{
+			ID: uuid.New(), OwnerID: uuid.New(), AppCode: "TIX123", Title: "Launch party", Type: entities.Party, MaxAttendees: 10,
+			AntiScalpingLevel: entities.ScalpingNone, StartDate: start, EndDate: start, StartTime: start, EndTime: start.Add(2 * time.Hour),
+			TicketTypes: tickets,
+		}
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoHosts(mockAppointmentRepo)
+		stubNoResources(mockAppointmentRepo)
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockBanListRepo := new(repomocks.BanListRepository)
+		stubBanListNotFound(mockBanListRepo)
+		mockOutboxRepo := newMockOutboxRepo()
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), new(repomocks.PromoCodeRepository), mockOutboxRepo, gormDB)
+
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "TIX123").Return(appointment, nil).Once()
+		return bookingService, mockAppointmentRepo, mockBookingRepo, mockOutboxRepo, sqlMock, appointment
+	}
+	request := func(ticket string) requests.BookingRequest {
+		return requests.BookingRequest{AppCode: "TIX123", Name: "Guest", Email: "guest@example.com", Date: start, StartTime: start, EndTime: start.Add(2 * time.Hour), AttendeeCount: 1, TicketType: ticket}
+	}
+
+	t.Run("books a ticket type with seats left", func(t *testing.T) {
+		bookingService, mockAppointmentRepo, mockBookingRepo, mockOutboxRepo, sqlMock, appointment := setup()
+		sqlMock.ExpectBegin()
+		mockAppointmentRepo.On("FindAndLock", "TIX123", mock.AnythingOfType("*gorm.DB")).Return(appointment, nil).Once()
+		mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Once()
+		mockBookingRepo.On("CountTicketSales", appointment.ID, mock.AnythingOfType("*time.Time")).Return(map[string]int{"general": 1}, nil).Once()
+		mockBookingRepo.On("Create", mock.AnythingOfType("*entities.Booking")).Return(nil).Once()
+		mockAppointmentRepo.On("Update", appointment).Return(nil).Once()
+		sqlMock.ExpectCommit()
+		mockOutboxRepo.On("Create", mock.Anything).Return(nil).Once()
+
+		booking, err := bookingService.BookAppointment(request("general"), "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "general", booking.TicketType)
+		assert.Equal(t, 1, appointment.AttendeesBooked)
+		mockBookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("fails when the ticket type is sold out", func(t *testing.T) {
+		bookingService, mockAppointmentRepo, mockBookingRepo, mockOutboxRepo, sqlMock, appointment := setup()
+		sqlMock.ExpectBegin()
+		mockAppointmentRepo.On("FindAndLock", "TIX123", mock.AnythingOfType("*gorm.DB")).Return(appointment, nil).Once()
+		mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Once()
+		mockBookingRepo.On("CountTicketSales", appointment.ID, mock.AnythingOfType("*time.Time")).Return(map[string]int{"general": 2}, nil).Once()
+		sqlMock.ExpectRollback()
+
+		_, err := bookingService.BookAppointment(request("general"), "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not enough General tickets left")
+		mockBookingRepo.AssertNotCalled(t, "Create", mock.Anything)
+		mockOutboxRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	for name, ticket := range map[string]string{
+		"not on sale yet": "vip",
+		"unknown":         "backstage",
+		"missing":         "",
+	} {
+		t.Run("rejects a ticket type that is "+name, func(t *testing.T) {
+			bookingService, _, mockBookingRepo, _, _, _ := setup()
+
+			_, err := bookingService.BookAppointment(request(ticket), "")
+
+			assert.Error(t, err)
+			mockBookingRepo.AssertNotCalled(t, "CountTicketSales", mock.Anything, mock.Anything)
+			mockBookingRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestBookAppointmentRedeemsPromoCode(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+
+	setup := func() (services.BookingService, *repomocks.AppointmentRepository, *repomocks.BookingRepository, *repomocks.PromoCodeRepository, *repomocks.OutboxRepository, sqlmock.Sqlmock, *entities.Appointment) {
+		appointment := &entities.Appointment{
+			ID: uuid.New(), OwnerID: uuid.New(), AppCode: "PROMO1", Title: "Workshop", Type: entities.Party, MaxAttendees: 10,
+			AntiScalpingLevel: entities.ScalpingNone, StartDate: start, EndDate: start, StartTime: start, EndTime: start.Add(2 * time.Hour),
+			Price: 2500, Currency: "USD",
+		}
+		mockAppointmentRepo := new(repomocks.AppointmentRepository)
+		stubAppointmentWithTx(mockAppointmentRepo)
+		stubNoHosts(mockAppointmentRepo)
+		stubNoResources(mockAppointmentRepo)
+		mockBookingRepo := new(repomocks.BookingRepository)
+		mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Maybe()
+		mockBanListRepo := new(repomocks.BanListRepository)
+		stubBanListNotFound(mockBanListRepo)
+		mockPromoRepo := new(repomocks.PromoCodeRepository)
+		mockPromoRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockPromoRepo).Maybe()
+		mockOutboxRepo := newMockOutboxRepo()
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		bookingService := services.NewBookingService(mockBookingRepo, mockAppointmentRepo, new(repomocks.UserRepository), mockBanListRepo, new(repomocks.WaitlistRepository), new(repomocks.SlotHoldRepository), new(repomocks.OrganizationRepository), mockPromoRepo, mockOutboxRepo, gormDB)
+
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "PROMO1").Return(appointment, nil).Once()
+		mockAppointmentRepo.On("FindAndLock", "PROMO1", mock.AnythingOfType("*gorm.DB")).Return(appointment, nil).Once()
+		return bookingService, mockAppointmentRepo, mockBookingRepo, mockPromoRepo, mockOutboxRepo, sqlMock, appointment
+	}
+	request := requests.BookingRequest{AppCode: "PROMO1", Name: "Guest", Email: "Guest@Example.com", Date: start, StartTime: start, EndTime: start.Add(2 * time.Hour), AttendeeCount: 2, PromoCode: "spring20"}
+	newPromo := func() *entities.PromoCode {
+		return &entities.PromoCode{ID: uuid.New(), Code: "SPRING20", Discount: entities.Discount{Type: entities.DiscountPercent, Value: 20}, MaxRedemptions: 10, MaxPerEmail: 1}
+	}
+
+	t.Run("takes the discount off and records the redemption", func(t *testing.T) {
+		bookingService, mockAppointmentRepo, mockBookingRepo, mockPromoRepo, mockOutboxRepo, sqlMock, appointment := setup()
+		promo := newPromo()
+		sqlMock.ExpectBegin()
+		mockPromoRepo.On("FindAndLockForAppointment", "spring20", appointment).Return(promo, nil).Once()
+		mockPromoRepo.On("CountRedemptionsByEmail", promo.ID, "guest@example.com").Return(int64(0), nil).Once()
+		mockPromoRepo.On("Update", promo).Return(nil).Once()
+		mockBookingRepo.On("Create", mock.AnythingOfType("*entities.Booking")).Return(nil).Once()
+		mockPromoRepo.On("CreateRedemption", mock.MatchedBy(func(r *entities.PromoCodeRedemption) bool {
+			return r.PromoCodeID == promo.ID && r.Email == "guest@example.com" && r.Discount == 1000
+		})).Return(nil).Once()
+		mockAppointmentRepo.On("Update", appointment).Return(nil).Once()
+		sqlMock.ExpectCommit()
+		mockOutboxRepo.On("Create", mock.Anything).Return(nil).Once()
+
+		booking, err := bookingService.BookAppointment(request, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "SPRING20", booking.PromoCode)
+		assert.Equal(t, int64(4000), booking.AmountDue)
+		assert.Equal(t, int64(1000), booking.DiscountAmount)
+		assert.Equal(t, entities.BookingPaymentDue, booking.PaymentStatus)
+		assert.Equal(t, 1, promo.Redemptions)
+		mockPromoRepo.AssertExpectations(t)
+	})
+
+	t.Run("refuses a guest who already used the code", func(t *testing.T) {
+		bookingService, _, mockBookingRepo, mockPromoRepo, mockOutboxRepo, sqlMock, appointment := setup()
+		promo := newPromo()
+		sqlMock.ExpectBegin()
+		mockPromoRepo.On("FindAndLockForAppointment", "spring20", appointment).Return(promo, nil).Once()
+		mockPromoRepo.On("CountRedemptionsByEmail", promo.ID, "guest@example.com").Return(int64(1), nil).Once()
+		sqlMock.ExpectRollback()
+
+		_, err := bookingService.BookAppointment(request, "")
+
+		assert.ErrorContains(t, err, "you have already used this promo code")
+		assert.Zero(t, promo.Redemptions)
+		mockBookingRepo.AssertNotCalled(t, "Create", mock.Anything)
+		mockOutboxRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("refuses a fully redeemed code", func(t *testing.T) {
+		bookingService, _, mockBookingRepo, mockPromoRepo, _, sqlMock, appointment := setup()
+		promo := newPromo()
+		promo.Redemptions = promo.MaxRedemptions
+		sqlMock.ExpectBegin()
+		mockPromoRepo.On("FindAndLockForAppointment", "spring20", appointment).Return(promo, nil).Once()
+		sqlMock.ExpectRollback()
+
+		_, err := bookingService.BookAppointment(request, "")
+
+		assert.ErrorContains(t, err, "promo code has been fully redeemed")
+		mockBookingRepo.AssertNotCalled(t, "Create", mock.Anything)
+		mockPromoRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("refuses a code that does not apply to the appointment", func(t *testing.T) {
+		bookingService, _, mockBookingRepo, mockPromoRepo, _, sqlMock, appointment := setup()
+		sqlMock.ExpectBegin()
+		mockPromoRepo.On("FindAndLockForAppointment", "spring20", appointment).Return(nil, repoerrors.NotFoundError("promo code not found")).Once()
+		sqlMock.ExpectRollback()
+
+		_, err := bookingService.BookAppointment(request, "")
+
+		assert.ErrorContains(t, err, "promo code is not valid for this appointment")
+		mockBookingRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
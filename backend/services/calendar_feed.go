@@ -0,0 +1,59 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/utils"
+)
+
+// calendarFeedLookback is how far into the past the calendar feed lists bookings.
+const calendarFeedLookback = 90 * 24 * time.Hour
+
+// RotateCalendarFeedToken issues a new calendar feed token for the owner, revoking the previous
+// feed URL.
+func (s *bookingServiceImpl) RotateCalendarFeedToken(ownerID uuid.UUID) (string, error) {
+	user, err := s.userRepo.FindByID(ownerID.String())
+	if err != nil {
+		return "", serviceerrors.FromError(err)
+	}
+
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", serviceerrors.InternalError("failed to generate calendar feed token")
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	user.CalendarFeedToken = &token
+	if err := s.userRepo.Update(user); err != nil {
+		return "", serviceerrors.FromError(err)
+	}
+	return token, nil
+}
+
+// GetCalendarFeed renders the owner's booked reservations as an iCalendar feed. The token is the
+// only credential, so the feed is read-only and lists bookings from the last 90 days onwards.
+func (s *bookingServiceImpl) GetCalendarFeed(token string) ([]byte, error) {
+	if token == "" {
+		return nil, serviceerrors.NotFoundError("calendar feed not found")
+	}
+	owner, err := s.userRepo.FindByCalendarFeedToken(token)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+
+	bookings, err := s.bookingRepo.GetOwnerCalendarBookings(owner.ID, time.Now().Add(-calendarFeedLookback))
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+
+	calendar := utils.ICalendar{Name: owner.Name + " bookings", Events: make([]utils.ICalEvent, 0, len(bookings))}
+	for i := range bookings {
+		booking := &bookings[i]
+		calendar.Events = append(calendar.Events, booking.CalendarEvent(booking.Appointment.Title))
+	}
+	return calendar.Encode(), nil
+}
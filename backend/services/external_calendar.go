@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/repository"
+	"gorm.io/gorm"
+)
+
+// ExternalCalendarSyncInterval is how often the status scheduler re-fetches subscribed calendars.
+const ExternalCalendarSyncInterval = 15 * time.Minute
+
+// maxExternalCalendarSize caps uploaded and fetched ICS files.
+const maxExternalCalendarSize = 5 << 20
+
+type ExternalCalendarSyncSummary struct {
+	Calendars int64
+	Blocked   int64
+	Released  int64
+}
+
+type externalCalendarServiceImpl struct {
+	calendarRepo repository.ExternalCalendarRepository
+	bookingRepo  repository.BookingRepository
+	db           *gorm.DB
+	httpClient   *http.Client
+}
+
+func NewExternalCalendarService(calendarRepo repository.ExternalCalendarRepository, bookingRepo repository.BookingRepository, db *gorm.DB) ExternalCalendarService {
+	return &externalCalendarServiceImpl{
+		calendarRepo: calendarRepo,
+		bookingRepo:  bookingRepo,
+		db:           db,
+		httpClient:   newCalendarHTTPClient(),
+	}
+}
+
+func (s *externalCalendarServiceImpl) ListCalendars(ownerID uuid.UUID) ([]entities.ExternalCalendar, error) {
+	calendars, err := s.calendarRepo.ListByOwner(ownerID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return calendars, nil
+}
+
+// ImportCalendar stores the busy times of an uploaded ICS file and blocks the overlapping slots.
+func (s *externalCalendarServiceImpl) ImportCalendar(ownerID uuid.UUID, name string, data []byte) (*entities.ExternalCalendar, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "Imported calendar"
+	}
+	if len(data) > maxExternalCalendarSize {
+		return nil, serviceerrors.ValidationError("calendar file is too large")
+	}
+
+	now := time.Now()
+	busy, err := parseExternalBusy(data, now)
+	if err != nil {
+		return nil, serviceerrors.ValidationError(fmt.Sprintf("invalid calendar file: %v", err))
+	}
+
+	calendar := &entities.ExternalCalendar{OwnerID: ownerID, Name: name, Busy: busy, LastSyncedAt: &now}
+	if err := s.calendarRepo.Create(calendar); err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	if _, _, err := s.resyncOwner(ownerID, now); err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+// SubscribeCalendar registers an ICS URL, fetching it straight away so a bad address is reported
+// to the owner instead of surfacing later as a sync error.
+func (s *externalCalendarServiceImpl) SubscribeCalendar(ownerID uuid.UUID, req requests.ExternalCalendarRequest) (*entities.ExternalCalendar, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	busy, err := s.fetchBusy(context.Background(), req.URL, now)
+	if err != nil {
+		return nil, serviceerrors.ValidationError(fmt.Sprintf("could not load calendar: %v", err))
+	}
+
+	calendar := &entities.ExternalCalendar{OwnerID: ownerID, Name: strings.TrimSpace(req.Name), URL: req.URL, Busy: busy, LastSyncedAt: &now}
+	if err := s.calendarRepo.Create(calendar); err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	if _, _, err := s.resyncOwner(ownerID, now); err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+// DeleteCalendar removes the calendar and releases the slots only it was blocking.
+func (s *externalCalendarServiceImpl) DeleteCalendar(ownerID uuid.UUID, calendarID uuid.UUID) error {
+	calendar, err := s.calendarRepo.FindByID(ownerID, calendarID)
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+	if err := s.calendarRepo.Delete(calendar.ID); err != nil {
+		return serviceerrors.FromError(err)
+	}
+	_, _, err = s.resyncOwner(ownerID, time.Now())
+	return err
+}
+
+// SyncCalendars re-fetches every subscribed calendar and re-applies each owner's busy times, so
+// slots are blocked by new external events and released when those events disappear. A calendar
+// that fails to load keeps its previous busy times and records the error.
+func (s *externalCalendarServiceImpl) SyncCalendars(ctx context.Context, now time.Time) (ExternalCalendarSyncSummary, error) {
+	var summary ExternalCalendarSyncSummary
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	calendars, err := s.calendarRepo.ListAll()
+	if err != nil {
+		return summary, serviceerrors.FromError(err)
+	}
+
+	var owners []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for i := range calendars {
+		calendar := &calendars[i]
+		if !seen[calendar.OwnerID] {
+			seen[calendar.OwnerID] = true
+			owners = append(owners, calendar.OwnerID)
+		}
+		if calendar.URL == "" {
+			continue
+		}
+
+		busy, fetchErr := s.fetchBusy(ctx, calendar.URL, now)
+		if fetchErr != nil {
+			calendar.SyncError = fetchErr.Error()
+		} else {
+			calendar.Busy = busy
+			calendar.SyncError = ""
+			calendar.LastSyncedAt = &now
+		}
+		if err := s.calendarRepo.Update(calendar); err != nil {
+			return summary, serviceerrors.FromError(err)
+		}
+		summary.Calendars++
+	}
+
+	for _, ownerID := range owners {
+		blocked, released, err := s.resyncOwner(ownerID, now)
+		if err != nil {
+			log.Printf("[ExternalCalendarService] failed to apply busy times for owner %s: %v", ownerID, err)
+			continue
+		}
+		summary.Blocked += blocked
+		summary.Released += released
+	}
+	return summary, nil
+}
+
+// resyncOwner blocks the owner's upcoming slots that overlap a busy time on any of their external
+// calendars and releases the blocked ones that no longer do. Slots that already hold bookings are
+// flagged as well, but their bookings are left for the owner to resolve.
+func (s *externalCalendarServiceImpl) resyncOwner(ownerID uuid.UUID, now time.Time) (int64, int64, error) {
+	var blocked, released int64
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		calendars, err := s.calendarRepo.WithTx(tx).ListByOwner(ownerID)
+		if err != nil {
+			return err
+		}
+		var busy entities.BusyPeriods
+		for _, calendar := range calendars {
+			busy = append(busy, calendar.Busy...)
+		}
+
+		bookRepo := s.bookingRepo.WithTx(tx)
+		slots, err := bookRepo.ListOwnerUpcomingSlots(ownerID, now)
+		if err != nil {
+			return err
+		}
+
+		var toBlock, toRelease []uuid.UUID
+		for _, slot := range slots {
+			overlaps := busy.Overlaps(slot.StartTime, slot.EndTime)
+			switch {
+			case overlaps && !slot.Blocked:
+				toBlock = append(toBlock, slot.ID)
+			case !overlaps && slot.Blocked:
+				toRelease = append(toRelease, slot.ID)
+			}
+		}
+		if err := bookRepo.SetSlotsBlocked(toBlock, true); err != nil {
+			return err
+		}
+		if err := bookRepo.SetSlotsBlocked(toRelease, false); err != nil {
+			return err
+		}
+		blocked, released = int64(len(toBlock)), int64(len(toRelease))
+		return nil
+	})
+	if err != nil {
+		return 0, 0, serviceerrors.FromError(err)
+	}
+	return blocked, released, nil
+}
+
+func (s *externalCalendarServiceImpl) fetchBusy(ctx context.Context, url string, now time.Time) (entities.BusyPeriods, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	// The errors reach the owner, so they do not say how the server answered or failed to: that
+	// would let the calendar URL be used to probe other hosts.
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, errCalendarAddressBlocked) {
+			return nil, errCalendarAddressBlocked
+		}
+		log.Printf("[ExternalCalendarService] fetch %s failed: %v", url, err)
+		return nil, errors.New("calendar server could not be reached")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("[ExternalCalendarService] fetch %s responded with %s", url, resp.Status)
+		return nil, errors.New("calendar server did not return a calendar")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxExternalCalendarSize+1))
+	if err != nil {
+		log.Printf("[ExternalCalendarService] reading %s failed: %v", url, err)
+		return nil, errors.New("calendar server could not be reached")
+	}
+	if len(data) > maxExternalCalendarSize {
+		return nil, fmt.Errorf("calendar is too large")
+	}
+	return parseExternalBusy(data, now)
+}
+
+// parseExternalBusy keeps the busy times from now up to the horizon slots are generated for.
+func parseExternalBusy(data []byte, now time.Time) (entities.BusyPeriods, error) {
+	return entities.ParseBusyPeriods(data, now, now.Add(entities.MaxRecurrenceHorizon))
+}
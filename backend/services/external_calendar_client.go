@@ -0,0 +1,105 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// errCalendarAddressBlocked is returned when a calendar URL resolves to an address the server
+// must not reach on an owner's behalf.
+var errCalendarAddressBlocked = errors.New("calendar address is not allowed")
+
+// blockedCalendarNetworks are ranges that are not private by net.IP's definition but still lead
+// to infrastructure rather than the public internet.
+var blockedCalendarNetworks = mustParseCIDRs(
+	"0.0.0.0/8",     // "this" network
+	"100.64.0.0/10", // carrier-grade NAT
+	"192.0.0.0/24",  // IETF protocol assignments
+	"198.18.0.0/15", // benchmarking
+	"64:ff9b::/96",  // NAT64, which maps onto IPv4 addresses
+)
+
+// newCalendarHTTPClient returns the client used to fetch subscribed calendars. Owners choose the
+// URLs, so the client refuses to connect to loopback, private, link-local (such as cloud metadata
+// endpoints) and other internal addresses, checked on the resolved address of every connection,
+// redirects included. Networks listed in EXTERNAL_CALENDAR_ALLOWED_NETWORKS, as comma-separated
+// CIDRs, are let through, for calendars served from inside the deployment.
+func newCalendarHTTPClient() *http.Client {
+	allowed := calendarAllowedNetworks()
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !calendarAddressAllowed(ip, allowed) {
+				return errCalendarAddressBlocked
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: 15 * time.Second,
+		Transport: &http.Transport{
+			// No proxy: it would make the connection on our behalf and bypass the address check.
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+}
+
+func calendarAddressAllowed(ip net.IP, allowed []*net.IPNet) bool {
+	for _, network := range allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range blockedCalendarNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func calendarAllowedNetworks() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, value := range strings.Split(os.Getenv("EXTERNAL_CALENDAR_ALLOWED_NETWORKS"), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			log.Printf("[ExternalCalendarService] ignoring invalid allowed network %q: %v", value, err)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+func mustParseCIDRs(values ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			panic(fmt.Sprintf("invalid CIDR %q: %v", value, err))
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,177 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	repomocks "github.com/m13ha/asiko/repository/mocks"
+	services "github.com/m13ha/asiko/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestExternalCalendarBlocksAndReleasesSlots(t *testing.T) {
+	// The test server listens on loopback, which calendars may only reach when allowed.
+	t.Setenv("EXTERNAL_CALENDAR_ALLOWED_NETWORKS", "127.0.0.0/8,::1/128")
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Hour)
+	busySlot := entities.Booking{ID: uuid.New(), IsSlot: true, StartTime: start, EndTime: start.Add(30 * time.Minute)}
+	freeSlot := entities.Booking{ID: uuid.New(), IsSlot: true, StartTime: start.Add(2 * time.Hour), EndTime: start.Add(150 * time.Minute)}
+
+	var mu sync.Mutex
+	feed := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"DTSTART:" + start.Add(-15*time.Minute).Format("20060102T150405Z"),
+		"DTEND:" + start.Add(45*time.Minute).Format("20060102T150405Z"),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "text/calendar")
+		_, _ = w.Write([]byte(feed))
+	}))
+	defer server.Close()
+
+	mockCalendarRepo := new(repomocks.ExternalCalendarRepository)
+	mockBookingRepo := new(repomocks.BookingRepository)
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	calendarService := services.NewExternalCalendarService(mockCalendarRepo, mockBookingRepo, gormDB)
+	ownerID := uuid.New()
+
+	var stored *entities.ExternalCalendar
+	mockCalendarRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockCalendarRepo)
+	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo)
+	mockCalendarRepo.On("Create", mock.AnythingOfType("*entities.ExternalCalendar")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*entities.ExternalCalendar) }).
+		Return(nil).Once()
+	mockCalendarRepo.On("ListByOwner", ownerID).
+		Return(func(uuid.UUID) []entities.ExternalCalendar { return []entities.ExternalCalendar{*stored} }, nil)
+
+	// Subscribing blocks the slot that overlaps the external event.
+	sqlMock.ExpectBegin()
+	mockBookingRepo.On("ListOwnerUpcomingSlots", ownerID, mock.AnythingOfType("time.Time")).
+		Return([]entities.Booking{busySlot, freeSlot}, nil).Once()
+	mockBookingRepo.On("SetSlotsBlocked", []uuid.UUID{busySlot.ID}, true).Return(nil).Once()
+	mockBookingRepo.On("SetSlotsBlocked", []uuid.UUID(nil), false).Return(nil).Once()
+	sqlMock.ExpectCommit()
+
+	calendar, err := calendarService.SubscribeCalendar(ownerID, requests.ExternalCalendarRequest{Name: "Work", URL: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, calendar.URL)
+	assert.Len(t, calendar.Busy, 1)
+	assert.NotNil(t, calendar.LastSyncedAt)
+
+	// Once the event disappears from the feed, the next sync releases the slot.
+	mu.Lock()
+	feed = "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"
+	mu.Unlock()
+	busySlot.Blocked = true
+
+	mockCalendarRepo.On("ListAll").Return([]entities.ExternalCalendar{*stored}, nil).Once()
+	mockCalendarRepo.On("Update", mock.AnythingOfType("*entities.ExternalCalendar")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*entities.ExternalCalendar) }).
+		Return(nil).Once()
+	sqlMock.ExpectBegin()
+	mockBookingRepo.On("ListOwnerUpcomingSlots", ownerID, mock.AnythingOfType("time.Time")).
+		Return([]entities.Booking{busySlot, freeSlot}, nil).Once()
+	mockBookingRepo.On("SetSlotsBlocked", []uuid.UUID(nil), true).Return(nil).Once()
+	mockBookingRepo.On("SetSlotsBlocked", []uuid.UUID{busySlot.ID}, false).Return(nil).Once()
+	sqlMock.ExpectCommit()
+
+	summary, err := calendarService.SyncCalendars(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, services.ExternalCalendarSyncSummary{Calendars: 1, Blocked: 0, Released: 1}, summary)
+	assert.Empty(t, stored.Busy)
+	assert.Empty(t, stored.SyncError)
+
+	mockCalendarRepo.AssertExpectations(t)
+	mockBookingRepo.AssertExpectations(t)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestExternalCalendarSyncKeepsBusyTimesWhenFetchFails(t *testing.T) {
+	t.Setenv("EXTERNAL_CALENDAR_ALLOWED_NETWORKS", "127.0.0.0/8,::1/128")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	mockCalendarRepo := new(repomocks.ExternalCalendarRepository)
+	mockBookingRepo := new(repomocks.BookingRepository)
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	calendarService := services.NewExternalCalendarService(mockCalendarRepo, mockBookingRepo, gormDB)
+
+	start := time.Now().UTC().Add(24 * time.Hour)
+	calendar := entities.ExternalCalendar{
+		ID:      uuid.New(),
+		OwnerID: uuid.New(),
+		URL:     server.URL,
+		Busy:    entities.BusyPeriods{{Start: start, End: start.Add(time.Hour)}},
+	}
+	slot := entities.Booking{ID: uuid.New(), IsSlot: true, Blocked: true, StartTime: start, EndTime: start.Add(30 * time.Minute)}
+
+	var updated *entities.ExternalCalendar
+	mockCalendarRepo.On("ListAll").Return([]entities.ExternalCalendar{calendar}, nil).Once()
+	mockCalendarRepo.On("Update", mock.AnythingOfType("*entities.ExternalCalendar")).
+		Run(func(args mock.Arguments) { updated = args.Get(0).(*entities.ExternalCalendar) }).
+		Return(nil).Once()
+	mockCalendarRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockCalendarRepo)
+	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo)
+	mockCalendarRepo.On("ListByOwner", calendar.OwnerID).
+		Return(func(uuid.UUID) []entities.ExternalCalendar { return []entities.ExternalCalendar{*updated} }, nil).Once()
+	sqlMock.ExpectBegin()
+	mockBookingRepo.On("ListOwnerUpcomingSlots", calendar.OwnerID, mock.AnythingOfType("time.Time")).
+		Return([]entities.Booking{slot}, nil).Once()
+	mockBookingRepo.On("SetSlotsBlocked", []uuid.UUID(nil), mock.AnythingOfType("bool")).Return(nil).Twice()
+	sqlMock.ExpectCommit()
+
+	summary, err := calendarService.SyncCalendars(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), summary.Released)
+	assert.Equal(t, "calendar server did not return a calendar", updated.SyncError)
+	assert.Len(t, updated.Busy, 1)
+
+	mockCalendarRepo.AssertExpectations(t)
+	mockBookingRepo.AssertExpectations(t)
+}
+
+func TestSubscribeCalendarRefusesInternalAddresses(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		_, _ = w.Write([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+	}))
+	defer server.Close()
+
+	mockCalendarRepo := new(repomocks.ExternalCalendarRepository)
+	calendarService := services.NewExternalCalendarService(mockCalendarRepo, new(repomocks.BookingRepository), nil)
+
+	for _, url := range []string{server.URL, "http://169.254.169.254/latest/meta-data/", "http://10.0.0.1/cal.ics"} {
+		_, err := calendarService.SubscribeCalendar(uuid.New(), requests.ExternalCalendarRequest{Name: "Internal", URL: url})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "calendar address is not allowed")
+	}
+	assert.Zero(t, hits)
+	mockCalendarRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
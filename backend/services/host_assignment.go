@@ -0,0 +1,108 @@
+package services
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/repository"
+)
+
+// assignHost picks the host for a booking on appointment over [start, end), or returns nil when
+// the appointment has no hosts and the booking stays with the owner. It fails when every host is
+// busy.
+func assignHost(appRepo repository.AppointmentRepository, bookRepo repository.BookingRepository, appointment *entities.Appointment, start time.Time, end time.Time, current *uuid.UUID, moving uuid.UUID) (*uuid.UUID, error) {
+	hostID, ok, err := pickHost(appRepo, bookRepo, appointment, start, end, current, moving)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, serviceerrors.BookingSlotUnavailableError("no host is available at this time")
+	}
+	return hostID, nil
+}
+
+// pickHost chooses a host for a booking over [start, end) and reports false when none is free.
+// Bookings in a group slot share the host of the slot's earlier bookings. Otherwise current, the
+// host of a booking being moved, is kept while still a free host, and a host is picked by the
+// appointment's strategy, which round-robin remembers on the appointment. A host is busy while
+// they hold a live booking on another appointment that overlaps the range; moving is the ID of
+// the booking being moved, which never counts against its own host.
+func pickHost(appRepo repository.AppointmentRepository, bookRepo repository.BookingRepository, appointment *entities.Appointment, start time.Time, end time.Time, current *uuid.UUID, moving uuid.UUID) (*uuid.UUID, bool, error) {
+	hosts, err := appRepo.ListHosts(appointment.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(hosts) == 0 {
+		return nil, true, nil
+	}
+	entities.SortHosts(hosts)
+
+	hostIDs := make([]uuid.UUID, 0, len(hosts))
+	for _, host := range hosts {
+		hostIDs = append(hostIDs, host.UserID)
+	}
+	overlapping, err := bookRepo.ListHostBookingsBetween(hostIDs, start, end)
+	if err != nil {
+		return nil, false, err
+	}
+
+	busy := make(map[uuid.UUID]bool)
+	for _, booking := range overlapping {
+		if booking.ID == moving || booking.HostID == nil || !isActiveBookingStatus(booking.Status) {
+			continue
+		}
+		if booking.AppointmentID == appointment.ID {
+			if appointment.Type == entities.Group && booking.StartTime.Equal(start) {
+				return booking.HostID, true, nil
+			}
+			continue
+		}
+		busy[*booking.HostID] = true
+	}
+	free := func(id uuid.UUID) bool { return !busy[id] }
+
+	if current != nil && free(*current) {
+		for _, host := range hosts {
+			if host.UserID == *current {
+				return current, true, nil
+			}
+		}
+	}
+
+	var weekly map[uuid.UUID]int
+	if appointment.HostAssignment == entities.HostAssignmentLeastBusy {
+		if weekly, err = weeklyHostBookings(bookRepo, appointment, hostIDs, time.Now()); err != nil {
+			return nil, false, err
+		}
+	}
+
+	chosen, ok := entities.NextHost(appointment.HostAssignment, hosts, appointment.LastHostID, weekly, free)
+	if !ok {
+		return nil, false, nil
+	}
+	if err := appRepo.SetLastHost(appointment.ID, chosen); err != nil {
+		return nil, false, err
+	}
+	appointment.LastHostID = &chosen
+	return &chosen, true, nil
+}
+
+// weeklyHostBookings counts each host's bookings in the week, starting Monday in the appointment's
+// time zone, that contains now.
+func weeklyHostBookings(bookRepo repository.BookingRepository, appointment *entities.Appointment, hostIDs []uuid.UUID, now time.Time) (map[uuid.UUID]int, error) {
+	today := appointment.LocalDate(now)
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	bookings, err := bookRepo.ListHostBookingsBetween(hostIDs, weekStart, weekStart.AddDate(0, 0, 7))
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[uuid.UUID]int, len(hostIDs))
+	for _, booking := range bookings {
+		if booking.HostID != nil {
+			counts[*booking.HostID]++
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/repository"
+)
+
+// syncHostConflicts re-evaluates the owner's slots overlapping [start, end) after a booking on a
+// single appointment is made, moved or released. A slot is flagged while a booked single slot in
+// another of the owner's appointments overlaps it, and cleared once none does. Appointments that
+// allow parallel bookings are ignored on both sides.
+func syncHostConflicts(bookRepo repository.BookingRepository, ownerID uuid.UUID, start time.Time, end time.Time) error {
+	candidates, err := bookRepo.ListOwnerSlotsBetween(ownerID, start, end)
+	if err != nil || len(candidates) == 0 {
+		return err
+	}
+
+	// Candidates may reach past [start, end), so look for bookings across their full span.
+	from, to := candidates[0].StartTime, candidates[0].EndTime
+	for _, slot := range candidates {
+		if slot.StartTime.Before(from) {
+			from = slot.StartTime
+		}
+		if slot.EndTime.After(to) {
+			to = slot.EndTime
+		}
+	}
+	rows, err := bookRepo.ListOwnerSlotsBetween(ownerID, from, to)
+	if err != nil {
+		return err
+	}
+	var booked []entities.Booking
+	for _, row := range rows {
+		if occupiesHost(row) {
+			booked = append(booked, row)
+		}
+	}
+
+	var toFlag, toClear []uuid.UUID
+	for _, slot := range candidates {
+		if occupiesHost(slot) {
+			continue
+		}
+		conflict := false
+		for _, other := range booked {
+			if other.AppointmentID != slot.AppointmentID && other.StartTime.Before(slot.EndTime) && slot.StartTime.Before(other.EndTime) {
+				conflict = true
+				break
+			}
+		}
+		switch {
+		case conflict && !slot.HostConflict:
+			toFlag = append(toFlag, slot.ID)
+		case !conflict && slot.HostConflict:
+			toClear = append(toClear, slot.ID)
+		}
+	}
+	if err := bookRepo.SetSlotsHostConflict(toFlag, true); err != nil {
+		return err
+	}
+	return bookRepo.SetSlotsHostConflict(toClear, false)
+}
+
+// checkHostAvailable rejects booking slot while the owner is booked in another of their
+// appointments at the same time. The host conflict flag normally hides such slots; this also
+// covers slots generated after the conflicting booking was made.
+func checkHostAvailable(bookRepo repository.BookingRepository, appointment *entities.Appointment, slot *entities.Booking) error {
+	if appointment.AllowParallelBookings {
+		return nil
+	}
+	rows, err := bookRepo.ListOwnerSlotsBetween(appointment.OwnerID, slot.StartTime, slot.EndTime)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if row.AppointmentID != appointment.ID && occupiesHost(row) {
+			return serviceerrors.BookingSlotUnavailableError("the host is already booked at this time")
+		}
+	}
+	return nil
+}
+
+// occupiesHost reports whether a slot row holds a live booking on a single appointment.
+func occupiesHost(slot entities.Booking) bool {
+	return slot.Appointment.Type == entities.Single && slot.SeatsBooked > 0 && isActiveBookingStatus(slot.Status)
+}
@@ -26,25 +26,97 @@ type BookingService interface {
 	BookAppointment(req requests.BookingRequest, userIDStr string) (*entities.Booking, error)
 	BookRegisteredUserAppointment(req requests.BookingRequest, userIDStr string) (*entities.Booking, error)
 	BookGuestAppointment(req requests.BookingRequest) (*entities.Booking, error)
-	GetAllBookingsForAppointment(ctx context.Context, req *http.Request, appcode string) (paginate.Page, error)
+	GetAllBookingsForAppointment(ctx context.Context, req *http.Request, appcode string, userID uuid.UUID) (paginate.Page, error)
+	ExportAttendees(appCode string, userID uuid.UUID) ([]byte, error)
 	GetUserBookings(ctx context.Context, req *http.Request, userID string, statuses []string) (paginate.Page, error)
+	GetHostBookings(ctx context.Context, req *http.Request, hostID uuid.UUID, statuses []string) (paginate.Page, error)
 	GetAvailableSlots(req *http.Request, appcode string) (paginate.Page, error)
 	GetAvailableSlotsByDay(req *http.Request, appcode string, dateStr string) (paginate.Page, error)
-	GetAvailableDates(ctx context.Context, appcode string) ([]string, error)
+	GetAvailableDates(ctx context.Context, appcode string) ([]responses.AvailableDateResponse, error)
 	GetBookingByCode(bookingCode string) (*entities.Booking, error)
-	UpdateBookingByCode(bookingCode string, req requests.BookingRequest) (*entities.Booking, error)
-	CancelBookingByCode(bookingCode string) (*entities.Booking, error)
-	ConfirmBooking(bookingCode string, ownerID uuid.UUID) (*entities.Booking, error)
-	RejectBooking(bookingCode string, ownerID uuid.UUID) (*entities.Booking, error)
+	UpdateBookingByCode(bookingCode string, req requests.BookingRequest, userIDStr string) (*entities.Booking, error)
+	CancelBookingByCode(bookingCode string, userIDStr string) (*entities.Booking, error)
+	JoinWaitlist(req requests.BookingRequest, userIDStr string) (*entities.WaitlistEntry, error)
+	HoldSlot(req requests.SlotHoldRequest) (*entities.SlotHold, error)
+	ReleaseExpiredHolds(ctx context.Context, now time.Time) (int64, error)
+	ConfirmBooking(bookingCode string, userID uuid.UUID) (*entities.Booking, error)
+	RejectBooking(bookingCode string, userID uuid.UUID) (*entities.Booking, error)
+	CheckInBooking(bookingCode string, userID uuid.UUID) (*entities.Booking, error)
+	MarkNoShow(bookingCode string, userID uuid.UUID) (*entities.Booking, error)
+	GetBookingTicket(bookingCode string) ([]byte, error)
+	ScanTicket(req requests.TicketScanRequest, userID uuid.UUID) (*entities.Booking, error)
+	RotateCalendarFeedToken(ownerID uuid.UUID) (string, error)
+	GetCalendarFeed(token string) ([]byte, error)
 	RefreshBookingStatuses(ctx context.Context, now time.Time) (BookingStatusRefreshSummary, error)
 }
 
 type AppointmentService interface {
 	CreateAppointment(req requests.AppointmentRequest, userId uuid.UUID) (*entities.Appointment, error)
 	GetAllAppointmentsCreatedByUser(userID string, r *http.Request, statuses []entities.AppointmentStatus) paginate.Page
-	UpdateAppointment(ctx context.Context, appointmentID uuid.UUID, ownerID uuid.UUID, req requests.AppointmentRequest) (*entities.Appointment, error)
-	DeleteAppointment(ctx context.Context, appointmentID uuid.UUID, ownerID uuid.UUID) (*entities.Appointment, error)
-	CancelAppointment(ctx context.Context, appointmentID uuid.UUID, ownerID uuid.UUID) (*entities.Appointment, error)
+	UpdateAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.AppointmentRequest) (*entities.Appointment, error)
+	DeleteAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error)
+	CancelAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error)
+	PublishAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.PublishAppointmentRequest) (*entities.Appointment, error)
+	PauseAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error)
+	ResumeAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error)
+	ArchiveAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error)
 	RefreshStatuses(ctx context.Context, now time.Time) (StatusRefreshSummary, error)
 	GetAppointmentByAppCode(appCode string) (*entities.Appointment, error)
+	ListHosts(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*responses.AppointmentHostsResponse, error)
+	SetHosts(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.AppointmentHostsRequest) (*responses.AppointmentHostsResponse, error)
+}
+
+type AvailabilityExceptionService interface {
+	ListExceptions(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) ([]entities.AvailabilityException, error)
+	CreateException(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.AvailabilityExceptionRequest) (*entities.AvailabilityException, error)
+	UpdateException(ctx context.Context, appointmentID uuid.UUID, exceptionID uuid.UUID, userID uuid.UUID, req requests.AvailabilityExceptionRequest) (*entities.AvailabilityException, error)
+	DeleteException(ctx context.Context, appointmentID uuid.UUID, exceptionID uuid.UUID, userID uuid.UUID) error
+}
+
+type ExternalCalendarService interface {
+	ListCalendars(ownerID uuid.UUID) ([]entities.ExternalCalendar, error)
+	ImportCalendar(ownerID uuid.UUID, name string, data []byte) (*entities.ExternalCalendar, error)
+	SubscribeCalendar(ownerID uuid.UUID, req requests.ExternalCalendarRequest) (*entities.ExternalCalendar, error)
+	DeleteCalendar(ownerID uuid.UUID, calendarID uuid.UUID) error
+	SyncCalendars(ctx context.Context, now time.Time) (ExternalCalendarSyncSummary, error)
+}
+
+type OrganizationService interface {
+	CreateOrganization(userID uuid.UUID, req requests.OrganizationRequest) (*entities.Organization, error)
+	ListOrganizations(userID uuid.UUID) ([]entities.Organization, error)
+	UpdateOrganization(organizationID uuid.UUID, userID uuid.UUID, req requests.OrganizationRequest) (*entities.Organization, error)
+	DeleteOrganization(organizationID uuid.UUID, userID uuid.UUID) error
+	ListMembers(organizationID uuid.UUID, userID uuid.UUID) ([]responses.OrganizationMemberResponse, error)
+	AddMember(organizationID uuid.UUID, userID uuid.UUID, req requests.OrganizationMemberRequest) (*responses.OrganizationMemberResponse, error)
+	UpdateMemberRole(organizationID uuid.UUID, userID uuid.UUID, memberUserID uuid.UUID, req requests.OrganizationRoleRequest) (*responses.OrganizationMemberResponse, error)
+	RemoveMember(organizationID uuid.UUID, userID uuid.UUID, memberUserID uuid.UUID) error
+}
+
+type ResourceService interface {
+	CreateResource(userID uuid.UUID, req requests.ResourceRequest) (*entities.Resource, error)
+	ListResources(userID uuid.UUID) ([]entities.Resource, error)
+	UpdateResource(resourceID uuid.UUID, userID uuid.UUID, req requests.ResourceRequest) (*entities.Resource, error)
+	DeleteResource(resourceID uuid.UUID, userID uuid.UUID) error
+	ListAppointmentResources(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) ([]entities.Resource, error)
+	SetAppointmentResources(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.AppointmentResourcesRequest) ([]entities.Resource, error)
+}
+
+type PromoCodeService interface {
+	CreatePromoCode(ctx context.Context, userID uuid.UUID, req requests.PromoCodeRequest) (*entities.PromoCode, error)
+	ListPromoCodes(userID uuid.UUID) ([]entities.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, promoID uuid.UUID, userID uuid.UUID, req requests.PromoCodeRequest) (*entities.PromoCode, error)
+	DeletePromoCode(promoID uuid.UUID, userID uuid.UUID) error
+}
+
+type ReminderService interface {
+	ScheduleReminders(ctx context.Context, booking *entities.Booking) error
+	CancelReminders(ctx context.Context, booking *entities.Booking) error
+	SendDueReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+type PaymentService interface {
+	CreatePaymentIntent(ctx context.Context, bookingCode string) (*responses.PaymentIntentResponse, error)
+	HandleWebhook(ctx context.Context, payload []byte, header http.Header) error
+	RefundCancelledBooking(ctx context.Context, booking *entities.Booking) error
+	RefundBooking(ctx context.Context, booking *entities.Booking) error
 }
@@ -14,6 +14,8 @@ import (
 
 	requests "github.com/m13ha/asiko/models/requests"
 
+	responses "github.com/m13ha/asiko/models/responses"
+
 	services "github.com/m13ha/asiko/services"
 
 	time "time"
@@ -26,9 +28,9 @@ type AppointmentService struct {
 	mock.Mock
 }
 
-// CancelAppointment provides a mock function with given fields: ctx, appointmentID, ownerID
-func (_m *AppointmentService) CancelAppointment(ctx context.Context, appointmentID uuid.UUID, ownerID uuid.UUID) (*entities.Appointment, error) {
-	ret := _m.Called(ctx, appointmentID, ownerID)
+// CancelAppointment provides a mock function with given fields: ctx, appointmentID, userID
+func (_m *AppointmentService) CancelAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error) {
+	ret := _m.Called(ctx, appointmentID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CancelAppointment")
@@ -37,10 +39,10 @@ func (_m *AppointmentService) CancelAppointment(ctx context.Context, appointment
 	var r0 *entities.Appointment
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*entities.Appointment, error)); ok {
-		return rf(ctx, appointmentID, ownerID)
+		return rf(ctx, appointmentID, userID)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *entities.Appointment); ok {
-		r0 = rf(ctx, appointmentID, ownerID)
+		r0 = rf(ctx, appointmentID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Appointment)
@@ -48,7 +50,7 @@ func (_m *AppointmentService) CancelAppointment(ctx context.Context, appointment
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
-		r1 = rf(ctx, appointmentID, ownerID)
+		r1 = rf(ctx, appointmentID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,9 +106,69 @@ func (_m *AppointmentService) GetAllAppointmentsCreatedByUser(userID string, r *
 	return r0
 }
 
-// UpdateAppointment provides a mock function with given fields: ctx, appointmentID, ownerID, req
-func (_m *AppointmentService) UpdateAppointment(ctx context.Context, appointmentID uuid.UUID, ownerID uuid.UUID, req requests.AppointmentRequest) (*entities.Appointment, error) {
-	ret := _m.Called(ctx, appointmentID, ownerID, req)
+// ListHosts provides a mock function with given fields: ctx, appointmentID, userID
+func (_m *AppointmentService) ListHosts(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*responses.AppointmentHostsResponse, error) {
+	ret := _m.Called(ctx, appointmentID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListHosts")
+	}
+
+	var r0 *responses.AppointmentHostsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*responses.AppointmentHostsResponse, error)); ok {
+		return rf(ctx, appointmentID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *responses.AppointmentHostsResponse); ok {
+		r0 = rf(ctx, appointmentID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*responses.AppointmentHostsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, appointmentID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetHosts provides a mock function with given fields: ctx, appointmentID, userID, req
+func (_m *AppointmentService) SetHosts(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.AppointmentHostsRequest) (*responses.AppointmentHostsResponse, error) {
+	ret := _m.Called(ctx, appointmentID, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetHosts")
+	}
+
+	var r0 *responses.AppointmentHostsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, requests.AppointmentHostsRequest) (*responses.AppointmentHostsResponse, error)); ok {
+		return rf(ctx, appointmentID, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, requests.AppointmentHostsRequest) *responses.AppointmentHostsResponse); ok {
+		r0 = rf(ctx, appointmentID, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*responses.AppointmentHostsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, requests.AppointmentHostsRequest) error); ok {
+		r1 = rf(ctx, appointmentID, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateAppointment provides a mock function with given fields: ctx, appointmentID, userID, req
+func (_m *AppointmentService) UpdateAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.AppointmentRequest) (*entities.Appointment, error) {
+	ret := _m.Called(ctx, appointmentID, userID, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateAppointment")
@@ -115,10 +177,10 @@ func (_m *AppointmentService) UpdateAppointment(ctx context.Context, appointment
 	var r0 *entities.Appointment
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, requests.AppointmentRequest) (*entities.Appointment, error)); ok {
-		return rf(ctx, appointmentID, ownerID, req)
+		return rf(ctx, appointmentID, userID, req)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, requests.AppointmentRequest) *entities.Appointment); ok {
-		r0 = rf(ctx, appointmentID, ownerID, req)
+		r0 = rf(ctx, appointmentID, userID, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Appointment)
@@ -126,7 +188,7 @@ func (_m *AppointmentService) UpdateAppointment(ctx context.Context, appointment
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, requests.AppointmentRequest) error); ok {
-		r1 = rf(ctx, appointmentID, ownerID, req)
+		r1 = rf(ctx, appointmentID, userID, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -134,9 +196,9 @@ func (_m *AppointmentService) UpdateAppointment(ctx context.Context, appointment
 	return r0, r1
 }
 
-// DeleteAppointment provides a mock function with given fields: ctx, appointmentID, ownerID
-func (_m *AppointmentService) DeleteAppointment(ctx context.Context, appointmentID uuid.UUID, ownerID uuid.UUID) (*entities.Appointment, error) {
-	ret := _m.Called(ctx, appointmentID, ownerID)
+// DeleteAppointment provides a mock function with given fields: ctx, appointmentID, userID
+func (_m *AppointmentService) DeleteAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error) {
+	ret := _m.Called(ctx, appointmentID, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteAppointment")
@@ -145,10 +207,10 @@ func (_m *AppointmentService) DeleteAppointment(ctx context.Context, appointment
 	var r0 *entities.Appointment
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*entities.Appointment, error)); ok {
-		return rf(ctx, appointmentID, ownerID)
+		return rf(ctx, appointmentID, userID)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *entities.Appointment); ok {
-		r0 = rf(ctx, appointmentID, ownerID)
+		r0 = rf(ctx, appointmentID, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Appointment)
@@ -156,7 +218,7 @@ func (_m *AppointmentService) DeleteAppointment(ctx context.Context, appointment
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
-		r1 = rf(ctx, appointmentID, ownerID)
+		r1 = rf(ctx, appointmentID, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -194,6 +256,126 @@ func (_m *AppointmentService) GetAppointmentByAppCode(appCode string) (*entities
 	return r0, r1
 }
 
+// ArchiveAppointment provides a mock function with given fields: ctx, appointmentID, userID
+func (_m *AppointmentService) ArchiveAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error) {
+	ret := _m.Called(ctx, appointmentID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveAppointment")
+	}
+
+	var r0 *entities.Appointment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*entities.Appointment, error)); ok {
+		return rf(ctx, appointmentID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *entities.Appointment); ok {
+		r0 = rf(ctx, appointmentID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Appointment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, appointmentID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PauseAppointment provides a mock function with given fields: ctx, appointmentID, userID
+func (_m *AppointmentService) PauseAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error) {
+	ret := _m.Called(ctx, appointmentID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseAppointment")
+	}
+
+	var r0 *entities.Appointment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*entities.Appointment, error)); ok {
+		return rf(ctx, appointmentID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *entities.Appointment); ok {
+		r0 = rf(ctx, appointmentID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Appointment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, appointmentID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishAppointment provides a mock function with given fields: ctx, appointmentID, userID, req
+func (_m *AppointmentService) PublishAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.PublishAppointmentRequest) (*entities.Appointment, error) {
+	ret := _m.Called(ctx, appointmentID, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishAppointment")
+	}
+
+	var r0 *entities.Appointment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, requests.PublishAppointmentRequest) (*entities.Appointment, error)); ok {
+		return rf(ctx, appointmentID, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, requests.PublishAppointmentRequest) *entities.Appointment); ok {
+		r0 = rf(ctx, appointmentID, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Appointment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID, requests.PublishAppointmentRequest) error); ok {
+		r1 = rf(ctx, appointmentID, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResumeAppointment provides a mock function with given fields: ctx, appointmentID, userID
+func (_m *AppointmentService) ResumeAppointment(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) (*entities.Appointment, error) {
+	ret := _m.Called(ctx, appointmentID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeAppointment")
+	}
+
+	var r0 *entities.Appointment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*entities.Appointment, error)); ok {
+		return rf(ctx, appointmentID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *entities.Appointment); ok {
+		r0 = rf(ctx, appointmentID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Appointment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, appointmentID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RefreshStatuses provides a mock function with given fields: ctx, now
 func (_m *AppointmentService) RefreshStatuses(ctx context.Context, now time.Time) (services.StatusRefreshSummary, error) {
 	ret := _m.Called(ctx, now)
@@ -5,7 +5,6 @@ package mocks
 import (
 	context "context"
 	http "net/http"
-	time "time"
 
 	entities "github.com/m13ha/asiko/models/entities"
 
@@ -15,9 +14,13 @@ import (
 
 	requests "github.com/m13ha/asiko/models/requests"
 
-	uuid "github.com/google/uuid"
+	responses "github.com/m13ha/asiko/models/responses"
 
 	services "github.com/m13ha/asiko/services"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
 )
 
 // BookingService is an autogenerated mock type for the BookingService type
@@ -115,9 +118,9 @@ func (_m *BookingService) BookRegisteredUserAppointment(req requests.BookingRequ
 	return r0, r1
 }
 
-// CancelBookingByCode provides a mock function with given fields: bookingCode
-func (_m *BookingService) CancelBookingByCode(bookingCode string) (*entities.Booking, error) {
-	ret := _m.Called(bookingCode)
+// CancelBookingByCode provides a mock function with given fields: bookingCode, userIDStr
+func (_m *BookingService) CancelBookingByCode(bookingCode string, userIDStr string) (*entities.Booking, error) {
+	ret := _m.Called(bookingCode, userIDStr)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CancelBookingByCode")
@@ -125,19 +128,19 @@ func (_m *BookingService) CancelBookingByCode(bookingCode string) (*entities.Boo
 
 	var r0 *entities.Booking
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*entities.Booking, error)); ok {
-		return rf(bookingCode)
+	if rf, ok := ret.Get(0).(func(string, string) (*entities.Booking, error)); ok {
+		return rf(bookingCode, userIDStr)
 	}
-	if rf, ok := ret.Get(0).(func(string) *entities.Booking); ok {
-		r0 = rf(bookingCode)
+	if rf, ok := ret.Get(0).(func(string, string) *entities.Booking); ok {
+		r0 = rf(bookingCode, userIDStr)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Booking)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(bookingCode)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(bookingCode, userIDStr)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -145,9 +148,39 @@ func (_m *BookingService) CancelBookingByCode(bookingCode string) (*entities.Boo
 	return r0, r1
 }
 
-// ConfirmBooking provides a mock function with given fields: bookingCode, ownerID
-func (_m *BookingService) ConfirmBooking(bookingCode string, ownerID uuid.UUID) (*entities.Booking, error) {
-	ret := _m.Called(bookingCode, ownerID)
+// CheckInBooking provides a mock function with given fields: bookingCode, userID
+func (_m *BookingService) CheckInBooking(bookingCode string, userID uuid.UUID) (*entities.Booking, error) {
+	ret := _m.Called(bookingCode, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckInBooking")
+	}
+
+	var r0 *entities.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID) (*entities.Booking, error)); ok {
+		return rf(bookingCode, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID) *entities.Booking); ok {
+		r0 = rf(bookingCode, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uuid.UUID) error); ok {
+		r1 = rf(bookingCode, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmBooking provides a mock function with given fields: bookingCode, userID
+func (_m *BookingService) ConfirmBooking(bookingCode string, userID uuid.UUID) (*entities.Booking, error) {
+	ret := _m.Called(bookingCode, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ConfirmBooking")
@@ -156,10 +189,10 @@ func (_m *BookingService) ConfirmBooking(bookingCode string, ownerID uuid.UUID)
 	var r0 *entities.Booking
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string, uuid.UUID) (*entities.Booking, error)); ok {
-		return rf(bookingCode, ownerID)
+		return rf(bookingCode, userID)
 	}
 	if rf, ok := ret.Get(0).(func(string, uuid.UUID) *entities.Booking); ok {
-		r0 = rf(bookingCode, ownerID)
+		r0 = rf(bookingCode, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Booking)
@@ -167,7 +200,7 @@ func (_m *BookingService) ConfirmBooking(bookingCode string, ownerID uuid.UUID)
 	}
 
 	if rf, ok := ret.Get(1).(func(string, uuid.UUID) error); ok {
-		r1 = rf(bookingCode, ownerID)
+		r1 = rf(bookingCode, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -175,9 +208,39 @@ func (_m *BookingService) ConfirmBooking(bookingCode string, ownerID uuid.UUID)
 	return r0, r1
 }
 
-// GetAllBookingsForAppointment provides a mock function with given fields: ctx, req, appcode
-func (_m *BookingService) GetAllBookingsForAppointment(ctx context.Context, req *http.Request, appcode string) (paginate.Page, error) {
-	ret := _m.Called(ctx, req, appcode)
+// ExportAttendees provides a mock function with given fields: appCode, userID
+func (_m *BookingService) ExportAttendees(appCode string, userID uuid.UUID) ([]byte, error) {
+	ret := _m.Called(appCode, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportAttendees")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID) ([]byte, error)); ok {
+		return rf(appCode, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID) []byte); ok {
+		r0 = rf(appCode, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uuid.UUID) error); ok {
+		r1 = rf(appCode, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllBookingsForAppointment provides a mock function with given fields: ctx, req, appcode, userID
+func (_m *BookingService) GetAllBookingsForAppointment(ctx context.Context, req *http.Request, appcode string, userID uuid.UUID) (paginate.Page, error) {
+	ret := _m.Called(ctx, req, appcode, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllBookingsForAppointment")
@@ -185,17 +248,47 @@ func (_m *BookingService) GetAllBookingsForAppointment(ctx context.Context, req
 
 	var r0 paginate.Page
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *http.Request, string) (paginate.Page, error)); ok {
-		return rf(ctx, req, appcode)
+	if rf, ok := ret.Get(0).(func(context.Context, *http.Request, string, uuid.UUID) (paginate.Page, error)); ok {
+		return rf(ctx, req, appcode, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *http.Request, string) paginate.Page); ok {
-		r0 = rf(ctx, req, appcode)
+	if rf, ok := ret.Get(0).(func(context.Context, *http.Request, string, uuid.UUID) paginate.Page); ok {
+		r0 = rf(ctx, req, appcode, userID)
 	} else {
 		r0 = ret.Get(0).(paginate.Page)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *http.Request, string) error); ok {
-		r1 = rf(ctx, req, appcode)
+	if rf, ok := ret.Get(1).(func(context.Context, *http.Request, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, req, appcode, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAvailableDates provides a mock function with given fields: ctx, appcode
+func (_m *BookingService) GetAvailableDates(ctx context.Context, appcode string) ([]responses.AvailableDateResponse, error) {
+	ret := _m.Called(ctx, appcode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvailableDates")
+	}
+
+	var r0 []responses.AvailableDateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]responses.AvailableDateResponse, error)); ok {
+		return rf(ctx, appcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []responses.AvailableDateResponse); ok {
+		r0 = rf(ctx, appcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]responses.AvailableDateResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, appcode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -259,29 +352,29 @@ func (_m *BookingService) GetAvailableSlotsByDay(req *http.Request, appcode stri
 	return r0, r1
 }
 
-// GetAvailableDates provides a mock function with given fields: ctx, appcode
-func (_m *BookingService) GetAvailableDates(ctx context.Context, appcode string) ([]string, error) {
-	ret := _m.Called(ctx, appcode)
+// GetBookingByCode provides a mock function with given fields: bookingCode
+func (_m *BookingService) GetBookingByCode(bookingCode string) (*entities.Booking, error) {
+	ret := _m.Called(bookingCode)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAvailableDates")
+		panic("no return value specified for GetBookingByCode")
 	}
 
-	var r0 []string
+	var r0 *entities.Booking
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
-		return rf(ctx, appcode)
+	if rf, ok := ret.Get(0).(func(string) (*entities.Booking, error)); ok {
+		return rf(bookingCode)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = rf(ctx, appcode)
+	if rf, ok := ret.Get(0).(func(string) *entities.Booking); ok {
+		r0 = rf(bookingCode)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).(*entities.Booking)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, appcode)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(bookingCode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -289,24 +382,24 @@ func (_m *BookingService) GetAvailableDates(ctx context.Context, appcode string)
 	return r0, r1
 }
 
-// GetBookingByCode provides a mock function with given fields: bookingCode
-func (_m *BookingService) GetBookingByCode(bookingCode string) (*entities.Booking, error) {
+// GetBookingTicket provides a mock function with given fields: bookingCode
+func (_m *BookingService) GetBookingTicket(bookingCode string) ([]byte, error) {
 	ret := _m.Called(bookingCode)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBookingByCode")
+		panic("no return value specified for GetBookingTicket")
 	}
 
-	var r0 *entities.Booking
+	var r0 []byte
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*entities.Booking, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) ([]byte, error)); ok {
 		return rf(bookingCode)
 	}
-	if rf, ok := ret.Get(0).(func(string) *entities.Booking); ok {
+	if rf, ok := ret.Get(0).(func(string) []byte); ok {
 		r0 = rf(bookingCode)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.Booking)
+			r0 = ret.Get(0).([]byte)
 		}
 	}
 
@@ -319,6 +412,64 @@ func (_m *BookingService) GetBookingByCode(bookingCode string) (*entities.Bookin
 	return r0, r1
 }
 
+// GetCalendarFeed provides a mock function with given fields: token
+func (_m *BookingService) GetCalendarFeed(token string) ([]byte, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendarFeed")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]byte, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHostBookings provides a mock function with given fields: ctx, req, hostID, statuses
+func (_m *BookingService) GetHostBookings(ctx context.Context, req *http.Request, hostID uuid.UUID, statuses []string) (paginate.Page, error) {
+	ret := _m.Called(ctx, req, hostID, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHostBookings")
+	}
+
+	var r0 paginate.Page
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *http.Request, uuid.UUID, []string) (paginate.Page, error)); ok {
+		return rf(ctx, req, hostID, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *http.Request, uuid.UUID, []string) paginate.Page); ok {
+		r0 = rf(ctx, req, hostID, statuses)
+	} else {
+		r0 = ret.Get(0).(paginate.Page)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *http.Request, uuid.UUID, []string) error); ok {
+		r1 = rf(ctx, req, hostID, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserBookings provides a mock function with given fields: ctx, req, userID, statuses
 func (_m *BookingService) GetUserBookings(ctx context.Context, req *http.Request, userID string, statuses []string) (paginate.Page, error) {
 	ret := _m.Called(ctx, req, userID, statuses)
@@ -347,6 +498,154 @@ func (_m *BookingService) GetUserBookings(ctx context.Context, req *http.Request
 	return r0, r1
 }
 
+// HoldSlot provides a mock function with given fields: req
+func (_m *BookingService) HoldSlot(req requests.SlotHoldRequest) (*entities.SlotHold, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HoldSlot")
+	}
+
+	var r0 *entities.SlotHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(requests.SlotHoldRequest) (*entities.SlotHold, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(requests.SlotHoldRequest) *entities.SlotHold); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.SlotHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(requests.SlotHoldRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JoinWaitlist provides a mock function with given fields: req, userIDStr
+func (_m *BookingService) JoinWaitlist(req requests.BookingRequest, userIDStr string) (*entities.WaitlistEntry, error) {
+	ret := _m.Called(req, userIDStr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JoinWaitlist")
+	}
+
+	var r0 *entities.WaitlistEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(requests.BookingRequest, string) (*entities.WaitlistEntry, error)); ok {
+		return rf(req, userIDStr)
+	}
+	if rf, ok := ret.Get(0).(func(requests.BookingRequest, string) *entities.WaitlistEntry); ok {
+		r0 = rf(req, userIDStr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.WaitlistEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(requests.BookingRequest, string) error); ok {
+		r1 = rf(req, userIDStr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkNoShow provides a mock function with given fields: bookingCode, userID
+func (_m *BookingService) MarkNoShow(bookingCode string, userID uuid.UUID) (*entities.Booking, error) {
+	ret := _m.Called(bookingCode, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkNoShow")
+	}
+
+	var r0 *entities.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID) (*entities.Booking, error)); ok {
+		return rf(bookingCode, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID) *entities.Booking); ok {
+		r0 = rf(bookingCode, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uuid.UUID) error); ok {
+		r1 = rf(bookingCode, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RotateCalendarFeedToken provides a mock function with given fields: ownerID
+func (_m *BookingService) RotateCalendarFeedToken(ownerID uuid.UUID) (string, error) {
+	ret := _m.Called(ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateCalendarFeedToken")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (string, error)); ok {
+		return rf(ownerID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) string); ok {
+		r0 = rf(ownerID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScanTicket provides a mock function with given fields: req, userID
+func (_m *BookingService) ScanTicket(req requests.TicketScanRequest, userID uuid.UUID) (*entities.Booking, error) {
+	ret := _m.Called(req, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanTicket")
+	}
+
+	var r0 *entities.Booking
+	var r1 error
+	if rf, ok := ret.Get(0).(func(requests.TicketScanRequest, uuid.UUID) (*entities.Booking, error)); ok {
+		return rf(req, userID)
+	}
+	if rf, ok := ret.Get(0).(func(requests.TicketScanRequest, uuid.UUID) *entities.Booking); ok {
+		r0 = rf(req, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.Booking)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(requests.TicketScanRequest, uuid.UUID) error); ok {
+		r1 = rf(req, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RefreshBookingStatuses provides a mock function with given fields: ctx, now
 func (_m *BookingService) RefreshBookingStatuses(ctx context.Context, now time.Time) (services.BookingStatusRefreshSummary, error) {
 	ret := _m.Called(ctx, now)
@@ -375,9 +674,9 @@ func (_m *BookingService) RefreshBookingStatuses(ctx context.Context, now time.T
 	return r0, r1
 }
 
-// RejectBooking provides a mock function with given fields: bookingCode, ownerID
-func (_m *BookingService) RejectBooking(bookingCode string, ownerID uuid.UUID) (*entities.Booking, error) {
-	ret := _m.Called(bookingCode, ownerID)
+// RejectBooking provides a mock function with given fields: bookingCode, userID
+func (_m *BookingService) RejectBooking(bookingCode string, userID uuid.UUID) (*entities.Booking, error) {
+	ret := _m.Called(bookingCode, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RejectBooking")
@@ -386,10 +685,10 @@ func (_m *BookingService) RejectBooking(bookingCode string, ownerID uuid.UUID) (
 	var r0 *entities.Booking
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string, uuid.UUID) (*entities.Booking, error)); ok {
-		return rf(bookingCode, ownerID)
+		return rf(bookingCode, userID)
 	}
 	if rf, ok := ret.Get(0).(func(string, uuid.UUID) *entities.Booking); ok {
-		r0 = rf(bookingCode, ownerID)
+		r0 = rf(bookingCode, userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Booking)
@@ -397,7 +696,35 @@ func (_m *BookingService) RejectBooking(bookingCode string, ownerID uuid.UUID) (
 	}
 
 	if rf, ok := ret.Get(1).(func(string, uuid.UUID) error); ok {
-		r1 = rf(bookingCode, ownerID)
+		r1 = rf(bookingCode, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseExpiredHolds provides a mock function with given fields: ctx, now
+func (_m *BookingService) ReleaseExpiredHolds(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseExpiredHolds")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -405,9 +732,9 @@ func (_m *BookingService) RejectBooking(bookingCode string, ownerID uuid.UUID) (
 	return r0, r1
 }
 
-// UpdateBookingByCode provides a mock function with given fields: bookingCode, req
-func (_m *BookingService) UpdateBookingByCode(bookingCode string, req requests.BookingRequest) (*entities.Booking, error) {
-	ret := _m.Called(bookingCode, req)
+// UpdateBookingByCode provides a mock function with given fields: bookingCode, req, userIDStr
+func (_m *BookingService) UpdateBookingByCode(bookingCode string, req requests.BookingRequest, userIDStr string) (*entities.Booking, error) {
+	ret := _m.Called(bookingCode, req, userIDStr)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateBookingByCode")
@@ -415,19 +742,19 @@ func (_m *BookingService) UpdateBookingByCode(bookingCode string, req requests.B
 
 	var r0 *entities.Booking
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, requests.BookingRequest) (*entities.Booking, error)); ok {
-		return rf(bookingCode, req)
+	if rf, ok := ret.Get(0).(func(string, requests.BookingRequest, string) (*entities.Booking, error)); ok {
+		return rf(bookingCode, req, userIDStr)
 	}
-	if rf, ok := ret.Get(0).(func(string, requests.BookingRequest) *entities.Booking); ok {
-		r0 = rf(bookingCode, req)
+	if rf, ok := ret.Get(0).(func(string, requests.BookingRequest, string) *entities.Booking); ok {
+		r0 = rf(bookingCode, req, userIDStr)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.Booking)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, requests.BookingRequest) error); ok {
-		r1 = rf(bookingCode, req)
+	if rf, ok := ret.Get(1).(func(string, requests.BookingRequest, string) error); ok {
+		r1 = rf(bookingCode, req, userIDStr)
 	} else {
 		r1 = ret.Error(1)
 	}
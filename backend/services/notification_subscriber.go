@@ -5,15 +5,17 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/google/uuid"
 	"github.com/m13ha/asiko/events"
 )
 
+// internalNotificationSubscriber names the in-app notification handlers on the event bus. The
+// outbox records it on the events they handled, so it must not change.
+const internalNotificationSubscriber = "in_app_notifications"
+
 // RegisterInternalHandlers subscribes the internal notification service to relevant events.
 func RegisterInternalHandlers(bus events.EventBus, svc EventNotificationService) {
-	bus.Subscribe(func(ctx context.Context, event events.Event) error {
-		if event.Name != events.EventBookingCreated {
-			return nil
-		}
+	bus.SubscribeTo(events.EventBookingCreated, internalNotificationSubscriber, func(ctx context.Context, event events.Event) error {
 		p, ok := event.Data.(events.BookingEventData)
 		if !ok || p.Booking == nil {
 			return nil
@@ -24,13 +26,16 @@ func RegisterInternalHandlers(bus events.EventBus, svc EventNotificationService)
 		if err := svc.CreateEventNotification(p.OwnerID, "BOOKING_CREATED", message, p.Booking.ID); err != nil {
 			log.Printf("Failed to create internal notification: %v", err)
 		}
+		if hostID, ok := assignedHost(p); ok {
+			message := fmt.Sprintf("New booking by %s for %s was assigned to you.", p.Booking.Name, p.AppointmentTitle)
+			if err := svc.CreateEventNotification(hostID, "BOOKING_CREATED", message, p.Booking.ID); err != nil {
+				log.Printf("Failed to create host notification: %v", err)
+			}
+		}
 		return nil
 	})
 
-	bus.Subscribe(func(ctx context.Context, event events.Event) error {
-		if event.Name != events.EventAppointmentCreated {
-			return nil
-		}
+	bus.SubscribeTo(events.EventAppointmentCreated, internalNotificationSubscriber, func(ctx context.Context, event events.Event) error {
 		p, ok := event.Data.(events.AppointmentEventData)
 		if !ok || p.Appointment == nil {
 			return nil
@@ -43,10 +48,7 @@ func RegisterInternalHandlers(bus events.EventBus, svc EventNotificationService)
 		return nil
 	})
 
-	bus.Subscribe(func(ctx context.Context, event events.Event) error {
-		if event.Name != events.EventAppointmentUpdated {
-			return nil
-		}
+	bus.SubscribeTo(events.EventAppointmentUpdated, internalNotificationSubscriber, func(ctx context.Context, event events.Event) error {
 		p, ok := event.Data.(events.AppointmentEventData)
 		if !ok || p.Appointment == nil {
 			return nil
@@ -59,10 +61,7 @@ func RegisterInternalHandlers(bus events.EventBus, svc EventNotificationService)
 		return nil
 	})
 
-	bus.Subscribe(func(ctx context.Context, event events.Event) error {
-		if event.Name != events.EventAppointmentDeleted {
-			return nil
-		}
+	bus.SubscribeTo(events.EventAppointmentDeleted, internalNotificationSubscriber, func(ctx context.Context, event events.Event) error {
 		p, ok := event.Data.(events.AppointmentEventData)
 		if !ok || p.Appointment == nil {
 			return nil
@@ -75,10 +74,7 @@ func RegisterInternalHandlers(bus events.EventBus, svc EventNotificationService)
 		return nil
 	})
 
-	bus.Subscribe(func(ctx context.Context, event events.Event) error {
-		if event.Name != events.EventBookingCancelled {
-			return nil
-		}
+	bus.SubscribeTo(events.EventBookingCancelled, internalNotificationSubscriber, func(ctx context.Context, event events.Event) error {
 		p, ok := event.Data.(events.BookingEventData)
 		if !ok || p.Booking == nil {
 			return nil
@@ -88,13 +84,15 @@ func RegisterInternalHandlers(bus events.EventBus, svc EventNotificationService)
 		if err := svc.CreateEventNotification(p.OwnerID, "BOOKING_CANCELLED", message, p.Booking.ID); err != nil {
 			log.Printf("Failed to create internal notification: %v", err)
 		}
+		if hostID, ok := assignedHost(p); ok {
+			if err := svc.CreateEventNotification(hostID, "BOOKING_CANCELLED", message, p.Booking.ID); err != nil {
+				log.Printf("Failed to create host notification: %v", err)
+			}
+		}
 		return nil
 	})
 
-	bus.Subscribe(func(ctx context.Context, event events.Event) error {
-		if event.Name != events.EventBookingUpdated {
-			return nil
-		}
+	bus.SubscribeTo(events.EventBookingUpdated, internalNotificationSubscriber, func(ctx context.Context, event events.Event) error {
 		p, ok := event.Data.(events.BookingEventData)
 		if !ok || p.Booking == nil {
 			return nil
@@ -104,13 +102,15 @@ func RegisterInternalHandlers(bus events.EventBus, svc EventNotificationService)
 		if err := svc.CreateEventNotification(p.OwnerID, "BOOKING_UPDATED", message, p.Booking.ID); err != nil {
 			log.Printf("Failed to create internal notification: %v", err)
 		}
+		if hostID, ok := assignedHost(p); ok {
+			if err := svc.CreateEventNotification(hostID, "BOOKING_UPDATED", message, p.Booking.ID); err != nil {
+				log.Printf("Failed to create host notification: %v", err)
+			}
+		}
 		return nil
 	})
 
-	bus.Subscribe(func(ctx context.Context, event events.Event) error {
-		if event.Name != events.EventBookingRejected {
-			return nil
-		}
+	bus.SubscribeTo(events.EventBookingRejected, internalNotificationSubscriber, func(ctx context.Context, event events.Event) error {
 		p, ok := event.Data.(events.BookingEventData)
 		if !ok || p.Booking == nil {
 			return nil
@@ -126,3 +126,12 @@ func RegisterInternalHandlers(bus events.EventBus, svc EventNotificationService)
 		return nil
 	})
 }
+
+// assignedHost returns the host the booking is assigned to when that is someone other than the
+// owner, who is notified anyway.
+func assignedHost(p events.BookingEventData) (uuid.UUID, bool) {
+	if p.Booking.HostID == nil || *p.Booking.HostID == p.OwnerID {
+		return uuid.Nil, false
+	}
+	return *p.Booking.HostID, true
+}
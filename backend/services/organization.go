@@ -0,0 +1,239 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/models/responses"
+	"github.com/m13ha/asiko/repository"
+	"gorm.io/gorm"
+)
+
+type organizationServiceImpl struct {
+	orgRepo  repository.OrganizationRepository
+	userRepo repository.UserRepository
+	access   appointmentAccess
+	db       *gorm.DB
+}
+
+func NewOrganizationService(orgRepo repository.OrganizationRepository, userRepo repository.UserRepository, db *gorm.DB) OrganizationService {
+	return &organizationServiceImpl{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+		access:   appointmentAccess{orgRepo: orgRepo},
+		db:       db,
+	}
+}
+
+// CreateOrganization creates an organization with the caller as its first owner.
+func (s *organizationServiceImpl) CreateOrganization(userID uuid.UUID, req requests.OrganizationRequest) (*entities.Organization, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	organization := &entities.Organization{Name: req.Name, CreatedByID: userID}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		orgRepo := s.orgRepo.WithTx(tx)
+		if err := orgRepo.Create(organization); err != nil {
+			return err
+		}
+		return orgRepo.AddMember(&entities.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         userID,
+			Role:           entities.OrganizationRoleOwner,
+		})
+	})
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return organization, nil
+}
+
+func (s *organizationServiceImpl) ListOrganizations(userID uuid.UUID) ([]entities.Organization, error) {
+	organizations, err := s.orgRepo.ListForUser(userID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return organizations, nil
+}
+
+func (s *organizationServiceImpl) UpdateOrganization(organizationID uuid.UUID, userID uuid.UUID, req requests.OrganizationRequest) (*entities.Organization, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.access.authorizeOrganization(organizationID, userID, entities.PermissionManageOrganization); err != nil {
+		return nil, err
+	}
+
+	organization, err := s.orgRepo.FindByID(organizationID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	organization.Name = req.Name
+	if err := s.orgRepo.Update(organization); err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return organization, nil
+}
+
+// DeleteOrganization removes the organization. Its appointments stay with the members who created
+// them.
+func (s *organizationServiceImpl) DeleteOrganization(organizationID uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.access.authorizeOrganization(organizationID, userID, entities.PermissionManageOrganization); err != nil {
+		return err
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		return s.orgRepo.WithTx(tx).Delete(organizationID)
+	})
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+	return nil
+}
+
+func (s *organizationServiceImpl) ListMembers(organizationID uuid.UUID, userID uuid.UUID) ([]responses.OrganizationMemberResponse, error) {
+	if _, err := s.access.authorizeOrganization(organizationID, userID, entities.PermissionViewBookings); err != nil {
+		return nil, err
+	}
+	members, err := s.orgRepo.ListMembers(organizationID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+
+	result := make([]responses.OrganizationMemberResponse, 0, len(members))
+	for _, member := range members {
+		result = append(result, toOrganizationMemberResponse(member, member.User))
+	}
+	return result, nil
+}
+
+// AddMember gives a registered user a role in the organization. Only owners may add other owners.
+func (s *organizationServiceImpl) AddMember(organizationID uuid.UUID, userID uuid.UUID, req requests.OrganizationMemberRequest) (*responses.OrganizationMemberResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.access.authorizeOrganization(organizationID, userID, permissionToAssign(req.Role)); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByEmail(req.Email)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, serviceerrors.NotFoundError("no user is registered with that email")
+		}
+		return nil, serviceerrors.FromError(err)
+	}
+	if _, err := s.orgRepo.FindMember(organizationID, user.ID); err == nil {
+		return nil, serviceerrors.ConflictError("user is already a member of this organization")
+	} else if !isRepoNotFound(err) {
+		return nil, serviceerrors.FromError(err)
+	}
+
+	member := &entities.OrganizationMember{OrganizationID: organizationID, UserID: user.ID, Role: req.Role}
+	if err := s.orgRepo.AddMember(member); err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	response := toOrganizationMemberResponse(*member, *user)
+	return &response, nil
+}
+
+// UpdateMemberRole changes a member's role. Only owners may promote to or demote from owner, and
+// the last owner cannot be demoted.
+func (s *organizationServiceImpl) UpdateMemberRole(organizationID uuid.UUID, userID uuid.UUID, memberUserID uuid.UUID, req requests.OrganizationRoleRequest) (*responses.OrganizationMemberResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	caller, err := s.access.authorizeOrganization(organizationID, userID, permissionToAssign(req.Role))
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.orgRepo.FindMember(organizationID, memberUserID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	if member.Role == entities.OrganizationRoleOwner && req.Role != entities.OrganizationRoleOwner {
+		if !caller.Role.Can(entities.PermissionManageOrganization) {
+			return nil, serviceerrors.ForbiddenError("your organization role does not allow this action")
+		}
+		if err := s.ensureAnotherOwner(organizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	member.Role = req.Role
+	if err := s.orgRepo.UpdateMember(member); err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	user, err := s.userRepo.FindByID(member.UserID.String())
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	response := toOrganizationMemberResponse(*member, *user)
+	return &response, nil
+}
+
+// RemoveMember takes a user out of the organization. Members may always leave; removing someone
+// else needs the members permission, or the organization permission for an owner. The last owner
+// cannot leave.
+func (s *organizationServiceImpl) RemoveMember(organizationID uuid.UUID, userID uuid.UUID, memberUserID uuid.UUID) error {
+	permission := entities.PermissionManageMembers
+	if memberUserID == userID {
+		permission = entities.PermissionViewBookings
+	}
+	caller, err := s.access.authorizeOrganization(organizationID, userID, permission)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.orgRepo.FindMember(organizationID, memberUserID)
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+	if member.Role == entities.OrganizationRoleOwner {
+		if memberUserID != userID && !caller.Role.Can(entities.PermissionManageOrganization) {
+			return serviceerrors.ForbiddenError("your organization role does not allow this action")
+		}
+		if err := s.ensureAnotherOwner(organizationID); err != nil {
+			return err
+		}
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		return s.orgRepo.WithTx(tx).RemoveMember(organizationID, memberUserID)
+	})
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+	return nil
+}
+
+func (s *organizationServiceImpl) ensureAnotherOwner(organizationID uuid.UUID) error {
+	owners, err := s.orgRepo.CountMembersWithRole(organizationID, entities.OrganizationRoleOwner)
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+	if owners <= 1 {
+		return serviceerrors.ConflictError("an organization must keep at least one owner")
+	}
+	return nil
+}
+
+// permissionToAssign is the permission needed to give someone role.
+func permissionToAssign(role entities.OrganizationRole) entities.Permission {
+	if role == entities.OrganizationRoleOwner {
+		return entities.PermissionManageOrganization
+	}
+	return entities.PermissionManageMembers
+}
+
+func toOrganizationMemberResponse(member entities.OrganizationMember, user entities.User) responses.OrganizationMemberResponse {
+	return responses.OrganizationMemberResponse{
+		UserID:    member.UserID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	repomocks "github.com/m13ha/asiko/repository/mocks"
+	services "github.com/m13ha/asiko/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOrganizationMembership(t *testing.T) {
+	orgID := uuid.New()
+	adminID := uuid.New()
+	ownerID := uuid.New()
+	admin := &entities.OrganizationMember{OrganizationID: orgID, UserID: adminID, Role: entities.OrganizationRoleAdmin}
+	owner := &entities.OrganizationMember{OrganizationID: orgID, UserID: ownerID, Role: entities.OrganizationRoleOwner}
+
+	t.Run("admins add staff by email", func(t *testing.T) {
+		mockOrgRepo := new(repomocks.OrganizationRepository)
+		mockUserRepo := new(repomocks.UserRepository)
+		desk := &entities.User{ID: uuid.New(), Name: "Front Desk", Email: "desk@example.com"}
+		mockOrgRepo.On("FindMember", orgID, adminID).Return(admin, nil).Once()
+		mockUserRepo.On("FindByEmail", "desk@example.com").Return(desk, nil).Once()
+		mockOrgRepo.On("FindMember", orgID, desk.ID).Return(nil, repoerrors.NotFoundError("organization member not found")).Once()
+		mockOrgRepo.On("AddMember", mock.MatchedBy(func(member *entities.OrganizationMember) bool {
+			return member.UserID == desk.ID && member.Role == entities.OrganizationRoleStaff
+		})).Return(nil).Once()
+		svc := services.NewOrganizationService(mockOrgRepo, mockUserRepo, nil)
+
+		member, err := svc.AddMember(orgID, adminID, requests.OrganizationMemberRequest{Email: "Desk@Example.com", Role: "Staff"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Front Desk", member.Name)
+		assert.Equal(t, entities.OrganizationRoleStaff, member.Role)
+		mockOrgRepo.AssertExpectations(t)
+	})
+
+	t.Run("admins cannot add owners", func(t *testing.T) {
+		mockOrgRepo := new(repomocks.OrganizationRepository)
+		mockUserRepo := new(repomocks.UserRepository)
+		mockOrgRepo.On("FindMember", orgID, adminID).Return(admin, nil).Once()
+		svc := services.NewOrganizationService(mockOrgRepo, mockUserRepo, nil)
+
+		_, err := svc.AddMember(orgID, adminID, requests.OrganizationMemberRequest{Email: "boss@example.com", Role: entities.OrganizationRoleOwner})
+
+		assert.Error(t, err)
+		mockUserRepo.AssertNotCalled(t, "FindByEmail", mock.Anything)
+		mockOrgRepo.AssertNotCalled(t, "AddMember", mock.Anything)
+	})
+
+	t.Run("the last owner cannot leave", func(t *testing.T) {
+		mockOrgRepo := new(repomocks.OrganizationRepository)
+		mockOrgRepo.On("FindMember", orgID, ownerID).Return(owner, nil).Twice()
+		mockOrgRepo.On("CountMembersWithRole", orgID, entities.OrganizationRoleOwner).Return(int64(1), nil).Once()
+		svc := services.NewOrganizationService(mockOrgRepo, new(repomocks.UserRepository), nil)
+
+		err := svc.RemoveMember(orgID, ownerID, ownerID)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "at least one owner")
+		mockOrgRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/events"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// outboxBatchSize caps how many events one dispatch delivers before yielding to the next tick.
+	outboxBatchSize = 100
+	// outboxLease is how long claimed events are kept from other dispatchers while they are
+	// delivered. It outlasts the event bus's own retries of a batch's handlers.
+	outboxLease = 5 * time.Minute
+	// Failed deliveries are retried after outboxRetryDelay, doubling with each attempt up to
+	// outboxMaxRetryDelay, until maxOutboxAttempts is reached.
+	outboxRetryDelay    = 10 * time.Second
+	outboxMaxRetryDelay = time.Hour
+	maxOutboxAttempts   = 10
+	// Delivered events are kept for outboxRetention, cleaned up every outboxCleanupInterval.
+	outboxRetention       = 7 * 24 * time.Hour
+	outboxCleanupInterval = time.Hour
+)
+
+// enqueueEvent writes event to the outbox as part of tx. It is delivered to the event bus by the
+// outbox dispatcher once tx commits, and never if tx rolls back.
+func enqueueEvent(outboxRepo repository.OutboxRepository, tx *gorm.DB, event events.Event) error {
+	payload, err := events.Encode(event)
+	if err != nil {
+		return serviceerrors.InternalError(err.Error())
+	}
+	return outboxRepo.WithTx(tx).Create(&entities.OutboxEvent{
+		Name:        event.Name,
+		Payload:     string(payload),
+		Status:      entities.OutboxStatusPending,
+		AvailableAt: time.Now(),
+	})
+}
+
+type OutboxDispatcher interface {
+	Start(ctx context.Context)
+	DispatchPending(ctx context.Context, now time.Time) (int, error)
+}
+
+type outboxDispatcher struct {
+	outboxRepo  repository.OutboxRepository
+	bus         events.EventBus
+	db          *gorm.DB
+	interval    time.Duration
+	lastCleanup time.Time
+}
+
+// NewOutboxDispatcher delivers stored events to bus subscribers, polling the outbox every
+// interval.
+func NewOutboxDispatcher(outboxRepo repository.OutboxRepository, bus events.EventBus, db *gorm.DB, interval time.Duration) OutboxDispatcher {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &outboxDispatcher{
+		outboxRepo: outboxRepo,
+		bus:        bus,
+		db:         db,
+		interval:   interval,
+	}
+}
+
+func (d *outboxDispatcher) Start(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	go func() {
+		d.run(ctx)
+	}()
+}
+
+func (d *outboxDispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.tick(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *outboxDispatcher) tick(ctx context.Context) {
+	now := time.Now()
+
+	for {
+		delivered, err := d.DispatchPending(ctx, now)
+		if err != nil {
+			log.Printf("[OutboxDispatcher] dispatch error: %v", err)
+			break
+		}
+		// A full batch may have left more events behind.
+		if delivered < outboxBatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if now.Sub(d.lastCleanup) < outboxCleanupInterval {
+		return
+	}
+	d.lastCleanup = now
+	removed, err := d.outboxRepo.DeleteDeliveredBefore(now.Add(-outboxRetention))
+	if err != nil {
+		log.Printf("[OutboxDispatcher] cleanup error: %v", err)
+	} else if removed > 0 {
+		log.Printf("[OutboxDispatcher] removed delivered events: %d", removed)
+	}
+}
+
+// DispatchPending delivers up to outboxBatchSize events available at now to their bus subscribers
+// and returns how many it took. The events are claimed in a short transaction that pushes their
+// AvailableAt out by outboxLease, so other dispatchers skip them, and are handed to the bus
+// together once it commits. Each event then records the subscribers that acknowledged it: it is
+// marked delivered once all have, and otherwise retried later with backoff for the rest only. A
+// dispatcher that stops mid-batch leaves its events to be claimed again when the lease runs out.
+func (d *outboxDispatcher) DispatchPending(ctx context.Context, now time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	claimed, taken, err := d.claim(now)
+	if err != nil || len(claimed) == 0 {
+		return taken, err
+	}
+
+	acks := newOutboxAcks()
+	queueErrs := make([]error, len(claimed))
+	for i, c := range claimed {
+		n, err := d.bus.Deliver(ctx, c.event, c.stored.DeliveredTo, acks.ackFor(i))
+		if err != nil {
+			queueErrs[i] = err
+			continue
+		}
+		acks.expect(n)
+	}
+	acks.wait()
+
+	var errs []error
+	for i, c := range claimed {
+		failure := errors.Join(queueErrs[i], acks.err(i))
+		if err := d.record(c.stored, acks.deliveredTo(i), failure); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return taken, serviceerrors.FromError(errors.Join(errs...))
+	}
+	return taken, nil
+}
+
+// claimedEvent is an outbox row leased for delivery and the event decoded from it.
+type claimedEvent struct {
+	stored *entities.OutboxEvent
+	event  events.Event
+}
+
+// claim leases the events available at now and decodes them. It returns the leased events and
+// how many rows it took, including those that could not be decoded and were failed instead.
+func (d *outboxDispatcher) claim(now time.Time) ([]claimedEvent, int, error) {
+	var claimed []claimedEvent
+	taken := 0
+	err := d.db.Transaction(func(tx *gorm.DB) error {
+		outboxRepo := d.outboxRepo.WithTx(tx)
+		due, err := outboxRepo.FindAndLockDue(now, outboxBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range due {
+			stored := &due[i]
+			stored.Attempts++
+			event, err := events.Decode(stored.Name, []byte(stored.Payload))
+			if err != nil {
+				// A payload that cannot be decoded never will be, so it is not retried.
+				stored.Status = entities.OutboxStatusFailed
+				stored.LastError = err.Error()
+				log.Printf("[OutboxDispatcher] event %s %s dropped: %v", stored.Name, stored.ID, err)
+			} else {
+				stored.AvailableAt = now.Add(outboxLease)
+				claimed = append(claimed, claimedEvent{stored: stored, event: event})
+			}
+			if err := outboxRepo.Update(stored); err != nil {
+				return err
+			}
+		}
+		taken = len(due)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, serviceerrors.FromError(err)
+	}
+	return claimed, taken, nil
+}
+
+// record stores the outcome of delivering a claimed event: the subscribers that handled it, and
+// either its delivery or, when failure is set, when it is retried.
+func (d *outboxDispatcher) record(stored *entities.OutboxEvent, deliveredTo []string, failure error) error {
+	now := time.Now()
+	stored.DeliveredTo = append(stored.DeliveredTo, deliveredTo...)
+	if failure != nil {
+		stored.LastError = failure.Error()
+		if stored.Attempts >= maxOutboxAttempts {
+			stored.Status = entities.OutboxStatusFailed
+		} else {
+			stored.AvailableAt = now.Add(outboxBackoff(stored.Attempts))
+		}
+		log.Printf("[OutboxDispatcher] event %s %s failed (attempt %d): %v", stored.Name, stored.ID, stored.Attempts, failure)
+	} else {
+		stored.Status = entities.OutboxStatusDelivered
+		stored.DeliveredAt = &now
+		stored.LastError = ""
+	}
+	return d.outboxRepo.Update(stored)
+}
+
+// outboxAcks collects the bus's acknowledgements for events published together, by each event's
+// position among them. Acknowledgements may arrive before Deliver reports how many to expect.
+type outboxAcks struct {
+	mu        sync.Mutex
+	delivered map[int][]string
+	failures  map[int][]error
+	expected  int
+	received  int
+	sealed    bool
+	done      chan struct{}
+}
+
+func newOutboxAcks() *outboxAcks {
+	return &outboxAcks{
+		delivered: make(map[int][]string),
+		failures:  make(map[int][]error),
+		done:      make(chan struct{}),
+	}
+}
+
+// ackFor returns the events.Ack recording outcomes for the event at position i.
+func (a *outboxAcks) ackFor(i int) events.Ack {
+	return func(subscriber string, err error) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if err != nil {
+			a.failures[i] = append(a.failures[i], fmt.Errorf("%s: %w", subscriber, err))
+		} else {
+			a.delivered[i] = append(a.delivered[i], subscriber)
+		}
+		a.received++
+		a.checkDone()
+	}
+}
+
+// expect adds n acknowledgements to wait for.
+func (a *outboxAcks) expect(n int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.expected += n
+}
+
+// wait blocks until every expected acknowledgement has arrived. No more may be expected after.
+func (a *outboxAcks) wait() {
+	a.mu.Lock()
+	a.sealed = true
+	a.checkDone()
+	a.mu.Unlock()
+	<-a.done
+}
+
+func (a *outboxAcks) checkDone() {
+	if a.sealed && a.received == a.expected {
+		select {
+		case <-a.done:
+		default:
+			close(a.done)
+		}
+	}
+}
+
+// deliveredTo returns the subscribers that handled the event at position i.
+func (a *outboxAcks) deliveredTo(i int) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.delivered[i]
+}
+
+// err joins the failures acknowledged for the event at position i.
+func (a *outboxAcks) err(i int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return errors.Join(a.failures[i]...)
+}
+
+// outboxBackoff is how long to wait before retrying an event that has failed attempts times.
+func outboxBackoff(attempts int) time.Duration {
+	delay := outboxRetryDelay
+	for i := 1; i < attempts && delay < outboxMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxRetryDelay)
+}
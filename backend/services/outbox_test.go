@@ -0,0 +1,246 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/m13ha/asiko/events"
+	"github.com/m13ha/asiko/models/entities"
+	repomocks "github.com/m13ha/asiko/repository/mocks"
+	services "github.com/m13ha/asiko/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockOutboxRepo() *repomocks.OutboxRepository {
+	mockOutboxRepo := new(repomocks.OutboxRepository)
+	mockOutboxRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockOutboxRepo).Maybe()
+	return mockOutboxRepo
+}
+
+// outboxEvent matches an outbox row whose decoded event satisfies match.
+func outboxEvent(match func(event events.Event) bool) interface{} {
+	return mock.MatchedBy(func(stored *entities.OutboxEvent) bool {
+		event, err := events.Decode(stored.Name, []byte(stored.Payload))
+		return err == nil && stored.Status == entities.OutboxStatusPending && match(event)
+	})
+}
+
+func setupOutboxDispatcher(handler events.EventHandler) (services.OutboxDispatcher, *repomocks.OutboxRepository, sqlmock.Sqlmock) {
+	mockOutboxRepo := newMockOutboxRepo()
+	bus := events.NewSyncEventBus()
+	bus.Subscribe("test", handler)
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+
+	dispatcher := services.NewOutboxDispatcher(mockOutboxRepo, bus, gormDB, time.Second)
+	return dispatcher, mockOutboxRepo, sqlMock
+}
+
+func storedBookingEvent(t *testing.T, name, bookingCode string) entities.OutboxEvent {
+	payload, err := events.Encode(events.Event{Name: name, Data: events.BookingEventData{
+		Booking:          &entities.Booking{BookingCode: bookingCode},
+		AppointmentTitle: "Consultation",
+	}})
+	assert.NoError(t, err)
+	return entities.OutboxEvent{Name: name, Payload: string(payload), Status: entities.OutboxStatusPending}
+}
+
+// expectClaim expects one dispatch to claim due in a transaction of its own, leasing each row,
+// and then to record each row's outcome.
+func expectClaim(mockOutboxRepo *repomocks.OutboxRepository, sqlMock sqlmock.Sqlmock, now time.Time, due []entities.OutboxEvent) {
+	sqlMock.ExpectBegin()
+	mockOutboxRepo.On("FindAndLockDue", now, 100).Return(due, nil).Once()
+	mockOutboxRepo.On("Update", mock.AnythingOfType("*entities.OutboxEvent")).Return(nil).Times(2 * len(due))
+	sqlMock.ExpectCommit()
+}
+
+func TestDispatchPendingEvents(t *testing.T) {
+	now := time.Date(2026, 6, 8, 9, 0, 0, 0, time.UTC)
+
+	t.Run("publishes stored events and marks them delivered", func(t *testing.T) {
+		var received []events.Event
+		dispatcher, mockOutboxRepo, sqlMock := setupOutboxDispatcher(func(ctx context.Context, event events.Event) error {
+			received = append(received, event)
+			return nil
+		})
+		due := []entities.OutboxEvent{storedBookingEvent(t, events.EventBookingConfirmed, "BK1")}
+		expectClaim(mockOutboxRepo, sqlMock, now, due)
+
+		delivered, err := dispatcher.DispatchPending(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		if assert.Len(t, received, 1) {
+			data, ok := received[0].Data.(events.BookingEventData)
+			assert.True(t, ok)
+			assert.Equal(t, "BK1", data.Booking.BookingCode)
+			assert.Equal(t, "Consultation", data.AppointmentTitle)
+		}
+		assert.Equal(t, entities.OutboxStatusDelivered, due[0].Status)
+		assert.NotNil(t, due[0].DeliveredAt)
+		assert.Equal(t, 1, due[0].Attempts)
+		assert.Equal(t, entities.OutboxSubscribers{"test"}, due[0].DeliveredTo)
+		mockOutboxRepo.AssertExpectations(t)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("retries an event later when a subscriber fails", func(t *testing.T) {
+		dispatcher, mockOutboxRepo, sqlMock := setupOutboxDispatcher(func(ctx context.Context, event events.Event) error {
+			return errors.New("smtp down")
+		})
+		due := []entities.OutboxEvent{storedBookingEvent(t, events.EventBookingCreated, "BK1")}
+		due[0].Attempts = 2
+		expectClaim(mockOutboxRepo, sqlMock, now, due)
+
+		_, err := dispatcher.DispatchPending(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.OutboxStatusPending, due[0].Status)
+		assert.Equal(t, 3, due[0].Attempts)
+		assert.Contains(t, due[0].LastError, "smtp down")
+		assert.Empty(t, due[0].DeliveredTo)
+		assert.WithinDuration(t, time.Now().Add(40*time.Second), due[0].AvailableAt, 5*time.Second)
+	})
+
+	t.Run("claims events before publishing them", func(t *testing.T) {
+		mockOutboxRepo := newMockOutboxRepo()
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		bus := events.NewSyncEventBus()
+		dispatcher := services.NewOutboxDispatcher(mockOutboxRepo, bus, gormDB, time.Second)
+		due := []entities.OutboxEvent{storedBookingEvent(t, events.EventBookingCreated, "BK1")}
+		var leasedUntil time.Time
+		bus.Subscribe("test", func(ctx context.Context, event events.Event) error {
+			// The claiming transaction has committed and the row is leased by the time handlers run.
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+			leasedUntil = due[0].AvailableAt
+			return nil
+		})
+		expectClaim(mockOutboxRepo, sqlMock, now, due)
+
+		_, err := dispatcher.DispatchPending(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, now.Add(5*time.Minute), leasedUntil)
+		assert.Equal(t, entities.OutboxStatusDelivered, due[0].Status)
+	})
+
+	t.Run("redelivers only to subscribers that have not handled the event", func(t *testing.T) {
+		mockOutboxRepo := newMockOutboxRepo()
+		bus := events.NewAsyncEventBus(events.AsyncConfig{Workers: 2, MaxAttempts: 2, RetryDelay: time.Millisecond})
+		defer bus.Close(context.Background())
+		var notified, emailed atomic.Int32
+		bus.SubscribeTo(events.EventBookingCreated, "in_app_notifications", func(ctx context.Context, event events.Event) error {
+			notified.Add(1)
+			return nil
+		})
+		smtpDown := atomic.Bool{}
+		smtpDown.Store(true)
+		bus.SubscribeTo(events.EventBookingCreated, "email", func(ctx context.Context, event events.Event) error {
+			if smtpDown.Load() {
+				return errors.New("smtp down")
+			}
+			emailed.Add(1)
+			return nil
+		})
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		dispatcher := services.NewOutboxDispatcher(mockOutboxRepo, bus, gormDB, time.Second)
+		due := []entities.OutboxEvent{storedBookingEvent(t, events.EventBookingCreated, "BK1")}
+		expectClaim(mockOutboxRepo, sqlMock, now, due)
+
+		_, err := dispatcher.DispatchPending(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), notified.Load())
+		assert.Equal(t, entities.OutboxStatusPending, due[0].Status)
+		assert.Nil(t, due[0].DeliveredAt)
+		assert.Contains(t, due[0].LastError, "smtp down")
+		assert.Equal(t, entities.OutboxSubscribers{"in_app_notifications"}, due[0].DeliveredTo)
+
+		// The retry reaches only the email handler, so the owner is not notified twice.
+		smtpDown.Store(false)
+		retryAt := due[0].AvailableAt
+		expectClaim(mockOutboxRepo, sqlMock, retryAt, due)
+
+		_, err = dispatcher.DispatchPending(context.Background(), retryAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), notified.Load())
+		assert.Equal(t, int32(1), emailed.Load())
+		assert.Equal(t, entities.OutboxStatusDelivered, due[0].Status)
+		assert.ElementsMatch(t, entities.OutboxSubscribers{"in_app_notifications", "email"}, due[0].DeliveredTo)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("publishes a batch of events together", func(t *testing.T) {
+		mockOutboxRepo := newMockOutboxRepo()
+		bus := events.NewAsyncEventBus(events.AsyncConfig{Workers: 3})
+		defer bus.Close(context.Background())
+		var running, overlapped atomic.Int32
+		bus.Subscribe("test", func(ctx context.Context, event events.Event) error {
+			if running.Add(1) > 1 {
+				overlapped.Store(1)
+			}
+			time.Sleep(20 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+		db, sqlMock, _ := sqlmock.New()
+		gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		dispatcher := services.NewOutboxDispatcher(mockOutboxRepo, bus, gormDB, time.Second)
+		due := []entities.OutboxEvent{
+			storedBookingEvent(t, events.EventBookingCreated, "BK1"),
+			storedBookingEvent(t, events.EventBookingCreated, "BK2"),
+			storedBookingEvent(t, events.EventBookingCreated, "BK3"),
+		}
+		expectClaim(mockOutboxRepo, sqlMock, now, due)
+
+		delivered, err := dispatcher.DispatchPending(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, delivered)
+		assert.Equal(t, int32(1), overlapped.Load(), "events of one batch should be handled concurrently")
+		for _, stored := range due {
+			assert.Equal(t, entities.OutboxStatusDelivered, stored.Status)
+		}
+	})
+
+	t.Run("fails events that cannot be decoded without retrying", func(t *testing.T) {
+		called := false
+		dispatcher, mockOutboxRepo, sqlMock := setupOutboxDispatcher(func(ctx context.Context, event events.Event) error {
+			called = true
+			return nil
+		})
+		due := []entities.OutboxEvent{{Name: "booking.unknown", Payload: "{}", Status: entities.OutboxStatusPending}}
+
+		sqlMock.ExpectBegin()
+		mockOutboxRepo.On("FindAndLockDue", now, 100).Return(due, nil).Once()
+		mockOutboxRepo.On("Update", mock.AnythingOfType("*entities.OutboxEvent")).Return(nil).Once()
+		sqlMock.ExpectCommit()
+
+		delivered, err := dispatcher.DispatchPending(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.False(t, called)
+		assert.Equal(t, entities.OutboxStatusFailed, due[0].Status)
+		assert.NotEmpty(t, due[0].LastError)
+		mockOutboxRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/events"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/responses"
+	"github.com/m13ha/asiko/payments"
+	"github.com/m13ha/asiko/repository"
+	"gorm.io/gorm"
+)
+
+type paymentServiceImpl struct {
+	paymentRepo     repository.PaymentRepository
+	bookingRepo     repository.BookingRepository
+	appointmentRepo repository.AppointmentRepository
+	provider        payments.PaymentProvider
+	outboxRepo      repository.OutboxRepository
+	db              *gorm.DB
+}
+
+func NewPaymentService(paymentRepo repository.PaymentRepository, bookingRepo repository.BookingRepository, appointmentRepo repository.AppointmentRepository, provider payments.PaymentProvider, outboxRepo repository.OutboxRepository, db *gorm.DB) PaymentService {
+	return &paymentServiceImpl{
+		paymentRepo:     paymentRepo,
+		bookingRepo:     bookingRepo,
+		appointmentRepo: appointmentRepo,
+		provider:        provider,
+		outboxRepo:      outboxRepo,
+		db:              db,
+	}
+}
+
+// bookingClosed reports whether a booking no longer holds its seats, so money paid for it
+// must be returned.
+func bookingClosed(booking *entities.Booking) bool {
+	switch strings.ToLower(booking.Status) {
+	case entities.BookingStatusCancelled, entities.BookingStatusCanceled, entities.BookingStatusRejected, entities.BookingStatusExpired:
+		return true
+	}
+	return false
+}
+
+// CreatePaymentIntent starts a payment for a booking that is due. It may be called again to
+// retry after a failed payment; each call starts a new intent.
+func (s *paymentServiceImpl) CreatePaymentIntent(ctx context.Context, bookingCode string) (*responses.PaymentIntentResponse, error) {
+	booking, err := s.bookingRepo.GetBookingByCode(bookingCode)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	switch booking.PaymentStatus {
+	case entities.BookingPaymentDue:
+	case entities.BookingPaymentPaid:
+		return nil, serviceerrors.ConflictError("booking is already paid")
+	default:
+		return nil, serviceerrors.ConflictError("booking has nothing to pay")
+	}
+	if bookingClosed(booking) {
+		return nil, serviceerrors.ConflictError("booking is no longer active")
+	}
+
+	intent, err := s.provider.CreateIntent(ctx, payments.IntentRequest{
+		Reference:   booking.BookingCode,
+		Amount:      booking.AmountDue,
+		Currency:    booking.Currency,
+		Email:       booking.Email,
+		Description: "Booking " + booking.BookingCode,
+	})
+	if err != nil {
+		log.Printf("[CreatePaymentIntent] provider error: %v", err)
+		return nil, serviceerrors.InternalError("could not start the payment")
+	}
+
+	payment := &entities.Payment{
+		BookingCode:   booking.BookingCode,
+		AppointmentID: booking.AppointmentID,
+		Provider:      s.provider.Name(),
+		IntentID:      intent.ID,
+		Amount:        booking.AmountDue,
+		Currency:      booking.Currency,
+		Status:        entities.PaymentStatusPending,
+	}
+	if err := s.paymentRepo.Create(payment); err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+
+	return &responses.PaymentIntentResponse{
+		BookingCode:  booking.BookingCode,
+		Provider:     payment.Provider,
+		IntentID:     intent.ID,
+		ClientSecret: intent.ClientSecret,
+		Amount:       payment.Amount,
+		Currency:     payment.Currency,
+	}, nil
+}
+
+// HandleWebhook applies a signed provider event. Events for unknown intents and repeated
+// deliveries are acknowledged without effect.
+func (s *paymentServiceImpl) HandleWebhook(ctx context.Context, payload []byte, header http.Header) error {
+	event, err := s.provider.ParseWebhook(payload, header)
+	if err != nil {
+		return serviceerrors.UnauthorizedError(err.Error())
+	}
+
+	switch event.Type {
+	case payments.EventPaymentSucceeded:
+		return s.completePayment(ctx, event.IntentID)
+	case payments.EventPaymentFailed:
+		return s.failPayment(event.IntentID)
+	default:
+		return nil
+	}
+}
+
+// completePayment marks the booking paid and confirms it if it was pending. A payment that
+// arrives for a booking that has since closed, or was already paid, is refunded.
+func (s *paymentServiceImpl) completePayment(ctx context.Context, intentID string) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		payRepo := s.paymentRepo.WithTx(tx)
+		bookRepo := s.bookingRepo.WithTx(tx)
+
+		payment, err := payRepo.FindAndLockByIntentID(intentID)
+		if isRepoNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if payment.Status != entities.PaymentStatusPending && payment.Status != entities.PaymentStatusFailed {
+			return nil
+		}
+		now := time.Now()
+		payment.Status = entities.PaymentStatusSucceeded
+		payment.PaidAt = &now
+		if err := payRepo.Update(payment); err != nil {
+			return err
+		}
+
+		booking, err := bookRepo.GetBookingByCode(payment.BookingCode)
+		if err != nil && !isRepoNotFound(err) {
+			return err
+		}
+		if booking == nil || bookingClosed(booking) || booking.PaymentStatus != entities.BookingPaymentDue {
+			return s.refund(ctx, payRepo, payment)
+		}
+
+		booking.PaymentStatus = entities.BookingPaymentPaid
+		confirmed := strings.ToLower(booking.Status) == entities.BookingStatusPending
+		if confirmed {
+			booking.Available = false
+			booking.Status = entities.BookingStatusConfirmed
+		}
+		if err := bookRepo.Update(booking); err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+		return s.enqueueConfirmed(tx, booking)
+	})
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+	return nil
+}
+
+func (s *paymentServiceImpl) failPayment(intentID string) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		payRepo := s.paymentRepo.WithTx(tx)
+
+		payment, err := payRepo.FindAndLockByIntentID(intentID)
+		if isRepoNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if payment.Status != entities.PaymentStatusPending {
+			return nil
+		}
+		payment.Status = entities.PaymentStatusFailed
+		return payRepo.Update(payment)
+	})
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+	return nil
+}
+
+// RefundCancelledBooking refunds a paid booking cancelled by its guest when the appointment's
+// refund window still allows it.
+func (s *paymentServiceImpl) RefundCancelledBooking(ctx context.Context, booking *entities.Booking) error {
+	if booking.PaymentStatus != entities.BookingPaymentPaid {
+		return nil
+	}
+	appointment, err := s.appointmentRepo.FindAppointmentByAppCode(booking.AppCode)
+	if err != nil && !isRepoNotFound(err) {
+		return serviceerrors.FromError(err)
+	}
+	if appointment != nil && !appointment.CanRefundAt(booking.StartTime, time.Now()) {
+		return nil
+	}
+	return s.RefundBooking(ctx, booking)
+}
+
+// RefundBooking refunds the completed payment for a booking in full, if there is one. The
+// booking's payment status is updated both in the database and on booking.
+func (s *paymentServiceImpl) RefundBooking(ctx context.Context, booking *entities.Booking) error {
+	refunded := false
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		payRepo := s.paymentRepo.WithTx(tx)
+		bookRepo := s.bookingRepo.WithTx(tx)
+
+		payment, err := payRepo.FindAndLockSucceeded(booking.BookingCode)
+		if isRepoNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.refund(ctx, payRepo, payment); err != nil {
+			return err
+		}
+		refunded = true
+
+		// A released single slot may already be rebooked under a new code.
+		current, err := bookRepo.GetBookingByCode(booking.BookingCode)
+		if isRepoNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if current.PaymentStatus != entities.BookingPaymentPaid {
+			return nil
+		}
+		current.PaymentStatus = entities.BookingPaymentRefunded
+		return bookRepo.Update(current)
+	})
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+
+	if refunded && booking.PaymentStatus == entities.BookingPaymentPaid {
+		booking.PaymentStatus = entities.BookingPaymentRefunded
+	}
+	return nil
+}
+
+// refund returns a completed payment through the provider and records it. It runs with the
+// payment locked, and the provider call carries an idempotency key derived from the payment, so
+// retrying after the transaction rolled back returns the refund already issued rather than
+// refunding twice.
+func (s *paymentServiceImpl) refund(ctx context.Context, payRepo repository.PaymentRepository, payment *entities.Payment) error {
+	refund, err := s.provider.Refund(ctx, payments.RefundRequest{
+		IntentID:       payment.IntentID,
+		Amount:         payment.Amount,
+		Currency:       payment.Currency,
+		IdempotencyKey: "refund_" + payment.ID.String(),
+	})
+	if err != nil {
+		return serviceerrors.InternalError("refund failed: " + err.Error())
+	}
+	now := time.Now()
+	payment.Status = entities.PaymentStatusRefunded
+	payment.RefundID = refund.ID
+	payment.RefundedAt = &now
+	return payRepo.Update(payment)
+}
+
+func (s *paymentServiceImpl) enqueueConfirmed(tx *gorm.DB, booking *entities.Booking) error {
+	payload := events.BookingEventData{
+		Booking:        booking,
+		RecipientEmail: booking.Email,
+		RecipientName:  booking.Name,
+	}
+	if appointment, err := s.appointmentRepo.FindAppointmentByAppCode(booking.AppCode); err == nil {
+		payload.OwnerID = appointment.OwnerID
+		payload.AppointmentTitle = appointment.Title
+	}
+	return enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingConfirmed, Data: payload})
+}
@@ -0,0 +1,196 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/events"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/payments"
+	repomocks "github.com/m13ha/asiko/repository/mocks"
+	services "github.com/m13ha/asiko/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupPaymentService() (services.PaymentService, *payments.FakeProvider, *repomocks.PaymentRepository, *repomocks.BookingRepository, *repomocks.AppointmentRepository, *repomocks.OutboxRepository, sqlmock.Sqlmock) {
+	provider := payments.NewFakeProvider("whsec_test")
+	mockPaymentRepo := new(repomocks.PaymentRepository)
+	mockPaymentRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockPaymentRepo).Maybe()
+	mockBookingRepo := new(repomocks.BookingRepository)
+	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Maybe()
+	mockAppointmentRepo := new(repomocks.AppointmentRepository)
+	mockOutboxRepo := newMockOutboxRepo()
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+
+	paymentService := services.NewPaymentService(mockPaymentRepo, mockBookingRepo, mockAppointmentRepo, provider, mockOutboxRepo, gormDB)
+	return paymentService, provider, mockPaymentRepo, mockBookingRepo, mockAppointmentRepo, mockOutboxRepo, sqlMock
+}
+
+func signedWebhook(provider *payments.FakeProvider, payload string) ([]byte, http.Header) {
+	header := http.Header{}
+	header.Set(payments.FakeSignatureHeader, provider.Sign([]byte(payload)))
+	return []byte(payload), header
+}
+
+func TestCreatePaymentIntent(t *testing.T) {
+	t.Run("starts a payment for a booking that is due", func(t *testing.T) {
+		paymentService, _, mockPaymentRepo, mockBookingRepo, _, _, _ := setupPaymentService()
+		booking := &entities.Booking{AppointmentID: uuid.New(), BookingCode: "BK1", Status: entities.BookingStatusPending, AmountDue: 2500, Currency: "USD", PaymentStatus: entities.BookingPaymentDue}
+		mockBookingRepo.On("GetBookingByCode", "BK1").Return(booking, nil).Once()
+		mockPaymentRepo.On("Create", mock.MatchedBy(func(p *entities.Payment) bool {
+			return p.BookingCode == "BK1" && p.Amount == 2500 && p.Status == entities.PaymentStatusPending && p.IntentID != ""
+		})).Return(nil).Once()
+
+		intent, err := paymentService.CreatePaymentIntent(context.Background(), "BK1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, payments.ProviderFake, intent.Provider)
+		assert.Equal(t, int64(2500), intent.Amount)
+		assert.NotEmpty(t, intent.ClientSecret)
+		mockPaymentRepo.AssertExpectations(t)
+	})
+
+	t.Run("refuses bookings that are paid or free", func(t *testing.T) {
+		paymentService, _, mockPaymentRepo, mockBookingRepo, _, _, _ := setupPaymentService()
+		mockBookingRepo.On("GetBookingByCode", "PAID").Return(&entities.Booking{BookingCode: "PAID", PaymentStatus: entities.BookingPaymentPaid}, nil).Once()
+		mockBookingRepo.On("GetBookingByCode", "FREE").Return(&entities.Booking{BookingCode: "FREE"}, nil).Once()
+
+		_, err := paymentService.CreatePaymentIntent(context.Background(), "PAID")
+		assert.ErrorContains(t, err, "already paid")
+		_, err = paymentService.CreatePaymentIntent(context.Background(), "FREE")
+		assert.ErrorContains(t, err, "nothing to pay")
+		mockPaymentRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestPaymentWebhook(t *testing.T) {
+	t.Run("marks the booking paid and confirms it", func(t *testing.T) {
+		paymentService, provider, mockPaymentRepo, mockBookingRepo, mockAppointmentRepo, mockOutboxRepo, sqlMock := setupPaymentService()
+		payment := &entities.Payment{BookingCode: "BK1", IntentID: "fake_pi_1", Amount: 2500, Currency: "USD", Status: entities.PaymentStatusPending}
+		booking := &entities.Booking{AppCode: "APP1", BookingCode: "BK1", Status: entities.BookingStatusPending, AmountDue: 2500, PaymentStatus: entities.BookingPaymentDue}
+
+		sqlMock.ExpectBegin()
+		mockPaymentRepo.On("FindAndLockByIntentID", "fake_pi_1").Return(payment, nil).Once()
+		mockPaymentRepo.On("Update", payment).Return(nil).Once()
+		mockBookingRepo.On("GetBookingByCode", "BK1").Return(booking, nil).Once()
+		mockBookingRepo.On("Update", booking).Return(nil).Once()
+		sqlMock.ExpectCommit()
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "APP1").Return(&entities.Appointment{Title: "Workshop"}, nil).Once()
+		mockOutboxRepo.On("Create", outboxEvent(func(e events.Event) bool { return e.Name == events.EventBookingConfirmed })).Return(nil).Once()
+
+		payload, header := signedWebhook(provider, `{"type":"payment.succeeded","intent_id":"fake_pi_1"}`)
+		err := paymentService.HandleWebhook(context.Background(), payload, header)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.PaymentStatusSucceeded, payment.Status)
+		assert.NotNil(t, payment.PaidAt)
+		assert.Equal(t, entities.BookingPaymentPaid, booking.PaymentStatus)
+		assert.Equal(t, entities.BookingStatusConfirmed, booking.Status)
+		mockOutboxRepo.AssertExpectations(t)
+	})
+
+	t.Run("ignores repeated deliveries", func(t *testing.T) {
+		paymentService, provider, mockPaymentRepo, mockBookingRepo, _, mockOutboxRepo, sqlMock := setupPaymentService()
+		sqlMock.ExpectBegin()
+		mockPaymentRepo.On("FindAndLockByIntentID", "fake_pi_1").Return(&entities.Payment{IntentID: "fake_pi_1", Status: entities.PaymentStatusSucceeded}, nil).Once()
+		sqlMock.ExpectCommit()
+
+		payload, header := signedWebhook(provider, `{"type":"payment.succeeded","intent_id":"fake_pi_1"}`)
+		err := paymentService.HandleWebhook(context.Background(), payload, header)
+
+		assert.NoError(t, err)
+		mockBookingRepo.AssertNotCalled(t, "GetBookingByCode", mock.Anything)
+		mockOutboxRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("refunds a payment for a cancelled booking", func(t *testing.T) {
+		paymentService, provider, mockPaymentRepo, mockBookingRepo, _, mockOutboxRepo, sqlMock := setupPaymentService()
+		payment := &entities.Payment{BookingCode: "BK1", IntentID: "fake_pi_1", Amount: 2500, Currency: "USD", Status: entities.PaymentStatusPending}
+		booking := &entities.Booking{BookingCode: "BK1", Status: entities.BookingStatusCancelled, PaymentStatus: entities.BookingPaymentDue}
+
+		sqlMock.ExpectBegin()
+		mockPaymentRepo.On("FindAndLockByIntentID", "fake_pi_1").Return(payment, nil).Once()
+		mockPaymentRepo.On("Update", payment).Return(nil).Twice()
+		mockBookingRepo.On("GetBookingByCode", "BK1").Return(booking, nil).Once()
+		sqlMock.ExpectCommit()
+
+		payload, header := signedWebhook(provider, `{"type":"payment.succeeded","intent_id":"fake_pi_1"}`)
+		err := paymentService.HandleWebhook(context.Background(), payload, header)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.PaymentStatusRefunded, payment.Status)
+		assert.NotEmpty(t, payment.RefundID)
+		mockBookingRepo.AssertNotCalled(t, "Update", mock.Anything)
+		mockOutboxRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("rejects unsigned webhooks", func(t *testing.T) {
+		paymentService, _, mockPaymentRepo, _, _, _, _ := setupPaymentService()
+
+		err := paymentService.HandleWebhook(context.Background(), []byte(`{"type":"payment.succeeded","intent_id":"fake_pi_1"}`), http.Header{})
+
+		assert.ErrorContains(t, err, "invalid webhook signature")
+		mockPaymentRepo.AssertNotCalled(t, "FindAndLockByIntentID", mock.Anything)
+	})
+}
+
+func TestRefundCancelledBooking(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour)
+	appointment := &entities.Appointment{AppCode: "APP1", RefundCutoffHours: 48}
+
+	t.Run("keeps the payment after the refund window", func(t *testing.T) {
+		paymentService, _, mockPaymentRepo, _, mockAppointmentRepo, _, _ := setupPaymentService()
+		booking := &entities.Booking{AppCode: "APP1", BookingCode: "BK1", StartTime: start, PaymentStatus: entities.BookingPaymentPaid}
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "APP1").Return(appointment, nil).Once()
+
+		err := paymentService.RefundCancelledBooking(context.Background(), booking)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookingPaymentPaid, booking.PaymentStatus)
+		mockPaymentRepo.AssertNotCalled(t, "FindAndLockSucceeded", mock.Anything)
+	})
+
+	t.Run("refunds inside the refund window", func(t *testing.T) {
+		paymentService, _, mockPaymentRepo, mockBookingRepo, mockAppointmentRepo, _, sqlMock := setupPaymentService()
+		booking := &entities.Booking{AppCode: "APP1", BookingCode: "BK1", StartTime: start.Add(48 * time.Hour), Status: entities.BookingStatusCancelled, PaymentStatus: entities.BookingPaymentPaid}
+		stored := *booking
+		payment := &entities.Payment{BookingCode: "BK1", IntentID: "fake_pi_1", Amount: 2500, Currency: "USD", Status: entities.PaymentStatusSucceeded}
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "APP1").Return(appointment, nil).Once()
+		sqlMock.ExpectBegin()
+		mockPaymentRepo.On("FindAndLockSucceeded", "BK1").Return(payment, nil).Once()
+		mockPaymentRepo.On("Update", payment).Return(nil).Once()
+		mockBookingRepo.On("GetBookingByCode", "BK1").Return(&stored, nil).Once()
+		mockBookingRepo.On("Update", &stored).Return(nil).Once()
+		sqlMock.ExpectCommit()
+
+		err := paymentService.RefundCancelledBooking(context.Background(), booking)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.PaymentStatusRefunded, payment.Status)
+		assert.Equal(t, entities.BookingPaymentRefunded, stored.PaymentStatus)
+		assert.Equal(t, entities.BookingPaymentRefunded, booking.PaymentStatus)
+	})
+
+	t.Run("skips bookings without a completed payment", func(t *testing.T) {
+		paymentService, _, mockPaymentRepo, mockBookingRepo, _, _, sqlMock := setupPaymentService()
+		sqlMock.ExpectBegin()
+		mockPaymentRepo.On("FindAndLockSucceeded", "BK1").Return(nil, repoerrors.NotFoundError("no completed payment for booking")).Once()
+		sqlMock.ExpectCommit()
+
+		err := paymentService.RefundBooking(context.Background(), &entities.Booking{BookingCode: "BK1"})
+
+		assert.NoError(t, err)
+		mockBookingRepo.AssertNotCalled(t, "GetBookingByCode", mock.Anything)
+	})
+}
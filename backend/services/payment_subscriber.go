@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/m13ha/asiko/events"
+)
+
+// paymentSubscriber names the payment handlers on the event bus. The outbox records it on the
+// events they handled, so it must not change.
+const paymentSubscriber = "payments"
+
+// RegisterPaymentHandlers refunds paid bookings when they are rejected or cancelled by the
+// owner, and when guests cancel them inside the appointment's refund window.
+func RegisterPaymentHandlers(bus events.EventBus, svc PaymentService) {
+	refund := func(ctx context.Context, event events.Event) error {
+		p, ok := event.Data.(events.BookingEventData)
+		if !ok || p.Booking == nil {
+			return nil
+		}
+
+		refund := svc.RefundBooking
+		if event.Name == events.EventBookingCancelled && !p.CancelledByOwner {
+			refund = svc.RefundCancelledBooking
+		}
+		// Returning the error has the outbox retry; refunds are idempotent per payment.
+		if err := refund(ctx, p.Booking); err != nil {
+			log.Printf("Failed to refund booking %s: %v", p.Booking.BookingCode, err)
+			return err
+		}
+		return nil
+	}
+	bus.SubscribeTo(events.EventBookingCancelled, paymentSubscriber, refund)
+	bus.SubscribeTo(events.EventBookingRejected, paymentSubscriber, refund)
+}
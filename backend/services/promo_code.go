@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/repository"
+	"github.com/m13ha/asiko/utils"
+	"gorm.io/gorm"
+)
+
+type promoCodeServiceImpl struct {
+	promoRepo repository.PromoCodeRepository
+	access    appointmentAccess
+	db        *gorm.DB
+}
+
+func NewPromoCodeService(promoRepo repository.PromoCodeRepository, appointmentRepo repository.AppointmentRepository, orgRepo repository.OrganizationRepository, db *gorm.DB) PromoCodeService {
+	return &promoCodeServiceImpl{
+		promoRepo: promoRepo,
+		access:    appointmentAccess{appointmentRepo: appointmentRepo, orgRepo: orgRepo},
+		db:        db,
+	}
+}
+
+func (s *promoCodeServiceImpl) CreatePromoCode(ctx context.Context, userID uuid.UUID, req requests.PromoCodeRequest) (*entities.PromoCode, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkAppointments(ctx, userID, req.AppointmentIDs); err != nil {
+		return nil, err
+	}
+
+	promo := &entities.PromoCode{OwnerID: userID}
+	applyPromoCodeRequest(promo, req)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		promoRepo := s.promoRepo.WithTx(tx)
+		if err := promoRepo.Create(promo); err != nil {
+			return err
+		}
+		return promoRepo.ReplaceAppointments(promo.ID, req.AppointmentIDs)
+	})
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return promo, nil
+}
+
+func (s *promoCodeServiceImpl) ListPromoCodes(userID uuid.UUID) ([]entities.PromoCode, error) {
+	promos, err := s.promoRepo.ListByOwner(userID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return promos, nil
+}
+
+// UpdatePromoCode replaces the promo code's details. Redemptions already made keep their
+// discount and keep counting against the new limits.
+func (s *promoCodeServiceImpl) UpdatePromoCode(ctx context.Context, promoID uuid.UUID, userID uuid.UUID, req requests.PromoCodeRequest) (*entities.PromoCode, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	promo, err := s.findPromoCode(promoID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAppointments(ctx, userID, req.AppointmentIDs); err != nil {
+		return nil, err
+	}
+
+	applyPromoCodeRequest(promo, req)
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		promoRepo := s.promoRepo.WithTx(tx)
+		if err := promoRepo.Update(promo); err != nil {
+			return err
+		}
+		return promoRepo.ReplaceAppointments(promo.ID, req.AppointmentIDs)
+	})
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return promo, nil
+}
+
+// DeletePromoCode stops the promo code from being redeemed. Bookings that used it keep their
+// discount.
+func (s *promoCodeServiceImpl) DeletePromoCode(promoID uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.findPromoCode(promoID, userID); err != nil {
+		return err
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		return s.promoRepo.WithTx(tx).Delete(promoID)
+	})
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+	return nil
+}
+
+func applyPromoCodeRequest(promo *entities.PromoCode, req requests.PromoCodeRequest) {
+	promo.Code = req.Code
+	promo.Description = req.Description
+	promo.Discount = req.Discount
+	promo.Currency = req.Currency
+	promo.MaxRedemptions = req.MaxRedemptions
+	promo.MaxPerEmail = req.MaxPerEmail
+	promo.ValidFrom = req.ValidFrom
+	promo.ValidUntil = req.ValidUntil
+	promo.AppointmentIDs = req.AppointmentIDs
+}
+
+// checkAppointments requires the user to be able to manage every appointment a code is
+// restricted to.
+func (s *promoCodeServiceImpl) checkAppointments(ctx context.Context, userID uuid.UUID, appointmentIDs []uuid.UUID) error {
+	for _, appointmentID := range appointmentIDs {
+		if _, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments); err != nil {
+			return serviceerrors.FromError(err)
+		}
+	}
+	return nil
+}
+
+func (s *promoCodeServiceImpl) findPromoCode(promoID uuid.UUID, userID uuid.UUID) (*entities.PromoCode, error) {
+	promo, err := s.promoRepo.FindByID(promoID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	if promo.OwnerID != userID {
+		return nil, serviceerrors.ForbiddenError("you are not the owner of this promo code")
+	}
+	return promo, nil
+}
+
+// redeemPromoCode takes the discount of the promo code guests of appointment enter as code off
+// booking, which must already be priced. The code stays locked until the booking transaction tx
+// ends, so concurrent bookings cannot both take its last redemption. The returned code is
+// recorded against the booking with recordRedemption once the booking has its booking code.
+func redeemPromoCode(promoRepo repository.PromoCodeRepository, tx *gorm.DB, appointment *entities.Appointment, booking *entities.Booking, code string, now time.Time) (*entities.PromoCode, error) {
+	if code == "" {
+		return nil, nil
+	}
+	promoRepo = promoRepo.WithTx(tx)
+	promo, err := promoRepo.FindAndLockForAppointment(code, appointment)
+	if isRepoNotFound(err) {
+		return nil, serviceerrors.ValidationError("promo code is not valid for this appointment")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if booking.AmountDue <= 0 {
+		return nil, serviceerrors.ValidationError("promo codes only apply to paid bookings")
+	}
+	if ok, reason := promo.Redeemable(now, booking.Currency); !ok {
+		return nil, serviceerrors.ConflictError(reason)
+	}
+
+	if promo.MaxPerEmail > 0 {
+		email := utils.NormalizeEmail(booking.Email)
+		if email == "" {
+			return nil, serviceerrors.ValidationError("an email address is required to use this promo code")
+		}
+		used, err := promoRepo.CountRedemptionsByEmail(promo.ID, email)
+		if err != nil {
+			return nil, err
+		}
+		if used >= int64(promo.MaxPerEmail) {
+			return nil, serviceerrors.ConflictError("you have already used this promo code")
+		}
+	}
+
+	booking.ApplyPromoCode(promo.Code, promo.Discount)
+	promo.Redemptions++
+	if err := promoRepo.Update(promo); err != nil {
+		return nil, err
+	}
+	return promo, nil
+}
+
+// recordRedemption records that booking used promo, as returned by redeemPromoCode.
+func recordRedemption(promoRepo repository.PromoCodeRepository, tx *gorm.DB, promo *entities.PromoCode, booking *entities.Booking) error {
+	if promo == nil {
+		return nil
+	}
+	return promoRepo.WithTx(tx).CreateRedemption(&entities.PromoCodeRedemption{
+		PromoCodeID: promo.ID,
+		BookingCode: booking.BookingCode,
+		Email:       utils.NormalizeEmail(booking.Email),
+		Discount:    booking.DiscountAmount,
+	})
+}
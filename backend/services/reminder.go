@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/notifications"
+	"github.com/m13ha/asiko/repository"
+	"gorm.io/gorm"
+)
+
+const (
+	// reminderBatchSize caps how many reminders one scheduler tick sends; the rest wait for the
+	// next tick.
+	reminderBatchSize = 100
+	// Failed sends are retried after reminderRetryDelay times the attempts so far, until
+	// maxReminderAttempts is reached or the booking starts.
+	reminderRetryDelay  = 5 * time.Minute
+	maxReminderAttempts = 5
+)
+
+type reminderServiceImpl struct {
+	reminderRepo        repository.ReminderRepository
+	bookingRepo         repository.BookingRepository
+	appointmentRepo     repository.AppointmentRepository
+	notificationService notifications.NotificationService
+	db                  *gorm.DB
+}
+
+func NewReminderService(reminderRepo repository.ReminderRepository, bookingRepo repository.BookingRepository, appointmentRepo repository.AppointmentRepository, notificationService notifications.NotificationService, db *gorm.DB) ReminderService {
+	return &reminderServiceImpl{
+		reminderRepo:        reminderRepo,
+		bookingRepo:         bookingRepo,
+		appointmentRepo:     appointmentRepo,
+		notificationService: notificationService,
+		db:                  db,
+	}
+}
+
+// ScheduleReminders replaces the booking's pending reminders with those its appointment's
+// reminder offsets call for. Only confirmed bookings are reminded, so scheduling a booking that
+// is not confirmed just cancels its reminders.
+func (s *reminderServiceImpl) ScheduleReminders(ctx context.Context, booking *entities.Booking) error {
+	var reminders []entities.BookingReminder
+	if booking.Status == entities.BookingStatusConfirmed {
+		appointment, err := s.appointmentRepo.FindAppointmentByAppCode(booking.AppCode)
+		if err != nil {
+			return serviceerrors.FromError(err)
+		}
+		reminders = entities.RemindersFor(booking, appointment.ReminderOffsets, time.Now())
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		reminderRepo := s.reminderRepo.WithTx(tx)
+		if _, err := reminderRepo.CancelPending(booking.BookingCode); err != nil {
+			return err
+		}
+		return reminderRepo.CreateBatch(reminders)
+	})
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+	return nil
+}
+
+// CancelReminders cancels the reminders the booking has not been sent yet.
+func (s *reminderServiceImpl) CancelReminders(ctx context.Context, booking *entities.Booking) error {
+	if _, err := s.reminderRepo.CancelPending(booking.BookingCode); err != nil {
+		return serviceerrors.FromError(err)
+	}
+	return nil
+}
+
+// SendDueReminders sends the reminders due at now and returns how many went out. Each reminder
+// stays locked from being picked up until it is marked sent in the same transaction, so
+// concurrent workers and restarts do not send it twice. Reminders whose booking was cancelled,
+// unconfirmed or moved since they were scheduled are cancelled instead of sent.
+func (s *reminderServiceImpl) SendDueReminders(ctx context.Context, now time.Time) (int, error) {
+	sent := 0
+	for i := 0; i < reminderBatchSize; i++ {
+		if err := ctx.Err(); err != nil {
+			return sent, err
+		}
+		var reminder *entities.BookingReminder
+		delivered := false
+		err := s.db.Transaction(func(tx *gorm.DB) error {
+			reminderRepo := s.reminderRepo.WithTx(tx)
+			var err error
+			reminder, err = reminderRepo.FindAndLockNextDue(now)
+			if isRepoNotFound(err) {
+				reminder = nil
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			booking, err := s.bookingRepo.WithTx(tx).GetBookingByCode(reminder.BookingCode)
+			if err != nil && !isRepoNotFound(err) {
+				return err
+			}
+			if booking == nil || !reminder.AppliesTo(booking, now) {
+				reminder.Status = entities.ReminderStatusCancelled
+				return reminderRepo.Update(reminder)
+			}
+			if appointment, err := s.appointmentRepo.WithTx(tx).FindAppointmentByAppCode(booking.AppCode); err == nil {
+				booking.Appointment = *appointment
+			}
+
+			reminder.Attempts++
+			if sendErr := s.notificationService.SendBookingReminder(booking); sendErr != nil {
+				reminder.LastError = sendErr.Error()
+				retryAt := now.Add(time.Duration(reminder.Attempts) * reminderRetryDelay)
+				if reminder.Attempts >= maxReminderAttempts || !retryAt.Before(reminder.StartsAt) {
+					reminder.Status = entities.ReminderStatusFailed
+				} else {
+					reminder.SendAt = retryAt
+				}
+				log.Printf("[ReminderService] reminder %s for booking %s failed (attempt %d): %v", reminder.ID, reminder.BookingCode, reminder.Attempts, sendErr)
+				return reminderRepo.Update(reminder)
+			}
+
+			sentAt := now
+			reminder.Status = entities.ReminderStatusSent
+			reminder.SentAt = &sentAt
+			reminder.LastError = ""
+			delivered = true
+			return reminderRepo.Update(reminder)
+		})
+		if err != nil {
+			return sent, serviceerrors.FromError(err)
+		}
+		if reminder == nil {
+			break
+		}
+		if delivered {
+			sent++
+		}
+	}
+	return sent, nil
+}
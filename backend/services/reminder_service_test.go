@@ -0,0 +1,165 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	repoerrors "github.com/m13ha/asiko/errors/repoerrors"
+	"github.com/m13ha/asiko/models/entities"
+	notificationmocks "github.com/m13ha/asiko/notifications/mocks"
+	repomocks "github.com/m13ha/asiko/repository/mocks"
+	services "github.com/m13ha/asiko/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupReminderService() (services.ReminderService, *repomocks.ReminderRepository, *repomocks.BookingRepository, *repomocks.AppointmentRepository, *notificationmocks.NotificationService, sqlmock.Sqlmock) {
+	mockReminderRepo := new(repomocks.ReminderRepository)
+	mockReminderRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockReminderRepo).Maybe()
+	mockBookingRepo := new(repomocks.BookingRepository)
+	mockBookingRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockBookingRepo).Maybe()
+	mockAppointmentRepo := new(repomocks.AppointmentRepository)
+	mockAppointmentRepo.On("WithTx", mock.AnythingOfType("*gorm.DB")).Return(mockAppointmentRepo).Maybe()
+	mockNotificationService := new(notificationmocks.NotificationService)
+	db, sqlMock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+
+	reminderService := services.NewReminderService(mockReminderRepo, mockBookingRepo, mockAppointmentRepo, mockNotificationService, gormDB)
+	return reminderService, mockReminderRepo, mockBookingRepo, mockAppointmentRepo, mockNotificationService, sqlMock
+}
+
+func TestScheduleReminders(t *testing.T) {
+	t.Run("replaces pending reminders for a confirmed booking", func(t *testing.T) {
+		reminderService, mockReminderRepo, _, mockAppointmentRepo, _, sqlMock := setupReminderService()
+		start := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+		booking := &entities.Booking{BookingCode: "BK1", AppCode: "AP1", Status: entities.BookingStatusConfirmed, StartTime: start}
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "AP1").Return(&entities.Appointment{AppCode: "AP1", ReminderOffsets: entities.ReminderOffsets{1440, 60}}, nil).Once()
+		sqlMock.ExpectBegin()
+		mockReminderRepo.On("CancelPending", "BK1").Return(int64(2), nil).Once()
+		mockReminderRepo.On("CreateBatch", mock.MatchedBy(func(reminders []entities.BookingReminder) bool {
+			return len(reminders) == 2 &&
+				reminders[0].SendAt.Equal(start.Add(-24*time.Hour)) &&
+				reminders[1].SendAt.Equal(start.Add(-time.Hour)) &&
+				reminders[1].BookingCode == "BK1"
+		})).Return(nil).Once()
+		sqlMock.ExpectCommit()
+
+		err := reminderService.ScheduleReminders(context.Background(), booking)
+
+		assert.NoError(t, err)
+		mockReminderRepo.AssertExpectations(t)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("only cancels reminders for a booking that is not confirmed", func(t *testing.T) {
+		reminderService, mockReminderRepo, _, mockAppointmentRepo, _, sqlMock := setupReminderService()
+		booking := &entities.Booking{BookingCode: "BK1", AppCode: "AP1", Status: entities.BookingStatusPending, StartTime: time.Now().Add(48 * time.Hour)}
+		sqlMock.ExpectBegin()
+		mockReminderRepo.On("CancelPending", "BK1").Return(int64(2), nil).Once()
+		mockReminderRepo.On("CreateBatch", mock.MatchedBy(func(reminders []entities.BookingReminder) bool {
+			return len(reminders) == 0
+		})).Return(nil).Once()
+		sqlMock.ExpectCommit()
+
+		err := reminderService.ScheduleReminders(context.Background(), booking)
+
+		assert.NoError(t, err)
+		mockReminderRepo.AssertExpectations(t)
+		mockAppointmentRepo.AssertNotCalled(t, "FindAppointmentByAppCode", mock.Anything)
+	})
+}
+
+func TestSendDueReminders(t *testing.T) {
+	now := time.Date(2026, 6, 2, 8, 0, 0, 0, time.UTC)
+	start := now.Add(time.Hour)
+
+	t.Run("sends a due reminder once and marks it sent", func(t *testing.T) {
+		reminderService, mockReminderRepo, mockBookingRepo, mockAppointmentRepo, mockNotificationService, sqlMock := setupReminderService()
+		reminder := &entities.BookingReminder{BookingCode: "BK1", StartsAt: start, SendAt: now, Status: entities.ReminderStatusPending}
+		booking := &entities.Booking{BookingCode: "BK1", AppCode: "AP1", Status: entities.BookingStatusConfirmed, StartTime: start}
+
+		sqlMock.ExpectBegin()
+		mockReminderRepo.On("FindAndLockNextDue", now).Return(reminder, nil).Once()
+		mockBookingRepo.On("GetBookingByCode", "BK1").Return(booking, nil).Once()
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "AP1").Return(&entities.Appointment{Title: "Consultation"}, nil).Once()
+		mockNotificationService.On("SendBookingReminder", mock.MatchedBy(func(b *entities.Booking) bool {
+			return b.BookingCode == "BK1" && b.Appointment.Title == "Consultation"
+		})).Return(nil).Once()
+		mockReminderRepo.On("Update", mock.MatchedBy(func(r *entities.BookingReminder) bool {
+			return r.Status == entities.ReminderStatusSent && r.SentAt != nil && r.Attempts == 1
+		})).Return(nil).Once()
+		sqlMock.ExpectCommit()
+		sqlMock.ExpectBegin()
+		mockReminderRepo.On("FindAndLockNextDue", now).Return(nil, repoerrors.NotFoundError("no reminders due")).Once()
+		sqlMock.ExpectCommit()
+
+		sent, err := reminderService.SendDueReminders(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		mockReminderRepo.AssertExpectations(t)
+		mockNotificationService.AssertExpectations(t)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("cancels reminders for bookings that moved or were cancelled", func(t *testing.T) {
+		reminderService, mockReminderRepo, mockBookingRepo, _, mockNotificationService, sqlMock := setupReminderService()
+		moved := &entities.BookingReminder{BookingCode: "BK1", StartsAt: start, SendAt: now, Status: entities.ReminderStatusPending}
+		gone := &entities.BookingReminder{BookingCode: "BK2", StartsAt: start, SendAt: now, Status: entities.ReminderStatusPending}
+
+		sqlMock.ExpectBegin()
+		mockReminderRepo.On("FindAndLockNextDue", now).Return(moved, nil).Once()
+		mockBookingRepo.On("GetBookingByCode", "BK1").Return(&entities.Booking{BookingCode: "BK1", Status: entities.BookingStatusConfirmed, StartTime: start.Add(24 * time.Hour)}, nil).Once()
+		mockReminderRepo.On("Update", moved).Return(nil).Once()
+		sqlMock.ExpectCommit()
+		sqlMock.ExpectBegin()
+		mockReminderRepo.On("FindAndLockNextDue", now).Return(gone, nil).Once()
+		mockBookingRepo.On("GetBookingByCode", "BK2").Return(nil, repoerrors.NotFoundError("booking not found")).Once()
+		mockReminderRepo.On("Update", gone).Return(nil).Once()
+		sqlMock.ExpectCommit()
+		sqlMock.ExpectBegin()
+		mockReminderRepo.On("FindAndLockNextDue", now).Return(nil, repoerrors.NotFoundError("no reminders due")).Once()
+		sqlMock.ExpectCommit()
+
+		sent, err := reminderService.SendDueReminders(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Zero(t, sent)
+		assert.Equal(t, entities.ReminderStatusCancelled, moved.Status)
+		assert.Equal(t, entities.ReminderStatusCancelled, gone.Status)
+		mockNotificationService.AssertNotCalled(t, "SendBookingReminder", mock.Anything)
+	})
+
+	t.Run("retries a failed send later", func(t *testing.T) {
+		reminderService, mockReminderRepo, mockBookingRepo, mockAppointmentRepo, mockNotificationService, sqlMock := setupReminderService()
+		reminder := &entities.BookingReminder{BookingCode: "BK1", StartsAt: start, SendAt: now, Status: entities.ReminderStatusPending}
+		booking := &entities.Booking{BookingCode: "BK1", AppCode: "AP1", Status: entities.BookingStatusConfirmed, StartTime: start}
+
+		sqlMock.ExpectBegin()
+		mockReminderRepo.On("FindAndLockNextDue", now).Return(reminder, nil).Once()
+		mockBookingRepo.On("GetBookingByCode", "BK1").Return(booking, nil).Once()
+		mockAppointmentRepo.On("FindAppointmentByAppCode", "AP1").Return(&entities.Appointment{}, nil).Once()
+		mockNotificationService.On("SendBookingReminder", booking).Return(errors.New("provider down")).Once()
+		mockReminderRepo.On("Update", reminder).Return(nil).Once()
+		sqlMock.ExpectCommit()
+		sqlMock.ExpectBegin()
+		mockReminderRepo.On("FindAndLockNextDue", now).Return(nil, repoerrors.NotFoundError("no reminders due")).Once()
+		sqlMock.ExpectCommit()
+
+		sent, err := reminderService.SendDueReminders(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Zero(t, sent)
+		assert.Equal(t, entities.ReminderStatusPending, reminder.Status)
+		assert.Equal(t, 1, reminder.Attempts)
+		assert.Equal(t, "provider down", reminder.LastError)
+		assert.True(t, reminder.SendAt.After(now))
+	})
+}
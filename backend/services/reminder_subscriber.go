@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/m13ha/asiko/events"
+)
+
+// reminderSubscriber names the reminder handlers on the event bus. The outbox records it on the
+// events they handled, so it must not change.
+const reminderSubscriber = "reminders"
+
+// RegisterReminderHandlers keeps bookings' reminder jobs in step with the bookings: they are
+// scheduled when a booking is confirmed, replaced when it is updated, and cancelled when it is
+// cancelled or rejected. Both handlers replace or cancel whatever is pending, so failures are
+// returned for the outbox to retry.
+func RegisterReminderHandlers(bus events.EventBus, svc ReminderService) {
+	schedule := func(ctx context.Context, event events.Event) error {
+		p, ok := event.Data.(events.BookingEventData)
+		if !ok || p.Booking == nil {
+			return nil
+		}
+		if err := svc.ScheduleReminders(ctx, p.Booking); err != nil {
+			log.Printf("Failed to schedule reminders for booking %s: %v", p.Booking.BookingCode, err)
+			return err
+		}
+		return nil
+	}
+	cancel := func(ctx context.Context, event events.Event) error {
+		p, ok := event.Data.(events.BookingEventData)
+		if !ok || p.Booking == nil {
+			return nil
+		}
+		if err := svc.CancelReminders(ctx, p.Booking); err != nil {
+			log.Printf("Failed to cancel reminders for booking %s: %v", p.Booking.BookingCode, err)
+			return err
+		}
+		return nil
+	}
+
+	bus.SubscribeTo(events.EventBookingConfirmed, reminderSubscriber, schedule)
+	bus.SubscribeTo(events.EventBookingUpdated, reminderSubscriber, schedule)
+	bus.SubscribeTo(events.EventBookingCancelled, reminderSubscriber, cancel)
+	bus.SubscribeTo(events.EventBookingRejected, reminderSubscriber, cancel)
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/repository"
+	"gorm.io/gorm"
+)
+
+type resourceServiceImpl struct {
+	resourceRepo    repository.ResourceRepository
+	appointmentRepo repository.AppointmentRepository
+	access          appointmentAccess
+	db              *gorm.DB
+}
+
+func NewResourceService(resourceRepo repository.ResourceRepository, appointmentRepo repository.AppointmentRepository, orgRepo repository.OrganizationRepository, db *gorm.DB) ResourceService {
+	return &resourceServiceImpl{
+		resourceRepo:    resourceRepo,
+		appointmentRepo: appointmentRepo,
+		access:          appointmentAccess{appointmentRepo: appointmentRepo, orgRepo: orgRepo},
+		db:              db,
+	}
+}
+
+func (s *resourceServiceImpl) CreateResource(userID uuid.UUID, req requests.ResourceRequest) (*entities.Resource, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.OrganizationID != nil {
+		if _, err := s.access.authorizeOrganization(*req.OrganizationID, userID, entities.PermissionManageAppointments); err != nil {
+			return nil, err
+		}
+	}
+
+	resource := &entities.Resource{
+		OwnerID:        userID,
+		OrganizationID: req.OrganizationID,
+		Name:           req.Name,
+		Description:    req.Description,
+		Capacity:       req.Capacity,
+	}
+	if err := s.resourceRepo.Create(resource); err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return resource, nil
+}
+
+func (s *resourceServiceImpl) ListResources(userID uuid.UUID) ([]entities.Resource, error) {
+	resources, err := s.resourceRepo.ListForUser(userID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return resources, nil
+}
+
+// UpdateResource replaces the resource's details. A lower capacity does not cancel bookings
+// already using the resource; it only limits new ones.
+func (s *resourceServiceImpl) UpdateResource(resourceID uuid.UUID, userID uuid.UUID, req requests.ResourceRequest) (*entities.Resource, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	resource, err := s.findResource(resourceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.OrganizationID != nil && (resource.OrganizationID == nil || *req.OrganizationID != *resource.OrganizationID) {
+		if _, err := s.access.authorizeOrganization(*req.OrganizationID, userID, entities.PermissionManageAppointments); err != nil {
+			return nil, err
+		}
+	}
+
+	resource.Name = req.Name
+	resource.Description = req.Description
+	resource.Capacity = req.Capacity
+	resource.OrganizationID = req.OrganizationID
+	if err := s.resourceRepo.Update(resource); err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return resource, nil
+}
+
+// DeleteResource removes the resource. Appointments that required it no longer do; their
+// existing bookings are kept.
+func (s *resourceServiceImpl) DeleteResource(resourceID uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.findResource(resourceID, userID); err != nil {
+		return err
+	}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		return s.resourceRepo.WithTx(tx).Delete(resourceID)
+	})
+	if err != nil {
+		return serviceerrors.FromError(err)
+	}
+	return nil
+}
+
+func (s *resourceServiceImpl) ListAppointmentResources(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID) ([]entities.Resource, error) {
+	if _, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionViewBookings); err != nil {
+		return nil, err
+	}
+	resources, err := s.appointmentRepo.ListResources(appointmentID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return resources, nil
+}
+
+// SetAppointmentResources replaces the resources every new booking on the appointment uses. The
+// caller must be able to manage both the appointment and each resource. Existing bookings are not
+// checked against the new resources.
+func (s *resourceServiceImpl) SetAppointmentResources(ctx context.Context, appointmentID uuid.UUID, userID uuid.UUID, req requests.AppointmentResourcesRequest) ([]entities.Resource, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.access.findAppointment(ctx, appointmentID, userID, entities.PermissionManageAppointments); err != nil {
+		return nil, err
+	}
+	for _, resourceID := range req.ResourceIDs {
+		if _, err := s.findResource(resourceID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		return s.appointmentRepo.WithTx(tx).ReplaceResources(appointmentID, req.ResourceIDs)
+	})
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	resources, err := s.appointmentRepo.ListResources(appointmentID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	return resources, nil
+}
+
+// findResource loads a resource the user may manage: one they own, or one shared with an
+// organization whose appointments they may manage.
+func (s *resourceServiceImpl) findResource(resourceID uuid.UUID, userID uuid.UUID) (*entities.Resource, error) {
+	resource, err := s.resourceRepo.FindByID(resourceID)
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+	if resource.OwnerID == userID {
+		return resource, nil
+	}
+	if resource.OrganizationID == nil {
+		return nil, serviceerrors.ForbiddenError("you are not the owner of this resource")
+	}
+	if _, err := s.access.authorizeOrganization(*resource.OrganizationID, userID, entities.PermissionManageAppointments); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/repository"
+)
+
+// reserveResources locks the resources appointmentID requires and fails when one of them is
+// already used over [start, end) by as many other slots as its capacity. The locks are held until
+// the booking transaction commits, so appointments sharing a resource book it one at a time and
+// the booking being made counts against the resource from then on. ignored lists bookings, such
+// as one being moved, that no longer use the resource.
+func reserveResources(appRepo repository.AppointmentRepository, bookRepo repository.BookingRepository, appointmentID uuid.UUID, start time.Time, end time.Time, ignored ...uuid.UUID) error {
+	resources, err := appRepo.FindAndLockResources(appointmentID)
+	if err != nil {
+		return err
+	}
+	for _, resource := range resources {
+		bookings, err := bookRepo.ListResourceBookingsBetween(resource.ID, start, end)
+		if err != nil {
+			return err
+		}
+		if !resource.HasRoomFor(appointmentID, start, bookings, ignored...) {
+			return serviceerrors.BookingSlotUnavailableError(fmt.Sprintf("%s is already booked at this time", resource.Name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/repository"
+	"gorm.io/gorm"
+)
+
+// SlotHoldTTL is how long held seats stay reserved before the scheduler releases them.
+const SlotHoldTTL = 10 * time.Minute
+
+// expiredHoldBatchSize caps how many expired holds a single scheduler tick releases.
+const expiredHoldBatchSize = 100
+
+// HoldSlot reserves seats on a slot for SlotHoldTTL. The seats, and the resources the
+// appointment requires, count as booked until the hold is redeemed through
+// BookingRequest.HoldToken or released by ReleaseExpiredHolds. A slot nobody has booked is marked
+// held meanwhile, so it is not taken for a booking by the calendar feed or the scheduler.
+func (s *bookingServiceImpl) HoldSlot(req requests.SlotHoldRequest) (*entities.SlotHold, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	appointment, err := s.appointmentRepo.FindAppointmentByAppCode(req.AppCode)
+	if err != nil {
+		return nil, err
+	}
+	if ok, reason := appointment.IsBookable(); !ok {
+		return nil, serviceerrors.ConflictError(reason)
+	}
+	if appointment.Type == entities.Party {
+		return nil, serviceerrors.ValidationError("holds are only available for slot-based appointments")
+	}
+	if appointment.Type == entities.Single && req.Seats != 1 {
+		return nil, serviceerrors.ValidationError("single appointments allow exactly one attendee per slot")
+	}
+	if req.Seats > appointment.MaxAttendees {
+		return nil, serviceerrors.ValidationError("attendee count exceeds maximum allowed")
+	}
+	if err := checkBookingWindow(appointment, req.StartTime); err != nil {
+		return nil, err
+	}
+
+	var hold *entities.SlotHold
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		bookRepo := s.bookingRepo.WithTx(tx)
+
+		slot, err := bookRepo.FindAndLockAvailableSlot(req.AppCode, req.Date, req.StartTime)
+		if err != nil {
+			return serviceerrors.BookingSlotUnavailableError("no available slot found")
+		}
+		if slot.Capacity-slot.SeatsBooked < req.Seats {
+			return serviceerrors.BookingCapacityExceededError("not enough capacity for this slot")
+		}
+		if err := reserveResources(s.appointmentRepo.WithTx(tx), bookRepo, slot.AppointmentID, slot.StartTime, slot.EndTime); err != nil {
+			return err
+		}
+
+		if slot.SeatsBooked == 0 {
+			slot.Status = entities.BookingStatusHeld
+		}
+		slot.SeatsBooked += req.Seats
+		slot.NormalizeState()
+		if err := bookRepo.Update(slot); err != nil {
+			return err
+		}
+
+		hold = &entities.SlotHold{
+			Token:         uuid.NewString(),
+			AppointmentID: slot.AppointmentID,
+			AppCode:       slot.AppCode,
+			Date:          slot.Date,
+			StartTime:     slot.StartTime,
+			Seats:         req.Seats,
+			Status:        entities.SlotHoldStatusHeld,
+			ExpiresAt:     time.Now().Add(SlotHoldTTL),
+		}
+		return s.holdRepo.WithTx(tx).Create(hold)
+	})
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+
+	return hold, nil
+}
+
+// lockSlotForBooking locks the slot a booking request targets. Without a hold token the slot must
+// have free seats. With one, the hold is redeemed: its seats are handed back on the returned
+// slot so the caller books against them as if they had never been reserved.
+func (s *bookingServiceImpl) lockSlotForBooking(tx *gorm.DB, bookRepo repository.BookingRepository, req requests.BookingRequest) (*entities.Booking, error) {
+	if req.HoldToken == "" {
+		slot, err := bookRepo.FindAndLockAvailableSlot(req.AppCode, req.Date, req.StartTime)
+		if err != nil {
+			return nil, serviceerrors.BookingSlotUnavailableError("no available slot found")
+		}
+		return slot, nil
+	}
+
+	holdRepo := s.holdRepo.WithTx(tx)
+	hold, err := holdRepo.FindByTokenAndLock(req.HoldToken)
+	if err != nil {
+		if isRepoNotFound(err) {
+			return nil, serviceerrors.ValidationError("invalid hold token")
+		}
+		return nil, err
+	}
+	if !hold.IsActive(time.Now()) {
+		return nil, serviceerrors.ConflictError("slot hold has expired or was already used")
+	}
+	if hold.AppCode != req.AppCode || !hold.Date.Equal(req.Date) || !hold.StartTime.Equal(req.StartTime) {
+		return nil, serviceerrors.ValidationError("hold token does not match the requested slot")
+	}
+	if req.AttendeeCount > hold.Seats {
+		return nil, serviceerrors.ValidationError("attendee count exceeds the seats held")
+	}
+
+	slot, err := bookRepo.FindAndLockSlot(hold.AppCode, hold.Date, hold.StartTime)
+	if err != nil {
+		return nil, serviceerrors.BookingSlotUnavailableError("no available slot found")
+	}
+	slot.SeatsBooked -= hold.Seats
+	slot.NormalizeState()
+
+	hold.Status = entities.SlotHoldStatusRedeemed
+	if err := holdRepo.Update(hold); err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
+
+// ReleaseExpiredHolds returns the seats of expired holds to their slots, promoting waitlisted
+// guests into them where possible, and reports how many holds were released.
+func (s *bookingServiceImpl) ReleaseExpiredHolds(ctx context.Context, now time.Time) (int64, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var released int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		holdRepo := s.holdRepo.WithTx(tx)
+		bookRepo := s.bookingRepo.WithTx(tx)
+
+		holds, err := holdRepo.FindExpiredAndLock(now, expiredHoldBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range holds {
+			hold := &holds[i]
+			slot, err := bookRepo.FindAndLockSlot(hold.AppCode, hold.Date, hold.StartTime)
+			if err != nil && !isRepoNotFound(err) {
+				return err
+			}
+			if slot != nil {
+				slot.SeatsBooked -= hold.Seats
+				if appointment, appErr := s.appointmentRepo.FindAppointmentByAppCode(hold.AppCode); appErr == nil {
+					promoted, promoteErr := s.promoteFromWaitlist(tx, appointment, slot)
+					if promoteErr != nil {
+						return promoteErr
+					}
+					if err := s.enqueuePromotions(tx, appointment, promoted); err != nil {
+						return err
+					}
+				} else if !isRepoNotFound(appErr) {
+					return appErr
+				}
+				slot.NormalizeState()
+				if slot.Status == entities.BookingStatusHeld && slot.SeatsBooked == 0 {
+					slot.Status = entities.BookingStatusActive
+				}
+				if err := bookRepo.Update(slot); err != nil {
+					return err
+				}
+			}
+
+			hold.Status = entities.SlotHoldStatusReleased
+			if err := holdRepo.Update(hold); err != nil {
+				return err
+			}
+			released++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, serviceerrors.FromError(err)
+	}
+
+	return released, nil
+}
@@ -13,16 +13,21 @@ type StatusScheduler interface {
 type statusScheduler struct {
 	appointmentService AppointmentService
 	bookingService     BookingService
+	calendarService    ExternalCalendarService
+	reminderService    ReminderService
 	interval           time.Duration
+	lastCalendarSync   time.Time
 }
 
-func NewStatusScheduler(appointmentService AppointmentService, bookingService BookingService, interval time.Duration) StatusScheduler {
+func NewStatusScheduler(appointmentService AppointmentService, bookingService BookingService, calendarService ExternalCalendarService, reminderService ReminderService, interval time.Duration) StatusScheduler {
 	if interval <= 0 {
 		interval = time.Minute
 	}
 	return &statusScheduler{
 		appointmentService: appointmentService,
 		bookingService:     bookingService,
+		calendarService:    calendarService,
+		reminderService:    reminderService,
 		interval:           interval,
 	}
 }
@@ -59,9 +64,10 @@ func (s *statusScheduler) tick(ctx context.Context) {
 	appointmentSummary, err := s.appointmentService.RefreshStatuses(ctx, now)
 	if err != nil {
 		log.Printf("[StatusScheduler] appointment refresh error: %v", err)
-	} else if appointmentSummary.PendingToOngoing+appointmentSummary.Completed > 0 {
+	} else if appointmentSummary.Published+appointmentSummary.PendingToOngoing+appointmentSummary.Completed > 0 {
 		log.Printf(
-			"[StatusScheduler] appointment updates — pending→ongoing:%d completed:%d",
+			"[StatusScheduler] appointment updates — published:%d pending→ongoing:%d completed:%d",
+			appointmentSummary.Published,
 			appointmentSummary.PendingToOngoing,
 			appointmentSummary.Completed,
 		)
@@ -70,11 +76,44 @@ func (s *statusScheduler) tick(ctx context.Context) {
 	bookingSummary, err := s.bookingService.RefreshBookingStatuses(ctx, now)
 	if err != nil {
 		log.Printf("[StatusScheduler] booking refresh error: %v", err)
-	} else if bookingSummary.Ongoing+bookingSummary.Expired > 0 {
+	} else if bookingSummary.Ongoing+bookingSummary.NoShow+bookingSummary.Attended+bookingSummary.Expired > 0 {
 		log.Printf(
-			"[StatusScheduler] booking updates — ongoing:%d expired:%d",
+			"[StatusScheduler] booking updates — ongoing:%d no-show:%d attended:%d expired:%d",
 			bookingSummary.Ongoing,
+			bookingSummary.NoShow,
+			bookingSummary.Attended,
 			bookingSummary.Expired,
 		)
 	}
+
+	released, err := s.bookingService.ReleaseExpiredHolds(ctx, now)
+	if err != nil {
+		log.Printf("[StatusScheduler] hold release error: %v", err)
+	} else if released > 0 {
+		log.Printf("[StatusScheduler] released expired holds: %d", released)
+	}
+
+	reminders, err := s.reminderService.SendDueReminders(ctx, now)
+	if err != nil {
+		log.Printf("[StatusScheduler] reminder send error: %v", err)
+	} else if reminders > 0 {
+		log.Printf("[StatusScheduler] sent booking reminders: %d", reminders)
+	}
+
+	// External calendars are fetched over the network, so they sync less often than statuses.
+	if now.Sub(s.lastCalendarSync) < ExternalCalendarSyncInterval {
+		return
+	}
+	s.lastCalendarSync = now
+	calendarSummary, err := s.calendarService.SyncCalendars(ctx, now)
+	if err != nil {
+		log.Printf("[StatusScheduler] external calendar sync error: %v", err)
+	} else if calendarSummary.Blocked+calendarSummary.Released > 0 {
+		log.Printf(
+			"[StatusScheduler] external calendar sync — calendars:%d blocked:%d released:%d",
+			calendarSummary.Calendars,
+			calendarSummary.Blocked,
+			calendarSummary.Released,
+		)
+	}
 }
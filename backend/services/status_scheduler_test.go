@@ -13,8 +13,10 @@ import (
 func TestStatusSchedulerTickInvokesServices(t *testing.T) {
 	mockAppointmentService := new(servicesmocks.AppointmentService)
 	mockBookingService := new(servicesmocks.BookingService)
+	mockCalendarService := new(servicesmocks.ExternalCalendarService)
+	mockReminderService := new(servicesmocks.ReminderService)
 
-	callDone := make(chan struct{}, 2)
+	callDone := make(chan struct{}, 5)
 	mockAppointmentService.
 		On("RefreshStatuses", mock.Anything, mock.AnythingOfType("time.Time")).
 		Run(func(args mock.Arguments) { callDone <- struct{}{} }).
@@ -23,17 +25,32 @@ func TestStatusSchedulerTickInvokesServices(t *testing.T) {
 	mockBookingService.
 		On("RefreshBookingStatuses", mock.Anything, mock.AnythingOfType("time.Time")).
 		Run(func(args mock.Arguments) { callDone <- struct{}{} }).
-		Return(services.BookingStatusRefreshSummary{Ongoing: 3, Expired: 4}, nil).
+		Return(services.BookingStatusRefreshSummary{Ongoing: 3, NoShow: 1, Attended: 2, Expired: 4}, nil).
+		Once()
+	mockBookingService.
+		On("ReleaseExpiredHolds", mock.Anything, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { callDone <- struct{}{} }).
+		Return(int64(1), nil).
+		Once()
+	mockReminderService.
+		On("SendDueReminders", mock.Anything, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { callDone <- struct{}{} }).
+		Return(2, nil).
+		Once()
+	mockCalendarService.
+		On("SyncCalendars", mock.Anything, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { callDone <- struct{}{} }).
+		Return(services.ExternalCalendarSyncSummary{Calendars: 1, Blocked: 2}, nil).
 		Once()
 
-	scheduler := services.NewStatusScheduler(mockAppointmentService, mockBookingService, time.Hour)
+	scheduler := services.NewStatusScheduler(mockAppointmentService, mockBookingService, mockCalendarService, mockReminderService, time.Hour)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	scheduler.Start(ctx)
 
 	timeout := time.After(2 * time.Second)
-	for i := 0; i < 2; i++ {
+	for i := 0; i < 5; i++ {
 		select {
 		case <-callDone:
 		case <-timeout:
@@ -44,4 +61,6 @@ func TestStatusSchedulerTickInvokesServices(t *testing.T) {
 
 	mockAppointmentService.AssertExpectations(t)
 	mockBookingService.AssertExpectations(t)
+	mockCalendarService.AssertExpectations(t)
+	mockReminderService.AssertExpectations(t)
 }
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/utils"
+)
+
+// GetBookingTicket renders the booking's signed ticket as a QR code PNG. Tickets are only issued
+// while the booking still admits its guests.
+func (s *bookingServiceImpl) GetBookingTicket(bookingCode string) ([]byte, error) {
+	booking, err := s.GetBookingByCode(bookingCode)
+	if err != nil {
+		return nil, err
+	}
+	if booking.IsSlot && booking.Available {
+		return nil, serviceerrors.NotFoundError("booking not found")
+	}
+	switch strings.ToLower(booking.Status) {
+	case entities.BookingStatusCancelled, entities.BookingStatusCanceled, entities.BookingStatusRejected, entities.BookingStatusExpired:
+		return nil, serviceerrors.ConflictError("tickets are not issued for bookings in this status")
+	}
+
+	ticket, err := utils.SignTicket(booking.BookingCode)
+	if err != nil {
+		log.Printf("[GetBookingTicket] cannot sign ticket: %v", err)
+		return nil, serviceerrors.InternalError("tickets are not configured")
+	}
+	png, err := utils.TicketQRCode(ticket)
+	if err != nil {
+		return nil, serviceerrors.InternalError("failed to render ticket")
+	}
+	return png, nil
+}
+
+// ScanTicket checks a guest in from their scanned ticket. The ticket must carry a valid signature
+// for one of the owner's bookings and may only be admitted once.
+func (s *bookingServiceImpl) ScanTicket(req requests.TicketScanRequest, userID uuid.UUID) (*entities.Booking, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	bookingCode, err := utils.VerifyTicket(req.Ticket)
+	if errors.Is(err, utils.ErrTicketKeyMissing) {
+		log.Printf("[ScanTicket] cannot verify ticket: %v", err)
+		return nil, serviceerrors.InternalError("tickets are not configured")
+	}
+	if err != nil {
+		return nil, serviceerrors.ValidationError("invalid ticket")
+	}
+
+	booking, err := s.findManagedBooking(bookingCode, userID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.CheckedInAt != nil {
+		return nil, serviceerrors.ConflictError("ticket has already been scanned")
+	}
+	return s.checkIn(booking)
+}
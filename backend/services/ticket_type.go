@@ -0,0 +1,85 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/repository"
+)
+
+// checkTicketSale resolves the ticket type a booking of attendees asks for and checks that it is
+// on sale at now. Appointments without ticket types sell plain seats and return nil.
+func checkTicketSale(appointment *entities.Appointment, key string, attendees int, now time.Time) (*entities.TicketType, error) {
+	if len(appointment.TicketTypes) == 0 {
+		if key != "" {
+			return nil, serviceerrors.ValidationError("this appointment has no ticket types")
+		}
+		return nil, nil
+	}
+	if key == "" {
+		return nil, serviceerrors.ValidationError("choose a ticket type")
+	}
+	ticket, ok := appointment.TicketTypes.Find(key)
+	if !ok {
+		return nil, serviceerrors.ValidationError(fmt.Sprintf("unknown ticket type %q", key))
+	}
+	if err := ticket.CheckSale(attendees, now); err != nil {
+		return nil, serviceerrors.ValidationError(err.Error())
+	}
+	return ticket, nil
+}
+
+// checkTicketChange resolves the ticket type of a booking being changed to attendees on
+// appointment. Sale windows do not apply to seats already bought, but the per-booking limit does.
+// Seats of a ticket type the appointment no longer sells count as plain seats, unless the booking
+// is moved onto an appointment that sells ticket types.
+func checkTicketChange(appointment *entities.Appointment, key string, attendees int, moved bool) (*entities.TicketType, error) {
+	ticket, ok := appointment.TicketTypes.Find(key)
+	if !ok {
+		if moved && len(appointment.TicketTypes) > 0 {
+			return nil, serviceerrors.ValidationError("the booking's ticket type is not sold on that appointment")
+		}
+		return nil, nil
+	}
+	if err := ticket.CheckLimit(attendees); err != nil {
+		return nil, serviceerrors.ValidationError(err.Error())
+	}
+	return ticket, nil
+}
+
+// reserveTickets fails when fewer than attendees seats of ticket are left in the group slot, or
+// party, starting at start. It must run in the booking transaction with the slot or party locked,
+// before the booking is saved. A nil ticket always fits.
+func reserveTickets(bookRepo repository.BookingRepository, appointment *entities.Appointment, ticket *entities.TicketType, attendees int, start time.Time) error {
+	fits, err := ticketsFit(bookRepo, appointment, ticket, attendees, start)
+	if err != nil {
+		return err
+	}
+	if !fits {
+		return serviceerrors.BookingCapacityExceededError(fmt.Sprintf("not enough %s tickets left", ticket.Name))
+	}
+	return nil
+}
+
+// ticketsFit reports whether attendees more seats of ticket are unsold in the group slot, or
+// party, starting at start.
+func ticketsFit(bookRepo repository.BookingRepository, appointment *entities.Appointment, ticket *entities.TicketType, attendees int, start time.Time) (bool, error) {
+	if ticket == nil || attendees <= 0 {
+		return true, nil
+	}
+	sales, err := bookRepo.CountTicketSales(appointment.ID, &start)
+	if err != nil {
+		return false, err
+	}
+	return sales[ticket.Key]+attendees <= ticket.Capacity, nil
+}
+
+// ticketKey is the key stored on bookings of ticket, empty for plain seats.
+func ticketKey(ticket *entities.TicketType) string {
+	if ticket == nil {
+		return ""
+	}
+	return ticket.Key
+}
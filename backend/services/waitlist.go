@@ -0,0 +1,352 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	serviceerrors "github.com/m13ha/asiko/errors/serviceerrors"
+	"github.com/m13ha/asiko/events"
+	"github.com/m13ha/asiko/models/entities"
+	"github.com/m13ha/asiko/models/requests"
+	"github.com/m13ha/asiko/repository"
+	"github.com/m13ha/asiko/utils"
+	"gorm.io/gorm"
+)
+
+// JoinWaitlist queues the booker for a slot or party that is currently full. Joining is refused
+// while seats are still free, so guests are never queued behind an open booking.
+func (s *bookingServiceImpl) JoinWaitlist(req requests.BookingRequest, userIDStr string) (*entities.WaitlistEntry, error) {
+	if userIDStr == "" {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := utils.Validate(req); err != nil {
+			return nil, serviceerrors.UserError("invalid waitlist data: " + err.Error())
+		}
+	}
+
+	appointment, err := s.appointmentRepo.FindAppointmentByAppCode(req.AppCode)
+	if err != nil {
+		return nil, err
+	}
+	if ok, reason := appointment.IsBookable(); !ok {
+		return nil, serviceerrors.ConflictError(reason)
+	}
+	if appointment.Type == entities.Single && req.AttendeeCount != 1 {
+		return nil, serviceerrors.ValidationError("single appointments allow exactly one attendee per slot")
+	}
+	if appointment.Type == entities.Group && req.AttendeeCount > appointment.MaxAttendees {
+		return nil, serviceerrors.ValidationError("attendee count exceeds maximum allowed")
+	}
+	if appointment.Type == entities.Party {
+		loc := appointment.Location()
+		if !sameDay(req.Date, appointment.StartDate, loc) {
+			return nil, serviceerrors.ValidationError("party bookings must use the appointment start date")
+		}
+		if !sameClock(req.StartTime, appointment.StartTime, loc) || !sameClock(req.EndTime, appointment.EndTime, loc) {
+			return nil, serviceerrors.ValidationError("party booking times must match the appointment window")
+		}
+	}
+
+	answers, err := validateFormAnswers(appointment, req.FormAnswers)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := checkTicketSale(appointment, req.TicketType, req.AttendeeCount, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &entities.WaitlistEntry{
+		AppointmentID: appointment.ID,
+		AppCode:       appointment.AppCode,
+		AttendeeCount: req.AttendeeCount,
+		Description:   req.Description,
+		FormAnswers:   answers,
+		TicketType:    ticketKey(ticket),
+		Status:        entities.WaitlistStatusWaiting,
+	}
+	if userIDStr != "" {
+		user, err := s.userRepo.FindByID(userIDStr)
+		if err != nil {
+			return nil, err
+		}
+		entry.UserID = &user.ID
+		entry.Name = user.Name
+		entry.Email = user.Email
+		if user.PhoneNumber != nil {
+			entry.Phone = *user.PhoneNumber
+		}
+	} else {
+		entry.Name = req.Name
+		entry.Email = utils.NormalizeEmail(req.Email)
+		entry.Phone = req.Phone
+	}
+
+	if entry.Email != "" {
+		if _, banErr := s.banListRepo.FindByUserAndEmail(appointment.OwnerID, utils.NormalizeEmail(entry.Email)); banErr == nil {
+			return nil, serviceerrors.ForbiddenError("you are not allowed to book this appointment")
+		} else if !isRepoNotFound(banErr) {
+			return nil, serviceerrors.FromError(banErr)
+		}
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		waitRepo := s.waitlistRepo.WithTx(tx)
+
+		// The booking the entry would be promoted to must not collide with the guest's own.
+		held, err := hasActiveBooking(s.bookingRepo.WithTx(tx), appointment.ID, entry.Email, entry.Phone)
+		if err != nil {
+			return err
+		}
+		if held {
+			return serviceerrors.ConflictError("you already have a booking for this appointment")
+		}
+
+		if appointment.Type == entities.Party {
+			lockedAppointment, err := s.appointmentRepo.WithTx(tx).FindAndLock(appointment.AppCode, tx)
+			if err != nil {
+				return err
+			}
+			if lockedAppointment.AttendeesBooked+req.AttendeeCount <= lockedAppointment.MaxAttendees {
+				ticketsLeft, err := ticketsFit(s.bookingRepo.WithTx(tx), lockedAppointment, ticket, req.AttendeeCount, partyStart(lockedAppointment))
+				if err != nil {
+					return err
+				}
+				if ticketsLeft {
+					return serviceerrors.ConflictError("the party still has room; book it directly")
+				}
+			}
+			entry.Date = lockedAppointment.LocalDate(lockedAppointment.StartDate).UTC()
+			entry.StartTime = partyStart(lockedAppointment)
+			entry.EndTime = lockedAppointment.LocalDateTime(lockedAppointment.EndDate, lockedAppointment.EndTime).UTC()
+		} else {
+			slot, err := s.bookingRepo.WithTx(tx).FindAndLockSlot(req.AppCode, req.Date, req.StartTime)
+			if err != nil {
+				return serviceerrors.BookingSlotUnavailableError("slot not found")
+			}
+			if slot.Available && slot.SeatsBooked+req.AttendeeCount <= slot.Capacity {
+				ticketsLeft, err := ticketsFit(s.bookingRepo.WithTx(tx), appointment, ticket, req.AttendeeCount, slot.StartTime)
+				if err != nil {
+					return err
+				}
+				if ticketsLeft {
+					return serviceerrors.ConflictError("the slot still has room; book it directly")
+				}
+			}
+			entry.Date = slot.Date
+			entry.StartTime = slot.StartTime
+			entry.EndTime = slot.EndTime
+		}
+
+		if entry.Email != "" {
+			if _, err := waitRepo.FindWaitingByEmail(appointment.ID, entry.StartTime, entry.Email); err == nil {
+				return serviceerrors.ConflictError("this email is already on the waitlist for this slot")
+			} else if !isRepoNotFound(err) {
+				return err
+			}
+		}
+
+		return waitRepo.Create(entry)
+	})
+	if err != nil {
+		return nil, serviceerrors.FromError(err)
+	}
+
+	return entry, nil
+}
+
+// partyStart is the UTC start of a party, matching the StartTime of its bookings.
+func partyStart(appointment *entities.Appointment) time.Time {
+	return appointment.LocalDateTime(appointment.StartDate, appointment.StartTime).UTC()
+}
+
+// promoteFromWaitlist turns waiting entries into bookings, oldest first, while the freed seats
+// fit the entry at the head of the queue. It must run in the transaction that released the
+// seats, with the appointment (party) or slot (group, single) already locked.
+//
+// For parties it increments appointment.AttendeesBooked, and for group slots slot.SeatsBooked;
+// the caller persists either. A single slot is the booking row itself, so it is rewritten for
+// the promoted guest with a fresh booking code and saved here. Promoted bookings are assigned a
+// host like new ones; promotion stops when no host is free, or when the entry's ticket type has
+// no seats left. Callers release the freed booking before promoting so its tickets are counted
+// as unsold. Entries whose guest has booked the appointment since joining are expired.
+func (s *bookingServiceImpl) promoteFromWaitlist(tx *gorm.DB, appointment *entities.Appointment, slot *entities.Booking) ([]*entities.Booking, error) {
+	waitRepo := s.waitlistRepo.WithTx(tx)
+	bookRepo := s.bookingRepo.WithTx(tx)
+
+	start := partyStart(appointment)
+	if slot != nil {
+		start = slot.StartTime
+	}
+
+	var promoted []*entities.Booking
+	for {
+		entry, err := waitRepo.FindNextAndLock(appointment.ID, start)
+		if isRepoNotFound(err) {
+			return promoted, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// A guest who booked since joining cannot hold a second booking on the appointment;
+		// the entry is dropped so the queue moves on.
+		held, err := hasActiveBooking(bookRepo, appointment.ID, entry.Email, entry.Phone)
+		if err != nil {
+			return nil, err
+		}
+		if held {
+			entry.Status = entities.WaitlistStatusExpired
+			if err := waitRepo.Update(entry); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		switch appointment.Type {
+		case entities.Party:
+			if appointment.AttendeesBooked+entry.AttendeeCount > appointment.MaxAttendees {
+				return promoted, nil
+			}
+		case entities.Group:
+			if slot.SeatsBooked+entry.AttendeeCount > slot.Capacity {
+				return promoted, nil
+			}
+		default:
+			if len(promoted) > 0 {
+				return promoted, nil
+			}
+		}
+		ticket, _ := appointment.TicketTypes.Find(entry.TicketType)
+		fits, err := ticketsFit(bookRepo, appointment, ticket, entry.AttendeeCount, start)
+		if err != nil {
+			return nil, err
+		}
+		if !fits {
+			return promoted, nil
+		}
+
+		var current *uuid.UUID
+		moving := uuid.Nil
+		if appointment.Type == entities.Single {
+			current, moving = slot.HostID, slot.ID
+		}
+		hostID, ok, err := pickHost(s.appointmentRepo.WithTx(tx), bookRepo, appointment, entry.StartTime, entry.EndTime, current, moving)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return promoted, nil
+		}
+
+		var booking *entities.Booking
+		switch appointment.Type {
+		case entities.Party:
+			booking = bookingFromWaitlist(entry)
+			booking.HostID = hostID
+			booking.SetPrice(appointment.PriceFor(ticket, entry.AttendeeCount), appointment.Currency)
+			if err := bookRepo.Create(booking); err != nil {
+				return nil, err
+			}
+			appointment.AttendeesBooked += entry.AttendeeCount
+		case entities.Group:
+			booking = bookingFromWaitlist(entry)
+			booking.HostID = hostID
+			booking.SetPrice(appointment.PriceFor(ticket, entry.AttendeeCount), appointment.Currency)
+			if err := bookRepo.Create(booking); err != nil {
+				return nil, err
+			}
+			slot.SeatsBooked += entry.AttendeeCount
+		default:
+			slot.ReissueBookingCode()
+			slot.UserID = entry.UserID
+			slot.Name = entry.Name
+			slot.Email = entry.Email
+			slot.Phone = entry.Phone
+			slot.Description = entry.Description
+			slot.FormAnswers = entry.FormAnswers
+			slot.DeviceID = ""
+			slot.RescheduleCount = 0
+			slot.HostID = hostID
+			slot.SeatsBooked = slot.Capacity
+			slot.Status = entities.BookingStatusPending
+			slot.ClearPrice()
+			slot.SetPrice(appointment.PriceFor(nil, 1), appointment.Currency)
+			slot.NormalizeState()
+			if err := bookRepo.Update(slot); err != nil {
+				return nil, err
+			}
+			booking = slot
+		}
+
+		entry.Status = entities.WaitlistStatusPromoted
+		entry.BookingID = &booking.ID
+		if err := waitRepo.Update(entry); err != nil {
+			return nil, err
+		}
+		promoted = append(promoted, booking)
+	}
+}
+
+// hasActiveBooking reports whether the email or phone already holds an active booking on the
+// appointment, which the anti-scalping unique indexes allow only once.
+func hasActiveBooking(bookRepo repository.BookingRepository, appointmentID uuid.UUID, email string, phone string) (bool, error) {
+	if email != "" {
+		if _, err := bookRepo.FindActiveBookingByEmail(appointmentID, utils.NormalizeEmail(email)); err == nil {
+			return true, nil
+		} else if !isRepoNotFound(err) {
+			return false, err
+		}
+	}
+	if phone = strings.TrimSpace(phone); phone != "" {
+		if _, err := bookRepo.FindActiveBookingByPhone(appointmentID, phone); err == nil {
+			return true, nil
+		} else if !isRepoNotFound(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+func bookingFromWaitlist(entry *entities.WaitlistEntry) *entities.Booking {
+	return &entities.Booking{
+		AppointmentID: entry.AppointmentID,
+		AppCode:       entry.AppCode,
+		UserID:        entry.UserID,
+		Name:          entry.Name,
+		Email:         entry.Email,
+		Phone:         entry.Phone,
+		Date:          entry.Date,
+		StartTime:     entry.StartTime,
+		EndTime:       entry.EndTime,
+		Available:     false,
+		IsSlot:        false,
+		Capacity:      entry.AttendeeCount,
+		SeatsBooked:   entry.AttendeeCount,
+		AttendeeCount: entry.AttendeeCount,
+		Description:   entry.Description,
+		FormAnswers:   entry.FormAnswers,
+		TicketType:    entry.TicketType,
+		Status:        entities.BookingStatusPending,
+	}
+}
+
+// enqueuePromotions records a promotion event in tx for each booking made from the waitlist.
+func (s *bookingServiceImpl) enqueuePromotions(tx *gorm.DB, appointment *entities.Appointment, promoted []*entities.Booking) error {
+	for _, booking := range promoted {
+		payload := events.BookingEventData{
+			Booking:          booking,
+			OwnerID:          appointment.OwnerID,
+			AppointmentTitle: appointment.Title,
+			RecipientEmail:   booking.Email,
+			RecipientName:    booking.Name,
+		}
+		if err := enqueueEvent(s.outboxRepo, tx, events.Event{Name: events.EventBookingPromoted, Data: payload}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
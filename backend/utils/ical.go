@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// iTIP methods used on calendar objects (RFC 5546).
+const (
+	ICalMethodPublish = "PUBLISH"
+	ICalMethodRequest = "REQUEST"
+	ICalMethodCancel  = "CANCEL"
+)
+
+// VEVENT STATUS values (RFC 5545 §3.8.1.11).
+const (
+	ICalStatusTentative = "TENTATIVE"
+	ICalStatusConfirmed = "CONFIRMED"
+	ICalStatusCancelled = "CANCELLED"
+)
+
+const icalTimeFormat = "20060102T150405Z"
+
+// ICalAddress is an organizer or attendee of an event.
+type ICalAddress struct {
+	Email string
+	Name  string
+}
+
+// ICalEvent is a single VEVENT. UID must stay the same across updates and cancellations of the
+// event so calendar clients replace it instead of adding a copy; Sequence orders those revisions.
+type ICalEvent struct {
+	UID         string
+	Sequence    int
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Status      string
+	Organizer   *ICalAddress
+	Attendees   []ICalAddress
+}
+
+// ICalendar is a VCALENDAR object. Method is empty for plain feeds.
+type ICalendar struct {
+	Name   string
+	Method string
+	Events []ICalEvent
+}
+
+// Encode renders the calendar as RFC 5545 text with CRLF line endings and folded long lines.
+func (c ICalendar) Encode() []byte {
+	var buf bytes.Buffer
+	w := icalWriter{buf: &buf}
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//Asiko//Bookings//EN")
+	w.line("CALSCALE:GREGORIAN")
+	if c.Method != "" {
+		w.line("METHOD:" + c.Method)
+	}
+	if c.Name != "" {
+		w.line("X-WR-CALNAME:" + escapeICalText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		stamp := event.Stamp
+		if stamp.IsZero() {
+			stamp = time.Now()
+		}
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + event.UID)
+		w.line(fmt.Sprintf("SEQUENCE:%d", event.Sequence))
+		w.line("DTSTAMP:" + stamp.UTC().Format(icalTimeFormat))
+		w.line("DTSTART:" + event.Start.UTC().Format(icalTimeFormat))
+		w.line("DTEND:" + event.End.UTC().Format(icalTimeFormat))
+		w.line("SUMMARY:" + escapeICalText(event.Summary))
+		if event.Description != "" {
+			w.line("DESCRIPTION:" + escapeICalText(event.Description))
+		}
+		if event.Status != "" {
+			w.line("STATUS:" + event.Status)
+		}
+		if event.Organizer != nil && event.Organizer.Email != "" {
+			w.line("ORGANIZER" + icalCommonName(event.Organizer.Name) + ":mailto:" + event.Organizer.Email)
+		}
+		for _, attendee := range event.Attendees {
+			if attendee.Email == "" {
+				continue
+			}
+			w.line("ATTENDEE;ROLE=REQ-PARTICIPANT" + icalCommonName(attendee.Name) + ":mailto:" + attendee.Email)
+		}
+		w.line("END:VEVENT")
+	}
+
+	w.line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+type icalWriter struct {
+	buf *bytes.Buffer
+}
+
+// line writes a content line, folding it into 75-octet chunks without splitting UTF-8 sequences.
+func (w icalWriter) line(content string) {
+	const limit = 75
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
+
+func isUTF8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+var icalTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeICalText(value string) string {
+	return icalTextEscaper.Replace(value)
+}
+
+func icalCommonName(name string) string {
+	name = strings.TrimSpace(strings.NewReplacer(`"`, "", "\r", "", "\n", " ").Replace(name))
+	if name == "" {
+		return ""
+	}
+	return `;CN="` + name + `"`
+}
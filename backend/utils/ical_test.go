@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICalendarEncode(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 30, 0, 0, time.FixedZone("WAT", 3600))
+	cal := ICalendar{
+		Method: ICalMethodRequest,
+		Events: []ICalEvent{{
+			UID:         "abc@asiko",
+			Sequence:    2,
+			Stamp:       start,
+			Start:       start,
+			End:         start.Add(30 * time.Minute),
+			Summary:     "Consult; follow-up, part 2",
+			Description: "Line one\nLine two",
+			Status:      ICalStatusConfirmed,
+			Organizer:   &ICalAddress{Email: "hello@example.com", Name: "Asiko"},
+			Attendees:   []ICalAddress{{Email: "guest@example.com", Name: "Guest"}},
+		}},
+	}
+
+	out := string(cal.Encode())
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"METHOD:REQUEST\r\n",
+		"UID:abc@asiko\r\n",
+		"SEQUENCE:2\r\n",
+		"DTSTART:20260302T083000Z\r\n",
+		"DTEND:20260302T090000Z\r\n",
+		`SUMMARY:Consult\; follow-up\, part 2` + "\r\n",
+		`DESCRIPTION:Line one\nLine two` + "\r\n",
+		"STATUS:CONFIRMED\r\n",
+		`ORGANIZER;CN="Asiko":mailto:hello@example.com` + "\r\n",
+		`ATTENDEE;ROLE=REQ-PARTICIPANT;CN="Guest":mailto:guest@example.com` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Encode() missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestICalendarFoldsLongLines(t *testing.T) {
+	summary := strings.Repeat("é", 60)
+	out := string(ICalendar{Events: []ICalEvent{{UID: "x", Summary: summary}}}.Encode())
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line exceeds 75 octets: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+summary+"\r\n") {
+		t.Errorf("folded summary does not unfold to the original")
+	}
+	if strings.Contains(out, "METHOD:") {
+		t.Errorf("feeds without a method should not carry METHOD")
+	}
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestTicketRoundTrip(t *testing.T) {
+	t.Setenv("TICKET_SECRET_KEY", "ticket-secret")
+
+	ticket, err := SignTicket("BK26010214152222")
+	if err != nil {
+		t.Fatalf("SignTicket() error = %v", err)
+	}
+	code, err := VerifyTicket(ticket)
+	if err != nil {
+		t.Fatalf("VerifyTicket() error = %v", err)
+	}
+	if code != "BK26010214152222" {
+		t.Errorf("VerifyTicket() = %q, want %q", code, "BK26010214152222")
+	}
+}
+
+func TestVerifyTicketRejectsTampering(t *testing.T) {
+	t.Setenv("TICKET_SECRET_KEY", "ticket-secret")
+	ticket, err := SignTicket("BK26010214152222")
+	if err != nil {
+		t.Fatalf("SignTicket() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		ticket string
+	}{
+		{name: "other booking code", ticket: "BK26010214153333" + ticket[len("BK26010214152222"):]},
+		{name: "missing signature", ticket: "BK26010214152222"},
+		{name: "empty", ticket: ""},
+		{name: "bad signature", ticket: "BK26010214152222.not-a-signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := VerifyTicket(tt.ticket); err == nil {
+				t.Errorf("VerifyTicket(%q) expected error", tt.ticket)
+			}
+		})
+	}
+
+	t.Setenv("TICKET_SECRET_KEY", "rotated-secret")
+	if _, err := VerifyTicket(ticket); err == nil {
+		t.Error("VerifyTicket() accepted a ticket signed with another key")
+	}
+}
+
+func TestTicketsRequireTheirOwnKey(t *testing.T) {
+	t.Setenv("TICKET_SECRET_KEY", "")
+	t.Setenv("JWT_SECRET_KEY", "jwt-secret")
+
+	if _, err := SignTicket("BK26010214152222"); !errors.Is(err, ErrTicketKeyMissing) {
+		t.Errorf("SignTicket() error = %v, want ErrTicketKeyMissing", err)
+	}
+	if _, err := VerifyTicket("BK26010214152222.signature"); !errors.Is(err, ErrTicketKeyMissing) {
+		t.Errorf("VerifyTicket() error = %v, want ErrTicketKeyMissing", err)
+	}
+}
+
+func TestTicketQRCodeIsPNG(t *testing.T) {
+	png, err := TicketQRCode("BK26010214152222.signature")
+	if err != nil {
+		t.Fatalf("TicketQRCode() error = %v", err)
+	}
+	if !bytes.HasPrefix(png, []byte("\x89PNG")) {
+		t.Error("TicketQRCode() did not return a PNG")
+	}
+}
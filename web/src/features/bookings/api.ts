@@ -52,5 +52,13 @@ export function confirmBookingByCode(bookingCode: string) {
 }
 
 export function getAvailableDates(appCode: string) {
-  return bookingsClient.getAvailableDates({ appCode });
+  // The endpoint returns local dates with their UTC bounds; the calendar only needs the local date.
+  return rawApi.jsonRequest<string[]>(
+    {
+      path: `/appointments/dates/${encodeURIComponent(appCode)}`,
+      method: 'GET',
+      headers: {},
+    },
+    (payload: Array<{ date: string }>) => payload.map((entry) => entry.date),
+  );
 }